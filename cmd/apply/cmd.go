@@ -0,0 +1,156 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apply
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/apply"
+	"github.com/openshift/rosa/pkg/clusterspec"
+	"github.com/openshift/rosa/pkg/interactive/confirm"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/reporter"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var args struct {
+	files  []string
+	dryRun bool
+	prune  bool
+}
+
+var Cmd = NewApplyCommand()
+
+func NewApplyCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "apply",
+		Short: "Make the day-2 resources of a cluster match a set of manifests",
+		Long: fmt.Sprintf("Reads manifests of the day-2 resources of a cluster, compares them with the "+
+			"resources of the cluster and creates, updates or deletes resources so that they match. "+
+			"Manifests are YAML documents with 'apiVersion: %s', the 'kind' of the resource and its "+
+			"'spec', written like in the OCM API. Supported kinds are %s.",
+			clusterspec.APIVersion, strings.Join(apply.KindNames(), ", ")),
+		Example: `  # Show the changes needed to make cluster "mycluster" match the manifests of a directory
+  rosa apply --cluster mycluster -f day2/ --dry-run
+
+  # Apply the manifests, deleting the machine pools, tuning configs, etc. that aren't in them
+  rosa apply --cluster mycluster -f day2/ --prune
+
+  # A manifest of a machine pool
+  apiVersion: rosa.openshift.io/v1alpha1
+  kind: MachinePool
+  spec:
+    id: gpu
+    instance_type: g4dn.xlarge
+    replicas: 2`,
+		Args: cobra.NoArgs,
+		Run:  run,
+	}
+
+	flags := cmd.Flags()
+	flags.StringArrayVarP(
+		&args.files,
+		"file",
+		"f",
+		nil,
+		"File or directory containing the manifests. Directories are read non recursively, "+
+			"using the '.yaml', '.yml' and '.json' files. Can be given multiple times.",
+	)
+	cmd.MarkFlagRequired("file")
+	flags.BoolVar(
+		&args.dryRun,
+		"dry-run",
+		false,
+		"Only show the changes, without applying them.",
+	)
+	flags.BoolVar(
+		&args.prune,
+		"prune",
+		false,
+		"Delete the resources that don't have a manifest. Only the kinds used in the manifests "+
+			"are pruned, and the default ingress and break glass credentials are never deleted.",
+	)
+	ocm.AddClusterFlag(cmd)
+	output.AddFlag(cmd)
+	return cmd
+}
+
+func run(_ *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithOCM()
+	defer r.Cleanup()
+
+	manifests, err := apply.Load(args.files)
+	if err != nil {
+		r.Reporter.Errorf("%v", err)
+		os.Exit(reporter.ExitCode())
+	}
+	if len(manifests) == 0 {
+		r.Reporter.Errorf("There are no manifests in '%s'", strings.Join(args.files, "', '"))
+		os.Exit(reporter.ExitCode())
+	}
+
+	clusterKey := r.GetClusterKey()
+	cluster := r.FetchCluster()
+	if cluster.State() != cmv1.ClusterStateReady {
+		r.Reporter.Errorf("Cluster '%s' is not yet ready", clusterKey)
+		os.Exit(reporter.ExitCode())
+	}
+
+	r.Reporter.Debugf("Comparing %d manifests with the resources of cluster '%s'", len(manifests), clusterKey)
+	plan, err := apply.NewPlan(r.OCMClient, cluster.ID(), manifests, args.prune)
+	if err != nil {
+		r.Reporter.Errorf("%v", err)
+		os.Exit(reporter.ExitCode())
+	}
+	if output.HasFlag() {
+		err = output.Print(plan)
+		if err != nil {
+			r.Reporter.Errorf("%v", err)
+			os.Exit(reporter.ExitCode())
+		}
+	} else if plan.Empty() {
+		r.Reporter.Infof("Cluster '%s' already matches the manifests", clusterKey)
+	} else {
+		plan.Print(os.Stdout)
+	}
+	err = plan.Err()
+	if err != nil {
+		r.Reporter.Errorf("%v", err)
+		os.Exit(reporter.ExitCode())
+	}
+	if plan.Empty() || args.dryRun {
+		return
+	}
+
+	if !confirm.Confirm("apply %d changes to cluster '%s'", len(plan.Changes), clusterKey) {
+		return
+	}
+	err = plan.Apply(r.OCMClient, cluster.ID(), func(change *apply.Change) {
+		r.Reporter.Infof("Applied %s of %s", change.Action, change.Object())
+	})
+	if err != nil {
+		r.Reporter.Errorf("%v", err)
+		os.Exit(reporter.ExitCode())
+	}
+	r.Reporter.Infof("Cluster '%s' now matches the manifests", clusterKey)
+}
@@ -0,0 +1,59 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clearcache
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/cache"
+	"github.com/openshift/rosa/pkg/reporter"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var Cmd = NewCacheClearCommand()
+
+func NewCacheClearCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "clear",
+		Short: "Remove all the responses from the local response cache",
+		Long: "Removes all the responses from the local response cache, for all the OCM environments " +
+			"and users, so that the next commands fetch them again.",
+		Example: `  # Clear the cache
+  rosa cache clear`,
+		Args: cobra.NoArgs,
+		Run:  run,
+	}
+}
+
+func run(_ *cobra.Command, _ []string) {
+	r := rosa.NewRuntime()
+
+	err := ClearCache()
+	if err != nil {
+		r.Reporter.Errorf("Failed to clear the cache: %v", err)
+		os.Exit(reporter.ExitCode())
+	}
+	r.Reporter.Infof("Cleared the cache")
+}
+
+func ClearCache() error {
+	// A cache file that can't be loaded is also cleared, by overwriting it:
+	service, _ := cache.NewRosaCacheService()
+	return service.Clear()
+}
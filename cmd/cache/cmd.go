@@ -0,0 +1,42 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/cmd/cache/clearcache"
+	"github.com/openshift/rosa/cmd/cache/status"
+)
+
+func NewCacheCommand() *cobra.Command {
+	Cmd := &cobra.Command{
+		Use:   "cache COMMAND",
+		Short: "Inspect or clear the local response cache",
+		Long: "Inspect or clear the local cache of slow OCM lookups that rarely change, like versions, " +
+			"regions, machine types and STS policies.\n\n" +
+			"Each cached response expires on its own schedule, and responses are kept apart for each " +
+			"OCM environment and user. Use the global '--no-cache' flag to ignore the cache for a " +
+			"single command.",
+		Args: cobra.NoArgs,
+	}
+	Cmd.AddCommand(status.Cmd)
+	Cmd.AddCommand(clearcache.Cmd)
+	return Cmd
+}
+
+var Cmd = NewCacheCommand()
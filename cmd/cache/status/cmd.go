@@ -0,0 +1,165 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/cache"
+	"github.com/openshift/rosa/pkg/config"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/reporter"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var (
+	Writer io.Writer = os.Stdout
+)
+
+var Cmd = NewCacheStatusCommand()
+
+func NewCacheStatusCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show the content of the local response cache",
+		Long: "Shows the location of the local response cache and the responses that it contains. " +
+			"The responses cached for the current login are marked with '*'.",
+		Example: `  # Show the cached responses
+  rosa cache status`,
+		Args: cobra.NoArgs,
+		Run:  run,
+	}
+	output.AddFlag(cmd)
+	return cmd
+}
+
+// Entry describes a cached response.
+type Entry struct {
+	Current    bool      `json:"current"`
+	Namespace  string    `json:"namespace"`
+	Name       string    `json:"name"`
+	Size       int       `json:"size"`
+	Expiration time.Time `json:"expiration"`
+}
+
+func run(_ *cobra.Command, _ []string) {
+	r := rosa.NewRuntime()
+
+	err := PrintStatus()
+	if err != nil {
+		r.Reporter.Errorf("%v", err)
+		os.Exit(reporter.ExitCode())
+	}
+}
+
+func PrintStatus() error {
+	service, err := cache.NewRosaCacheService()
+	if err != nil {
+		return err
+	}
+	store, err := service.LoadCache()
+	if err != nil {
+		return err
+	}
+	location, err := store.Dir()
+	if err != nil {
+		return err
+	}
+	entries := Entries(service.Items(), currentNamespace())
+
+	if output.HasFlag() {
+		return output.Print(entries)
+	}
+
+	fmt.Fprintf(Writer, "Cache file: %s\n", location)
+	if len(entries) == 0 {
+		fmt.Fprintf(Writer, "The cache is empty.\n")
+		return nil
+	}
+	fmt.Fprintf(Writer, "\n")
+	writer := tabwriter.NewWriter(Writer, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "CURRENT\tNAMESPACE\tNAME\tSIZE\tEXPIRES\n")
+	for _, entry := range entries {
+		current := ""
+		if entry.Current {
+			current = "*"
+		}
+		namespace := entry.Namespace
+		if namespace == "" {
+			namespace = "-"
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\n",
+			current,
+			namespace,
+			entry.Name,
+			humanize.Bytes(uint64(entry.Size)),
+			humanize.Time(entry.Expiration),
+		)
+	}
+	return writer.Flush()
+}
+
+// Entries describes the given cached items sorted by namespace and name. Items without a
+// namespace aren't specific to a login, so they are always current.
+func Entries(items map[string]cache.Item, current string) []Entry {
+	entries := []Entry{}
+	for key, item := range items {
+		namespace, name := cache.SplitKey(key)
+		size := 0
+		switch value := item.Object.(type) {
+		case []byte:
+			size = len(value)
+		case []string:
+			for _, text := range value {
+				size += len(text)
+			}
+		}
+		entries = append(entries, Entry{
+			Current:    namespace == "" || namespace == current,
+			Namespace:  namespace,
+			Name:       name,
+			Size:       size,
+			Expiration: item.Expiration,
+		})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Namespace != entries[j].Namespace {
+			return entries[i].Namespace < entries[j].Namespace
+		}
+		return entries[i].Name < entries[j].Name
+	})
+	return entries
+}
+
+// currentNamespace returns the namespace of the responses cached for the current login, or an
+// empty string if not logged in.
+func currentNamespace() string {
+	cfg, err := config.Load()
+	if err != nil || cfg == nil {
+		return ""
+	}
+	return ocm.CacheNamespace(cfg)
+}
@@ -0,0 +1,111 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package supportbundle
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/reporter"
+	"github.com/openshift/rosa/pkg/rosa"
+	"github.com/openshift/rosa/pkg/supportbundle"
+)
+
+const extension = ".tar.gz"
+
+var Cmd = &cobra.Command{
+	Use:     "support-bundle",
+	Aliases: []string{"supportbundle"},
+	Short:   "Collect a support bundle of a cluster",
+	Long: "Collect the artifacts that support cases usually ask for in a compressed tarball: the " +
+		"cluster, the install and uninstall logs, the limited support reasons, the inflight checks, the " +
+		"machine pools, the identity providers, the ingresses, the account and operator roles with " +
+		"their policy documents, the OIDC configuration and provider, the network verification and the " +
+		"version and environment of the tool. Secrets, tokens and passwords are redacted.",
+	Example: `  # Collect the support bundle of cluster "mycluster" in the current directory
+  rosa collect support-bundle -c mycluster
+
+  # Collect the support bundle in a given file
+  rosa collect support-bundle -c mycluster -f /tmp/mycluster.tar.gz`,
+	Args: cobra.NoArgs,
+	Run:  run,
+}
+
+var args struct {
+	file string
+	tail int
+}
+
+func init() {
+	flags := Cmd.Flags()
+	ocm.AddClusterFlag(Cmd)
+	flags.StringVarP(
+		&args.file,
+		"file",
+		"f",
+		"",
+		fmt.Sprintf("File to write the support bundle to. Defaults to "+
+			"'rosa-support-bundle-<cluster>-<time>%s' in the current directory.", extension),
+	)
+	flags.IntVar(
+		&args.tail,
+		"tail",
+		2000,
+		"Number of lines to get from the end of the install and uninstall logs.",
+	)
+}
+
+func run(_ *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithAWS().WithOCM()
+	defer r.Cleanup()
+
+	clusterKey := r.GetClusterKey()
+	cluster := r.FetchCluster()
+
+	file := args.file
+	if file == "" {
+		file = fmt.Sprintf("rosa-support-bundle-%s-%s%s", cluster.Name(),
+			time.Now().UTC().Format("20060102-150405"), extension)
+	}
+
+	r.Reporter.Infof("Collecting the support bundle of cluster '%s'", clusterKey)
+	bundle := supportbundle.Collect(r, cluster, args.tail)
+	for _, message := range bundle.Errors {
+		r.Reporter.Warnf("%s", message)
+	}
+
+	output, err := os.OpenFile(file, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		r.Reporter.Errorf("Failed to create support bundle file: %v", err)
+		os.Exit(reporter.ExitCode())
+	}
+	err = bundle.Write(output, strings.TrimSuffix(filepath.Base(file), extension))
+	if err == nil {
+		err = output.Close()
+	}
+	if err != nil {
+		r.Reporter.Errorf("Failed to write support bundle file: %v", err)
+		os.Exit(reporter.ExitCode())
+	}
+	r.Reporter.Infof("Wrote the support bundle of cluster '%s' to '%s'", clusterKey, file)
+}
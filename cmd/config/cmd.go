@@ -22,8 +22,11 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/openshift/rosa/cmd/config/deletecontext"
 	"github.com/openshift/rosa/cmd/config/get"
+	"github.com/openshift/rosa/cmd/config/getcontexts"
 	"github.com/openshift/rosa/cmd/config/set"
+	"github.com/openshift/rosa/cmd/config/usecontext"
 	"github.com/openshift/rosa/pkg/config"
 	"github.com/openshift/rosa/pkg/properties"
 )
@@ -54,6 +57,10 @@ following backends are supported for the keyring:
 - Windows: wincred
 
 Available Keyrings on your OS: %s
+
+Several logins can be kept side by side as named contexts. Use 'rosa login --context NAME' to
+create one, 'rosa config use-context NAME' to switch between them, or the global '--context'
+flag to use one for a single command.
 `, loc, strings.Join(config.ConfigVarDocs(), "\n"), properties.KeyringEnvKey, strings.Join(config.GetKeyrings(), ", "))
 }
 
@@ -66,6 +73,9 @@ func NewConfigCommand() *cobra.Command {
 	}
 	Cmd.AddCommand(get.Cmd)
 	Cmd.AddCommand(set.Cmd)
+	Cmd.AddCommand(getcontexts.Cmd)
+	Cmd.AddCommand(usecontext.Cmd)
+	Cmd.AddCommand(deletecontext.Cmd)
 	return Cmd
 }
 
@@ -95,6 +95,48 @@ var _ = Describe("Run Command", Ordered, func() {
 			Expect(err).To(BeNil())
 			Expect(strconv.FormatBool(currentConfig.FedRAMP)).To(Equal(fedramp))
 
+			proxyURL := "http://proxy.example.com:3128"
+			err = set.SaveConfig("proxy_url", proxyURL)
+			Expect(err).To(BeNil())
+			currentConfig, err = config.Load()
+			Expect(err).To(BeNil())
+			Expect(currentConfig.ProxyURL).To(Equal(proxyURL))
+
+			err = set.SaveConfig("proxy_url", "proxy.example.com:3128")
+			Expect(err).NotTo(BeNil())
+			Expect(err.Error()).To(ContainSubstring("Proxy URL 'proxy.example.com:3128' isn't valid"))
+
+			noProxy := ".internal.example.com,10.0.0.0/8"
+			err = set.SaveConfig("no_proxy", noProxy)
+			Expect(err).To(BeNil())
+			currentConfig, err = config.Load()
+			Expect(err).To(BeNil())
+			Expect(currentConfig.NoProxy).To(Equal(noProxy))
+
+			err = set.SaveConfig("ca_file", "/nonexistent/ca.pem")
+			Expect(err).NotTo(BeNil())
+			Expect(err.Error()).To(ContainSubstring("Failed to read CA file"))
+
+			err = set.SaveConfig("retry_attempts", "5")
+			Expect(err).To(BeNil())
+			err = set.SaveConfig("retry_backoff", "2s")
+			Expect(err).To(BeNil())
+			err = set.SaveConfig("retry_jitter", "0.5")
+			Expect(err).To(BeNil())
+			currentConfig, err = config.Load()
+			Expect(err).To(BeNil())
+			Expect(currentConfig.RetryAttempts).To(Equal(5))
+			Expect(currentConfig.RetryBackoff).To(Equal("2s"))
+			Expect(*currentConfig.RetryJitter).To(Equal(0.5))
+
+			err = set.SaveConfig("retry_attempts", "0")
+			Expect(err).NotTo(BeNil())
+			Expect(err.Error()).To(Equal("Retry attempts must be at least 1, but got 0"))
+
+			err = set.SaveConfig("retry_max_backoff", "forever")
+			Expect(err).NotTo(BeNil())
+			Expect(err.Error()).To(ContainSubstring("Invalid value 'forever' for 'retry_max_backoff'"))
+
 			insecure = "Incorrect"
 			err = set.SaveConfig("insecure", insecure)
 			Expect(err).NotTo(BeNil())
@@ -145,6 +187,13 @@ var _ = Describe("Run Command", Ordered, func() {
 			Expect(err).To(BeNil())
 			Expect(buf.String()).To(ContainSubstring(strconv.FormatBool(currentConfig.FedRAMP)))
 
+			err = get.PrintConfig("proxy_url")
+			Expect(err).To(BeNil())
+			Expect(buf.String()).To(ContainSubstring(currentConfig.ProxyURL))
+
+			err = get.PrintConfig("retry_attempts")
+			Expect(err).To(BeNil())
+
 			err = get.PrintConfig("test")
 			Expect(err).NotTo(BeNil())
 			Expect(err.Error()).To(Equal("'test' is not a supported setting"))
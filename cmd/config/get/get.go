@@ -25,6 +25,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/openshift/rosa/pkg/config"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -50,8 +51,8 @@ func run(_ *cobra.Command, argv []string) {
 
 	err := PrintConfig(argv[0])
 	if err != nil {
-		r.Reporter.Errorf(err.Error())
-		os.Exit(1)
+		r.Reporter.Errorf("%v", err)
+		os.Exit(reporter.ExitCode())
 	}
 }
 
@@ -98,6 +99,30 @@ func PrintConfig(arg string) error {
 		fmt.Fprintf(Writer, "%s\n", cfg.URL)
 	case "fedramp":
 		fmt.Fprintf(Writer, "%v\n", cfg.FedRAMP)
+	case "current_context":
+		fmt.Fprintf(Writer, "%s\n", cfg.CurrentContext)
+	case "ca_file":
+		fmt.Fprintf(Writer, "%s\n", cfg.CAFile)
+	case "proxy_url":
+		fmt.Fprintf(Writer, "%s\n", cfg.ProxyURL)
+	case "no_proxy":
+		fmt.Fprintf(Writer, "%s\n", cfg.NoProxy)
+	case "retry_attempts":
+		if cfg.RetryAttempts != 0 {
+			fmt.Fprintf(Writer, "%d\n", cfg.RetryAttempts)
+		} else {
+			fmt.Fprintf(Writer, "\n")
+		}
+	case "retry_backoff":
+		fmt.Fprintf(Writer, "%s\n", cfg.RetryBackoff)
+	case "retry_max_backoff":
+		fmt.Fprintf(Writer, "%s\n", cfg.RetryMaxBackoff)
+	case "retry_jitter":
+		if cfg.RetryJitter != nil {
+			fmt.Fprintf(Writer, "%v\n", *cfg.RetryJitter)
+		} else {
+			fmt.Fprintf(Writer, "\n")
+		}
 	default:
 		return fmt.Errorf("'%s' is not a supported setting", arg)
 	}
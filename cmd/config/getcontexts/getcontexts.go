@@ -0,0 +1,86 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package getcontexts
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/config"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/reporter"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var (
+	Writer io.Writer = os.Stdout
+)
+
+var Cmd = NewConfigGetContextsCommand()
+
+func NewConfigGetContextsCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "get-contexts",
+		Short: "Lists the login contexts",
+		Long:  "Lists the login contexts stored in the configuration. The current context is marked with '*'.",
+		Example: `  # List all login contexts
+  rosa config get-contexts`,
+		Args: cobra.NoArgs,
+		Run:  run,
+	}
+}
+
+func run(_ *cobra.Command, _ []string) {
+	r := rosa.NewRuntime()
+
+	err := PrintContexts()
+	if err != nil {
+		r.Reporter.Errorf("%v", err)
+		os.Exit(reporter.ExitCode())
+	}
+}
+
+func PrintContexts() error {
+	contexts, err := config.GetContexts()
+	if err != nil {
+		return fmt.Errorf("can't load config: %v", err)
+	}
+	if len(contexts) == 0 {
+		fmt.Fprintf(Writer, "No login contexts found. Use 'rosa login --context NAME' to create one.\n")
+		return nil
+	}
+
+	writer := tabwriter.NewWriter(Writer, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "CURRENT\tNAME\tURL\tFEDRAMP\n")
+	for _, context := range contexts {
+		current := ""
+		if context.Current {
+			current = "*"
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\n",
+			current,
+			context.Name,
+			context.Config.URL,
+			output.PrintBool(context.Config.FedRAMP),
+		)
+	}
+	return writer.Flush()
+}
@@ -25,6 +25,8 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/openshift/rosa/pkg/config"
+	"github.com/openshift/rosa/pkg/reporter"
+	"github.com/openshift/rosa/pkg/retry"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -46,8 +48,8 @@ func run(_ *cobra.Command, argv []string) {
 
 	err := SaveConfig(argv[0], argv[1])
 	if err != nil {
-		r.Reporter.Errorf(err.Error())
-		os.Exit(1)
+		r.Reporter.Errorf("%v", err)
+		os.Exit(reporter.ExitCode())
 	}
 }
 
@@ -80,6 +82,8 @@ func SaveConfig(arg, value string) error {
 		cfg.RefreshToken = value
 	case "scopes":
 		return fmt.Errorf("Setting scopes is unsupported")
+	case "current_context":
+		return fmt.Errorf("Setting current_context is unsupported, use 'rosa config use-context' instead")
 	case "token_url":
 		cfg.TokenURL = value
 	case "url":
@@ -89,6 +93,66 @@ func SaveConfig(arg, value string) error {
 		if err != nil {
 			return fmt.Errorf("Failed to set fedramp: %v", value)
 		}
+	case "ca_file":
+		if value != "" {
+			value, err = config.NormalizeCAFile(value)
+			if err != nil {
+				return err
+			}
+		}
+		cfg.CAFile = value
+	case "proxy_url":
+		if value != "" {
+			err = config.ValidateProxyURL(value)
+			if err != nil {
+				return err
+			}
+		}
+		cfg.ProxyURL = value
+	case "no_proxy":
+		cfg.NoProxy = value
+	case "retry_attempts":
+		cfg.RetryAttempts = 0
+		if value != "" {
+			cfg.RetryAttempts, err = strconv.Atoi(value)
+			if err != nil {
+				return fmt.Errorf("Failed to set retry_attempts: %v", value)
+			}
+			err = retry.ValidateAttempts(cfg.RetryAttempts)
+			if err != nil {
+				return err
+			}
+		}
+	case "retry_backoff":
+		if value != "" {
+			_, err = retry.ParseBackoff(arg, value)
+			if err != nil {
+				return err
+			}
+		}
+		cfg.RetryBackoff = value
+	case "retry_max_backoff":
+		if value != "" {
+			_, err = retry.ParseBackoff(arg, value)
+			if err != nil {
+				return err
+			}
+		}
+		cfg.RetryMaxBackoff = value
+	case "retry_jitter":
+		cfg.RetryJitter = nil
+		if value != "" {
+			var jitter float64
+			jitter, err = strconv.ParseFloat(value, 64)
+			if err != nil {
+				return fmt.Errorf("Failed to set retry_jitter: %v", value)
+			}
+			err = retry.ValidateJitter(jitter)
+			if err != nil {
+				return err
+			}
+			cfg.RetryJitter = &jitter
+		}
 	default:
 		return fmt.Errorf("'%s' is not a supported setting", arg)
 	}
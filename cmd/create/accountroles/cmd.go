@@ -29,6 +29,7 @@ import (
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/interactive/confirm"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/roles"
 	"github.com/openshift/rosa/pkg/rosa"
 )
@@ -169,7 +170,7 @@ func run(cmd *cobra.Command, argv []string) {
 	mode, err := interactive.GetMode()
 	if err != nil {
 		r.Reporter.Errorf("%s", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	var isHcpSharedVpc bool
@@ -181,7 +182,7 @@ func run(cmd *cobra.Command, argv []string) {
 			vpcEndpointRoleArnFlag, route53RoleArnFlag)
 		if err != nil {
 			r.Reporter.Errorf("%s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 
@@ -189,14 +190,14 @@ func run(cmd *cobra.Command, argv []string) {
 		err = aws.ARNValidator(args.vpcEndpointRoleArn)
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid policy ARN for %s: %s", vpcEndpointRoleArnFlag, err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 	if args.route53RoleArn != "" {
 		err = aws.ARNValidator(args.route53RoleArn)
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid policy ARN for %s: %s", route53RoleArnFlag, err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 
@@ -206,7 +207,7 @@ func run(cmd *cobra.Command, argv []string) {
 	err = login.Call(cmd, argv, r.Reporter)
 	if err != nil {
 		r.Reporter.Errorf("Failed to login to OCM: %v", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	r.WithOCM()
 	defer r.Cleanup()
@@ -214,13 +215,13 @@ func run(cmd *cobra.Command, argv []string) {
 	env, err := ocm.GetEnv()
 	if err != nil {
 		r.Reporter.Errorf("Failed to determine OCM environment: %v", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	managedPolicies := args.managed
 	if args.forcePolicyCreation && managedPolicies {
 		r.Reporter.Warnf("Forcing creation of policies only works for unmanaged policies")
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	if args.hostedCP && cmd.Flags().Changed("version") {
@@ -243,18 +244,18 @@ func run(cmd *cobra.Command, argv []string) {
 			managedPolicies = false
 		} else {
 			r.Reporter.Errorf("Setting `hosted-cp` as unmanaged policies is not supported")
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 
 	if isManagedSet && env == ocm.Production {
 		r.Reporter.Errorf("Classic ROSA managed policies are not supported in this environment")
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	if isHostedCPValueSet && r.Creator.IsGovcloud {
 		r.Reporter.Errorf("Setting `hosted-cp` is not supported for Govcloud AWS accounts")
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	// Validate AWS credentials for current user
@@ -265,12 +266,12 @@ func run(cmd *cobra.Command, argv []string) {
 	if err != nil {
 		r.OCMClient.LogEvent("ROSAInitCredentialsFailed", nil)
 		r.Reporter.Errorf("Error validating AWS credentials: %v", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	if !ok {
 		r.OCMClient.LogEvent("ROSAInitCredentialsInvalid", nil)
 		r.Reporter.Errorf("AWS credentials are invalid")
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	if r.Reporter.IsTerminal() {
 		r.Reporter.Infof("AWS credentials are valid!")
@@ -296,7 +297,7 @@ func run(cmd *cobra.Command, argv []string) {
 	policyVersion, err := r.OCMClient.GetPolicyVersion(version, channelGroup)
 	if err != nil {
 		r.Reporter.Errorf("Error getting version: %s", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	r.Reporter.Debugf("Creating account roles compatible with OpenShift versions up to %s", policyVersion)
@@ -315,20 +316,20 @@ func run(cmd *cobra.Command, argv []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid role prefix: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 	if len(prefix) > 32 {
 		r.Reporter.Errorf("Expected a prefix with no more than 32 characters")
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	if !aws.RoleNameRE.MatchString(prefix) {
 		r.Reporter.Errorf("Expected a valid role prefix matching %s", aws.RoleNameRE.String())
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	if !args.hostedCP && strings.HasSuffix(prefix, "-HCP") {
 		r.Reporter.Errorf("The '-HCP' suffix is reserved for hosted CP managed policies")
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	permissionsBoundary := args.permissionsBoundary
@@ -343,7 +344,7 @@ func run(cmd *cobra.Command, argv []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid policy ARN for permissions boundary: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 
@@ -351,7 +352,7 @@ func run(cmd *cobra.Command, argv []string) {
 		err = aws.ARNValidator(permissionsBoundary)
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid policy ARN for permissions boundary: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 
@@ -367,33 +368,33 @@ func run(cmd *cobra.Command, argv []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid path: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 
 	if path != "" && !aws.ARNPath.MatchString(path) {
 		r.Reporter.Errorf("The specified value for path is invalid. " +
 			"It must begin and end with '/' and contain only alphanumeric characters and/or '/' characters.")
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	if interactive.Enabled() {
 		mode, err = interactive.GetOptionMode(cmd, mode, "Role creation mode")
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid role creation mode: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 
 	if args.forcePolicyCreation && mode != interactive.ModeAuto {
 		r.Reporter.Warnf("Forcing creation of policies only works in auto mode")
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	policies, err := r.OCMClient.GetPolicies("AccountRole")
 	if err != nil {
 		r.Reporter.Errorf("Expected a valid role creation mode: %s", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	createClassic := args.classic
@@ -408,7 +409,7 @@ func run(cmd *cobra.Command, argv []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid value: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		isClassicValueSet = true
 	}
@@ -424,7 +425,7 @@ func run(cmd *cobra.Command, argv []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid value: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		isHostedCPValueSet = true
 	}
@@ -439,7 +440,7 @@ func run(cmd *cobra.Command, argv []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid value: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 
 		if !isHcpSharedVpc {
@@ -460,7 +461,7 @@ func run(cmd *cobra.Command, argv []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid value: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 	if interactive.Enabled() && isHcpSharedVpc && !r.Creator.IsGovcloud && createHostedCP {
@@ -475,7 +476,7 @@ func run(cmd *cobra.Command, argv []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid value: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 
@@ -487,7 +488,7 @@ func run(cmd *cobra.Command, argv []string) {
 			vpcEndpointRoleArnFlag, route53RoleArnFlag)
 		if err != nil {
 			r.Reporter.Errorf("%s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 
@@ -496,14 +497,14 @@ func run(cmd *cobra.Command, argv []string) {
 			vpcEndpointRoleArnFlag, route53RoleArnFlag)
 		if err != nil {
 			r.Reporter.Errorf("%s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 
 	rolesCreator, createRoles := initCreator(r, managedPolicies, createClassic, createHostedCP,
 		isClassicValueSet, isHostedCPValueSet)
 	if !createRoles {
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	input := buildRolesCreationInput(prefix, permissionsBoundary, r.Creator.AccountID, env, policies,
@@ -520,12 +521,12 @@ func run(cmd *cobra.Command, argv []string) {
 					ocm.Version:    policyVersion,
 					ocm.IsThrottle: "true",
 				})
-				os.Exit(1)
+				os.Exit(reporter.ExitCode())
 			}
 			r.OCMClient.LogEvent("ROSACreateAccountRolesModeAuto", map[string]string{
 				ocm.Response: ocm.Failure,
 			})
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		r.OCMClient.LogEvent("ROSACreateAccountRolesModeAuto", map[string]string{
 			ocm.Response: ocm.Success,
@@ -539,12 +540,12 @@ func run(cmd *cobra.Command, argv []string) {
 			r.OCMClient.LogEvent("ROSACreateAccountRolesModeManual", map[string]string{
 				ocm.Response: ocm.Failure,
 			})
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		err = rolesCreator.printCommands(r, input)
 		if err != nil {
 			r.Reporter.Errorf("%s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		if r.Reporter.IsTerminal() {
 			r.Reporter.Infof("All policy files saved to the current directory")
@@ -554,6 +555,6 @@ func run(cmd *cobra.Command, argv []string) {
 		})
 	default:
 		r.Reporter.Errorf("Invalid mode. Allowed values are %s", interactive.Modes)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 }
@@ -9,7 +9,9 @@ import (
 	"github.com/openshift/rosa/pkg/breakglasscredential"
 	"github.com/openshift/rosa/pkg/externalauthprovider"
 	"github.com/openshift/rosa/pkg/interactive"
+	"github.com/openshift/rosa/pkg/interrupt"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -41,8 +43,8 @@ func run(cmd *cobra.Command, argv []string) {
 	defer r.Cleanup()
 	err := runWithRuntime(r, cmd, argv)
 	if err != nil {
-		r.Reporter.Errorf(err.Error())
-		os.Exit(1)
+		r.Reporter.Errorf("%v", err)
+		os.Exit(reporter.ExitCode())
 	}
 }
 
@@ -73,11 +75,15 @@ func runWithRuntime(r *rosa.Runtime, cmd *cobra.Command, argv []string) error {
 		return err
 	}
 
+	done := interrupt.Track("break glass credential '%s' of cluster '%s', the kubeconfig can be retrieved "+
+		"later with 'rosa describe break-glass-credential %s -c %s --kubeconfig'",
+		credentialResponse.ID(), clusterKey, credentialResponse.ID(), clusterKey)
 	kubeconfig, err := r.OCMClient.PollKubeconfig(
 		cluster.ID(), credentialResponse.ID(), ocm.DefaultKubeConfigPollInterval, ocm.DefaultKubeConfigTimeout)
 	if err != nil {
 		return fmt.Errorf("An error occurred while polling for kubeconfig: %v", err)
 	}
+	done()
 
 	r.Reporter.Infof("Successfully created a break glass credential for cluster '%s'.",
 		clusterKey)
@@ -49,6 +49,7 @@ import (
 	"github.com/openshift/rosa/pkg/aws/tags"
 	"github.com/openshift/rosa/pkg/clusterautoscaler"
 	"github.com/openshift/rosa/pkg/clusterregistryconfig"
+	"github.com/openshift/rosa/pkg/clusterspec"
 	"github.com/openshift/rosa/pkg/fedramp"
 	"github.com/openshift/rosa/pkg/helper"
 	mpHelpers "github.com/openshift/rosa/pkg/helper/machinepools"
@@ -61,6 +62,7 @@ import (
 	interactiveOidc "github.com/openshift/rosa/pkg/interactive/oidc"
 	"github.com/openshift/rosa/pkg/interactive/securitygroups"
 	interactiveSgs "github.com/openshift/rosa/pkg/interactive/securitygroups"
+	"github.com/openshift/rosa/pkg/interrupt"
 	"github.com/openshift/rosa/pkg/machinepool"
 	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/output"
@@ -104,6 +106,8 @@ var args struct {
 
 	// Simulate creating a cluster
 	dryRun bool
+	// Read the cluster spec from a file
+	fromFile string
 	// Create a fake cluster with no AWS resources
 	fakeCluster bool
 	// Set custom properties in cluster spec
@@ -702,6 +706,14 @@ func initFlags(cmd *cobra.Command) {
 		"Simulate creating the cluster.",
 	)
 
+	flags.StringVar(
+		&args.fromFile,
+		"from-file",
+		"",
+		"Read the cluster spec from the given YAML file. Flags given in the command line override "+
+			"the values of the file.",
+	)
+
 	flags.BoolVar(
 		&args.fakeCluster,
 		"fake-cluster",
@@ -924,21 +936,38 @@ func networkTypeCompletion(cmd *cobra.Command, args []string, toComplete string)
 }
 
 func run(cmd *cobra.Command, _ []string) {
-	r := rosa.NewRuntime().WithAWS().WithOCM()
+	r := rosa.NewRuntime()
 	defer r.Cleanup()
 
+	// The values of the spec file are applied to the flags before anything else, so that they
+	// are used exactly like flags given in the command line:
+	if args.fromFile != "" {
+		spec, err := clusterspec.Load(args.fromFile)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(reporter.ExitCode())
+		}
+		err = clusterspec.Apply(spec, cmd.Flags())
+		if err != nil {
+			r.Reporter.Errorf("Failed to apply cluster spec file '%s': %s", args.fromFile, err)
+			os.Exit(reporter.ExitCode())
+		}
+	}
+
+	r.WithAWS().WithOCM()
+
 	// Validate mode
 	mode, err := interactive.GetMode()
 	if err != nil {
 		r.Reporter.Errorf("%s", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	for _, val := range userSpecifiedAutoscalerValues {
 		if val.Changed && !args.autoscalingEnabled {
 			r.Reporter.Errorf("Using autoscaling flag '%s', requires flag '--enable-autoscaling'. "+
 				"Please try again with flag", val.Name)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 
@@ -947,17 +976,17 @@ func run(cmd *cobra.Command, _ []string) {
 	if isHostedCP {
 		if fedramp.Enabled() {
 			r.Reporter.Errorf("Fedramp does not currently support Hosted Control Plane clusters. Please use classic")
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		if cmd.Flag(securitygroups.InfraSecurityGroupFlag).Changed {
 			r.Reporter.Errorf("Cannot use '%s' flag with Hosted Control Plane clusters, only '%s' is "+
 				"supported", securitygroups.InfraSecurityGroupFlag, securitygroups.ComputeSecurityGroupFlag)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		if cmd.Flag(securitygroups.ControlPlaneSecurityGroupFlag).Changed {
 			r.Reporter.Errorf("Cannot use '%s' flag with Hosted Control Plane clusters, only '%s' is "+
 				"supported", securitygroups.ControlPlaneSecurityGroupFlag, securitygroups.ComputeSecurityGroupFlag)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 
@@ -971,7 +1000,7 @@ func run(cmd *cobra.Command, _ []string) {
 	awsCreator, err := awsClient.GetCreator()
 	if err != nil {
 		r.Reporter.Errorf("Unable to get IAM credentials: %v", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	shardPinningEnabled := false
@@ -1021,7 +1050,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid cluster name: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 
@@ -1032,7 +1061,7 @@ func run(cmd *cobra.Command, _ []string) {
 		r.Reporter.Errorf("Cluster name must consist"+
 			" of no more than %d lowercase alphanumeric characters or '-', "+
 			"start with a letter, and end with an alphanumeric character.", ocm.MaxClusterNameLength)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	// Get cluster domain prefix
@@ -1050,7 +1079,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid domain prefix: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 
@@ -1061,7 +1090,7 @@ func run(cmd *cobra.Command, _ []string) {
 		r.Reporter.Errorf("Domain prefix must consist"+
 			" of no more than %d lowercase alphanumeric characters or '-', "+
 			"start with a letter, and end with an alphanumeric character.", ocm.MaxClusterDomainPrefixLength)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	if clusterHasLongNameWithoutDomainPrefix(clusterName, domainPrefix) {
@@ -1085,7 +1114,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid --hosted-cp value: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 
@@ -1093,7 +1122,7 @@ func run(cmd *cobra.Command, _ []string) {
 		techPreviewMsg, err := r.OCMClient.GetTechnologyPreviewMessage(ocm.HcpProduct, time.Now())
 		if err != nil {
 			r.Reporter.Errorf("%s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		if techPreviewMsg != "" {
 			r.Reporter.Infof(techPreviewMsg)
@@ -1114,20 +1143,20 @@ func run(cmd *cobra.Command, _ []string) {
 			clusterAdminPassword, err = idputils.GenerateRandomPassword()
 			if err != nil {
 				r.Reporter.Errorf("Failed to generate a random password")
-				os.Exit(1)
+				os.Exit(reporter.ExitCode())
 			}
 		}
 		// validates both user inputted custom password and randomly generated password
 		err = passwordValidator.PasswordValidator(clusterAdminPassword)
 		if err != nil {
 			r.Reporter.Errorf("%s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		if clusterAdminUser != "" {
 			err = idp.UsernameValidator(clusterAdminUser)
 			if err != nil {
 				r.Reporter.Errorf("%s", err)
-				os.Exit(1)
+				os.Exit(reporter.ExitCode())
 			}
 		} else {
 			clusterAdminUser = admin.ClusterAdminUsername
@@ -1143,7 +1172,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid value: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		if isClusterAdmin {
 			//clusterAdminUser = idp.GetIdpUserNameFromPrompt(cmd, r, "cluster-admin-user", clusterAdminUser, true)
@@ -1154,13 +1183,13 @@ func run(cmd *cobra.Command, _ []string) {
 			})
 			if err != nil {
 				r.Reporter.Errorf("Expected a valid value: %s", err)
-				os.Exit(1)
+				os.Exit(reporter.ExitCode())
 			}
 			if !isCustomAdminPassword {
 				clusterAdminPassword, err = idputils.GenerateRandomPassword()
 				if err != nil {
 					r.Reporter.Errorf("Failed to generate a random password")
-					os.Exit(1)
+					os.Exit(reporter.ExitCode())
 				}
 			} else {
 				clusterAdminPassword = idp.GetIdpPasswordFromPrompt(cmd, r,
@@ -1173,7 +1202,7 @@ func run(cmd *cobra.Command, _ []string) {
 
 	if isHostedCP && cmd.Flags().Changed(arguments.NewDefaultMPLabelsFlag) {
 		r.Reporter.Errorf("Setting the worker machine pool labels is not supported for hosted clusters")
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	// Billing Account
@@ -1182,7 +1211,7 @@ func run(cmd *cobra.Command, _ []string) {
 		isHcpBillingTechPreview, err := r.OCMClient.IsTechnologyPreview(ocm.HcpBillingAccount, time.Now())
 		if err != nil {
 			r.Reporter.Errorf("%s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 
 		if !isHcpBillingTechPreview {
@@ -1191,13 +1220,13 @@ func run(cmd *cobra.Command, _ []string) {
 				r.Reporter.Errorf("Provided billing account number %s is not valid. "+
 					"Rerun the command with a valid billing account number. %s",
 					billingAccount, listBillingAccountMessage)
-				os.Exit(1)
+				os.Exit(reporter.ExitCode())
 			}
 
 			cloudAccounts, err := r.OCMClient.GetBillingAccounts()
 			if err != nil {
 				r.Reporter.Errorf("%s", err)
-				os.Exit(1)
+				os.Exit(reporter.ExitCode())
 			}
 
 			billingAccounts := ocm.GenerateBillingAccountsList(cloudAccounts)
@@ -1207,7 +1236,7 @@ func run(cmd *cobra.Command, _ []string) {
 				billingAccount, err = provideBillingAccount(billingAccounts, awsCreator.AccountID, r)
 				if err != nil {
 					r.Reporter.Errorf("%s", err)
-					os.Exit(1)
+					os.Exit(reporter.ExitCode())
 				}
 			}
 
@@ -1223,7 +1252,7 @@ func run(cmd *cobra.Command, _ []string) {
 
 					if err != nil {
 						r.Reporter.Errorf("Expected a valid billing account: '%s'", err)
-						os.Exit(1)
+						os.Exit(reporter.ExitCode())
 					}
 
 					billingAccount = aws.ParseOption(billingAccount)
@@ -1232,7 +1261,7 @@ func run(cmd *cobra.Command, _ []string) {
 				err := ocm.ValidateBillingAccount(billingAccount)
 				if err != nil {
 					r.Reporter.Errorf("%v", err)
-					os.Exit(1)
+					os.Exit(reporter.ExitCode())
 				}
 
 				// Get contract info
@@ -1260,7 +1289,7 @@ func run(cmd *cobra.Command, _ []string) {
 
 	if !isHostedCP && billingAccount != "" {
 		r.Reporter.Errorf("Billing accounts are only supported for Hosted Control Plane clusters")
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	externalAuthProvidersEnabled := args.externalAuthProvidersEnabled
@@ -1269,7 +1298,7 @@ func run(cmd *cobra.Command, _ []string) {
 			r.Reporter.Errorf(
 				"External authentication configuration is only supported for a Hosted Control Plane cluster.",
 			)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 
@@ -1277,7 +1306,7 @@ func run(cmd *cobra.Command, _ []string) {
 
 	if etcdEncryptionKmsARN != "" && !isHostedCP {
 		r.Reporter.Errorf("etcd encryption kms arn is only allowed for hosted cp")
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	// all hosted clusters are sts
@@ -1286,7 +1315,7 @@ func run(cmd *cobra.Command, _ []string) {
 
 	if isSTS && isIAM {
 		r.Reporter.Errorf("Can't use both STS and mint mode at the same time.")
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	if interactive.Enabled() && (!isSTS && !isIAM) {
@@ -1298,7 +1327,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid --sts value: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		isIAM = !isSTS
 	}
@@ -1316,7 +1345,7 @@ func run(cmd *cobra.Command, _ []string) {
 		err = aws.ARNValidator(permissionsBoundary)
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid policy ARN for permissions boundary: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 
@@ -1324,12 +1353,12 @@ func run(cmd *cobra.Command, _ []string) {
 		if awsCreator.IsSTS {
 			r.Reporter.Errorf("Since your AWS credentials are returning an STS ARN you can only " +
 				"create STS clusters. Otherwise, switch to IAM credentials.")
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		err := awsClient.CheckAdminUserExists(aws.AdminUserName)
 		if err != nil {
 			r.Reporter.Errorf("IAM user '%s' does not exist. Run `rosa init` first", aws.AdminUserName)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		r.Reporter.Debugf("IAM user is valid!")
 	}
@@ -1346,7 +1375,7 @@ func run(cmd *cobra.Command, _ []string) {
 	defaultVersion, versionList, err := versions.GetVersionList(r, channelGroup, isSTS, isHostedCP, isHostedCP, true)
 	if err != nil {
 		r.Reporter.Errorf("%s", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	if version == "" {
 		version = defaultVersion
@@ -1361,13 +1390,13 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid OpenShift version: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 	version, err = r.OCMClient.ValidateVersion(version, versionList, channelGroup, isSTS, isHostedCP)
 	if err != nil {
 		r.Reporter.Errorf("Expected a valid OpenShift version: %s", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	if err := r.OCMClient.IsVersionCloseToEol(ocm.CloseToEolDays, version, channelGroup); err != nil {
 		r.Reporter.Warnf("%v", err)
@@ -1390,16 +1419,16 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid http tokens value : %v", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 	if err = ocm.ValidateHttpTokensValue(httpTokens); err != nil {
 		r.Reporter.Errorf("Expected a valid http tokens value : %v", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	if err := ocm.ValidateHttpTokensVersion(ocm.GetVersionMinor(version), httpTokens); err != nil {
-		r.Reporter.Errorf(err.Error())
-		os.Exit(1)
+		r.Reporter.Errorf("%v", err)
+		os.Exit(reporter.ExitCode())
 	}
 
 	// warn if mode is used for non sts cluster
@@ -1413,7 +1442,7 @@ func run(cmd *cobra.Command, _ []string) {
 		isValidMode := arguments.IsValidMode(interactive.Modes, mode)
 		if !isValidMode {
 			r.Reporter.Errorf("Invalid --mode '%s'. Allowed values are %s", mode, interactive.Modes)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 
@@ -1421,14 +1450,14 @@ func run(cmd *cobra.Command, _ []string) {
 		r.Reporter.Errorf("Cannot watch for STS cluster installation logs in mode 'auto' " +
 			"without also supplying '--yes' option." +
 			"To watch your cluster installation logs, run 'rosa logs install' instead after the cluster has began creating.")
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	if args.watch && isSTS && mode == interactive.ModeManual {
 		r.Reporter.Errorf("Cannot watch for STS cluster installation logs in mode 'manual'." +
 			"It requires manual commands to be performed as part of the process." +
 			"To watch your cluster installation logs, run 'rosa logs install' after the cluster has began creating.")
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	hasRoles := false
@@ -1445,7 +1474,7 @@ func run(cmd *cobra.Command, _ []string) {
 		}
 		if err != nil {
 			r.Reporter.Errorf("Failed to find %s role: %s", role.Name, err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 
 		if len(roleARNs) > 1 {
@@ -1484,7 +1513,7 @@ func run(cmd *cobra.Command, _ []string) {
 				})
 				if err != nil {
 					r.Reporter.Errorf("Expected a valid role ARN: %s", err)
-					os.Exit(1)
+					os.Exit(reporter.ExitCode())
 				}
 			}
 		} else if len(roleARNs) == 1 {
@@ -1508,7 +1537,7 @@ func run(cmd *cobra.Command, _ []string) {
 			hostedCPPolicies, err := awsClient.HasHostedCPPolicies(roleARN)
 			if err != nil {
 				r.Reporter.Errorf("Failed to determine if cluster has hosted CP policies: %v", err)
-				os.Exit(1)
+				os.Exit(reporter.ExitCode())
 			}
 			hasRoles = true
 			for roleType, role := range aws.AccountRoles {
@@ -1527,14 +1556,14 @@ func run(cmd *cobra.Command, _ []string) {
 				}
 				if err != nil {
 					r.Reporter.Errorf("Failed to find %s role: %s", role.Name, err)
-					os.Exit(1)
+					os.Exit(reporter.ExitCode())
 				}
 				selectedARN := ""
 				expectedResourceIDForAccRole, rolePrefix, err := getExpectedResourceIDForAccRole(
 					hostedCPPolicies, roleARN, roleType)
 				if err != nil {
 					r.Reporter.Errorf("Failed to get the expected resource ID for role type: %s", roleType)
-					os.Exit(1)
+					os.Exit(reporter.ExitCode())
 				}
 				r.Reporter.Debugf(
 					"Using '%s' as the role prefix to retrieve the expected resource ID for role type '%s'",
@@ -1546,7 +1575,7 @@ func run(cmd *cobra.Command, _ []string) {
 					resourceId, err := aws.GetResourceIdFromARN(rARN)
 					if err != nil {
 						r.Reporter.Errorf("Failed to get resource ID from arn. %s", err)
-						os.Exit(1)
+						os.Exit(reporter.ExitCode())
 					}
 					lowerCaseResourceIdToCheck := strings.ToLower(resourceId)
 					if lowerCaseResourceIdToCheck == expectedResourceIDForAccRole {
@@ -1595,7 +1624,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid ARN: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 
@@ -1603,7 +1632,7 @@ func run(cmd *cobra.Command, _ []string) {
 		err = aws.ARNValidator(roleARN)
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid Role ARN: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		isSTS = true
 	}
@@ -1624,7 +1653,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid External ID: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 
@@ -1644,18 +1673,18 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid ARN: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 	if supportRoleARN != "" {
 		err = aws.ARNValidator(supportRoleARN)
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid Support Role ARN: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	} else if roleARN != "" {
 		r.Reporter.Errorf("Support Role ARN is required: %s", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	// Instance IAM Roles
@@ -1676,18 +1705,18 @@ func run(cmd *cobra.Command, _ []string) {
 			})
 			if err != nil {
 				r.Reporter.Errorf("Expected a valid control plane IAM role ARN: %s", err)
-				os.Exit(1)
+				os.Exit(reporter.ExitCode())
 			}
 		}
 		if controlPlaneRoleARN != "" {
 			err = aws.ARNValidator(controlPlaneRoleARN)
 			if err != nil {
 				r.Reporter.Errorf("Expected a valid control plane instance IAM role ARN: %s", err)
-				os.Exit(1)
+				os.Exit(reporter.ExitCode())
 			}
 		} else if roleARN != "" {
 			r.Reporter.Errorf("Control plane instance IAM role ARN is required: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 
@@ -1708,18 +1737,18 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid worker IAM role ARN: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 	if workerRoleARN != "" {
 		err = aws.ARNValidator(workerRoleARN)
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid worker instance IAM role ARN: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	} else if roleARN != "" {
 		r.Reporter.Errorf("Worker instance IAM role ARN is required: %s", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	// combine role arns to list
@@ -1733,32 +1762,32 @@ func run(cmd *cobra.Command, _ []string) {
 	managedPolicies, err := awsClient.HasManagedPolicies(roleARN)
 	if err != nil {
 		r.Reporter.Errorf("Failed to determine if cluster has managed policies: %v", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	// check if role has hosted cp policy via AWS tag value
 	hostedCPPolicies, err := awsClient.HasHostedCPPolicies(roleARN)
 	if err != nil {
 		r.Reporter.Errorf("Failed to determine if cluster has hosted CP policies: %v", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	if managedPolicies {
 		rolePrefix, err := getAccountRolePrefix(hostedCPPolicies, roleARN, aws.InstallerAccountRole)
 		if err != nil {
 			r.Reporter.Errorf("Failed to find prefix from account role: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 
 		err = roles.ValidateAccountRolesManagedPolicies(r, rolePrefix, hostedCPPolicies)
 		if err != nil {
 			r.Reporter.Errorf("Failed while validating account roles: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	} else {
 		err = roles.ValidateUnmanagedAccountRoles(roleARNs, awsClient, version)
 		if err != nil {
 			r.Reporter.Errorf("Failed while validating account roles: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 
@@ -1784,31 +1813,31 @@ func run(cmd *cobra.Command, _ []string) {
 			})
 			if err != nil {
 				r.Reporter.Errorf("Expected a prefix for the operator IAM roles: %s", err)
-				os.Exit(1)
+				os.Exit(reporter.ExitCode())
 			}
 		}
 		if len(operatorRolesPrefix) == 0 {
 			r.Reporter.Errorf("Expected a prefix for the operator IAM roles: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		if len(operatorRolesPrefix) > 32 {
 			r.Reporter.Errorf("Expected a prefix with no more than 32 characters")
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		if !aws.RoleNameRE.MatchString(operatorRolesPrefix) {
 			r.Reporter.Errorf("Expected valid operator roles prefix matching %s", aws.RoleNameRE.String())
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 
 		credRequests, err := r.OCMClient.GetAllCredRequests()
 		if err != nil {
 			r.Reporter.Errorf("Error getting operator credential request from OCM %v", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		operatorRoles, err = r.AWSClient.GetOperatorRolesFromAccountByPrefix(operatorRolesPrefix, credRequests)
 		if err != nil {
 			r.Reporter.Errorf("There was a problem retrieving the Operator Roles from AWS: %v", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 
@@ -1817,12 +1846,12 @@ func run(cmd *cobra.Command, _ []string) {
 		credRequests, err := r.OCMClient.GetCredRequests(isHostedCP)
 		if err != nil {
 			r.Reporter.Errorf("Error getting operator credential request from OCM %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		accRolesPrefix, err := getAccountRolePrefix(hostedCPPolicies, roleARN, aws.InstallerAccountRole)
 		if err != nil {
 			r.Reporter.Errorf("Failed to find prefix from account role: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		if expectedOperatorRolePath != "" && !output.HasFlag() && r.Reporter.IsTerminal() {
 			r.Reporter.Infof("ARN path '%s' detected. This ARN path will be used for subsequent"+
@@ -1835,7 +1864,7 @@ func run(cmd *cobra.Command, _ []string) {
 				isSupported, err := ocm.CheckSupportedVersion(ocm.GetVersionMinor(version), operator.MinVersion())
 				if err != nil {
 					r.Reporter.Errorf("Error validating operator role '%s' version %s", operator.Name(), err)
-					os.Exit(1)
+					os.Exit(reporter.ExitCode())
 				}
 				if !isSupported {
 					continue
@@ -1856,13 +1885,13 @@ func run(cmd *cobra.Command, _ []string) {
 				if !strings.Contains(role, ",") {
 					r.Reporter.Errorf("Expected operator IAM roles to be a comma-separated " +
 						"list of name,namespace,role_arn")
-					os.Exit(1)
+					os.Exit(reporter.ExitCode())
 				}
 				roleData := strings.Split(role, ",")
 				if len(roleData) != 3 {
 					r.Reporter.Errorf("Expected operator IAM roles to be a comma-separated " +
 						"list of name,namespace,role_arn")
-					os.Exit(1)
+					os.Exit(reporter.ExitCode())
 				}
 				computedOperatorIamRoleList = append(computedOperatorIamRoleList, ocm.OperatorIAMRole{
 					Name:      roleData[0],
@@ -1876,20 +1905,20 @@ func run(cmd *cobra.Command, _ []string) {
 		if err != nil {
 			if !oidcConfig.Reusable() {
 				r.Reporter.Errorf("%v", err)
-				os.Exit(1)
+				os.Exit(reporter.ExitCode())
 			} else {
 				err = ocm.ValidateOperatorRolesMatchOidcProvider(r.Reporter, awsClient, computedOperatorIamRoleList,
 					oidcConfig.IssuerUrl(), ocm.GetVersionMinor(version), expectedOperatorRolePath, managedPolicies, true)
 				if err != nil {
 					r.Reporter.Errorf("%v", err)
-					os.Exit(1)
+					os.Exit(reporter.ExitCode())
 				}
 			}
 		}
 		err = validateUniqueIamRoleArnsForStsCluster(roleARNs, computedOperatorIamRoleList)
 		if err != nil {
-			r.Reporter.Errorf(err.Error())
-			os.Exit(1)
+			r.Reporter.Errorf("%v", err)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 
@@ -1908,7 +1937,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid set of tags: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		if len(tagsInput) > 0 {
 			_tags = strings.Split(tagsInput, ",")
@@ -1917,7 +1946,7 @@ func run(cmd *cobra.Command, _ []string) {
 	if len(_tags) > 0 {
 		if err := aws.UserTagValidator(_tags); err != nil {
 			r.Reporter.Errorf("%s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		delim := aws.GetTagsDelimiter(_tags)
 		for _, tag := range _tags {
@@ -1936,7 +1965,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid multi-AZ value: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 
@@ -1954,7 +1983,7 @@ func run(cmd *cobra.Command, _ []string) {
 	region, err := aws.GetRegion(arguments.GetRegion())
 	if err != nil {
 		r.Reporter.Errorf("Error getting region: %v", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	// Filter regions by OCP version for displaying in interactive mode
 	var versionFilter string
@@ -1966,12 +1995,12 @@ func run(cmd *cobra.Command, _ []string) {
 	regionList, regionAZ, err := r.OCMClient.GetRegionList(multiAZ, roleARN, externalID, versionFilter,
 		awsClient, isHostedCP, shardPinningEnabled)
 	if err != nil {
-		r.Reporter.Errorf(fmt.Sprintf("%s", err))
-		os.Exit(1)
+		r.Reporter.Errorf("%s", err)
+		os.Exit(reporter.ExitCode())
 	}
 	if region == "" {
 		r.Reporter.Errorf("Expected a valid AWS region")
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	} else if found := helper.Contains(regionList, region); isHostedCP && !shardPinningEnabled && !found {
 		r.Reporter.Warnf("Region '%s' not currently available for Hosted Control Plane cluster.", region)
 		interactive.Enable()
@@ -1987,17 +2016,17 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid AWS region: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 	if supportsMultiAZ, found := regionAZ[region]; found {
 		if !supportsMultiAZ && multiAZ {
 			r.Reporter.Errorf("Region '%s' does not support multiple availability zones", region)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	} else {
 		r.Reporter.Errorf("Region '%s' is not supported for this AWS account", region)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	awsClient, err = aws.NewClient().
@@ -2007,7 +2036,7 @@ func run(cmd *cobra.Command, _ []string) {
 		Build()
 	if err != nil {
 		r.Reporter.Errorf("Failed to create awsClient: %s", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	r.AWSClient = awsClient
 
@@ -2030,7 +2059,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid private-link value: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	} else if (privateLink || (isSTS && private)) && !fedramp.Enabled() && !isPrivateHostedCP {
 		// do not prompt users for privatelink if it is private hosted cluster
@@ -2045,7 +2074,7 @@ func run(cmd *cobra.Command, _ []string) {
 		private = true
 	} else if isSTS && private {
 		r.Reporter.Errorf("Private STS clusters are only supported through AWS PrivateLink")
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	} else if !isSTS {
 		privateWarning := "You will not be able to access your cluster until " +
 			"you edit network settings in your cloud provider."
@@ -2057,7 +2086,7 @@ func run(cmd *cobra.Command, _ []string) {
 			})
 			if err != nil {
 				r.Reporter.Errorf("Expected a valid private value: %s", err)
-				os.Exit(1)
+				os.Exit(reporter.ExitCode())
 			}
 		} else if private {
 			r.Reporter.Warnf("You are choosing to make your cluster private. %s", privateWarning)
@@ -2069,7 +2098,7 @@ func run(cmd *cobra.Command, _ []string) {
 
 	if isSTS && private && !privateLink {
 		r.Reporter.Errorf("Private STS clusters are only supported through AWS PrivateLink")
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	if privateLink || isHostedCP {
@@ -2097,7 +2126,7 @@ func run(cmd *cobra.Command, _ []string) {
 		GetDefaultClusterFlavors(args.flavour)
 	if dMachinecidr == nil || dPodcidr == nil || dServicecidr == nil {
 		r.Reporter.Errorf("Error retrieving default cluster flavors")
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	// Machine CIDR:
@@ -2113,7 +2142,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid CIDR value: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 
@@ -2130,7 +2159,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid CIDR value: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 	// Pod CIDR:
@@ -2146,7 +2175,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid CIDR value: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 
@@ -2169,13 +2198,13 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid value: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 
 	if isHostedCP && !subnetsProvided && !useExistingVPC {
 		r.Reporter.Errorf("All hosted clusters need a pre-configured VPC. Make sure to specify the subnet ids")
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	// For hosted cluster we will need the number of the private subnets the users has selected
@@ -2188,7 +2217,7 @@ func run(cmd *cobra.Command, _ []string) {
 		initialSubnets, err := getInitialValidSubnets(awsClient, subnetIDs, r.Reporter)
 		if err != nil {
 			r.Reporter.Errorf("Failed to get the list of subnets: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		if subnetsProvided {
 			useExistingVPC = true
@@ -2196,18 +2225,18 @@ func run(cmd *cobra.Command, _ []string) {
 		_, machineNetwork, err := net.ParseCIDR(machineCIDR.String())
 		if err != nil {
 			r.Reporter.Errorf("Unable to parse machine CIDR")
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		_, serviceNetwork, err := net.ParseCIDR(serviceCIDR.String())
 		if err != nil {
 			r.Reporter.Errorf("Unable to parse service CIDR")
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		var filterError error
 		subnets, filterError = filterCidrRangeSubnets(initialSubnets, machineNetwork, serviceNetwork, r)
 		if filterError != nil {
 			r.Reporter.Errorf("%s", filterError)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		if privateLink {
 			subnets = filterPrivateSubnets(subnets, r)
@@ -2219,10 +2248,10 @@ func run(cmd *cobra.Command, _ []string) {
 					"All Hosted Control Plane clusters need a pre-configured VPC. Please check: %s",
 					createVpcForHcpDoc,
 				)
-				os.Exit(1)
+				os.Exit(reporter.ExitCode())
 			}
 			if ok := confirm.Prompt(false, "Continue with default? A new RH Managed VPC will be created for your cluster"); !ok {
-				os.Exit(1)
+				os.Exit(reporter.ExitCode())
 			}
 			useExistingVPC = false
 			subnetsProvided = false
@@ -2243,7 +2272,7 @@ func run(cmd *cobra.Command, _ []string) {
 				if !verifiedSubnet {
 					r.Reporter.Errorf("Could not find the following subnet provided in region '%s': %s",
 						r.AWSClient.GetRegion(), subnetArg)
-					os.Exit(1)
+					os.Exit(reporter.ExitCode())
 				}
 			}
 		}
@@ -2291,7 +2320,7 @@ func run(cmd *cobra.Command, _ []string) {
 			})
 			if err != nil {
 				r.Reporter.Errorf("Expected valid subnet IDs: %s", err)
-				os.Exit(1)
+				os.Exit(reporter.ExitCode())
 			}
 			for i, subnet := range subnetIDs {
 				subnetIDs[i] = aws.ParseOption(subnet)
@@ -2310,7 +2339,7 @@ func run(cmd *cobra.Command, _ []string) {
 			}
 			if err != nil {
 				r.Reporter.Errorf("%s", err)
-				os.Exit(1)
+				os.Exit(reporter.ExitCode())
 			}
 		}
 
@@ -2346,7 +2375,7 @@ func run(cmd *cobra.Command, _ []string) {
 
 	if len(subnetIDs) == 0 && (isSharedVPC || isHcpSharedVpc) {
 		r.Reporter.Errorf("Installing a cluster into a shared VPC is only supported for BYO VPC clusters")
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	if isSubnetBelongToSharedVpc(r, awsCreator.AccountID, subnetIDs, mapSubnetIDToSubnet) {
@@ -2355,7 +2384,7 @@ func run(cmd *cobra.Command, _ []string) {
 			r.Reporter.Errorf("Installing a cluster into shared VPC is only supported for cluster "+
 				"which has a name no longer than %d characters or with a cluster domain prefix",
 				ocm.MaxClusterDomainPrefixLength)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 
 		isSharedVPC = true
@@ -2378,7 +2407,7 @@ func run(cmd *cobra.Command, _ []string) {
 			privateHostedZoneID, err = getPrivateHostedZoneID(cmd, privateHostedZoneID)
 			if err != nil {
 				r.Reporter.Errorf("%s", err)
-				os.Exit(1)
+				os.Exit(reporter.ExitCode())
 			}
 
 			// TODO: We can remove this and replace the above once we deprecate the old flags
@@ -2390,14 +2419,14 @@ func run(cmd *cobra.Command, _ []string) {
 
 				if err != nil {
 					r.Reporter.Errorf("%s", err)
-					os.Exit(1)
+					os.Exit(reporter.ExitCode())
 				}
 			}
 
 			sharedVPCRoleARN, err = getSharedVpcRoleArn(cmd, sharedVPCRoleARN)
 			if err != nil {
 				r.Reporter.Errorf("%s", err)
-				os.Exit(1)
+				os.Exit(reporter.ExitCode())
 			}
 
 			// TODO: We can remove this and replace the above once we deprecate the old flags
@@ -2407,14 +2436,14 @@ func run(cmd *cobra.Command, _ []string) {
 				vpcEndpointRoleArn, err = getVpcEndpointRoleArn(cmd, vpcEndpointRoleArn)
 				if err != nil {
 					r.Reporter.Errorf("%s", err)
-					os.Exit(1)
+					os.Exit(reporter.ExitCode())
 				}
 			}
 
 			baseDomain, err = getBaseDomain(r, cmd, baseDomain, isHostedCP)
 			if err != nil {
 				r.Reporter.Errorf("%s", err)
-				os.Exit(1)
+				os.Exit(reporter.ExitCode())
 			}
 		}
 	}
@@ -2426,17 +2455,17 @@ func run(cmd *cobra.Command, _ []string) {
 				hcpInternalCommunicationHostedZoneId)
 			if err != nil {
 				r.Reporter.Errorf("Error when validating flags: %v", err)
-				os.Exit(1)
+				os.Exit(reporter.ExitCode())
 			}
 		} else {
 			if vpcEndpointRoleArn != "" {
 				r.Reporter.Errorf(hcpSharedVpcFlagOnlyErrorMsg,
 					vpcEndpointRoleArnFlag)
-				os.Exit(1)
+				os.Exit(reporter.ExitCode())
 			} else if hcpInternalCommunicationHostedZoneId != "" {
 				r.Reporter.Errorf(hcpSharedVpcFlagOnlyErrorMsg,
 					hcpInternalCommunicationHostedZoneIdFlag)
-				os.Exit(1)
+				os.Exit(reporter.ExitCode())
 			}
 		}
 	}
@@ -2457,20 +2486,20 @@ func run(cmd *cobra.Command, _ []string) {
 			})
 			if err != nil {
 				r.Reporter.Errorf("Expected a valid value for select-availability-zones: %s", err)
-				os.Exit(1)
+				os.Exit(reporter.ExitCode())
 			}
 
 			if selectAvailabilityZones {
 				optionsAvailabilityZones, err := awsClient.DescribeAvailabilityZones()
 				if err != nil {
 					r.Reporter.Errorf("Failed to get the list of the availability zone: %s", err)
-					os.Exit(1)
+					os.Exit(reporter.ExitCode())
 				}
 
 				availabilityZones, err = selectAvailabilityZonesInteractively(cmd, optionsAvailabilityZones, multiAZ)
 				if err != nil {
 					r.Reporter.Errorf("%s", err)
-					os.Exit(1)
+					os.Exit(reporter.ExitCode())
 				}
 			}
 		}
@@ -2478,8 +2507,8 @@ func run(cmd *cobra.Command, _ []string) {
 		if isAvailabilityZonesSet || selectAvailabilityZones {
 			err = validateAvailabilityZones(multiAZ, availabilityZones, awsClient)
 			if err != nil {
-				r.Reporter.Errorf(fmt.Sprintf("%s", err))
-				os.Exit(1)
+				r.Reporter.Errorf("%s", err)
+				os.Exit(reporter.ExitCode())
 			}
 		}
 	}
@@ -2499,7 +2528,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid value for enable-customer-managed-key: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 
@@ -2515,14 +2544,14 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid value for kms-key-arn: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 
 	err = kmsArnRegexpValidator.ValidateKMSKeyARN(&kmsKeyARN)
 	if err != nil {
 		r.Reporter.Errorf("Expected a valid value for kms-key-arn: %s", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	// Compute node instance type:
@@ -2530,8 +2559,8 @@ func run(cmd *cobra.Command, _ []string) {
 	computeMachineTypeList, err := r.OCMClient.GetAvailableMachineTypesInRegion(region, availabilityZones, roleARN,
 		awsClient, externalID)
 	if err != nil {
-		r.Reporter.Errorf(fmt.Sprintf("%s", err))
-		os.Exit(1)
+		r.Reporter.Errorf("%s", err)
+		os.Exit(reporter.ExitCode())
 	}
 	if computeMachineType == "" {
 		computeMachineType = defaultComputeMachineType
@@ -2545,13 +2574,13 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid machine type: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 	err = computeMachineTypeList.ValidateMachineType(computeMachineType, multiAZ)
 	if err != nil {
 		r.Reporter.Errorf("Expected a valid machine type: %s", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	isAutoscalingSet := cmd.Flags().Changed("enable-autoscaling")
@@ -2568,7 +2597,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid value for enable-autoscaling: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 
@@ -2599,7 +2628,7 @@ func run(cmd *cobra.Command, _ []string) {
 		// if the user set compute-nodes and enabled autoscaling
 		if isReplicasSet {
 			r.Reporter.Errorf("Compute-nodes can't be set when autoscaling is enabled")
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		if interactive.Enabled() || !isMinReplicasSet {
 			minReplicas, err = interactive.GetInt(interactive.Input{
@@ -2613,13 +2642,13 @@ func run(cmd *cobra.Command, _ []string) {
 			})
 			if err != nil {
 				r.Reporter.Errorf("Expected a valid number of min replicas: %s", err)
-				os.Exit(1)
+				os.Exit(reporter.ExitCode())
 			}
 		}
 		err = replicaSizeValidation.MinReplicaValidatorOnClusterCreate()(minReplicas)
 		if err != nil {
 			r.Reporter.Errorf("%s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		replicaSizeValidation.MinReplicas = minReplicas
 
@@ -2635,19 +2664,19 @@ func run(cmd *cobra.Command, _ []string) {
 			})
 			if err != nil {
 				r.Reporter.Errorf("Expected a valid number of max replicas: %s", err)
-				os.Exit(1)
+				os.Exit(reporter.ExitCode())
 			}
 		}
 		err = replicaSizeValidation.MaxReplicaValidatorOnClusterCreate()(maxReplicas)
 		if err != nil {
 			r.Reporter.Errorf("%s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 
 		if isHostedCP {
 			if clusterautoscaler.IsAutoscalerSetViaCLI(cmd.Flags(), clusterAutoscalerFlagsPrefix) {
 				r.Reporter.Errorf("Hosted Control Plane clusters do not support cluster-autoscaler configuration")
-				os.Exit(1)
+				os.Exit(reporter.ExitCode())
 			}
 		} else {
 
@@ -2661,7 +2690,7 @@ func run(cmd *cobra.Command, _ []string) {
 				cmd.Flags(), clusterAutoscalerFlagsPrefix, true, autoscalerArgs, autoscalerValidationArgs)
 			if err != nil {
 				r.Reporter.Errorf("%s", err)
-				os.Exit(1)
+				os.Exit(reporter.ExitCode())
 			}
 		}
 	}
@@ -2676,7 +2705,7 @@ func run(cmd *cobra.Command, _ []string) {
 		// if the user set min/max replicas and hasn't enabled autoscaling
 		if isMinReplicasSet || isMaxReplicasSet {
 			r.Reporter.Errorf("Autoscaling must be enabled in order to set min and max replicas")
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 
 		if interactive.Enabled() {
@@ -2690,13 +2719,13 @@ func run(cmd *cobra.Command, _ []string) {
 			})
 			if err != nil {
 				r.Reporter.Errorf("Expected a valid number of compute nodes: %s", err)
-				os.Exit(1)
+				os.Exit(reporter.ExitCode())
 			}
 		}
 		err = replicaSizeValidation.MinReplicaValidatorOnClusterCreate()(computeNodes)
 		if err != nil {
 			r.Reporter.Errorf("%s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 
@@ -2713,20 +2742,20 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid comma-separated list of attributes: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 	labelMap, err := mpHelpers.ParseLabels(labels)
 	if err != nil {
 		r.Reporter.Errorf("%s", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	isVersionCompatibleComputeSgIds, err := versions.IsGreaterThanOrEqual(
 		version, ocm.MinVersionForAdditionalComputeSecurityGroupIdsDay1)
 	if err != nil {
 		r.Reporter.Errorf("There was a problem checking version compatibility: %v", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	additionalComputeSecurityGroupIds := args.additionalComputeSecurityGroupIds
@@ -2749,14 +2778,14 @@ func run(cmd *cobra.Command, _ []string) {
 	// Validate all remaining flags:
 	expiration, err := validateExpiration()
 	if err != nil {
-		r.Reporter.Errorf(fmt.Sprintf("%s", err))
-		os.Exit(1)
+		r.Reporter.Errorf("%s", err)
+		os.Exit(reporter.ExitCode())
 	}
 
 	// Network Type:
 	if err := validateNetworkType(args.networkType); err != nil {
 		r.Reporter.Errorf("%s", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	if cmd.Flags().Changed("network-type") && interactive.Enabled() {
 		args.networkType, err = interactive.GetOption(interactive.Input{
@@ -2767,7 +2796,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid network type: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 
@@ -2787,30 +2816,30 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid host prefix value: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 	err = hostPrefixValidator(hostPrefix)
 	if err != nil {
 		r.Reporter.Errorf("%s", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	machinePoolRootDisk, err := getMachinePoolRootDisk(r, cmd, version,
 		isHostedCP, defaultMachinePoolRootDiskSize)
 	if err != nil {
-		r.Reporter.Errorf(err.Error())
-		os.Exit(1)
+		r.Reporter.Errorf("%v", err)
+		os.Exit(reporter.ExitCode())
 	}
 
 	// No CNI
 	if cmd.Flags().Changed("no-cni") && !isHostedCP {
 		r.Reporter.Errorf("Disabling CNI is supported only for Hosted Control Planes")
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	if cmd.Flags().Changed("no-cni") && cmd.Flags().Changed("network-type") {
 		r.Reporter.Errorf("--no-cni and --network-type are mutually exclusive parameters")
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	noCni := args.noCni
 	if cmd.Flags().Changed("no-cni") && interactive.Enabled() {
@@ -2821,13 +2850,13 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid value for no CNI: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 
 	if cmd.Flags().Changed("fips") && isHostedCP {
 		r.Reporter.Errorf("FIPS support not available for Hosted Control Plane clusters")
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	fips := args.fips || fedramp.Enabled()
 	if interactive.Enabled() && !fedramp.Enabled() && !isHostedCP {
@@ -2838,7 +2867,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid FIPS value: %v", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 
@@ -2848,7 +2877,7 @@ func run(cmd *cobra.Command, _ []string) {
 	if etcdEncryptionKmsARN != "" {
 		if cmd.Flags().Changed("etcd-encryption") && !etcdEncryption {
 			r.Reporter.Errorf("etcd encryption cannot be disabled when encryption kms arn is provided")
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		} else {
 			etcdEncryption = true
 		}
@@ -2862,13 +2891,13 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid etcd-encryption value: %v", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 	if fips {
 		if cmd.Flags().Changed("etcd-encryption") && !etcdEncryption {
 			r.Reporter.Errorf("etcd encryption cannot be disabled on clusters with FIPS mode")
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		} else {
 			etcdEncryption = true
 		}
@@ -2886,7 +2915,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid value for etcd-encryption-kms-arn: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 
@@ -2896,7 +2925,7 @@ func run(cmd *cobra.Command, _ []string) {
 			"Expected a valid value for etcd-encryption-kms-arn matching %s",
 			kmsArnRegexpValidator.KmsArnRE,
 		)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	disableWorkloadMonitoring := args.disableWorkloadMonitoring
@@ -2908,7 +2937,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid disable-workload-monitoring value: %v", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 
@@ -2922,7 +2951,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid proxy-enabled value: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 
@@ -2937,13 +2966,13 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid http proxy: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 	err = ocm.ValidateHTTPProxy(httpProxy)
 	if err != nil {
 		r.Reporter.Errorf("%s", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	if enableProxy && interactive.Enabled() {
@@ -2957,13 +2986,13 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid https proxy: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 	err = interactive.IsURL(httpsProxy)
 	if err != nil {
 		r.Reporter.Errorf("%s", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	if enableProxy && interactive.Enabled() {
@@ -2978,7 +3007,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid set of no proxy domains/CIDR's: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		noProxySlice = helper.HandleEmptyStringOnSlice(strings.Split(noProxyInput, ","))
 	}
@@ -2987,20 +3016,20 @@ func run(cmd *cobra.Command, _ []string) {
 		duplicate, found := aws.HasDuplicates(noProxySlice)
 		if found {
 			r.Reporter.Errorf("Invalid no-proxy list, duplicate key '%s' found", duplicate)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		for _, domain := range noProxySlice {
 			err := aws.UserNoProxyValidator(domain)
 			if err != nil {
 				r.Reporter.Errorf("%s", err)
-				os.Exit(1)
+				os.Exit(reporter.ExitCode())
 			}
 		}
 	}
 
 	if httpProxy == "" && httpsProxy == "" && len(noProxySlice) > 0 {
 		r.Reporter.Errorf("Expected at least one of the following: http-proxy, https-proxy")
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	if useExistingVPC && interactive.Enabled() {
@@ -3014,13 +3043,13 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid additional trust bundle file name: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 	err = ocm.ValidateAdditionalTrustBundle(additionalTrustBundleFile)
 	if err != nil {
 		r.Reporter.Errorf("%s", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	// Get certificate contents
@@ -3029,7 +3058,7 @@ func run(cmd *cobra.Command, _ []string) {
 		cert, err := os.ReadFile(additionalTrustBundleFile)
 		if err != nil {
 			r.Reporter.Errorf("Failed to read additional trust bundle file: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		additionalTrustBundle = new(string)
 		*additionalTrustBundle = string(cert)
@@ -3037,13 +3066,13 @@ func run(cmd *cobra.Command, _ []string) {
 
 	if enableProxy && httpProxy == "" && httpsProxy == "" && additionalTrustBundleFile == "" {
 		r.Reporter.Errorf("Expected at least one of the following: http-proxy, https-proxy, additional-trust-bundle")
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	// Additional Allowed Principals
 	if cmd.Flags().Changed("additional-allowed-principals") && !isHostedCP {
 		r.Reporter.Errorf("Additional Allowed Principals is supported only for Hosted Control Planes")
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	additionalAllowedPrincipals := args.additionalAllowedPrincipals
 	if isHostedCP && interactive.Enabled() {
@@ -3055,14 +3084,14 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid value for Additional Allowed Principal ARNs: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		additionalAllowedPrincipals = helper.HandleEmptyStringOnSlice(strings.Split(aapInputs, ","))
 	}
 	if len(additionalAllowedPrincipals) > 0 {
 		if err := roles.ValidateAdditionalAllowedPrincipals(additionalAllowedPrincipals); err != nil {
-			r.Reporter.Errorf(err.Error())
-			os.Exit(1)
+			r.Reporter.Errorf("%v", err)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 
@@ -3071,7 +3100,7 @@ func run(cmd *cobra.Command, _ []string) {
 
 	if auditLogRoleARN != "" && !isHostedCP {
 		r.Reporter.Errorf("Audit log forwarding to AWS CloudWatch is only supported for Hosted Control Plane clusters")
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	if interactive.Enabled() && isHostedCP {
@@ -3082,7 +3111,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid value: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		if requestAuditLogForwarding {
 
@@ -3100,7 +3129,7 @@ func run(cmd *cobra.Command, _ []string) {
 			})
 			if err != nil {
 				r.Reporter.Errorf("Expected a valid value for audit-log-arn: %s", err)
-				os.Exit(1)
+				os.Exit(reporter.ExitCode())
 			}
 		} else {
 			auditLogRoleARN = ""
@@ -3109,31 +3138,31 @@ func run(cmd *cobra.Command, _ []string) {
 
 	if auditLogRoleARN != "" && !aws.RoleArnRE.MatchString(auditLogRoleARN) {
 		r.Reporter.Errorf("Expected a valid value for audit log arn matching %s", aws.RoleArnRE)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	isVersionCompatibleManagedIngressV2, err := versions.IsGreaterThanOrEqual(
 		version, ocm.MinVersionForManagedIngressV2)
 	if err != nil {
 		r.Reporter.Errorf("There was a problem checking version compatibility: %v", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	if ingress.IsDefaultIngressSetViaCLI(cmd.Flags()) {
 		if isHostedCP {
 			r.Reporter.Errorf("Updating default ingress settings is not supported for Hosted Control Plane clusters")
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		if !isVersionCompatibleManagedIngressV2 {
 			formattedVersion, err := versions.FormatMajorMinorPatch(ocm.MinVersionForManagedIngressV2)
 			if err != nil {
 				r.Reporter.Errorf(versions.MajorMinorPatchFormattedErrorOutput, err)
-				os.Exit(1)
+				os.Exit(reporter.ExitCode())
 			}
 			r.Reporter.Errorf(
 				"Updating default ingress settings is not supported for versions prior to '%s'",
 				formattedVersion,
 			)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 	routeSelector := ""
@@ -3150,7 +3179,7 @@ func run(cmd *cobra.Command, _ []string) {
 		if cmd.Flags().Changed(ingress.DefaultIngressRouteSelectorFlag) {
 			if isHostedCP {
 				r.Reporter.Errorf("Updating route selectors is not supported for Hosted Control Plane clusters")
-				os.Exit(1)
+				os.Exit(reporter.ExitCode())
 			}
 			routeSelector = args.defaultIngressRouteSelectors
 		} else if interactive.Enabled() && !isHostedCP && shouldAskCustomIngress {
@@ -3167,20 +3196,20 @@ func run(cmd *cobra.Command, _ []string) {
 			})
 			if err != nil {
 				r.Reporter.Errorf("Expected a valid comma-separated list of attributes: %s", err)
-				os.Exit(1)
+				os.Exit(reporter.ExitCode())
 			}
 			routeSelector = routeSelectorArg
 		}
 		routeSelectors, err = ingress.GetRouteSelector(routeSelector)
 		if err != nil {
 			r.Reporter.Errorf("%s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 
 		if cmd.Flags().Changed(ingress.DefaultIngressExcludedNamespacesFlag) {
 			if isHostedCP {
 				r.Reporter.Errorf("Updating excluded namespace is not supported for Hosted Control Plane clusters")
-				os.Exit(1)
+				os.Exit(reporter.ExitCode())
 			}
 			excludedNamespaces = args.defaultIngressExcludedNamespaces
 		} else if interactive.Enabled() && !isHostedCP && shouldAskCustomIngress {
@@ -3191,7 +3220,7 @@ func run(cmd *cobra.Command, _ []string) {
 			})
 			if err != nil {
 				r.Reporter.Errorf("Expected a valid comma-separated list of attributes: %s", err)
-				os.Exit(1)
+				os.Exit(reporter.ExitCode())
 			}
 			excludedNamespaces = excludedNamespacesArg
 		}
@@ -3200,7 +3229,7 @@ func run(cmd *cobra.Command, _ []string) {
 		if cmd.Flags().Changed(ingress.DefaultIngressWildcardPolicyFlag) {
 			if isHostedCP {
 				r.Reporter.Errorf("Updating Wildcard Policy is not supported for Hosted Control Plane clusters")
-				os.Exit(1)
+				os.Exit(reporter.ExitCode())
 			}
 			wildcardPolicy = args.defaultIngressWildcardPolicy
 		} else {
@@ -3218,7 +3247,7 @@ func run(cmd *cobra.Command, _ []string) {
 				})
 				if err != nil {
 					r.Reporter.Errorf("Expected a valid Wildcard Policy: %s", err)
-					os.Exit(1)
+					os.Exit(reporter.ExitCode())
 				}
 				wildcardPolicy = wildcardPolicyArg
 			}
@@ -3229,7 +3258,7 @@ func run(cmd *cobra.Command, _ []string) {
 				r.Reporter.Errorf(
 					"Updating Namespace Ownership Policy is not supported for Hosted Control Plane clusters",
 				)
-				os.Exit(1)
+				os.Exit(reporter.ExitCode())
 			}
 			namespaceOwnershipPolicy = args.defaultIngressNamespaceOwnershipPolicy
 		} else {
@@ -3247,7 +3276,7 @@ func run(cmd *cobra.Command, _ []string) {
 				})
 				if err != nil {
 					r.Reporter.Errorf("Expected a valid Namespace Ownership Policy: %s", err)
-					os.Exit(1)
+					os.Exit(reporter.ExitCode())
 				}
 				namespaceOwnershipPolicy = namespaceOwnershipPolicyArg
 			}
@@ -3357,7 +3386,7 @@ func run(cmd *cobra.Command, _ []string) {
 		autoscalerConfig, err := clusterautoscaler.CreateAutoscalerConfig(clusterAutoscaler)
 		if err != nil {
 			r.Reporter.Errorf("Failed creating autoscaler configuration: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 
 		clusterConfig.AutoscalerConfig = autoscalerConfig
@@ -3367,7 +3396,7 @@ func run(cmd *cobra.Command, _ []string) {
 		cmd.Flags(), clusterRegistryConfigArgs, isHostedCP, nil)
 	if err != nil {
 		r.Reporter.Errorf("%s", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	if clusterRegistryConfigArgs != nil {
 		allowedRegistries, blockedRegistries, insecureRegistries,
@@ -3383,7 +3412,7 @@ func run(cmd *cobra.Command, _ []string) {
 			ca, err := clusterregistryconfig.BuildAdditionalTrustedCAFromInputFile(additionalTrustedCa)
 			if err != nil {
 				r.Reporter.Errorf("Failed to build the additional trusted ca from file %s, got error: %s", additionalTrustedCa, err)
-				os.Exit(1)
+				os.Exit(reporter.ExitCode())
 			}
 			clusterConfig.AdditionalTrustedCa = ca
 			clusterConfig.AdditionalTrustedCaFile = additionalTrustedCa
@@ -3398,7 +3427,7 @@ func run(cmd *cobra.Command, _ []string) {
 	if args.useLocalCredentials {
 		if isSTS {
 			r.Reporter.Errorf("Local credentials are not supported for STS clusters")
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		props = append(props, properties.UseLocalCredentials)
 	}
@@ -3417,7 +3446,7 @@ func run(cmd *cobra.Command, _ []string) {
 	clusterConfig, err = clusterConfigFor(r.Reporter, clusterConfig, awsCreator, awsClient)
 	if err != nil {
 		r.Reporter.Errorf("%s", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	if !output.HasFlag() || r.Reporter.IsTerminal() {
@@ -3433,7 +3462,7 @@ func run(cmd *cobra.Command, _ []string) {
 	if !clusterConfig.IsSTS {
 		if err := r.OCMClient.EnsureNoPendingClusters(awsCreator); err != nil {
 			r.Reporter.Errorf("%v", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 
@@ -3444,7 +3473,7 @@ func run(cmd *cobra.Command, _ []string) {
 		} else {
 			r.Reporter.Errorf("Failed to create cluster: %s", err)
 		}
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	if args.dryRun {
@@ -3467,6 +3496,9 @@ func run(cmd *cobra.Command, _ []string) {
 
 	if isSTS {
 		if mode != "" {
+			done := interrupt.Track("cluster '%s', which can't be installed until its operator roles and OIDC "+
+				"provider are created with 'rosa create operator-roles -c %s' and 'rosa create oidc-provider -c %s'",
+				clusterName, clusterName, clusterName)
 			if !output.HasFlag() || r.Reporter.IsTerminal() {
 				r.Reporter.Infof("Preparing to create operator roles.")
 			}
@@ -3485,6 +3517,7 @@ func run(cmd *cobra.Command, _ []string) {
 				oidcprovider.Cmd.Flags().Set(oidcprovider.OidcConfigIdFlag, oidcConfig.ID())
 			}
 			oidcprovider.Cmd.Run(oidcprovider.Cmd, []string{clusterName, mode, ""})
+			done()
 		} else {
 			output := ""
 			if len(operatorRoles) == 0 {
@@ -3509,7 +3542,7 @@ func run(cmd *cobra.Command, _ []string) {
 					r.Reporter.Debugf("Failed to verify if OIDC provider exists: %s", err)
 				} else {
 					r.Reporter.Errorf("Failed to verify if OIDC provider exists: %s", err)
-					os.Exit(1)
+					os.Exit(reporter.ExitCode())
 				}
 			}
 			if !oidcProviderExists {
@@ -3590,7 +3623,7 @@ func validateNetworkType(networkType string) error {
 		return nil
 	}
 	if !helper.Contains(ocm.NetworkTypes, networkType) {
-		return fmt.Errorf(fmt.Sprintf("Expected a valid network type. Valid values: %v", ocm.NetworkTypes))
+		return fmt.Errorf("Expected a valid network type. Valid values: %v", ocm.NetworkTypes)
 	}
 	return nil
 }
@@ -3670,7 +3703,7 @@ func handleOidcConfigOptions(r *rosa.Runtime, cmd *cobra.Command, isSTS bool, is
 			})
 			if err != nil {
 				r.Reporter.Errorf("Expected a valid value: %s", err)
-				os.Exit(1)
+				os.Exit(reporter.ExitCode())
 			}
 			isOidcConfig = _isOidcConfig
 		}
@@ -3690,12 +3723,12 @@ func handleOidcConfigOptions(r *rosa.Runtime, cmd *cobra.Command, isSTS bool, is
 		}
 		r.Reporter.Errorf("Hosted Control Plane requires an OIDC Configuration ID\n" +
 			"Please run `rosa create oidc-config -h` and create one.")
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	oidcConfig, err := r.OCMClient.GetOidcConfig(oidcConfigId)
 	if err != nil {
 		r.Reporter.Errorf("There was a problem retrieving OIDC Config '%s': %v", oidcConfigId, err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	return oidcConfig
 }
@@ -3706,7 +3739,7 @@ func filterPrivateSubnets(initialSubnets []ec2types.Subnet, r *rosa.Runtime) []e
 	publicSubnetMap, err := r.AWSClient.FetchPublicSubnetMap(initialSubnets)
 	if err != nil {
 		r.Reporter.Errorf("Unable to check if subnet have an IGW: %v", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	for _, subnet := range initialSubnets {
 		skip := false
@@ -4272,7 +4305,7 @@ func getSecurityGroups(r *rosa.Runtime, cmd *cobra.Command, isVersionCompatibleC
 		if !useExistingVpc {
 			r.Reporter.Errorf("Setting the `%s` flag is only allowed for BYO VPC clusters",
 				securitygroups.SgKindFlagMap[kind])
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		if !isVersionCompatibleComputeSgIds {
 			formattedVersion, err := versions.FormatMajorMinorPatch(
@@ -4280,11 +4313,11 @@ func getSecurityGroups(r *rosa.Runtime, cmd *cobra.Command, isVersionCompatibleC
 			)
 			if err != nil {
 				r.Reporter.Errorf(versions.MajorMinorPatchFormattedErrorOutput, err)
-				os.Exit(1)
+				os.Exit(reporter.ExitCode())
 			}
 			r.Reporter.Errorf("Parameter '%s' is not supported prior to version '%s'",
 				securitygroups.SgKindFlagMap[kind], formattedVersion)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	} else if interactive.Enabled() && isVersionCompatibleComputeSgIds && useExistingVpc {
 		vpcId := ""
@@ -4295,7 +4328,7 @@ func getSecurityGroups(r *rosa.Runtime, cmd *cobra.Command, isVersionCompatibleC
 		}
 		if vpcId == "" {
 			r.Reporter.Warnf("Unexpected situation a VPC ID should have been selected based on chosen subnets")
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		*additionalSgIds = interactiveSgs.
 			GetSecurityGroupIds(r, cmd, vpcId, kind, "")
@@ -4318,7 +4351,7 @@ func getMachinePoolRootDisk(r *rosa.Runtime, cmd *cobra.Command, version string,
 			formattedVersion, err := versions.FormatMajorMinorPatch(ocm.MinVersionForMachinePoolRootDisk)
 			if err != nil {
 				r.Reporter.Errorf(versions.MajorMinorPatchFormattedErrorOutput, err)
-				os.Exit(1)
+				os.Exit(reporter.ExitCode())
 			}
 			return nil, fmt.Errorf(
 				"Updating Worker disk size is not supported for versions prior to '%s'",
@@ -25,6 +25,7 @@ import (
 	"github.com/openshift/rosa/pkg/externalauthprovider"
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -56,8 +57,8 @@ func run(cmd *cobra.Command, argv []string) {
 	defer r.Cleanup()
 	err := runWithRuntime(r, cmd, argv)
 	if err != nil {
-		r.Reporter.Errorf(err.Error())
-		os.Exit(1)
+		r.Reporter.Errorf("%v", err)
+		os.Exit(reporter.ExitCode())
 	}
 }
 
@@ -28,6 +28,7 @@ import (
 
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -332,12 +333,12 @@ func run(cmd *cobra.Command, _ []string) {
 	cluster := r.FetchCluster()
 	if cluster.State() != cmv1.ClusterStateReady {
 		r.Reporter.Errorf("Cluster '%s' is not yet ready", clusterKey)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	if cluster.ExternalAuthConfig().Enabled() {
 		r.Reporter.Errorf("Adding IDP is not supported for clusters with external authentication configured.")
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	// Grab all the IDP information interactively if necessary
@@ -364,12 +365,12 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid IdP type: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 	if idpType == "" {
 		r.Reporter.Errorf("Expected a valid IDP type. Options are: %s", strings.Join(validIdps, ","))
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	if idpType != "" {
@@ -381,7 +382,7 @@ func run(cmd *cobra.Command, _ []string) {
 		}
 		if !isValidIdp {
 			r.Reporter.Errorf("Expected a valid IDP type. Options are %s", validIdps)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 
@@ -401,7 +402,7 @@ func run(cmd *cobra.Command, _ []string) {
 	err = ValidateIdpName(idpName)
 	if err != nil {
 		r.Reporter.Errorf("%v", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	var idpBuilder cmv1.IdentityProviderBuilder
@@ -422,7 +423,7 @@ func run(cmd *cobra.Command, _ []string) {
 	}
 	if err != nil {
 		r.Reporter.Errorf("Failed to create IDP for cluster '%s': %v", clusterKey, err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	doCreateIDP(idpName, idpBuilder, cluster, clusterKey, r)
@@ -440,7 +441,7 @@ func getIDPName(cmd *cobra.Command, idpName string, r *rosa.Runtime) string {
 	})
 	if err != nil {
 		r.Reporter.Errorf("Expected a valid name for the identity provider: %s", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	return strings.Trim(idpName, " \t")
 }
@@ -473,13 +474,13 @@ func doCreateIDP(
 	idp, err := idpBuilder.Build()
 	if err != nil {
 		r.Reporter.Errorf("Failed to create IDP for cluster '%s': %v", clusterKey, err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	createdIdp, err := r.OCMClient.CreateIdentityProvider(cluster.ID(), idp)
 	if err != nil {
 		r.Reporter.Errorf("Failed to add IDP to cluster '%s': %s", clusterKey, err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	r.Reporter.Infof(
@@ -563,7 +564,7 @@ func getIdps(r *rosa.Runtime, cluster *cmv1.Cluster) []IdentityProvider {
 	ocmIdps, err := r.OCMClient.GetIdentityProviders(cluster.ID())
 	if err != nil {
 		r.Reporter.Errorf("Failed to get identity providers for cluster '%s': %v", cluster.ID(), err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	idps := []IdentityProvider{}
 	for _, idp := range ocmIdps {
@@ -161,7 +161,7 @@ func NetworkRunner(userOptions *opts.NetworkUserOptions) rosa.CommandRunner {
 					"default-template": fmt.Sprintf("%t", defaultTemplateUsed),
 				},
 			)
-			return service.CreateStack(&templateFile, &templateBody, parsedParams, parsedTags)
+			return service.CreateStack(ctx, &templateFile, &templateBody, parsedParams, parsedTags)
 		}
 	}
 }
@@ -1,6 +1,8 @@
 package network
 
 import (
+	"context"
+
 	"go.uber.org/mock/gomock"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -25,8 +27,8 @@ var _ = Describe("Network", func() {
 		It("should create a stack successfully", func() {
 			template := "example.yaml"
 			serviceMock := network.NewMockNetworkService(ctrl)
-			serviceMock.EXPECT().CreateStack(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
-			err := serviceMock.CreateStack(&template, nil, nil, nil)
+			serviceMock.EXPECT().CreateStack(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(1)
+			err := serviceMock.CreateStack(context.Background(), &template, nil, nil, nil)
 			Expect(err).ToNot(HaveOccurred())
 		})
 	})
@@ -33,6 +33,7 @@ import (
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/interactive/confirm"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -116,20 +117,20 @@ func run(cmd *cobra.Command, _ []string) {
 	mode, err := interactive.GetMode()
 	if err != nil {
 		r.Reporter.Errorf("%s", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	env, err := ocm.GetEnv()
 	if err != nil {
 		r.Reporter.Errorf("Failed to determine OCM environment: %v", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	// Determine if Classic ROSA managed policies are enabled
 	isManagedSet := cmd.Flags().Changed("managed-policies") || cmd.Flags().Changed("mp")
 	if isManagedSet && env == ocm.Production {
 		r.Reporter.Errorf("Classic ROSA managed policies are not supported in this environment")
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	managedPolicies := args.managed
 
@@ -156,16 +157,16 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid role prefix: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 	if len(prefix) > 32 {
 		r.Reporter.Errorf("Expected a prefix with no more than 32 characters")
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	if !aws.RoleNameRE.MatchString(prefix) {
 		r.Reporter.Errorf("Expected a valid role prefix matching %s", aws.RoleNameRE.String())
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	isAdmin := args.admin
@@ -179,7 +180,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid --admin value: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 
@@ -195,7 +196,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid policy ARN for permissions boundary: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 
@@ -203,7 +204,7 @@ func run(cmd *cobra.Command, _ []string) {
 		err = aws.ARNValidator(permissionsBoundary)
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid policy ARN for permissions boundary: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 
@@ -219,21 +220,21 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid path: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 
 	if path != "" && !aws.ARNPath.MatchString(path) {
 		r.Reporter.Errorf("The specified value for path is invalid. " +
 			"It must begin and end with '/' and contain only alphanumeric characters and/or '/' characters.")
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	if interactive.Enabled() {
 		mode, err = interactive.GetOptionMode(cmd, mode, "Role creation mode")
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid role creation mode: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 
@@ -241,7 +242,7 @@ func run(cmd *cobra.Command, _ []string) {
 	orgID, externalID, err := r.OCMClient.GetCurrentOrganization()
 	if err != nil {
 		r.Reporter.Errorf("Failed to get organization account: %v", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	roleNameRequested := aws.GetOCMRoleName(prefix, aws.OCMRole, externalID)
@@ -250,19 +251,19 @@ func run(cmd *cobra.Command, _ []string) {
 
 	if err != nil {
 		r.Reporter.Errorf("Error checking existing ocm-role: %v", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	if existsOnOCM {
 		r.Reporter.Errorf("Only one ocm-role can be created per AWS account '%s' per organization '%s'.\n"+
 			"In order to create a new ocm-role, you have to unlink the ocm-role '%s'.\n",
 			r.Creator.AccountID, orgID, selectedARN)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	policies, err := r.OCMClient.GetPolicies("OCMRole")
 	if err != nil {
 		r.Reporter.Errorf("Expected a valid role creation mode: %s", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	switch mode {
@@ -275,7 +276,7 @@ func run(cmd *cobra.Command, _ []string) {
 			r.OCMClient.LogEvent("ROSACreateOCMRoleModeAuto", map[string]string{
 				ocm.Response: ocm.Failure,
 			})
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		r.OCMClient.LogEvent("ROSACreateOCMRoleModeAuto", map[string]string{
 			ocm.Response: ocm.Success,
@@ -295,7 +296,7 @@ func run(cmd *cobra.Command, _ []string) {
 			r.OCMClient.LogEvent("ROSACreateOCMRoleModeManual", map[string]string{
 				ocm.Response: ocm.Failure,
 			})
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		if r.Reporter.IsTerminal() {
 			r.Reporter.Infof("All policy files saved to the current directory")
@@ -316,13 +317,13 @@ func run(cmd *cobra.Command, _ []string) {
 		)
 		if err != nil {
 			r.Reporter.Errorf("Failed to generate commands for manual mode: %v", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 
 		fmt.Println(commands)
 	default:
 		r.Reporter.Errorf("Invalid mode. Allowed values are %s", interactive.Modes)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 }
 
@@ -40,6 +40,7 @@ import (
 	"github.com/openshift/rosa/pkg/interactive/confirm"
 	interactiveRoles "github.com/openshift/rosa/pkg/interactive/roles"
 	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -141,14 +142,14 @@ func run(cmd *cobra.Command, _ []string) {
 	mode, err := interactive.GetMode()
 	if err != nil {
 		r.Reporter.Errorf("%s", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	// Get AWS region
 	region, err := aws.GetRegion(arguments.GetRegion())
 	if err != nil {
 		r.Reporter.Errorf("Error getting region: %v", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	args.region = region
 
@@ -160,17 +161,17 @@ func run(cmd *cobra.Command, _ []string) {
 
 	if args.rawFiles && mode != "" {
 		r.Reporter.Warnf("--%s param is not supported alongside --mode param.", rawFilesFlag)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	if args.rawFiles && args.installerRoleArn != "" {
 		r.Reporter.Warnf("--%s param is not supported alongside --%s param", rawFilesFlag, InstallerRoleArnFlag)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	if args.rawFiles && args.managed {
 		r.Reporter.Warnf("--%s param is not supported alongside --%s param", rawFilesFlag, managedFlag)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	if !args.rawFiles && interactive.Enabled() && !cmd.Flags().Changed("mode") {
@@ -183,23 +184,23 @@ func run(cmd *cobra.Command, _ []string) {
 		mode, err = interactive.GetOptionMode(cmd, mode, question)
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid %s: %s", question, err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 
 	if output.HasFlag() && mode != "" && mode != interactive.ModeAuto {
 		r.Reporter.Warnf("--output param is not supported outside auto mode.")
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	if args.managed && args.userPrefix != "" {
 		r.Reporter.Warnf("--%s param is not supported for managed OIDC config", userPrefixFlag)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	if args.managed && args.installerRoleArn != "" {
 		r.Reporter.Warnf("--%s param is not supported for managed OIDC config", InstallerRoleArnFlag)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	if !args.managed {
@@ -227,7 +228,7 @@ func run(cmd *cobra.Command, _ []string) {
 				})
 				if err != nil {
 					r.Reporter.Errorf("Expected a valid prefix for the configuration: %s", err)
-					os.Exit(1)
+					os.Exit(reporter.ExitCode())
 				}
 				args.userPrefix = prefix
 			}
@@ -239,7 +240,7 @@ func run(cmd *cobra.Command, _ []string) {
 				err := aws.ARNValidator(args.installerRoleArn)
 				if err != nil {
 					r.Reporter.Errorf("Expected a valid ARN: %s", err)
-					os.Exit(1)
+					os.Exit(reporter.ExitCode())
 				}
 				roleExists, _, err := r.AWSClient.CheckRoleExists(roleName)
 				if err != nil {
@@ -248,17 +249,17 @@ func run(cmd *cobra.Command, _ []string) {
 						args.installerRoleArn,
 						err,
 					)
-					os.Exit(1)
+					os.Exit(reporter.ExitCode())
 				}
 				if !roleExists {
 					r.Reporter.Errorf("Role '%s' does not exist", args.installerRoleArn)
-					os.Exit(1)
+					os.Exit(reporter.ExitCode())
 				}
 				isValid, err := r.AWSClient.ValidateAccountRoleVersionCompatibility(
 					roleName, aws.InstallerAccountRole, MinorVersionForGetSecret)
 				if err != nil {
 					r.Reporter.Errorf("There was a problem listing role tags: %v", err)
-					os.Exit(1)
+					os.Exit(reporter.ExitCode())
 				}
 				if !isValid {
 					r.Reporter.Errorf(
@@ -266,7 +267,7 @@ func run(cmd *cobra.Command, _ []string) {
 						args.installerRoleArn,
 						MinorVersionForGetSecret,
 					)
-					os.Exit(1)
+					os.Exit(reporter.ExitCode())
 				}
 			}
 		}
@@ -276,7 +277,7 @@ func run(cmd *cobra.Command, _ []string) {
 		if len([]rune(args.userPrefix)) > maxLengthUserPrefix {
 			r.Reporter.Errorf("Expected a valid prefix for the configuration: "+
 				"length of prefix is limited to %d characters", maxLengthUserPrefix)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 
@@ -285,14 +286,14 @@ func run(cmd *cobra.Command, _ []string) {
 		oidcConfigInput, err = oidcconfigs.BuildOidcConfigInput(args.userPrefix, args.region)
 		if err != nil {
 			r.Reporter.Errorf("%s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 
 	oidcConfigStrategy, err := getOidcConfigStrategy(mode, &oidcConfigInput)
 	if err != nil {
 		r.Reporter.Errorf("%s", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	oidcConfigId := oidcConfigStrategy.execute(r)
 	if !args.rawFiles {
@@ -304,7 +305,7 @@ func run(cmd *cobra.Command, _ []string) {
 			if err != nil {
 				r.Reporter.Errorf("Unable to attempt creation of OIDC provider; oidc config ID"+
 					" not found / not created successfully: %s", err)
-				os.Exit(1)
+				os.Exit(reporter.ExitCode())
 			}
 		} else {
 			r.Reporter.Infof("To create the OIDC provider, please run 'rosa create oidc-provider' with the ID " +
@@ -333,19 +334,19 @@ func (s *CreateUnmanagedOidcConfigRawStrategy) execute(r *rosa.Runtime) string {
 	err := helper.SaveDocument(string(privateKey), privateKeyFilename)
 	if err != nil {
 		r.Reporter.Errorf("There was a problem saving private key to a file: %s", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	discoveryDocumentFilename := fmt.Sprintf("discovery-document-%s.json", bucketName)
 	err = helper.SaveDocument(discoveryDocument, discoveryDocumentFilename)
 	if err != nil {
 		r.Reporter.Errorf("There was a problem saving discovery document to a file: %s", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	jwksFilename := fmt.Sprintf("jwks-%s.json", bucketName)
 	err = helper.SaveDocument(string(jwks[:]), jwksFilename)
 	if err != nil {
 		r.Reporter.Errorf("There was a problem saving JSON Web Key Set to a file: %s", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	if !output.HasFlag() && r.Reporter.IsTerminal() {
 		r.Reporter.Infof(
@@ -383,14 +384,14 @@ func (s *CreateUnmanagedOidcConfigAutoStrategy) execute(r *rosa.Runtime) string
 	err := r.AWSClient.CreateS3Bucket(bucketName, args.region)
 	if err != nil {
 		r.Reporter.Errorf("There was a problem creating S3 bucket '%s': %s", bucketName, err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	err = r.AWSClient.PutPublicReadObjectInS3Bucket(
 		bucketName, strings.NewReader(discoveryDocument), discoveryDocumentKey)
 	if err != nil {
 		r.Reporter.Errorf("There was a problem populating discovery "+
 			"document to S3 bucket '%s': %s", bucketName, err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	err = r.AWSClient.PutPublicReadObjectInS3Bucket(bucketName, bytes.NewReader(jwks), jwksKey)
 	if err != nil {
@@ -399,12 +400,12 @@ func (s *CreateUnmanagedOidcConfigAutoStrategy) execute(r *rosa.Runtime) string
 		}
 		r.Reporter.Errorf("There was a problem populating JWKS "+
 			"to S3 bucket '%s': %s", bucketName, err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	secretARN, err := r.AWSClient.CreateSecretInSecretsManager(privateKeySecretName, string(privateKey[:]))
 	if err != nil {
 		r.Reporter.Errorf("There was a problem saving private key to secrets manager: %s", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	oidcConfig, err := v1.NewOidcConfig().
 		Managed(false).
@@ -423,13 +424,13 @@ func (s *CreateUnmanagedOidcConfigAutoStrategy) execute(r *rosa.Runtime) string
 			"Please refer to documentation and try again through:\n"+
 			"\trosa register oidc-config --issuer-url %s --secret-arn %s --role-arn %s",
 			err, bucketUrl, secretARN, installerRoleArn)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	if output.HasFlag() {
 		err = output.Print(oidcConfig)
 		if err != nil {
 			r.Reporter.Errorf("%s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		os.Exit(0)
 	}
@@ -458,7 +459,7 @@ func (s *CreateUnmanagedOidcConfigManualStrategy) execute(r *rosa.Runtime) strin
 	err := helper.SaveDocument(string(privateKey), privateKeyFilename)
 	if err != nil {
 		r.Reporter.Errorf("There was a problem saving private key to a file: %s", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	createBucketConfig := ""
 	if args.region != aws.DefaultRegion {
@@ -491,7 +492,7 @@ func (s *CreateUnmanagedOidcConfigManualStrategy) execute(r *rosa.Runtime) strin
 	err = helper.SaveDocument(fmt.Sprintf(aws.ReadOnlyAnonUserPolicyTemplate, bucketName), readOnlyPolicyFilename)
 	if err != nil {
 		r.Reporter.Errorf("There was a problem saving bucket policy document to a file: %s", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	putBucketBucketPolicyCommand := awscb.NewS3ApiCommandBuilder().
 		SetCommand(awscb.PutBucketPolicy).
@@ -505,7 +506,7 @@ func (s *CreateUnmanagedOidcConfigManualStrategy) execute(r *rosa.Runtime) strin
 	err = helper.SaveDocument(discoveryDocument, discoveryDocumentFilename)
 	if err != nil {
 		r.Reporter.Errorf("There was a problem saving discovery document to a file: %s", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	putDiscoveryDocumentCommand := awscb.NewS3ApiCommandBuilder().
 		SetCommand(awscb.PutObject).
@@ -520,7 +521,7 @@ func (s *CreateUnmanagedOidcConfigManualStrategy) execute(r *rosa.Runtime) strin
 	err = helper.SaveDocument(string(jwks[:]), jwksFilename)
 	if err != nil {
 		r.Reporter.Errorf("There was a problem saving JSON Web Key Set to a file: %s", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	putJwksCommand := awscb.NewS3ApiCommandBuilder().
 		SetCommand(awscb.PutObject).
@@ -569,7 +570,7 @@ func (s *CreateManagedOidcConfigAutoStrategy) execute(r *rosa.Runtime) string {
 	oidcConfig, err := v1.NewOidcConfig().Managed(true).Build()
 	if err != nil {
 		r.Reporter.Errorf("There was a problem building the managed OIDC Configuration: %v", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	oidcConfig, err = r.OCMClient.CreateOidcConfig(oidcConfig)
 	if err != nil {
@@ -577,14 +578,14 @@ func (s *CreateManagedOidcConfigAutoStrategy) execute(r *rosa.Runtime) string {
 			spin.Stop()
 		}
 		r.Reporter.Errorf("There was a problem registering your managed OIDC Configuration: %v", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	s.oidcConfigInput.IssuerUrl = oidcConfig.IssuerUrl()
 	if output.HasFlag() {
 		err = output.Print(oidcConfig)
 		if err != nil {
 			r.Reporter.Errorf("%s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		os.Exit(0)
 	}
@@ -32,6 +32,7 @@ import (
 	interactiveOidc "github.com/openshift/rosa/pkg/interactive/oidc"
 	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -97,13 +98,13 @@ func run(cmd *cobra.Command, argv []string) {
 	if cmd.Flag("cluster").Changed && cmd.Flag(OidcConfigIdFlag).Changed {
 		r.Reporter.Errorf("A cluster key for STS cluster and an OIDC Config ID " +
 			"cannot be specified alongside each other.")
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	mode, err := interactive.GetMode()
 	if err != nil {
 		r.Reporter.Errorf("%s", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	// Determine if interactive mode is needed
@@ -119,7 +120,7 @@ func run(cmd *cobra.Command, argv []string) {
 		cluster = r.FetchCluster()
 		if !ocm.IsSts(cluster) {
 			r.Reporter.Errorf("Cluster '%s' is not an STS cluster.", clusterKey)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 
@@ -127,7 +128,7 @@ func run(cmd *cobra.Command, argv []string) {
 		mode, err = interactive.GetOptionMode(cmd, mode, "OIDC provider creation mode")
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid OIDC provider creation mode: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 
@@ -144,7 +145,7 @@ func run(cmd *cobra.Command, argv []string) {
 			oidcConfig, err := r.OCMClient.GetOidcConfig(args.oidcConfigId)
 			if err != nil {
 				r.Reporter.Errorf("There was a problem retrieving OIDC Config '%s': %v", args.oidcConfigId, err)
-				os.Exit(1)
+				os.Exit(reporter.ExitCode())
 			}
 			oidcEndpointURL = oidcConfig.IssuerUrl()
 		}
@@ -162,7 +163,7 @@ func run(cmd *cobra.Command, argv []string) {
 			r.Reporter.Debugf("Failed to verify if OIDC provider exists: %s", err)
 		} else {
 			r.Reporter.Errorf("Failed to verify if OIDC provider exists: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 	if oidcProviderExists {
@@ -170,7 +171,7 @@ func run(cmd *cobra.Command, argv []string) {
 			cluster.AWS().STS().OidcConfig() != nil && !cluster.AWS().STS().OidcConfig().Reusable() {
 			r.Reporter.Warnf("Cluster '%s' already has OIDC provider but has not yet started installation. "+
 				"Verify that the cluster operator roles exist and are configured correctly.", clusterKey)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		// Returns so that when called from create cluster does not interrupt flow
 		r.Reporter.Infof("OIDC provider already exists")
@@ -199,7 +200,7 @@ func run(cmd *cobra.Command, argv []string) {
 				ocm.ClusterID: clusterKey,
 				ocm.Response:  ocm.Failure,
 			})
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		r.OCMClient.LogEvent("ROSACreateOIDCProviderModeAuto", map[string]string{
 			ocm.ClusterID: clusterKey,
@@ -209,7 +210,7 @@ func run(cmd *cobra.Command, argv []string) {
 		commands, err := buildCommands(r, oidcEndpointURL, clusterId)
 		if err != nil {
 			r.Reporter.Errorf("There was an error building the list of resources: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 			r.OCMClient.LogEvent("ROSACreateOIDCProviderModeManual", map[string]string{
 				ocm.ClusterID: clusterKey,
 				ocm.Response:  ocm.Failure,
@@ -224,7 +225,7 @@ func run(cmd *cobra.Command, argv []string) {
 		fmt.Println(commands)
 	default:
 		r.Reporter.Errorf("Invalid mode. Allowed values are %s", interactive.Modes)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 }
 
@@ -15,6 +15,7 @@ import (
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/roles"
 	"github.com/openshift/rosa/pkg/rosa"
 )
@@ -44,7 +45,7 @@ func handleOperatorRoleCreationByClusterKey(r *rosa.Runtime, env string,
 	vpcEndpointRoleArn := args.vpcEndpointRoleArn
 	if cluster.AWS().STS().RoleARN() == "" {
 		r.Reporter.Errorf("Cluster '%s' is not an STS cluster.", clusterKey)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	// Check to see if IAM operator roles have already created
@@ -54,7 +55,7 @@ func handleOperatorRoleCreationByClusterKey(r *rosa.Runtime, env string,
 			r.Reporter.Debugf("Failed to verify if operator roles exist: '%v'", err)
 		} else {
 			r.Reporter.Errorf("Failed to verify if operator roles exist: '%v'", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 
@@ -68,13 +69,13 @@ func handleOperatorRoleCreationByClusterKey(r *rosa.Runtime, env string,
 	credRequests, err := r.OCMClient.GetCredRequests(cluster.Hypershift().Enabled())
 	if err != nil {
 		r.Reporter.Errorf("Error getting operator credential request from OCM '%v'", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	managedPolicies := cluster.AWS().STS().ManagedPolicies()
 	if args.forcePolicyCreation && managedPolicies {
 		r.Reporter.Warnf("Forcing creation of policies only works for unmanaged policies")
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	switch mode {
@@ -96,13 +97,13 @@ func handleOperatorRoleCreationByClusterKey(r *rosa.Runtime, env string,
 		roleName, err := aws.GetInstallerAccountRoleName(cluster)
 		if err != nil {
 			r.Reporter.Errorf("Expected parsing role account role '%s': '%v'", cluster.AWS().STS().RoleARN(), err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 
 		path, err := aws.GetPathFromAccountRole(cluster, aws.AccountRoles[aws.InstallerAccountRole].Name)
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid path for '%s': '%v'", cluster.AWS().STS().RoleARN(), err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		if path != "" && !output.HasFlag() && r.Reporter.IsTerminal() {
 			r.Reporter.Infof("ARN path '%s' detected in installer role '%s'. "+
@@ -117,7 +118,7 @@ func handleOperatorRoleCreationByClusterKey(r *rosa.Runtime, env string,
 		accountRoleVersion, err = r.AWSClient.GetAccountRoleVersion(roleName)
 		if err != nil {
 			r.Reporter.Errorf("Error getting account role version '%v'", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		err = createRoles(r, operatorRolesInput{
 			prefix:              operatorRolePolicyPrefix,
@@ -144,7 +145,7 @@ func handleOperatorRoleCreationByClusterKey(r *rosa.Runtime, env string,
 				ocm.Response:   ocm.Failure,
 				ocm.IsThrottle: isThrottle,
 			})
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		r.OCMClient.LogEvent("ROSACreateOperatorRolesModeAuto", map[string]string{
 			ocm.ClusterID: clusterKey,
@@ -155,7 +156,7 @@ func handleOperatorRoleCreationByClusterKey(r *rosa.Runtime, env string,
 			cluster, policies, credRequests, managedPolicies, hostedCPPolicies, route53RoleArn, vpcEndpointRoleArn)
 		if err != nil {
 			r.Reporter.Errorf("There was an error building the list of resources: '%v'", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 			r.OCMClient.LogEvent("ROSACreateOperatorRolesModeManual", map[string]string{
 				ocm.ClusterID: clusterKey,
 				ocm.Response:  ocm.Failure,
@@ -172,7 +173,7 @@ func handleOperatorRoleCreationByClusterKey(r *rosa.Runtime, env string,
 
 	default:
 		r.Reporter.Errorf("Invalid mode. Allowed values are '%s'", interactive.Modes)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	return nil
 }
@@ -187,7 +188,7 @@ func createRoles(r *rosa.Runtime, createInput operatorRolesInput) error {
 			isSupported, err := ocm.CheckSupportedVersion(ocm.GetVersionMinor(ver.ID()), operator.MinVersion())
 			if err != nil {
 				r.Reporter.Errorf("Error validating operator role '%s' version %s", operator.Name(), err)
-				os.Exit(1)
+				os.Exit(reporter.ExitCode())
 			}
 			if !isSupported {
 				continue
@@ -324,7 +325,7 @@ func buildCommands(r *rosa.Runtime, env string,
 		err := aws.GenerateOperatorRolePolicyFiles(r.Reporter, policies, credRequests, sharedVpcRoleArn, r.Creator.Partition)
 		if err != nil {
 			r.Reporter.Errorf("There was an error generating the policy files: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 
@@ -336,7 +337,7 @@ func buildCommands(r *rosa.Runtime, env string,
 			isSupported, err := ocm.CheckSupportedVersion(ocm.GetVersionMinor(ver.ID()), operator.MinVersion())
 			if err != nil {
 				r.Reporter.Errorf("Error validating operator role '%s' version %s", operator.Name(), err)
-				os.Exit(1)
+				os.Exit(reporter.ExitCode())
 			}
 			if !isSupported {
 				continue
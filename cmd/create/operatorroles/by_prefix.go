@@ -20,6 +20,7 @@ import (
 	interactiveRoles "github.com/openshift/rosa/pkg/interactive/roles"
 	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/roles"
 	"github.com/openshift/rosa/pkg/rosa"
 )
@@ -38,7 +39,7 @@ func handleOperatorRolesPrefixOptions(r *rosa.Runtime, cmd *cobra.Command) {
 	})
 	if err != nil {
 		r.Reporter.Errorf("Expected a prefix for the operator IAM roles: %s", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	args.prefix = operatorRolesPrefix
 
@@ -48,7 +49,7 @@ func handleOperatorRolesPrefixOptions(r *rosa.Runtime, cmd *cobra.Command) {
 
 	if cmd.Flags().Changed("hosted-cp") && r.Creator.IsGovcloud {
 		r.Reporter.Errorf("Setting `hosted-cp` is not supported for Govcloud AWS accounts")
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	isHostedCP := args.hostedCp
@@ -63,7 +64,7 @@ func handleOperatorRolesPrefixOptions(r *rosa.Runtime, cmd *cobra.Command) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid --hosted-cp value: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 	args.hostedCp = isHostedCP
@@ -83,7 +84,7 @@ func handleOperatorRoleCreationByPrefix(r *rosa.Runtime, env string,
 	oidcConfig, err := r.OCMClient.GetOidcConfig(args.oidcConfigId)
 	if err != nil {
 		r.Reporter.Errorf("There was a problem retrieving OIDC Config '%s': %v", args.oidcConfigId, err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	includeHostedCpSet := args.hostedCp
 	operatorRolesPrefix := args.prefix
@@ -97,12 +98,12 @@ func handleOperatorRoleCreationByPrefix(r *rosa.Runtime, env string,
 	installerRoleName, err := aws.GetResourceIdFromARN(installerRoleArn)
 	if err != nil {
 		r.Reporter.Errorf("%s", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	path, err := aws.GetPathFromARN(installerRoleArn)
 	if err != nil {
 		r.Reporter.Errorf("Expected a valid path for '%s': %v", installerRoleArn, err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	if path != "" && !output.HasFlag() && r.Reporter.IsTerminal() {
 		r.Reporter.Infof("ARN path '%s' detected in installer role '%s'. "+
@@ -114,30 +115,30 @@ func handleOperatorRoleCreationByPrefix(r *rosa.Runtime, env string,
 		aws.AccountRoles[aws.InstallerAccountRole].Name)
 	if !hasStandardNamedInstallerRole {
 		r.Reporter.Infof("Can only use installer roles created through ROSA CLI for this flow.")
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	operatorRolePolicyPrefix := installerRolePrefix
 	credRequests, err := r.OCMClient.GetCredRequests(includeHostedCpSet)
 	if err != nil {
 		r.Reporter.Errorf("Error getting operator credential request from OCM %s", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	managedPolicies, err := r.AWSClient.HasManagedPolicies(installerRoleArn)
 	if err != nil {
 		r.Reporter.Errorf("Failed to determine if cluster has managed policies: %v", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	awsCreator, err := r.AWSClient.GetCreator()
 	if err != nil {
 		r.Reporter.Errorf("Unable to get IAM credentials: %v", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	operatorIAMRoleList, err := convertCredRequestsOperatorRolesIntoV1OperatorIAMRole(credRequests,
 		args.prefix, awsCreator, path)
 	if err != nil {
 		r.Reporter.Errorf("%s", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	var hostedCPPolicies bool
@@ -145,27 +146,27 @@ func handleOperatorRoleCreationByPrefix(r *rosa.Runtime, env string,
 		hostedCPPolicies, err = r.AWSClient.HasHostedCPPolicies(args.installerRoleArn)
 		if err != nil {
 			r.Reporter.Errorf("Failed to determine if the Installer role ARN has hosted CP policies: %v", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 
 		if !hostedCPPolicies {
 			r.Reporter.Errorf(
 				"Failed to create the operator role since the Installer role ARN '%v' does not have managed policies",
 				args.installerRoleArn)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 
 	operatorRolesList, err := convertV1OperatorIAMRoleIntoOcmOperatorIamRole(operatorIAMRoleList)
 	if err != nil {
 		r.Reporter.Errorf("%v", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	err = ocm.ValidateOperatorRolesMatchOidcProvider(r.Reporter, r.AWSClient,
 		operatorRolesList, oidcConfig.IssuerUrl(), "4.0", path, managedPolicies, true)
 	if err != nil && !awserr.IsNoSuchEntityException(err) {
 		r.Reporter.Errorf("%v", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	switch mode {
@@ -190,7 +191,7 @@ func handleOperatorRoleCreationByPrefix(r *rosa.Runtime, env string,
 				ocm.Response:            ocm.Failure,
 				ocm.IsThrottle:          isThrottle,
 			})
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		if r.Reporter.IsTerminal() {
 			hostedCpOutputParam := ""
@@ -218,7 +219,7 @@ func handleOperatorRoleCreationByPrefix(r *rosa.Runtime, env string,
 			oidcEndpointUrl, hostedCPPolicies, sharedVpcRoleArn, sharedVpcEndpointRoleArn)
 		if err != nil {
 			r.Reporter.Errorf("There was an error building the list of resources: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 			r.OCMClient.LogEvent("ROSACreateOperatorRolesModeManual", map[string]string{
 				ocm.OperatorRolesPrefix: operatorRolesPrefix,
 				ocm.Response:            ocm.Failure,
@@ -234,7 +235,7 @@ func handleOperatorRoleCreationByPrefix(r *rosa.Runtime, env string,
 		fmt.Println(commands)
 	default:
 		r.Reporter.Errorf("Invalid mode. Allowed values are %s", interactive.Modes)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	return nil
 }
@@ -261,29 +262,29 @@ func validateArgumentsOperatorRolesCreationByPrefix(r *rosa.Runtime, operatorRol
 	oidcEndpointUrl string, installerRoleArn string) {
 	if len(operatorRolesPrefix) == 0 {
 		r.Reporter.Errorf("Expected a prefix for the operator IAM roles")
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	if len(operatorRolesPrefix) > 32 {
 		r.Reporter.Errorf("Expected a prefix with no more than 32 characters")
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	if !aws.RoleNameRE.MatchString(operatorRolesPrefix) {
 		r.Reporter.Errorf("Expected valid operator roles prefix matching %s", aws.RoleNameRE.String())
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	parsedURI, err := url.ParseRequestURI(oidcEndpointUrl)
 	if err != nil {
 		r.Reporter.Errorf("%s", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	if parsedURI.Scheme != helper.ProtocolHttps {
 		r.Reporter.Errorf("Expected OIDC endpoint URL '%s' to use an https:// scheme", oidcEndpointUrl)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	err = aws.ARNValidator(installerRoleArn)
 	if err != nil {
 		r.Reporter.Errorf("%s", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 }
 
@@ -422,7 +423,7 @@ func buildCommandsFromPrefix(r *rosa.Runtime, env string,
 		err := aws.GenerateOperatorRolePolicyFiles(r.Reporter, policies, credRequests, sharedVpcRoleArn, r.Creator.Partition)
 		if err != nil {
 			r.Reporter.Errorf("There was an error generating the policy files: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 
@@ -28,6 +28,7 @@ import (
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/interactive/confirm"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/roles"
 	"github.com/openshift/rosa/pkg/rosa"
 )
@@ -187,7 +188,7 @@ func run(cmd *cobra.Command, argv []string) {
 			vpcEndpointRoleArnFlag, hostedZoneRoleArnFlag)
 		if err != nil {
 			r.Reporter.Errorf("%s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 
@@ -195,27 +196,27 @@ func run(cmd *cobra.Command, argv []string) {
 		err = aws.ARNValidator(args.vpcEndpointRoleArn)
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid policy ARN for %s: %s", vpcEndpointRoleArnFlag, err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 	if args.sharedVpcRoleArn != "" {
 		err = aws.ARNValidator(args.sharedVpcRoleArn)
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid policy ARN for %s: %s", hostedZoneRoleArnFlag, err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 
 	env, err := ocm.GetEnv()
 	if err != nil {
 		r.Reporter.Errorf("Failed to determine OCM environment: %v", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	mode, err := interactive.GetMode()
 	if err != nil {
 		r.Reporter.Errorf("%s", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	// Determine if interactive mode is needed
@@ -225,30 +226,30 @@ func run(cmd *cobra.Command, argv []string) {
 
 	if !cmd.Flag("cluster").Changed && !cmd.Flag(PrefixFlag).Changed && !isProgmaticallyCalled {
 		r.Reporter.Errorf("Either a cluster key for STS cluster or an operator roles prefix must be specified.")
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	if cmd.Flag("cluster").Changed && cmd.Flag(PrefixFlag).Changed {
 		r.Reporter.Errorf("A cluster key for STS cluster and an operator roles prefix " +
 			"cannot be specified alongside each other.")
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	if cmd.Flag("cluster").Changed && cmd.Flag(OidcConfigIdFlag).Changed {
 		r.Reporter.Errorf("A cluster key for STS cluster and an OIDC configuration ID " +
 			"cannot be specified alongside each other.")
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	if !args.hostedCp && args.installerRoleArn != "" {
 		managedPolicies, err := r.AWSClient.HasManagedPolicies(args.installerRoleArn)
 		if err != nil {
 			r.Reporter.Errorf("Failed to determine if cluster has managed policies: %v", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		if managedPolicies {
 			r.Reporter.Errorf("The managed policies are not supported for classic operator-roles.")
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 
@@ -259,14 +260,14 @@ func run(cmd *cobra.Command, argv []string) {
 
 	if args.forcePolicyCreation && mode != interactive.ModeAuto {
 		r.Reporter.Warnf("Forcing creation of policies only works in auto mode")
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	if interactive.Enabled() && !isProgmaticallyCalled {
 		mode, err = interactive.GetOptionMode(cmd, mode, "Role creation mode")
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid role creation mode: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 
@@ -286,7 +287,7 @@ func run(cmd *cobra.Command, argv []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid policy ARN for permissions boundary: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 
@@ -301,7 +302,7 @@ func run(cmd *cobra.Command, argv []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid value: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 
 		if !isHcpSharedVpc {
@@ -322,7 +323,7 @@ func run(cmd *cobra.Command, argv []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid value: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 	if interactive.Enabled() && isHcpSharedVpc && !r.Creator.IsGovcloud {
@@ -337,7 +338,7 @@ func run(cmd *cobra.Command, argv []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid value: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 
@@ -345,50 +346,50 @@ func run(cmd *cobra.Command, argv []string) {
 		err = aws.ARNValidator(permissionsBoundary)
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid policy ARN for permissions boundary: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 
 	policies, err := r.OCMClient.GetPolicies("OperatorRole")
 	if err != nil {
 		r.Reporter.Errorf("Expected a valid role creation mode: %s", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	if args.prefix != "" {
 		if args.oidcConfigId == "" {
 			r.Reporter.Errorf("%s is mandatory for %s param flow.", OidcConfigIdFlag, PrefixFlag)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 
 		if args.installerRoleArn == "" {
 			r.Reporter.Errorf("%s is mandatory for %s param flow.", InstallerRoleArnFlag, PrefixFlag)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		channelGroup := args.channelGroup
 		latestPolicyVersion, err := r.OCMClient.GetLatestVersion(channelGroup)
 		if err != nil {
 			r.Reporter.Errorf("Error getting latest version: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		err = handleOperatorRoleCreationByPrefix(r, env, permissionsBoundary,
 			mode, policies, latestPolicyVersion, isHcpSharedVpc)
 		if err != nil {
 			r.Reporter.Errorf("Error creating operator roles: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		return
 	}
 	latestPolicyVersion, err := r.OCMClient.GetLatestVersion(cluster.Version().ChannelGroup())
 	if err != nil {
 		r.Reporter.Errorf("Error getting latest version: %s", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	err = handleOperatorRoleCreationByClusterKey(r, env, permissionsBoundary,
 		mode, policies, latestPolicyVersion, isHcpSharedVpc)
 	if err != nil {
 		r.Reporter.Errorf("Error creating operator roles: %s", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 }
 
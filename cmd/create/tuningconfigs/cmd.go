@@ -26,6 +26,7 @@ import (
 	"github.com/openshift/rosa/pkg/input"
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -91,7 +92,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid name: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 
@@ -109,20 +110,20 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid spec path: %v", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 
 	tuningConfig, err := buildTuningConfigFromInputFile(specPath, name, clusterKey)
 	if err != nil {
 		r.Reporter.Errorf("%v", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	_, err = r.OCMClient.CreateTuningConfig(cluster.ID(), tuningConfig)
 	if err != nil {
 		r.Reporter.Errorf("Failed to add tuning config to cluster '%s': %v", clusterKey, err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	r.Reporter.Infof("Tuning config '%s' has been created on cluster '%s'.", name, clusterKey)
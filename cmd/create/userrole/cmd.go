@@ -91,13 +91,13 @@ func run(cmd *cobra.Command, _ []string) {
 	mode, err := interactive.GetMode()
 	if err != nil {
 		r.Reporter.Errorf("%s", err)
-		os.Exit(1)
+		os.Exit(rprtr.ExitCode())
 	}
 
 	env, err := ocm.GetEnv()
 	if err != nil {
 		r.Reporter.Errorf("Failed to determine OCM environment: %v", err)
-		os.Exit(1)
+		os.Exit(rprtr.ExitCode())
 	}
 
 	// Determine if interactive mode is needed
@@ -123,16 +123,16 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid role prefix: %s", err)
-			os.Exit(1)
+			os.Exit(rprtr.ExitCode())
 		}
 	}
 	if len(prefix) > 32 {
 		r.Reporter.Errorf("Expected a prefix with no more than 32 characters")
-		os.Exit(1)
+		os.Exit(rprtr.ExitCode())
 	}
 	if !aws.RoleNameRE.MatchString(prefix) {
 		r.Reporter.Errorf("Expected a valid role prefix matching %s", aws.RoleNameRE.String())
-		os.Exit(1)
+		os.Exit(rprtr.ExitCode())
 	}
 	permissionsBoundary := args.permissionsBoundary
 	if interactive.Enabled() {
@@ -146,7 +146,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid policy ARN for permissions boundary: %s", err)
-			os.Exit(1)
+			os.Exit(rprtr.ExitCode())
 		}
 	}
 
@@ -154,7 +154,7 @@ func run(cmd *cobra.Command, _ []string) {
 		err = aws.ARNValidator(permissionsBoundary)
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid policy ARN for permissions boundary: %s", err)
-			os.Exit(1)
+			os.Exit(rprtr.ExitCode())
 		}
 	}
 
@@ -170,21 +170,21 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid path: %s", err)
-			os.Exit(1)
+			os.Exit(rprtr.ExitCode())
 		}
 	}
 
 	if path != "" && !aws.ARNPath.MatchString(path) {
 		r.Reporter.Errorf("The specified value for path is invalid. " +
 			"It must begin and end with '/' and contain only alphanumeric characters and/or '/' characters.")
-		os.Exit(1)
+		os.Exit(rprtr.ExitCode())
 	}
 
 	if interactive.Enabled() {
 		mode, err = interactive.GetOptionMode(cmd, mode, "Role creation mode")
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid role creation mode: %s", err)
-			os.Exit(1)
+			os.Exit(rprtr.ExitCode())
 		}
 	}
 
@@ -192,13 +192,13 @@ func run(cmd *cobra.Command, _ []string) {
 	currentAccount, err := r.OCMClient.GetCurrentAccount()
 	if err != nil {
 		r.Reporter.Errorf("Failed to get current account: %s", err)
-		os.Exit(1)
+		os.Exit(rprtr.ExitCode())
 	}
 
 	policies, err := r.OCMClient.GetPolicies("")
 	if err != nil {
 		r.Reporter.Errorf("Expected a valid role creation mode: %s", err)
-		os.Exit(1)
+		os.Exit(rprtr.ExitCode())
 	}
 
 	switch mode {
@@ -211,7 +211,7 @@ func run(cmd *cobra.Command, _ []string) {
 			r.OCMClient.LogEvent("ROSACreateUserRoleModeAuto", map[string]string{
 				ocm.Response: ocm.Failure,
 			})
-			os.Exit(1)
+			os.Exit(rprtr.ExitCode())
 		}
 		r.OCMClient.LogEvent("ROSACreateUserRoleModeAuto", map[string]string{
 			ocm.Response: ocm.Success,
@@ -224,7 +224,7 @@ func run(cmd *cobra.Command, _ []string) {
 		err = generateUserRolePolicyFiles(r.Reporter, env, r.Creator.Partition, currentAccount.ID(), policies)
 		if err != nil {
 			r.Reporter.Errorf("There was an error generating the policy files: %s", err)
-			os.Exit(1)
+			os.Exit(rprtr.ExitCode())
 		}
 		if r.Reporter.IsTerminal() {
 			r.Reporter.Infof("All policy files saved to the current directory")
@@ -242,7 +242,7 @@ func run(cmd *cobra.Command, _ []string) {
 
 	default:
 		r.Reporter.Errorf("Invalid mode. Allowed values are %s", interactive.Modes)
-		os.Exit(1)
+		os.Exit(rprtr.ExitCode())
 	}
 }
 
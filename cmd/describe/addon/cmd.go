@@ -18,6 +18,7 @@ package addon
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"regexp"
 	"strings"
@@ -25,6 +26,8 @@ import (
 	asv1 "github.com/openshift-online/ocm-sdk-go/addonsmgmt/v1"
 	"github.com/spf13/cobra"
 
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -45,6 +48,11 @@ var Cmd = &cobra.Command{
 	},
 }
 
+func init() {
+	output.AddFlag(Cmd)
+	output.RegisterTablePrinter(printAddOn)
+}
+
 func run(_ *cobra.Command, argv []string) {
 	r := rosa.NewRuntime().WithOCM()
 	defer r.Cleanup()
@@ -57,16 +65,29 @@ func run(_ *cobra.Command, argv []string) {
 		r.Reporter.Errorf("Failed to get add-on '%s': %s\n"+
 			"Try running 'rosa list addons' to see all available add-ons.",
 			addOnID, err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
+	}
+
+	if output.HasFlag() {
+		err = output.Print(addOn)
+	} else {
+		err = output.PrintTable(addOn)
 	}
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(reporter.ExitCode())
+	}
+}
 
-	printDescription(addOn)
-	printCredentialRequests(addOn.CredentialsRequests())
-	printParameters(addOn.Parameters())
+func printAddOn(addOn *asv1.Addon, writer io.Writer) error {
+	printDescription(addOn, writer)
+	printCredentialRequests(addOn.CredentialsRequests(), writer)
+	printParameters(addOn.Parameters(), writer)
+	return nil
 }
 
-func printDescription(addOn *asv1.Addon) {
-	fmt.Printf("ADD-ON\n"+
+func printDescription(addOn *asv1.Addon, writer io.Writer) {
+	fmt.Fprintf(writer, "ADD-ON\n"+
 		"ID:               %s\n"+
 		"Name:             %s\n"+
 		"Description:      %s\n"+
@@ -82,14 +103,14 @@ func printDescription(addOn *asv1.Addon) {
 		addOn.TargetNamespace(),
 		addOn.InstallMode(),
 	)
-	fmt.Println()
+	fmt.Fprintln(writer)
 }
 
-func printCredentialRequests(requests []*asv1.CredentialRequest) {
+func printCredentialRequests(requests []*asv1.CredentialRequest, writer io.Writer) {
 	if len(requests) > 0 {
-		fmt.Printf("CREDENTIALS REQUESTS\n")
+		fmt.Fprintf(writer, "CREDENTIALS REQUESTS\n")
 		for _, cr := range requests {
-			fmt.Printf(""+
+			fmt.Fprintf(writer, ""+
 				"- Service account:  %s\n"+
 				"  Secret name:      %s\n"+
 				"  Secret namespace: %s\n",
@@ -98,24 +119,24 @@ func printCredentialRequests(requests []*asv1.CredentialRequest) {
 				cr.Namespace(),
 			)
 			if len(cr.PolicyPermissions()) > 0 {
-				fmt.Printf("  Policy permissions:\n")
+				fmt.Fprintf(writer, "  Policy permissions:\n")
 				for _, p := range cr.PolicyPermissions() {
-					fmt.Printf("  - %s\n", p)
+					fmt.Fprintf(writer, "  - %s\n", p)
 				}
 			}
 		}
 	}
-	fmt.Println()
+	fmt.Fprintln(writer)
 }
 
-func printParameters(params *asv1.AddonParameterList) {
+func printParameters(params *asv1.AddonParameterList, writer io.Writer) {
 	if params.Len() > 0 {
-		fmt.Printf("ADD-ON PARAMETERS\n")
+		fmt.Fprintf(writer, "ADD-ON PARAMETERS\n")
 		params.Each(func(param *asv1.AddonParameter) bool {
 			if !param.Enabled() {
 				return true
 			}
-			fmt.Printf(""+
+			fmt.Fprintf(writer, ""+
 				"- ID:             %s\n"+
 				"  Name:           %s\n"+
 				"  Description:    %s\n"+
@@ -130,12 +151,12 @@ func printParameters(params *asv1.AddonParameterList) {
 				printBool(param.Editable()),
 			)
 			if param.DefaultValue() != "" {
-				fmt.Printf("  Default Value:  %s\n", param.DefaultValue())
+				fmt.Fprintf(writer, "  Default Value:  %s\n", param.DefaultValue())
 			}
 			if param.Validation() != "" {
-				fmt.Printf("  Validation:     /%s/\n", param.Validation())
+				fmt.Fprintf(writer, "  Validation:     /%s/\n", param.Validation())
 			}
-			fmt.Println()
+			fmt.Fprintln(writer)
 			return true
 		})
 	}
@@ -17,6 +17,8 @@ limitations under the License.
 package admin
 
 import (
+	"fmt"
+	"io"
 	"os"
 
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
@@ -24,6 +26,8 @@ import (
 
 	cadmin "github.com/openshift/rosa/cmd/create/admin"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -39,6 +43,19 @@ var Cmd = &cobra.Command{
 
 func init() {
 	ocm.AddClusterFlag(Cmd)
+	output.AddFlag(Cmd)
+	output.RegisterTablePrinter(printAdmin)
+}
+
+// clusterAdmin contains the details of the cluster-admin user needed to login to the cluster.
+type clusterAdmin struct {
+	APIURL           string `json:"api_url"`
+	IdentityProvider string `json:"identity_provider"`
+	Username         string `json:"username"`
+
+	// clusterKey is the name or identifier of the cluster given in the command line. It is only
+	// used by the table printer.
+	clusterKey string
 }
 
 func run(_ *cobra.Command, _ []string) {
@@ -50,30 +67,53 @@ func run(_ *cobra.Command, _ []string) {
 	cluster := r.FetchCluster()
 	if cluster.State() != cmv1.ClusterStateReady {
 		r.Reporter.Errorf("Cluster '%s' is not yet ready", clusterKey)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	if cluster.ExternalAuthConfig().Enabled() {
 		r.Reporter.Errorf(
 			"Describing the 'cluster-admin' user is not supported for clusters with external authentication configured.",
 		)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	// Try to find an existing htpasswd identity provider and
 	// check if cluster-admin user already exists
 	existingClusterAdminIdp, _, err := cadmin.FindIDPWithAdmin(cluster, r)
 	if err != nil {
-		r.Reporter.Errorf(err.Error())
-		os.Exit(1)
+		r.Reporter.Errorf("%v", err)
+		os.Exit(reporter.ExitCode())
 	}
-	if existingClusterAdminIdp != nil {
-		r.Reporter.Infof("There is '%s' user on cluster '%s'. To login, run the following command:\n"+
-			"   oc login %s --username %s",
-			cadmin.ClusterAdminUsername, clusterKey, cluster.API().URL(), cadmin.ClusterAdminUsername)
-	} else {
+	if existingClusterAdminIdp == nil {
+		if output.HasFlag() {
+			r.Reporter.Errorf("There is no '%s' user on cluster '%s'", cadmin.ClusterAdminUsername, clusterKey)
+			os.Exit(reporter.ExitCode())
+		}
 		r.Reporter.Warnf("There is no '%s' user on cluster '%s'. To create it run the following command:\n"+
 			"   rosa create admin -c %s", cadmin.ClusterAdminUsername, clusterKey, clusterKey)
 		os.Exit(0)
 	}
+
+	admin := &clusterAdmin{
+		APIURL:           cluster.API().URL(),
+		IdentityProvider: existingClusterAdminIdp.Name(),
+		Username:         cadmin.ClusterAdminUsername,
+		clusterKey:       clusterKey,
+	}
+	if output.HasFlag() {
+		err = output.Print(admin)
+	} else {
+		err = output.PrintTable(admin)
+	}
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(reporter.ExitCode())
+	}
+}
+
+func printAdmin(admin *clusterAdmin, writer io.Writer) error {
+	_, err := fmt.Fprintf(writer, "There is '%s' user on cluster '%s'. To login, run the following command:\n"+
+		"   oc login %s --username %s\n",
+		admin.Username, admin.clusterKey, admin.APIURL, admin.Username)
+	return err
 }
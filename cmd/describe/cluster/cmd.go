@@ -30,11 +30,14 @@ import (
 	ocmConsts "github.com/openshift-online/ocm-common/pkg/ocm/consts"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
 
 	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/clusterspec"
 	"github.com/openshift/rosa/pkg/helper/rolepolicybindings"
 	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -63,9 +66,14 @@ var args struct {
 }
 
 func init() {
-	output.AddFlag(Cmd)
+	output.AddSpecFlag(Cmd)
 	ocm.AddClusterFlag(Cmd)
 
+	// Clusters can also be printed as the spec file that creates them:
+	flag := Cmd.Flags().Lookup(output.FLAG_NAME)
+	flag.Usage = fmt.Sprintf("%s. Use '%s' to print the cluster spec file accepted by 'rosa create cluster "+
+		"--from-file'", flag.Usage, output.SPEC)
+
 	Cmd.Flags().BoolVar(
 		&args.getRolePolicyBindings,
 		"get-role-policy-bindings",
@@ -89,6 +97,15 @@ func run(cmd *cobra.Command, argv []string) {
 	cluster := r.FetchCluster()
 	isHypershift := cluster.Hypershift().Enabled()
 
+	if output.Spec() {
+		err = printSpec(r, cluster)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(reporter.ExitCode())
+		}
+		return
+	}
+
 	displayName := ""
 	subscription, subscriptionExists, err := r.OCMClient.GetSubscriptionBySubscriptionID(cluster.Subscription().ID())
 	if err != nil {
@@ -127,19 +144,19 @@ func run(cmd *cobra.Command, argv []string) {
 		scheduledUpgrade, upgradeState, err = r.OCMClient.GetScheduledUpgrade(cluster.ID())
 		if err != nil {
 			r.Reporter.Errorf("Failed to get scheduled upgrades for cluster '%s': %v", clusterKey, err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 
 		if output.HasFlag() {
 			f, err := formatCluster(cluster, scheduledUpgrade, upgradeState, displayName, migrations)
 			if err != nil {
 				r.Reporter.Errorf("%s", err)
-				os.Exit(1)
+				os.Exit(reporter.ExitCode())
 			}
 			err = output.Print(f)
 			if err != nil {
 				r.Reporter.Errorf("%s", err)
-				os.Exit(1)
+				os.Exit(reporter.ExitCode())
 			}
 			return
 		}
@@ -147,19 +164,19 @@ func run(cmd *cobra.Command, argv []string) {
 		controlPlaneScheduledUpgrade, err = r.OCMClient.GetControlPlaneScheduledUpgrade(cluster.ID())
 		if err != nil {
 			r.Reporter.Errorf("Failed to get scheduled upgrades for cluster '%s': %v", clusterKey, err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 
 		if output.HasFlag() {
 			f, err := formatClusterHypershift(cluster, controlPlaneScheduledUpgrade, displayName, migrations)
 			if err != nil {
 				r.Reporter.Errorf("%s", err)
-				os.Exit(1)
+				os.Exit(reporter.ExitCode())
 			}
 			err = output.Print(f)
 			if err != nil {
 				r.Reporter.Errorf("%s", err)
-				os.Exit(1)
+				os.Exit(reporter.ExitCode())
 			}
 			return
 		}
@@ -169,7 +186,7 @@ func run(cmd *cobra.Command, argv []string) {
 	creatorARN, err := arn.Parse(cluster.Properties()[ocmConsts.CreatorArn])
 	if err != nil {
 		r.Reporter.Errorf("Failed to parse creator ARN for cluster '%s'", clusterKey)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	phase := ""
 
@@ -250,7 +267,7 @@ func run(cmd *cobra.Command, argv []string) {
 	}
 	if err != nil {
 		r.Reporter.Errorf("Failed to get machine pools for cluster '%s': %v", clusterKey, err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	// Print short cluster description:
@@ -346,7 +363,7 @@ func run(cmd *cobra.Command, argv []string) {
 			rolePolicyBindings, err := r.OCMClient.ListRolePolicyBindings(cluster.ID(), true)
 			if err != nil {
 				r.Reporter.Errorf("Failed to get rolePolicyBinding: %s", err)
-				os.Exit(1)
+				os.Exit(reporter.ExitCode())
 			}
 			rolePolicyDetails = rolepolicybindings.TransformToRolePolicyDetails(rolePolicyBindings)
 		}
@@ -357,8 +374,8 @@ func run(cmd *cobra.Command, argv []string) {
 			policyStr, err := getRolePolicyBindings(cluster.AWS().STS().RoleARN(), rolePolicyDetails,
 				"                            -")
 			if err != nil {
-				r.Reporter.Errorf(err.Error())
-				os.Exit(1)
+				r.Reporter.Errorf("%v", err)
+				os.Exit(reporter.ExitCode())
 			}
 			str = str + policyStr
 		}
@@ -375,8 +392,8 @@ func run(cmd *cobra.Command, argv []string) {
 				policyStr, err := getRolePolicyBindings(cluster.AWS().STS().SupportRoleARN(), rolePolicyDetails,
 					"                            -")
 				if err != nil {
-					r.Reporter.Errorf(err.Error())
-					os.Exit(1)
+					r.Reporter.Errorf("%v", err)
+					os.Exit(reporter.ExitCode())
 				}
 				str = str + policyStr
 			}
@@ -393,8 +410,8 @@ func run(cmd *cobra.Command, argv []string) {
 						rolePolicyDetails,
 						"                            -")
 					if err != nil {
-						r.Reporter.Errorf(err.Error())
-						os.Exit(1)
+						r.Reporter.Errorf("%v", err)
+						os.Exit(reporter.ExitCode())
 					}
 					str = str + policyStr
 				}
@@ -408,8 +425,8 @@ func run(cmd *cobra.Command, argv []string) {
 						rolePolicyDetails,
 						"                            -")
 					if err != nil {
-						r.Reporter.Errorf(err.Error())
-						os.Exit(1)
+						r.Reporter.Errorf("%v", err)
+						os.Exit(reporter.ExitCode())
 					}
 					str = str + policyStr
 				}
@@ -426,8 +443,8 @@ func run(cmd *cobra.Command, argv []string) {
 						rolePolicyDetails,
 						"   -")
 					if err != nil {
-						r.Reporter.Errorf(err.Error())
-						os.Exit(1)
+						r.Reporter.Errorf("%v", err)
+						os.Exit(reporter.ExitCode())
 					}
 					str = str + policyStr
 				}
@@ -563,7 +580,7 @@ func run(cmd *cobra.Command, argv []string) {
 				if err != nil {
 					r.Reporter.Errorf("Failed to get allowlist with id '%s': %v",
 						cluster.RegistryConfig().PlatformAllowlist().ID(), err)
-					os.Exit(1)
+					os.Exit(reporter.ExitCode())
 				}
 			}
 			registryConfigOutput := getClusterRegistryConfig(cluster, allowlist)
@@ -575,7 +592,7 @@ func run(cmd *cobra.Command, argv []string) {
 		zeroEgressStatus, err := getZeroEgressStatus(r, cluster)
 		if err != nil {
 			r.Reporter.Errorf("Failed to get zero egress info: %v", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		if zeroEgressStatus != "" {
 			str = fmt.Sprintf("%s"+"%s\n", str, zeroEgressStatus)
@@ -595,7 +612,7 @@ func run(cmd *cobra.Command, argv []string) {
 	limitedSupportReasons, err := r.OCMClient.GetLimitedSupportReasons(cluster.ID())
 	if err != nil {
 		r.Reporter.Errorf("Failed to get limited support reasons for cluster '%s': %v", cluster.ID(), err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	if len(limitedSupportReasons) > 0 {
 		str = fmt.Sprintf("%s"+"Limited Support:\n", str)
@@ -610,7 +627,7 @@ func run(cmd *cobra.Command, argv []string) {
 	inflightChecks, err := r.OCMClient.GetInflightChecks(cluster.ID())
 	if err != nil {
 		r.Reporter.Errorf("Failed to get inflight checks for cluster '%s': %v", cluster.ID(), err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	if len(inflightChecks) > 0 {
 		summaries := []string{}
@@ -1051,3 +1068,31 @@ func getZeroEgressStatus(r *rosa.Runtime, cluster *cmv1.Cluster) (string, error)
 	}
 	return "", nil
 }
+
+// printSpec prints the cluster spec that creates a cluster like the given one.
+func printSpec(r *rosa.Runtime, cluster *cmv1.Cluster) error {
+	var autoscaler *cmv1.ClusterAutoscaler
+	if !cluster.Hypershift().Enabled() {
+		var err error
+		autoscaler, err = r.OCMClient.GetClusterAutoscaler(cluster.ID())
+		if err != nil {
+			return fmt.Errorf("Failed to get autoscaler of cluster '%s': %v", cluster.Name(), err)
+		}
+	}
+	ingresses, err := r.OCMClient.GetIngresses(cluster.ID())
+	if err != nil {
+		return fmt.Errorf("Failed to get ingresses of cluster '%s': %v", cluster.Name(), err)
+	}
+	var defaultIngress *cmv1.Ingress
+	for _, ingress := range ingresses {
+		if ingress.Default() {
+			defaultIngress = ingress
+		}
+	}
+	data, err := yaml.Marshal(clusterspec.FromCluster(cluster, autoscaler, defaultIngress))
+	if err != nil {
+		return fmt.Errorf("Failed to print spec of cluster '%s': %v", cluster.Name(), err)
+	}
+	fmt.Print(string(data))
+	return nil
+}
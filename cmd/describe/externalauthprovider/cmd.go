@@ -27,6 +27,7 @@ import (
 	"github.com/openshift/rosa/pkg/externalauthprovider"
 	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -63,8 +64,8 @@ func run(cmd *cobra.Command, argv []string) {
 	defer r.Cleanup()
 	err := runWithRuntime(r, cmd, argv)
 	if err != nil {
-		r.Reporter.Errorf(err.Error())
-		os.Exit(1)
+		r.Reporter.Errorf("%v", err)
+		os.Exit(reporter.ExitCode())
 	}
 }
 
@@ -100,7 +101,7 @@ func runWithRuntime(r *rosa.Runtime, cmd *cobra.Command, argv []string) error {
 		err = output.Print(externalAuthConfig)
 		if err != nil {
 			r.Reporter.Errorf("%v", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		return nil
 	}
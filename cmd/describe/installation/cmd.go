@@ -18,12 +18,15 @@ package installation
 
 import (
 	"fmt"
+	"io"
 	"os"
 
 	asv1 "github.com/openshift-online/ocm-sdk-go/addonsmgmt/v1"
 	"github.com/spf13/cobra"
 
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -60,6 +63,9 @@ func init() {
 		"",
 		"Name or ID of the addon installation (required).",
 	)
+
+	output.AddFlag(Cmd)
+	output.RegisterTablePrinter(printAddonInstallation)
 }
 
 func run(_ *cobra.Command, _ []string) {
@@ -69,19 +75,19 @@ func run(_ *cobra.Command, _ []string) {
 	if args.clusterKey == "" {
 		r.Reporter.Errorf(
 			"Expected the cluster to be specified with the --cluster flag")
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	ocm.SetClusterKey(args.clusterKey)
 
 	if args.installationKey == "" {
 		r.Reporter.Errorf(
 			"Expected the add-on installation to be specified with the --addon flag")
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	if err := describeAddonInstallation(r, args.installationKey); err != nil {
 		r.Reporter.Errorf("Failed to describe add-on installation: %v", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 }
 
@@ -93,7 +99,14 @@ func describeAddonInstallation(r *rosa.Runtime, installationKey string) error {
 		return err
 	}
 
-	fmt.Printf(`%-28s %s
+	if output.HasFlag() {
+		return output.Print(installation)
+	}
+	return output.PrintTable(installation)
+}
+
+func printAddonInstallation(installation *asv1.AddonInstallation, writer io.Writer) error {
+	fmt.Fprintf(writer, `%-28s %s
 %-28s %s
 %-28s %s
 `,
@@ -104,10 +117,10 @@ func describeAddonInstallation(r *rosa.Runtime, installationKey string) error {
 
 	parameters := installation.Parameters()
 	if parameters.Len() > 0 {
-		fmt.Println("Parameters:")
+		fmt.Fprintln(writer, "Parameters:")
 	}
 	parameters.Each(func(parameter *asv1.AddonInstallationParameter) bool {
-		fmt.Printf("\t%-28q: %q\n", parameter.Id(), parameter.Value())
+		fmt.Fprintf(writer, "\t%-28q: %q\n", parameter.Id(), parameter.Value())
 		return true
 	})
 
@@ -18,11 +18,15 @@ package service
 
 import (
 	"fmt"
+	"io"
 	"os"
 
+	msv1 "github.com/openshift-online/ocm-sdk-go/servicemgmt/v1"
 	"github.com/spf13/cobra"
 
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -49,6 +53,9 @@ func init() {
 		"",
 		"The id of the service to describe",
 	)
+
+	output.AddFlag(Cmd)
+	output.RegisterTablePrinter(printService)
 }
 
 func run(cmd *cobra.Command, _ []string) {
@@ -58,7 +65,7 @@ func run(cmd *cobra.Command, _ []string) {
 	if args.ID == "" {
 		r.Reporter.Errorf("id not specified.")
 		cmd.Help()
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	// Try to find the cluster:
@@ -66,10 +73,22 @@ func run(cmd *cobra.Command, _ []string) {
 	service, err := r.OCMClient.GetManagedService(args)
 	if err != nil {
 		r.Reporter.Errorf("Failed to get service with id %q: %v", args.ID, err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
+	}
+
+	if output.HasFlag() {
+		err = output.Print(service)
+	} else {
+		err = output.PrintTable(service)
 	}
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(reporter.ExitCode())
+	}
+}
 
-	fmt.Printf(`%-28s%s
+func printService(service *msv1.ManagedService, writer io.Writer) error {
+	fmt.Fprintf(writer, `%-28s%s
 %-28s%s
 %-28s%s
 %-28s%s
@@ -87,11 +106,12 @@ func run(cmd *cobra.Command, _ []string) {
 
 	parameters := service.Parameters()
 	if len(parameters) > 0 {
-		fmt.Printf("%-28s\n", "Parameters:")
+		fmt.Fprintf(writer, "%-28s\n", "Parameters:")
 	}
 	for _, param := range parameters {
-		fmt.Printf("\t%-28q: %q\n",
+		fmt.Fprintf(writer, "\t%-28q: %q\n",
 			param.ID(),
 			param.Value())
 	}
+	return nil
 }
@@ -18,6 +18,7 @@ package upgrade
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"strings"
 
@@ -27,6 +28,8 @@ import (
 	"github.com/openshift/rosa/pkg/interactive/confirm"
 	"github.com/openshift/rosa/pkg/machinepool"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -60,6 +63,17 @@ func init() {
 	)
 
 	confirm.AddFlag(flags)
+	output.AddFlag(Cmd)
+	output.RegisterTablePrinter(printHypershiftUpgrades[*cmv1.ControlPlaneUpgradePolicy])
+	output.RegisterTablePrinter(printHypershiftUpgrades[*cmv1.NodePoolUpgradePolicy])
+	output.RegisterTablePrinter(printClassicUpgrades)
+}
+
+// classicUpgrades contains the upgrade policies of a classic cluster and the state of the
+// scheduled upgrade.
+type classicUpgrades struct {
+	policies []*cmv1.UpgradePolicy
+	state    *cmv1.UpgradePolicyState
 }
 
 func run(_ *cobra.Command, _ []string) {
@@ -67,8 +81,8 @@ func run(_ *cobra.Command, _ []string) {
 	defer r.Cleanup()
 	err := runWithRuntime(r)
 	if err != nil {
-		r.Reporter.Errorf(err.Error())
-		os.Exit(1)
+		r.Reporter.Errorf("%v", err)
+		os.Exit(reporter.ExitCode())
 	}
 }
 
@@ -99,28 +113,41 @@ func describeHypershiftUpgrades(r *rosa.Runtime, clusterID string, nodePoolID st
 		if err != nil {
 			return fmt.Errorf("Failed to get upgrades for cluster '%s': %v", clusterKey, err)
 		}
+		if output.HasFlag() {
+			return output.Print(upgrades)
+		}
 		if len(upgrades) < 1 {
 			r.Reporter.Infof("No scheduled upgrades for cluster '%s'", clusterKey)
 			return nil
 		}
-
-		for _, upgrade := range upgrades {
-			fmt.Print(formatHypershiftUpgrade(upgrade))
-		}
+		return output.PrintTable(upgrades)
 	} else {
 		_, upgrades, err := r.OCMClient.GetHypershiftNodePoolUpgrades(clusterID, clusterKey, nodePoolID)
 		if err != nil {
 			return fmt.Errorf("Failed to get upgrades for machine pool '%s' in cluster '%s': %v", nodePoolID,
 				clusterKey, err)
 		}
+		if output.HasFlag() {
+			return output.Print(upgrades)
+		}
 		if upgrades == nil || len(upgrades) < 1 {
 			r.Reporter.Infof("No scheduled upgrades for machine pool '%s' in cluster '%s'", nodePoolID, clusterKey)
 			return nil
 		}
+		return output.PrintTable(upgrades)
+	}
+}
 
-		for _, upgrade := range upgrades {
-			fmt.Print(formatHypershiftUpgrade(upgrade))
-		}
+func printHypershiftUpgrades[T ocm.HypershiftUpgrader](upgrades []T, writer io.Writer) error {
+	for _, upgrade := range upgrades {
+		fmt.Fprint(writer, formatHypershiftUpgrade(upgrade))
+	}
+	return nil
+}
+
+func printClassicUpgrades(upgrades *classicUpgrades, writer io.Writer) error {
+	for _, upgrade := range upgrades.policies {
+		fmt.Fprint(writer, formatClassicUpgrade(upgrade, upgrades.state))
 	}
 	return nil
 }
@@ -189,6 +216,9 @@ func describeClassicUpgrades(r *rosa.Runtime, clusterID string) error {
 	if err != nil {
 		return fmt.Errorf("Failed to get upgrade with cluster id '%s': %v", clusterID, err)
 	}
+	if output.HasFlag() {
+		return output.Print(upgrades)
+	}
 	_, upgradeState, err := r.OCMClient.GetScheduledUpgrade(clusterID)
 	if err != nil {
 		return fmt.Errorf("Failed to get scheduled upgrades for cluster '%s': %v", clusterID, err)
@@ -197,9 +227,8 @@ func describeClassicUpgrades(r *rosa.Runtime, clusterID string) error {
 		r.Reporter.Infof("No scheduled upgrades for cluster id '%s'", clusterID)
 		return nil
 	}
-
-	for _, upgrade := range upgrades {
-		fmt.Print(formatClassicUpgrade(upgrade, upgradeState))
-	}
-	return nil
+	return output.PrintTable(&classicUpgrades{
+		policies: upgrades,
+		state:    upgradeState,
+	})
 }
@@ -1,6 +1,7 @@
 package upgrade
 
 import (
+	"bytes"
 	"fmt"
 	"net/http"
 	"time"
@@ -11,6 +12,7 @@ import (
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	. "github.com/openshift-online/ocm-sdk-go/testing"
 
+	"github.com/openshift/rosa/pkg/output"
 	"github.com/openshift/rosa/pkg/test"
 )
 
@@ -63,6 +65,29 @@ Version:                           4.12.19
 `, nowUTC.Format("2006-01-02 15:04 MST"), nowUTC.Format("2006-01-02 15:04 MST"))))
 		})
 	})
+	Context("Table printers", func() {
+		It("Prints the upgrades of a machine pool", func() {
+			policy := buildNodePoolUpgradePolicy()
+			var buffer bytes.Buffer
+			err := output.WriteTable(&buffer, []*cmv1.NodePoolUpgradePolicy{policy, policy})
+			Expect(err).To(BeNil())
+			Expect(buffer.String()).To(Equal(formatHypershiftUpgrade(policy) + formatHypershiftUpgrade(policy)))
+		})
+		It("Prints the upgrades of a classic cluster with the scheduled state", func() {
+			state, err := cmv1.NewUpgradePolicyState().Value("scheduled").Build()
+			Expect(err).To(BeNil())
+			policy, err := cmv1.NewUpgradePolicy().ID("id1").ClusterID("id1").Version("4.12.25").Build()
+			Expect(err).To(BeNil())
+			var buffer bytes.Buffer
+			err = output.WriteTable(&buffer, &classicUpgrades{
+				policies: []*cmv1.UpgradePolicy{policy},
+				state:    state,
+			})
+			Expect(err).To(BeNil())
+			Expect(buffer.String()).To(Equal(formatClassicUpgrade(policy, state)))
+			Expect(buffer.String()).To(ContainSubstring("Upgrade State:                     scheduled"))
+		})
+	})
 	Context("Describe Classic Upgrades", func() {
 		var testRuntime test.TestingRuntime
 		var clusterID = "cluster1"
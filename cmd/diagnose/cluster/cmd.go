@@ -0,0 +1,75 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/diagnose"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/reporter"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var Cmd = &cobra.Command{
+	Use:   "cluster",
+	Short: "Diagnose the health of a cluster",
+	Long: "Check the health signals of a cluster and report the problems found, with their severity, " +
+		"an explanation and the suggested remediation. It checks the state of the cluster, the limited " +
+		"support reasons, the inflight checks, the upgrades of the cluster and of its machine pools, the " +
+		"replicas of the machine pools, the network verification of the subnets, the OIDC provider and " +
+		"the operator roles. The command exits with code 1 when it finds errors.",
+	Example: `  # Diagnose cluster "mycluster"
+  rosa diagnose cluster -c mycluster
+
+  # Get the report in JSON format
+  rosa diagnose cluster -c mycluster -o json`,
+	Args: cobra.NoArgs,
+	Run:  run,
+}
+
+func init() {
+	ocm.AddClusterFlag(Cmd)
+	output.AddFlag(Cmd)
+}
+
+func run(_ *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithAWS().WithOCM()
+	defer r.Cleanup()
+
+	clusterKey := r.GetClusterKey()
+	cluster := r.FetchCluster()
+
+	r.Reporter.Debugf("Diagnosing cluster '%s'", clusterKey)
+	report := diagnose.Diagnose(r, cluster)
+	if output.HasFlag() {
+		err := output.Print(report)
+		if err != nil {
+			r.Reporter.Errorf("%v", err)
+			os.Exit(reporter.ExitCode())
+		}
+	} else {
+		report.Print(os.Stdout)
+	}
+	if report.Severity == diagnose.SeverityError {
+		r.Reporter.Errorf("Found %d errors on cluster '%s'", report.Count(diagnose.SeverityError), clusterKey)
+		os.Exit(reporter.ExitCode())
+	}
+}
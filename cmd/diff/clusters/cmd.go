@@ -0,0 +1,115 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusters
+
+import (
+	"fmt"
+	"os"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/clusterdiff"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/reporter"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var Cmd = &cobra.Command{
+	Use:   "clusters CLUSTER_A CLUSTER_B",
+	Short: "Compare the configuration of two clusters",
+	Long: "Compare the configuration of two clusters and show the differences as a unified diff. It " +
+		"compares the version, channel group, networking, proxy, encryption, registry, autoscaler and " +
+		"default ingress settings, the machine pools, the identity providers, the kubelet and tuning " +
+		"configs, and the add-ons with their parameters. Objects are matched by name, and identifiers, " +
+		"timestamps, status and other fields specific to each cluster are ignored.",
+	Example: `  # Compare the staging and production clusters
+  rosa diff clusters staging production
+
+  # Get the differences in JSON format
+  rosa diff clusters staging production -o json`,
+	Args: cobra.ExactArgs(2),
+	Run:  run,
+}
+
+func init() {
+	output.AddFlag(Cmd)
+}
+
+// result is the JSON representation of the comparison.
+type result struct {
+	Clusters    []string                  `json:"clusters"`
+	Differences []*clusterdiff.Difference `json:"differences"`
+}
+
+func run(_ *cobra.Command, argv []string) {
+	r := rosa.NewRuntime().WithAWS().WithOCM()
+	defer r.Cleanup()
+
+	clusters := make([]*cmv1.Cluster, len(argv))
+	snapshots := make([]clusterdiff.Snapshot, len(argv))
+	for i, clusterKey := range argv {
+		if !ocm.IsValidClusterKey(clusterKey) {
+			r.Reporter.Errorf("Cluster name, identifier or external identifier '%s' isn't valid: it "+
+				"must contain only letters, digits, dashes and underscores", clusterKey)
+			os.Exit(reporter.ExitCode())
+		}
+		cluster, err := r.OCMClient.GetCluster(clusterKey, r.Creator)
+		if err != nil {
+			r.Reporter.Errorf("Failed to get cluster '%s': %v", clusterKey, err)
+			os.Exit(reporter.ExitCode())
+		}
+		r.Reporter.Debugf("Taking snapshot of cluster '%s'", clusterKey)
+		snapshot, err := clusterdiff.Take(r.OCMClient, cluster)
+		if err != nil {
+			r.Reporter.Errorf("Failed to get the configuration of cluster '%s': %v", clusterKey, err)
+			os.Exit(reporter.ExitCode())
+		}
+		clusters[i] = cluster
+		snapshots[i] = snapshot
+	}
+
+	if output.HasFlag() {
+		err := output.Print(&result{
+			Clusters:    []string{clusters[0].Name(), clusters[1].Name()},
+			Differences: clusterdiff.Compare(snapshots[0], snapshots[1]),
+		})
+		if err != nil {
+			r.Reporter.Errorf("%v", err)
+			os.Exit(reporter.ExitCode())
+		}
+		return
+	}
+
+	if len(clusterdiff.Compare(snapshots[0], snapshots[1])) == 0 {
+		r.Reporter.Infof("Clusters '%s' and '%s' have the same configuration", clusters[0].Name(),
+			clusters[1].Name())
+		return
+	}
+	err := clusterdiff.Unified(os.Stdout, clusterName(clusters[0]), snapshots[0], clusterName(clusters[1]),
+		snapshots[1])
+	if err != nil {
+		r.Reporter.Errorf("Failed to compare clusters: %v", err)
+		os.Exit(reporter.ExitCode())
+	}
+}
+
+// clusterName returns the name of the cluster with its identifier, used in the headers of the diff.
+func clusterName(cluster *cmv1.Cluster) string {
+	return fmt.Sprintf("%s (%s)", cluster.Name(), cluster.ID())
+}
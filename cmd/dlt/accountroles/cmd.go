@@ -33,6 +33,7 @@ import (
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/interactive/confirm"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/roles"
 	"github.com/openshift/rosa/pkg/rosa"
 )
@@ -107,13 +108,13 @@ func run(cmd *cobra.Command, _ []string) {
 	mode, err := interactive.GetMode()
 	if err != nil {
 		r.Reporter.Errorf("%s", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	env, err := ocm.GetEnv()
 	if err != nil {
 		r.Reporter.Errorf("Error getting environment %s", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	deleteClassic, deleteHostedCP := setDeleteRoles(cmd.Flags().Changed("classic"),
@@ -122,12 +123,12 @@ func run(cmd *cobra.Command, _ []string) {
 	clusters, err := r.OCMClient.GetAllClusters(r.Creator)
 	if err != nil {
 		r.Reporter.Errorf("Error getting clusters %s", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	if cmd.Flags().Changed("hosted-cp") && r.Creator.IsGovcloud {
 		r.Reporter.Errorf("Setting `hosted-cp` is not supported for Govcloud AWS accounts")
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	prefix := args.prefix
@@ -144,23 +145,23 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid role prefix: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 	if len(prefix) > 32 {
 		r.Reporter.Errorf("Expected a prefix with no more than 32 characters")
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	if !aws.RoleNameRE.MatchString(prefix) {
 		r.Reporter.Errorf("Expected a valid role prefix matching %s", aws.RoleNameRE.String())
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	if interactive.Enabled() {
 		mode, err = interactive.GetOptionMode(cmd, mode, "Account role deletion mode")
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid Account role deletion mode: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 
@@ -168,7 +169,7 @@ func run(cmd *cobra.Command, _ []string) {
 		err = deleteAccountRoles(r, cmd, env, prefix, clusters, mode, false)
 		if err != nil {
 			r.Reporter.Errorf("%s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 
@@ -180,7 +181,7 @@ func run(cmd *cobra.Command, _ []string) {
 		err = deleteAccountRoles(r, cmd, env, prefix, clusters, mode, true)
 		if err != nil {
 			r.Reporter.Errorf("%s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 }
@@ -31,6 +31,7 @@ import (
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/interactive/confirm"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -96,7 +97,7 @@ func run(_ *cobra.Command, _ []string) {
 	err := handleClusterDelete(r, cluster, clusterKey, args.bestEffort)
 	if err != nil {
 		r.Reporter.Errorf("%s", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	if cluster.AWS().STS().RoleARN() != "" {
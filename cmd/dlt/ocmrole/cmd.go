@@ -30,6 +30,7 @@ import (
 	"github.com/openshift/rosa/pkg/helper"
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/interactive/confirm"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -70,13 +71,13 @@ func run(cmd *cobra.Command, argv []string) {
 	mode, err := interactive.GetMode()
 	if err != nil {
 		r.Reporter.Errorf("%s", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	orgID, _, err := r.OCMClient.GetCurrentOrganization()
 	if err != nil {
 		r.Reporter.Errorf("Error getting organization account: %v", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	if len(argv) > 0 {
@@ -110,26 +111,26 @@ func run(cmd *cobra.Command, argv []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid ocm role ARN to delete from the current organization: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 
 	err = aws.ARNValidator(roleARN)
 	if err != nil {
 		r.Reporter.Errorf("Expected a valid ocm role ARN to delete from the current organization: %s", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	err = r.AWSClient.ValidateRoleARNAccountIDMatchCallerAccountID(roleARN)
 	if err != nil {
 		r.Reporter.Errorf("%s", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	managedPolicies, err := r.AWSClient.HasManagedPolicies(roleARN)
 	if err != nil {
 		r.Reporter.Errorf("Failed to determine if cluster has managed policies: %v", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	if !confirm.Prompt(true, "Delete '%s' ocm role?", roleARN) {
@@ -139,7 +140,7 @@ func run(cmd *cobra.Command, argv []string) {
 	linkedRoles, err := r.OCMClient.GetOrganizationLinkedOCMRoles(orgID)
 	if err != nil {
 		r.Reporter.Errorf("An error occurred while trying to get the organization linked roles: %s", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	isLinked := helper.Contains(linkedRoles, roleARN)
 
@@ -147,19 +148,19 @@ func run(cmd *cobra.Command, argv []string) {
 		mode, err = interactive.GetOptionMode(cmd, mode, "OCM role deletion mode")
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid OCM role deletion mode: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 
 	roleName, err := aws.GetResourceIdFromARN(roleARN)
 	if err != nil {
 		r.Reporter.Errorf("%s", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	if !aws.IsOCMRole(&roleName) {
 		r.Reporter.Errorf("Role '%s' is not an OCM role", roleName)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	roleExistOnAWS, existingRoleARN, err := r.AWSClient.CheckRoleExists(roleName)
@@ -170,7 +171,7 @@ func run(cmd *cobra.Command, argv []string) {
 		r.Reporter.Warnf("the ARN %s does not exist. Nothing to delete", roleARN)
 	} else if existingRoleARN != roleARN {
 		r.Reporter.Warnf("role with same name but different ARN exists. Existing role ARN: %s", existingRoleARN)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	switch mode {
@@ -186,7 +187,7 @@ func run(cmd *cobra.Command, argv []string) {
 			err := r.AWSClient.DeleteOCMRole(roleName, managedPolicies)
 			if err != nil {
 				r.Reporter.Errorf("There was an error deleting the OCM role: %s", err)
-				os.Exit(1)
+				os.Exit(reporter.ExitCode())
 			}
 			r.Reporter.Infof("Successfully deleted the OCM role")
 		}
@@ -195,7 +196,7 @@ func run(cmd *cobra.Command, argv []string) {
 		commands, err := buildCommands(roleName, roleARN, isLinked, r.AWSClient, roleExistOnAWS, managedPolicies)
 		if err != nil {
 			r.Reporter.Errorf("%s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		if r.Reporter.IsTerminal() {
 			if roleExistOnAWS {
@@ -207,7 +208,7 @@ func run(cmd *cobra.Command, argv []string) {
 		fmt.Println(commands)
 	default:
 		r.Reporter.Errorf("Invalid mode. Allowed values are %s", interactive.Modes)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 }
 
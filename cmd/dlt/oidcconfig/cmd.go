@@ -34,6 +34,7 @@ import (
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/interactive/confirm"
 	interactiveOidc "github.com/openshift/rosa/pkg/interactive/oidc"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -82,14 +83,14 @@ func run(cmd *cobra.Command, _ []string) {
 	mode, err := interactive.GetMode()
 	if err != nil {
 		r.Reporter.Errorf("%s", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	// Get AWS region
 	region, err := aws.GetRegion(arguments.GetRegion())
 	if err != nil {
 		r.Reporter.Errorf("Error getting region: %v", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	args.region = region
 
@@ -102,7 +103,7 @@ func run(cmd *cobra.Command, _ []string) {
 		mode, err = interactive.GetOptionMode(cmd, mode, "OIDC Config deletion mode")
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid OIDC provider creation mode: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 
@@ -114,7 +115,7 @@ func run(cmd *cobra.Command, _ []string) {
 	oidcConfigStrategy, err := getOidcConfigStrategy(mode, oidcConfigInput)
 	if err != nil {
 		r.Reporter.Errorf("%s", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	oidcConfigStrategy.execute(r)
 	arguments.DisableRegionDeprecationWarning = true // disable region deprecation warning
@@ -141,7 +142,7 @@ func buildOidcConfigInput(r *rosa.Runtime) OidcConfigInput {
 	oidcConfig, err := r.OCMClient.GetOidcConfig(args.oidcConfigId)
 	if err != nil {
 		r.Reporter.Errorf("There was a problem retrieving the OIDC Config '%s': %v", args.oidcConfigId, err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	secretArn := oidcConfig.SecretArn()
 	bucketName := ""
@@ -150,12 +151,12 @@ func buildOidcConfigInput(r *rosa.Runtime) OidcConfigInput {
 		if args.region != parsedSecretArn.Region {
 			r.Reporter.Errorf("Secret region '%s' differs from chosen region '%s', "+
 				"please run the command supplying region parameter.", parsedSecretArn.Region, args.region)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		secretResourceName, err := aws.GetResourceIdFromSecretArn(secretArn)
 		if err != nil {
 			r.Reporter.Errorf("There was a problem parsing secret ARN '%s' : %v", secretArn, err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		// The secret when creating from ROSA options has the following format
 		// rosa-private-key-<prefix>-oidc-<random-hash-length-4>-<random-aws-created-hash>
@@ -171,11 +172,11 @@ func buildOidcConfigInput(r *rosa.Runtime) OidcConfigInput {
 	hasClusterUsingOidcConfig, err := r.OCMClient.HasAClusterUsingOidcEndpointUrl(issuerUrl)
 	if err != nil {
 		r.Reporter.Errorf("There was a problem checking if any clusters are using OIDC config '%s' : %v", issuerUrl, err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	if hasClusterUsingOidcConfig {
 		r.Reporter.Errorf("There are clusters using OIDC config '%s', can't delete the configuration", issuerUrl)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	return OidcConfigInput{
 		BucketName:          bucketName,
@@ -207,12 +208,12 @@ func (s *deleteUnmanagedOidcConfigAutoStrategy) execute(r *rosa.Runtime) {
 	err := r.AWSClient.DeleteSecretInSecretsManager(privateKeySecretArn)
 	if err != nil {
 		r.Reporter.Errorf("There was a problem deleting private key from secrets manager: %s", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	err = r.AWSClient.DeleteS3Bucket(bucketName)
 	if err != nil {
 		r.Reporter.Errorf("There was a problem deleting S3 bucket '%s': %s", bucketName, err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	if spin != nil {
 		spin.Stop()
@@ -34,6 +34,7 @@ import (
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/interactive/confirm"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/roles"
 	"github.com/openshift/rosa/pkg/rosa"
 )
@@ -92,7 +93,7 @@ func run(cmd *cobra.Command, _ []string) {
 	mode, err := interactive.GetMode()
 	if err != nil {
 		r.Reporter.Errorf("%s", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	// Determine if interactive mode is needed
@@ -102,14 +103,14 @@ func run(cmd *cobra.Command, _ []string) {
 
 	if !cmd.Flag("cluster").Changed && !cmd.Flag(PrefixFlag).Changed {
 		r.Reporter.Errorf("Either a cluster key or a prefix must be specified.")
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	if interactive.Enabled() {
 		mode, err = interactive.GetOptionMode(cmd, mode, "Operator roles deletion mode")
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid operator role deletion mode: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 
@@ -128,10 +129,10 @@ func run(cmd *cobra.Command, _ []string) {
 			if errors.GetType(err) == errors.Conflict {
 				r.Reporter.Errorf("More than one cluster found with the same name '%s'. Please "+
 					"use cluster ID instead", clusterKey)
-				os.Exit(1)
+				os.Exit(reporter.ExitCode())
 			}
 			r.Reporter.Errorf("Error validating cluster '%s': %v", clusterKey, err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		if sub != nil {
 			clusterKey = sub.ClusterID()
@@ -140,17 +141,17 @@ func run(cmd *cobra.Command, _ []string) {
 		if err != nil {
 			if errors.GetType(err) != errors.NotFound {
 				r.Reporter.Errorf("Error validating cluster '%s': %v", clusterKey, err)
-				os.Exit(1)
+				os.Exit(reporter.ExitCode())
 			} else if sub == nil {
 				r.Reporter.Errorf("Failed to get cluster '%s': %v", r.ClusterKey, err)
-				os.Exit(1)
+				os.Exit(reporter.ExitCode())
 			}
 		}
 
 		if cluster != nil && cluster.ID() != "" {
 			r.Reporter.Errorf("Cluster '%s' is in '%s' state. Operator roles can be deleted only for the "+
 				"uninstalled clusters", cluster.ID(), cluster.State())
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		isHypershift := false
 		if cluster != nil {
@@ -167,7 +168,7 @@ func run(cmd *cobra.Command, _ []string) {
 		credRequests, err := r.OCMClient.GetCredRequests(isHypershift)
 		if err != nil {
 			r.Reporter.Errorf("Error getting operator credential request from OCM %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		foundOperatorRoles, _ = r.AWSClient.GetOperatorRolesFromAccountByClusterID(sub.ClusterID(), credRequests)
 	} else {
@@ -180,24 +181,24 @@ func run(cmd *cobra.Command, _ []string) {
 		if err != nil {
 			r.Reporter.Errorf("There was a problem checking if any clusters"+
 				" are using Operator Roles Prefix '%s' : %v", args.prefix, err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		if hasClusterUsingOperatorRolesPrefix {
 			if spin != nil {
 				spin.Stop()
 			}
 			r.Reporter.Errorf("There are clusters using Operator Roles Prefix '%s', can't delete the IAM roles", args.prefix)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		credRequests, err := r.OCMClient.GetAllCredRequests()
 		if err != nil {
 			r.Reporter.Errorf("Error getting operator credential request from OCM %v", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		foundOperatorRoles, err = r.AWSClient.GetOperatorRolesFromAccountByPrefix(args.prefix, credRequests)
 		if err != nil {
 			r.Reporter.Errorf("There was a problem retrieving the Operator Roles from AWS: %v", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 
@@ -221,12 +222,12 @@ func run(cmd *cobra.Command, _ []string) {
 	_, roleARN, err := r.AWSClient.CheckRoleExists(foundOperatorRoles[0])
 	if err != nil {
 		r.Reporter.Errorf("Failed to get '%s' role ARN", foundOperatorRoles[0])
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	managedPolicies, err := r.AWSClient.HasManagedPolicies(roleARN)
 	if err != nil {
 		r.Reporter.Errorf("Failed to determine if cluster has managed policies: %v", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	errOccured := false
@@ -281,7 +282,7 @@ func run(cmd *cobra.Command, _ []string) {
 		policyMap, arbitraryPolicyMap, err := r.AWSClient.GetOperatorRolePolicies(foundOperatorRoles)
 		if err != nil {
 			r.Reporter.Errorf("There was an error getting the policy: %v", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 
 		// Get HCP shared vpc policy details if the user is deleting roles related to HCP shared vpc
@@ -304,7 +305,7 @@ func run(cmd *cobra.Command, _ []string) {
 		fmt.Println(commands)
 	default:
 		r.Reporter.Errorf("Invalid mode. Allowed values are %s", interactive.Modes)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 }
 
@@ -29,6 +29,7 @@ import (
 	"github.com/openshift/rosa/pkg/helper"
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/interactive/confirm"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -69,7 +70,7 @@ func run(cmd *cobra.Command, argv []string) {
 	mode, err := interactive.GetMode()
 	if err != nil {
 		r.Reporter.Errorf("%s", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	if len(argv) > 0 {
@@ -103,20 +104,20 @@ func run(cmd *cobra.Command, argv []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid user role ARN to delete from the current AWS account: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 
 	err = aws.ARNValidator(roleARN)
 	if err != nil {
 		r.Reporter.Errorf("Expected a valid user role ARN to delete from the current AWS account: %s", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	err = r.AWSClient.ValidateRoleARNAccountIDMatchCallerAccountID(roleARN)
 	if err != nil {
 		r.Reporter.Errorf("%s", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	if !confirm.Prompt(true, "Delete the '%s' role from the AWS account?", roleARN) {
@@ -126,13 +127,13 @@ func run(cmd *cobra.Command, argv []string) {
 	currentAccount, err := r.OCMClient.GetCurrentAccount()
 	if err != nil {
 		r.Reporter.Errorf("Error getting current account: %v", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	linkedRoles, err := r.OCMClient.GetAccountLinkedUserRoles(currentAccount.ID())
 	if err != nil {
 		r.Reporter.Errorf("An error occurred while trying to get the account linked roles")
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	isLinked := helper.Contains(linkedRoles, roleARN)
 
@@ -140,14 +141,14 @@ func run(cmd *cobra.Command, argv []string) {
 		mode, err = interactive.GetOptionMode(cmd, mode, "User role deletion mode")
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid role deletion mode: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 
 	roleName, err := aws.GetResourceIdFromARN(roleARN)
 	if err != nil {
 		r.Reporter.Errorf("%s", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	roleExistOnAWS, existingRoleARN, err := r.AWSClient.CheckRoleExists(roleName)
@@ -158,17 +159,17 @@ func run(cmd *cobra.Command, argv []string) {
 		r.Reporter.Warnf("the ARN %s does not exist. Nothing to delete", roleARN)
 	} else if existingRoleARN != roleARN {
 		r.Reporter.Warnf("role with same name but different ARN exists. Existing role ARN: %s", existingRoleARN)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	isUserRole, err := r.AWSClient.IsUserRole(&roleName)
 	if err != nil {
 		r.Reporter.Errorf("%s", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	if !isUserRole {
 		r.Reporter.Errorf("Role '%s' is not a user role", roleName)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	switch mode {
@@ -184,7 +185,7 @@ func run(cmd *cobra.Command, argv []string) {
 		err := r.AWSClient.DeleteUserRole(roleName)
 		if err != nil {
 			r.Reporter.Errorf("There was an error deleting the user role: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		r.Reporter.Infof("Successfully deleted the user role")
 	case interactive.ModeManual:
@@ -192,7 +193,7 @@ func run(cmd *cobra.Command, argv []string) {
 		commands, err := buildCommands(roleName, roleARN, isLinked, r.AWSClient)
 		if err != nil {
 			r.Reporter.Errorf("%s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		if r.Reporter.IsTerminal() {
 			r.Reporter.Infof("Run the following commands to delete the user role:\n")
@@ -200,7 +201,7 @@ func run(cmd *cobra.Command, argv []string) {
 		fmt.Println(commands)
 	default:
 		r.Reporter.Errorf("Invalid mode. Allowed values are %s", interactive.Modes)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 }
 
@@ -24,6 +24,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/openshift/rosa/cmd/verify/oc"
+	"github.com/openshift/rosa/pkg/config"
 	helper "github.com/openshift/rosa/pkg/helper/download"
 	rprtr "github.com/openshift/rosa/pkg/reporter"
 )
@@ -53,10 +54,15 @@ func run(cmd *cobra.Command, argv []string) {
 
 	reporter.Infof("Downloading %s", downloadURL)
 
-	err := helper.Download(downloadURL, filename)
+	cfg, err := config.Load()
+	if err != nil {
+		reporter.Errorf("Failed to load config file: %v", err)
+		os.Exit(rprtr.ExitCode())
+	}
+	err = helper.Download(cfg, downloadURL, filename)
 	if err != nil {
 		reporter.Errorf("%s", err)
-		os.Exit(1)
+		os.Exit(rprtr.ExitCode())
 	}
 
 	reporter.Infof("Successfully downloaded %s", filename)
@@ -23,6 +23,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/openshift/rosa/pkg/config"
 	helper "github.com/openshift/rosa/pkg/helper/download"
 	rprtr "github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/version"
@@ -51,10 +52,15 @@ func run(_ *cobra.Command, _ []string) {
 
 	reporter.Infof("Downloading %s to your current directory", downloadURL)
 
-	err := helper.Download(downloadURL, filename)
+	cfg, err := config.Load()
+	if err != nil {
+		reporter.Errorf("Failed to load config file: %v", err)
+		os.Exit(rprtr.ExitCode())
+	}
+	err = helper.Download(cfg, downloadURL, filename)
 	if err != nil {
 		reporter.Errorf("%s", err)
-		os.Exit(1)
+		os.Exit(rprtr.ExitCode())
 	}
 
 	reporter.Infof("Successfully downloaded %s", filename)
@@ -26,6 +26,7 @@ import (
 	"github.com/openshift/rosa/pkg/clusterautoscaler"
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/preview"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -65,6 +66,7 @@ func NewEditAutoscalerCommand() *cobra.Command {
 
 	ocm.AddClusterFlag(cmd)
 	interactive.AddFlag(flags)
+	preview.AddFlag(flags)
 	autoscalerArgs := clusterautoscaler.AddClusterAutoscalerFlags(cmd, argsPrefix)
 	cmd.Run = rosa.DefaultRunner(rosa.RuntimeWithOCM(), EditAutoscalerRunner(autoscalerArgs))
 	return cmd
@@ -129,6 +131,21 @@ func EditAutoscalerRunner(autoscalerArgs *clusterautoscaler.AutoscalerArgs) rosa
 				cluster.ID(), err)
 		}
 
+		update, err := ocm.BuildClusterAutoscaler(autoscalerConfig).Build()
+		if err != nil {
+			return fmt.Errorf("Failed updating autoscaler configuration for cluster '%s': %s",
+				cluster.ID(), err)
+		}
+		changes, err := preview.Compare(fmt.Sprintf("autoscaler of cluster '%s'", clusterKey),
+			autoscaler, update, cmv1.MarshalClusterAutoscaler)
+		if err != nil {
+			return fmt.Errorf("Failed to compare autoscaler configuration for cluster '%s': %s",
+				cluster.ID(), err)
+		}
+		if !changes.Review(r) {
+			return nil
+		}
+
 		_, err = r.OCMClient.UpdateClusterAutoscaler(cluster.ID(), autoscalerConfig)
 		if err != nil {
 			return fmt.Errorf("Failed updating autoscaler configuration for cluster '%s': %s",
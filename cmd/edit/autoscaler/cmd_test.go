@@ -10,9 +10,12 @@ import (
 	. "github.com/onsi/gomega/ghttp"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	. "github.com/openshift-online/ocm-sdk-go/testing"
+	"github.com/spf13/cobra"
 
 	"github.com/openshift/rosa/pkg/clusterautoscaler"
 	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/preview"
+	"github.com/openshift/rosa/pkg/rosa"
 	"github.com/openshift/rosa/pkg/test"
 	. "github.com/openshift/rosa/pkg/test"
 )
@@ -186,5 +189,38 @@ var _ = Describe("edit autoscaler", func() {
 			err := runner(context.Background(), t.RosaRuntime, cmd, nil)
 			Expect(err).NotTo(HaveOccurred())
 		})
+
+		It("Shows the changes without updating the autoscaler in dry run mode", func() {
+			defer preview.SetDryRun(false)
+			cluster := MockCluster(func(c *cmv1.ClusterBuilder) {
+				c.State(cmv1.ClusterStateReady)
+			})
+			autoscaler := test.MockAutoscaler(func(a *cmv1.ClusterAutoscalerBuilder) {
+				a.LogVerbosity(2)
+				a.ScaleDown(cmv1.NewAutoscalerScaleDownConfig().UtilizationThreshold("0.5"))
+				a.ResourceLimits(cmv1.NewAutoscalerResourceLimits().MaxNodesTotal(10))
+			})
+
+			// Only the GET is routed, so the test fails if the autoscaler is updated
+			t.ApiServer.AppendHandlers(
+				RespondWithJSON(
+					http.StatusOK, FormatClusterList([]*cmv1.Cluster{cluster})))
+			t.ApiServer.RouteToHandler(http.MethodGet,
+				fmt.Sprintf("/api/clusters_mgmt/v1/clusters/%s/autoscaler", cluster.ID()),
+				RespondWithJSON(http.StatusOK, FormatResource(autoscaler)))
+			args := &clusterautoscaler.AutoscalerArgs{}
+			args.LogVerbosity = 1
+			runner := EditAutoscalerRunner(args)
+			cmd := NewEditAutoscalerCommand()
+			cmd.Flags().Set("log-verbosity", "1")
+			cmd.Flags().Set("dry-run", "true")
+			t.SetCluster("cluster", nil)
+			stdout, _, err := test.RunWithOutputCapture(func(r *rosa.Runtime, cmd *cobra.Command) error {
+				return runner(context.Background(), r, cmd, nil)
+			}, t.RosaRuntime, cmd)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stdout).To(ContainSubstring("~ update autoscaler of cluster 'cluster'\n"))
+			Expect(stdout).To(ContainSubstring("    log_verbosity: 2 => 1\n"))
+		})
 	})
 })
@@ -34,10 +34,18 @@ import (
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/interactive/confirm"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/preview"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
-const enableDeleteProtectionFlagName = "enable-delete-protection"
+const (
+	enableDeleteProtectionFlagName = "enable-delete-protection"
+
+	// Reasons displayed next to the changes that disrupt the nodes of the cluster:
+	registryChangeReason   = "all machinepool nodes will be recreated"
+	networkMigrationReason = "cluster nodes will reboot"
+)
 
 var args struct {
 	// Basic options
@@ -207,6 +215,8 @@ func init() {
 			"followed by a CIDR. \nExample: '--ovn-internal-subnets=\"join=192.168.255.0/24,transit=192.168.255.0/24,"+
 			"masquerade=192.168.255.0/24\"'",
 	)
+
+	preview.AddFlag(flags)
 }
 
 func run(cmd *cobra.Command, _ []string) {
@@ -240,20 +250,20 @@ func run(cmd *cobra.Command, _ []string) {
 	// Validate flags:
 	expiration, err := validateExpiration()
 	if err != nil {
-		r.Reporter.Errorf(fmt.Sprintf("%s", err))
-		os.Exit(1)
+		r.Reporter.Errorf("%s", err)
+		os.Exit(reporter.ExitCode())
 	}
 
 	ovnInternalSubnets, err := validateOvnInternalSubnetConfiguration()
 	if err != nil {
-		r.Reporter.Errorf(fmt.Sprintf("%s", err))
-		os.Exit(1)
+		r.Reporter.Errorf("%s", err)
+		os.Exit(reporter.ExitCode())
 	}
 
 	networkType, err := validateNetworkType()
 	if err != nil {
-		r.Reporter.Errorf(fmt.Sprintf("%s", err))
-		os.Exit(1)
+		r.Reporter.Errorf("%s", err)
+		os.Exit(reporter.ExitCode())
 	}
 
 	if interactive.Enabled() {
@@ -304,7 +314,7 @@ func run(cmd *cobra.Command, _ []string) {
 			len(noProxySlice) > 0 ||
 			(additionalTrustBundleFile != nil && *additionalTrustBundleFile != "")) {
 		r.Reporter.Errorf("Cluster-wide proxy is not supported on clusters using the default VPC")
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	var additionalAllowedPrincipals []string
@@ -327,7 +337,7 @@ func run(cmd *cobra.Command, _ []string) {
 	privateWarning, err = warnUserForOAuthHCPVisibility(r, clusterKey, cluster, privateWarning)
 	if err != nil {
 		r.Reporter.Errorf("%v", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	if interactive.Enabled() {
 		privateValue, err = interactive.GetBool(interactive.Input{
@@ -337,12 +347,12 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid private value: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		private = &privateValue
 	} else if privateValue {
 		r.Reporter.Warnf("You are choosing to make your cluster API private. %s", privateWarning)
-		if !confirm.Confirm("set cluster '%s' as private", clusterKey) {
+		if !preview.DryRun() && !confirm.Confirm("set cluster '%s' as private", clusterKey) {
 			os.Exit(0)
 		}
 	}
@@ -365,11 +375,11 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid disable-workload-monitoring value: %v", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		disableWorkloadMonitoring = &disableWorkloadMonitoringValue
 	} else if disableWorkloadMonitoringValue {
-		if !confirm.Confirm("disable workload monitoring for your cluster %s", clusterKey) {
+		if !preview.DryRun() && !confirm.Confirm("disable workload monitoring for your cluster %s", clusterKey) {
 			os.Exit(0)
 		}
 	}
@@ -386,7 +396,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid proxy-enabled value: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		enableProxy = enableProxyValue
 	}
@@ -420,7 +430,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid http proxy: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 
 		if def != httpProxyValue && httpProxyValue == input.DoubleQuotesToRemove {
@@ -435,7 +445,7 @@ func run(cmd *cobra.Command, _ []string) {
 		err = ocm.ValidateHTTPProxy(*httpProxy)
 		if err != nil {
 			r.Reporter.Errorf("%s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 
@@ -459,7 +469,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid https proxy: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		if def != httpsProxyValue && httpsProxyValue == input.DoubleQuotesToRemove {
 			//user entered double quotes ("") to remove the existing value
@@ -473,7 +483,7 @@ func run(cmd *cobra.Command, _ []string) {
 		err = interactive.IsURL(*httpsProxy)
 		if err != nil {
 			r.Reporter.Errorf("%s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 
@@ -490,13 +500,13 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid set of no proxy domains/CIDR's: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		noProxySlice = helper.HandleEmptyStringOnSlice(strings.Split(noProxyInput, ","))
 	}
 	if isExpectedHTTPProxyOrHTTPSProxy(httpProxy, httpsProxy, noProxySlice, cluster) {
 		r.Reporter.Errorf("Expected at least one of the following: http-proxy, https-proxy")
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	if len(noProxySlice) > 0 {
@@ -507,13 +517,13 @@ func run(cmd *cobra.Command, _ []string) {
 		duplicate, found := aws.HasDuplicates(noProxySlice)
 		if found {
 			r.Reporter.Errorf("Invalid no-proxy list, duplicate key '%s' found", duplicate)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		for _, domain := range noProxySlice {
 			err := aws.UserNoProxyValidator(domain)
 			if err != nil {
 				r.Reporter.Errorf("%s", err)
-				os.Exit(1)
+				os.Exit(reporter.ExitCode())
 			}
 		}
 	}
@@ -531,7 +541,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid -update-additional-trust-bundle value: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		updateAdditionalTrustBundle = updateAdditionalTrustBundleValue
 	}
@@ -554,7 +564,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid additional trust bundle file name: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 
 		if len(additionalTrustBundleFileValue) == 0 {
@@ -572,7 +582,7 @@ func run(cmd *cobra.Command, _ []string) {
 		err = ocm.ValidateAdditionalTrustBundle(*additionalTrustBundleFile)
 		if err != nil {
 			r.Reporter.Errorf("%s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 
@@ -589,7 +599,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid update-additional-allowed-principals value: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		updateAdditionalAllowedPrincipals = updateAdditionalAllowedPrincipalsValue
 	}
@@ -601,7 +611,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid value for Additional Allowed Principal ARNs: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		additionalAllowedPrincipals = helper.HandleEmptyStringOnSlice(strings.Split(aapInputs, ","))
 	}
@@ -611,8 +621,8 @@ func run(cmd *cobra.Command, _ []string) {
 			additionalAllowedPrincipals = []string{}
 		} else {
 			if err := roles.ValidateAdditionalAllowedPrincipals(additionalAllowedPrincipals); err != nil {
-				r.Reporter.Errorf(err.Error())
-				os.Exit(1)
+				r.Reporter.Errorf("%v", err)
+				os.Exit(reporter.ExitCode())
 			}
 		}
 	}
@@ -621,13 +631,13 @@ func run(cmd *cobra.Command, _ []string) {
 	auditLogRole, err := setAuditLogForwarding(r, cmd, cluster, args.auditLogRoleARN)
 	if err != nil {
 		r.Reporter.Errorf("%s", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	if interactive.Enabled() && aws.IsHostedCP(cluster) {
 		auditLogRole, err = auditLogInteractivePrompt(r, cmd, cluster)
 		if err != nil {
 			r.Reporter.Errorf("%s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 
@@ -659,7 +669,7 @@ func run(cmd *cobra.Command, _ []string) {
 				cert, err := os.ReadFile(*additionalTrustBundleFile)
 				if err != nil {
 					r.Reporter.Errorf("Failed to read additional trust bundle file: %s", err)
-					os.Exit(1)
+					os.Exit(reporter.ExitCode())
 				}
 				*clusterConfig.AdditionalTrustBundle = string(cert)
 			}
@@ -674,7 +684,7 @@ func run(cmd *cobra.Command, _ []string) {
 		cmd.Flags(), clusterRegistryConfigArgs, aws.IsHostedCP(cluster), cluster)
 	if err != nil {
 		r.Reporter.Errorf("%s", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	if clusterRegistryConfigArgs != nil {
 		allowedRegistries, blockedRegistries, insecureRegistries,
@@ -685,14 +695,14 @@ func run(cmd *cobra.Command, _ []string) {
 		// prompt for a warning if any registry config field is set
 		if allowedRegistries != nil || blockedRegistries != nil || insecureRegistries != nil ||
 			additionalTrustedCa != "" || allowedRegistriesForImport != "" || platformAllowlist != "" {
-			if PromptUserToAcceptRegistryChange(r) {
+			if preview.DryRun() || PromptUserToAcceptRegistryChange(r) {
 				clusterConfig, err = BuildClusterConfigWithRegistry(clusterConfig, allowedRegistries,
 					blockedRegistries, insecureRegistries,
 					additionalTrustedCa, allowedRegistriesForImport, platformAllowlist)
 			}
 			if err != nil {
 				r.Reporter.Errorf("%s", err)
-				os.Exit(1)
+				os.Exit(reporter.ExitCode())
 			}
 		}
 	}
@@ -717,21 +727,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid value: %v", err)
-			os.Exit(1)
-		}
-	}
-
-	if cluster.DeleteProtection().Enabled() != deleteProtection {
-		r.Reporter.Debugf("Updating cluster deletion protection to : %t", deleteProtection)
-		newDeleteProtection, err := cmv1.NewDeleteProtection().Enabled(deleteProtection).Build()
-		if err != nil {
-			r.Reporter.Errorf("Failed to build delete protection: %v", err)
-			os.Exit(1)
-		}
-
-		if err := r.OCMClient.UpdateClusterDeletionProtection(cluster.ID(), newDeleteProtection); err != nil {
-			r.Reporter.Errorf("Failed to update cluster delete protection: %v", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 
@@ -752,7 +748,7 @@ func run(cmd *cobra.Command, _ []string) {
 
 		if err != nil {
 			r.Reporter.Errorf("%s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 
 		if migrateNetworkType && interactive.Enabled() {
@@ -763,7 +759,7 @@ func run(cmd *cobra.Command, _ []string) {
 			})
 			if err != nil {
 				r.Reporter.Errorf("Expected a valid value: %v", err)
-				os.Exit(1)
+				os.Exit(reporter.ExitCode())
 			}
 
 			ovnSubnets, _ := interactive.GetString(interactive.Input{
@@ -782,12 +778,15 @@ func run(cmd *cobra.Command, _ []string) {
 	}
 
 	if cmd.Flags().Changed(ocm.NetworkTypeFlagName) && networkType == ocm.NetworkTypeOvn {
-
-		migrateNetworkType, err = confirmMigration()
+		if preview.DryRun() {
+			migrateNetworkType = true
+		} else {
+			migrateNetworkType, err = confirmMigration()
+		}
 
 		if err != nil {
 			r.Reporter.Errorf("%s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 
@@ -804,12 +803,12 @@ func run(cmd *cobra.Command, _ []string) {
 
 		if billingAccount != "" && !aws.IsHostedCP(cluster) {
 			r.Reporter.Errorf("Billing accounts are only supported for Hosted Control Plane clusters")
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		if billingAccount != "" && !ocm.IsValidAWSAccount(billingAccount) {
 			r.Reporter.Errorf("Provided billing account number %s is not valid. "+
 				"Rerun the command with a valid billing account number", billingAccount)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	} else {
 		billingAccount = cluster.AWS().BillingAccountID()
@@ -819,7 +818,7 @@ func run(cmd *cobra.Command, _ []string) {
 		cloudAccounts, err := r.OCMClient.GetBillingAccounts()
 		if err != nil {
 			r.Reporter.Errorf("%s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 
 		billingAccounts := ocm.GenerateBillingAccountsList(cloudAccounts)
@@ -835,7 +834,7 @@ func run(cmd *cobra.Command, _ []string) {
 
 			if err != nil {
 				r.Reporter.Errorf("Expected a valid billing account: '%s'", err)
-				os.Exit(1)
+				os.Exit(reporter.ExitCode())
 			}
 
 			billingAccount = aws.ParseOption(billingAccount)
@@ -844,7 +843,7 @@ func run(cmd *cobra.Command, _ []string) {
 		err = ocm.ValidateBillingAccount(billingAccount)
 		if err != nil {
 			r.Reporter.Errorf("%v", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 
 		// Get contract info
@@ -870,11 +869,45 @@ func run(cmd *cobra.Command, _ []string) {
 		clusterConfig.BillingAccount = billingAccount
 	}
 
+	update, err := r.OCMClient.BuildClusterUpdate(clusterConfig)
+	if err != nil {
+		r.Reporter.Errorf("Failed to update cluster: %v", err)
+		os.Exit(reporter.ExitCode())
+	}
+	changes, err := preview.Compare(fmt.Sprintf("cluster '%s'", clusterKey), cluster, update, cmv1.MarshalCluster)
+	if err != nil {
+		r.Reporter.Errorf("Failed to compare cluster '%s': %v", clusterKey, err)
+		os.Exit(reporter.ExitCode())
+	}
+	changes.Add("delete_protection.enabled", cluster.DeleteProtection().Enabled(), deleteProtection)
+	if clusterConfig.NetworkType != "" {
+		changes.Add("network.type", cluster.Network().Type(), clusterConfig.NetworkType)
+	}
+	changes.Disrupts("registry_config", registryChangeReason)
+	changes.Disrupts("network.type", networkMigrationReason)
+	if !changes.Review(r) {
+		os.Exit(0)
+	}
+
+	if cluster.DeleteProtection().Enabled() != deleteProtection {
+		r.Reporter.Debugf("Updating cluster deletion protection to : %t", deleteProtection)
+		newDeleteProtection, err := cmv1.NewDeleteProtection().Enabled(deleteProtection).Build()
+		if err != nil {
+			r.Reporter.Errorf("Failed to build delete protection: %v", err)
+			os.Exit(reporter.ExitCode())
+		}
+
+		if err := r.OCMClient.UpdateClusterDeletionProtection(cluster.ID(), newDeleteProtection); err != nil {
+			r.Reporter.Errorf("Failed to update cluster delete protection: %v", err)
+			os.Exit(reporter.ExitCode())
+		}
+	}
+
 	r.Reporter.Debugf("Updating cluster '%s'", clusterKey)
 	err = r.OCMClient.UpdateCluster(cluster.ID(), r.Creator, clusterConfig)
 	if err != nil {
 		r.Reporter.Errorf("Failed to update cluster: %v", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	r.Reporter.Infof("Updated cluster '%s'", clusterKey)
 }
@@ -1009,13 +1042,14 @@ func confirmAuditLogForwarding(r *rosa.Runtime, auditLogArn *string) {
 
 	if *auditLogArn != "" {
 		r.Reporter.Warnf("You are choosing to enable audit log forwarding")
-		if !confirm.Confirm("enable audit log forwarding for cluster with the provided role arn '%s'", *auditLogArn) {
+		if !preview.DryRun() &&
+			!confirm.Confirm("enable audit log forwarding for cluster with the provided role arn '%s'", *auditLogArn) {
 			os.Exit(0)
 		}
 		return
 	}
 	r.Reporter.Warnf("You are choosing to disable audit log forwarding.")
-	if !confirm.Confirm("disable audit log forwarding for cluster") {
+	if !preview.DryRun() && !confirm.Confirm("disable audit log forwarding for cluster") {
 		os.Exit(0)
 	}
 }
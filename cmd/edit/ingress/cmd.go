@@ -33,6 +33,8 @@ import (
 	"github.com/openshift/rosa/pkg/interactive/confirm"
 	"github.com/openshift/rosa/pkg/interactive/consts"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/preview"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -131,6 +133,7 @@ func init() {
 	)
 
 	addIngressV2Flags(flags)
+	preview.AddFlag(flags)
 
 	Cmd.RegisterFlagCompletionFunc(lbTypeFlag, lbTypeCompletion)
 	Cmd.RegisterFlagCompletionFunc(wildcardPolicyFlag, wildcardPoliciesTypeCompletion)
@@ -162,7 +165,7 @@ func run(cmd *cobra.Command, argv []string) {
 			"Ingress identifier '%s' isn't valid: it must contain between three and five lowercase letters or digits",
 			ingressKey,
 		)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	clusterKey := r.GetClusterKey()
@@ -181,7 +184,7 @@ func run(cmd *cobra.Command, argv []string) {
 		hasLegacyIngressSupport, err = r.OCMClient.HasLegacyIngressSupport(cluster)
 		if err != nil {
 			r.Reporter.Errorf("There was a problem checking version compatibility: %v", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 
@@ -191,12 +194,12 @@ func run(cmd *cobra.Command, argv []string) {
 				"New ingress attributes %s can't be supplied for Hosted Control Plane clusters",
 				utils.SliceToSortedString(exclusivelyIngressV2Flags),
 			)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		} else if hasLegacyIngressSupport {
 			r.Reporter.Errorf("New ingress attributes %s can't be supplied for legacy supported clusters."+
 				" For more information on how to be supported please check: %s",
 				utils.SliceToSortedString(exclusivelyIngressV2Flags), ingressV2DocLink)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 
@@ -204,7 +207,7 @@ func run(cmd *cobra.Command, argv []string) {
 		r.Reporter.Errorf(
 			"Classic cluster '%s' is PrivateLink on legacy ingress support and does not allow updating ingresses",
 			clusterKey)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	var private *bool
@@ -218,7 +221,7 @@ func run(cmd *cobra.Command, argv []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid private value: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		private = &privArg
 	}
@@ -228,10 +231,22 @@ func run(cmd *cobra.Command, argv []string) {
 			Private: private,
 		}
 
+		changes := preview.New(fmt.Sprintf("ingress '%s' on cluster '%s'", ingressKey, clusterKey))
+		if private != nil {
+			listening := cmv1.ListeningMethodExternal
+			if *private {
+				listening = cmv1.ListeningMethodInternal
+			}
+			changes.Add("api.listening", string(cluster.API().Listening()), string(listening))
+		}
+		if !changes.Review(r) {
+			os.Exit(0)
+		}
+
 		err := r.OCMClient.UpdateCluster(clusterKey, r.Creator, clusterConfig)
 		if err != nil {
 			r.Reporter.Errorf("Failed to update cluster API on cluster '%s': %v", clusterKey, err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		r.Reporter.Infof("Updated ingress '%s' on cluster '%s'", ingressKey, clusterKey)
 		os.Exit(0)
@@ -240,18 +255,18 @@ func run(cmd *cobra.Command, argv []string) {
 	ingress, err := r.OCMClient.GetIngress(cluster.ID(), ingressKey)
 	if err != nil {
 		r.Reporter.Errorf("Failed to fetch ingress: %v", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	var routeSelector *string
 	if cmd.Flags().Changed(routeSelectorFlag) || cmd.Flags().Changed(labelMatchFlag) {
 		if ocm.IsHyperShiftCluster(cluster) {
 			r.Reporter.Errorf("Updating route selectors is not supported for Hosted Control Plane clusters")
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		if ingress.Default() && hasLegacyIngressSupport {
 			r.Reporter.Errorf("Updating route selectors for default ingress is not allowed for legacy ingress support")
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		routeSelector = &args.routeSelector
 	} else if interactive.Enabled() && !ocm.IsHyperShiftCluster(cluster) &&
@@ -269,7 +284,7 @@ func run(cmd *cobra.Command, argv []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid comma-separated list of attributes: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		routeSelector = &routeSelectorArg
 	}
@@ -278,11 +293,11 @@ func run(cmd *cobra.Command, argv []string) {
 	if cmd.Flags().Changed(lbTypeFlag) {
 		if ocm.IsHyperShiftCluster(cluster) {
 			r.Reporter.Errorf("Updating Load Balancer Type is not supported for Hosted Control Plane clusters")
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		if ocm.IsSts(cluster) && hasLegacyIngressSupport {
 			r.Reporter.Errorf("Updating Load Balancer Type is not supported for STS clusters on legacy ingress support")
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		lbType = &args.lbType
 	} else if interactive.Enabled() && (!ocm.IsHyperShiftCluster(cluster) &&
@@ -299,7 +314,7 @@ func run(cmd *cobra.Command, argv []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid Load Balancer type: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		lbType = &lbTypeArg
 	}
@@ -313,7 +328,7 @@ func run(cmd *cobra.Command, argv []string) {
 		if cmd.Flags().Changed(excludedNamespacesFlag) {
 			if ocm.IsHyperShiftCluster(cluster) {
 				r.Reporter.Errorf("Updating excluded namespace is not supported for Hosted Control Plane clusters")
-				os.Exit(1)
+				os.Exit(reporter.ExitCode())
 			}
 			excludedNamespaces = &args.excludedNamespaces
 		} else if isInteractiveEnabledAndNotHcp {
@@ -324,14 +339,14 @@ func run(cmd *cobra.Command, argv []string) {
 			})
 			if err != nil {
 				r.Reporter.Errorf("Expected a valid comma-separated list of attributes: %s", err)
-				os.Exit(1)
+				os.Exit(reporter.ExitCode())
 			}
 			excludedNamespaces = &excludedNamespacesArg
 		}
 		if cmd.Flags().Changed(wildcardPolicyFlag) {
 			if ocm.IsHyperShiftCluster(cluster) {
 				r.Reporter.Errorf("Updating Wildcard Policy is not supported for Hosted Control Plane clusters")
-				os.Exit(1)
+				os.Exit(reporter.ExitCode())
 			}
 			wildcardPolicy = &args.wildcardPolicy
 		} else if isInteractiveEnabledAndNotHcp {
@@ -343,7 +358,7 @@ func run(cmd *cobra.Command, argv []string) {
 			})
 			if err != nil {
 				r.Reporter.Errorf("Expected a valid Wildcard Policy: %s", err)
-				os.Exit(1)
+				os.Exit(reporter.ExitCode())
 			}
 			wildcardPolicy = &wildcardPolicyArg
 		}
@@ -352,7 +367,7 @@ func run(cmd *cobra.Command, argv []string) {
 				r.Reporter.Errorf(
 					"Updating Namespace Ownership Policy is not supported for Hosted Control Plane clusters",
 				)
-				os.Exit(1)
+				os.Exit(reporter.ExitCode())
 			}
 			namespaceOwnershipPolicy = &args.namespaceOwnershipPolicy
 		} else if isInteractiveEnabledAndNotHcp {
@@ -364,7 +379,7 @@ func run(cmd *cobra.Command, argv []string) {
 			})
 			if err != nil {
 				r.Reporter.Errorf("Expected a valid Namespace Ownership Policy: %s", err)
-				os.Exit(1)
+				os.Exit(reporter.ExitCode())
 			}
 			namespaceOwnershipPolicy = &namespaceOwnershipPolicyArg
 		}
@@ -374,12 +389,12 @@ func run(cmd *cobra.Command, argv []string) {
 				r.Reporter.Errorf(
 					"Updating Cluster Component Routes is not supported for Hosted Control Plane clusters",
 				)
-				os.Exit(1)
+				os.Exit(reporter.ExitCode())
 			}
 			componentRoutes, err = parseComponentRoutes(args.componentRoutes)
 			if err != nil {
 				r.Reporter.Errorf("An error occurred whilst parsing the supplied component routes: %s", err)
-				os.Exit(1)
+				os.Exit(reporter.ExitCode())
 			}
 		} else if isInteractiveEnabledAndNotHcp {
 			componentRoutes = map[string]*cmv1.ComponentRouteBuilder{}
@@ -400,7 +415,7 @@ func run(cmd *cobra.Command, argv []string) {
 						})
 						if err != nil {
 							r.Reporter.Errorf("Expected a valid component route '%s': %s", parameterName, err)
-							os.Exit(1)
+							os.Exit(reporter.ExitCode())
 						}
 						// TODO: use reflection, couldn't get it to work
 						if parameterName == hostnameParameter {
@@ -415,6 +430,7 @@ func run(cmd *cobra.Command, argv []string) {
 		}
 	}
 
+	current := ingress
 	curListening := ingress.Listening()
 	curRouteSelectors := ingress.RouteSelectors()
 	curLbType := ingress.LoadBalancerType()
@@ -439,7 +455,7 @@ func run(cmd *cobra.Command, argv []string) {
 			routeSelectors, err = helper.GetRouteSelector(*routeSelector)
 			if err != nil {
 				r.Reporter.Errorf("%s", err)
-				os.Exit(1)
+				os.Exit(reporter.ExitCode())
 			}
 		}
 		ingressBuilder = ingressBuilder.RouteSelectors(routeSelectors)
@@ -472,7 +488,7 @@ func run(cmd *cobra.Command, argv []string) {
 	ingress, err = ingressBuilder.Build()
 	if err != nil {
 		r.Reporter.Errorf("Failed to create ingress for cluster '%s': %v", clusterKey, err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	sameRouteSelectors := routeSelector == nil || reflect.DeepEqual(curRouteSelectors, ingress.RouteSelectors())
@@ -499,12 +515,22 @@ func run(cmd *cobra.Command, argv []string) {
 		os.Exit(0)
 	}
 
+	changes, err := preview.Compare(fmt.Sprintf("ingress '%s' on cluster '%s'", ingressKey, clusterKey),
+		current, ingress, cmv1.MarshalIngress)
+	if err != nil {
+		r.Reporter.Errorf("Failed to compare ingress '%s' on cluster '%s': %s", ingressKey, clusterKey, err)
+		os.Exit(reporter.ExitCode())
+	}
+	if !changes.Review(r) {
+		os.Exit(0)
+	}
+
 	r.Reporter.Debugf("Updating ingress '%s' on cluster '%s'", ingress.ID(), clusterKey)
 	_, err = r.OCMClient.UpdateIngress(cluster.ID(), ingress)
 	if err != nil {
 		r.Reporter.Errorf("Failed to update ingress '%s' on cluster '%s': %s",
 			ingress.ID(), clusterKey, err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	r.Reporter.Infof("Updated ingress '%s' on cluster '%s'", ingress.ID(), clusterKey)
 }
@@ -9,8 +9,11 @@ import (
 	"github.com/onsi/gomega/format"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	. "github.com/openshift-online/ocm-sdk-go/testing"
+	"github.com/spf13/cobra"
 
 	. "github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/preview"
+	"github.com/openshift/rosa/pkg/rosa"
 	"github.com/openshift/rosa/pkg/test"
 )
 
@@ -149,6 +152,39 @@ var _ = Describe("Edit Machinepool", func() {
 					"2", "--enable-autoscaling", "true", "--interactive", "false", "--max-replicas",
 					"10"})).To(Succeed())
 			})
+			It("Shows the recreation of the nodes without editing the nodepool in dry run mode", func() {
+				defer preview.SetDryRun(false)
+				kubeletConfigNodePool, err := cmv1.NewNodePool().ID(nodePoolId).Version(version).
+					AWSNodePool(awsNodePool).AvailabilityZone("us-east-1a").Replicas(2).
+					KubeletConfigs("kc1").Build()
+				Expect(err).ToNot(HaveOccurred())
+				kubeletConfigs := []*cmv1.KubeletConfig{
+					test.MockKubeletConfig(func(k *cmv1.KubeletConfigBuilder) { k.Name("kc1") }),
+					test.MockKubeletConfig(func(k *cmv1.KubeletConfigBuilder) { k.Name("kc2") }),
+				}
+				t.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, test.FormatResource(kubeletConfigNodePool)))
+				t.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, test.FormatKubeletConfigList(kubeletConfigs)))
+				// No handler for the edit, so the test fails if the nodepool is updated
+				t.SetCluster(clusterId, mockClusterReady)
+				args := NewEditMachinepoolUserOptions()
+				args.machinepool = nodePoolId
+				args.replicas = 2
+				args.kubeletConfigs = "kc2"
+				runner := EditMachinePoolRunner(args)
+				cmd := NewEditMachinePoolCommand()
+				Expect(cmd.Flag("cluster").Value.Set(clusterId)).To(Succeed())
+				Expect(cmd.Flags().Set("replicas", "2")).To(Succeed())
+				Expect(cmd.Flags().Set("kubelet-configs", "kc2")).To(Succeed())
+				Expect(cmd.Flags().Set("dry-run", "true")).To(Succeed())
+				stdout, _, err := test.RunWithOutputCaptureAndArgv(func(r *rosa.Runtime, cmd *cobra.Command,
+					argv []string) error {
+					return runner(context.Background(), r, cmd, argv)
+				}, t.RosaRuntime, cmd, &[]string{nodePoolId})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(stdout).To(HavePrefix("~ update machine pool 'test-nodepool'\n" +
+					"  ! kubelet_configs: [\"kc1\"] => [\"kc2\"] (the nodes of the machine pool will be recreated)\n" +
+					"INFO: Dry run"))
+			})
 		})
 	})
 })
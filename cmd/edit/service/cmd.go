@@ -24,6 +24,7 @@ import (
 
 	"github.com/openshift/rosa/pkg/arguments"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -60,13 +61,13 @@ func run(cmd *cobra.Command, argv []string) {
 	err := arguments.ParseKnownFlags(cmd, argv, false)
 	if err != nil {
 		r.Reporter.Errorf("Failed to parse flags: %v", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	if args.ID == "" {
 		r.Reporter.Errorf("Service id not specified.")
 		cmd.Help()
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	// Try to find the service:
@@ -74,13 +75,13 @@ func run(cmd *cobra.Command, argv []string) {
 	service, err := r.OCMClient.GetManagedService(ocm.DescribeManagedServiceArgs{ID: args.ID})
 	if err != nil {
 		r.Reporter.Errorf("Failed to get service %q: %v", args.ID, err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	addOn, err := r.OCMClient.GetAddOn(service.Service())
 	if err != nil {
 		r.Reporter.Errorf("Failed to get add-on %q: %s", service.Service(), err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	addonParameters := addOn.Parameters()
@@ -92,7 +93,7 @@ func run(cmd *cobra.Command, argv []string) {
 	err = arguments.ParseKnownFlags(cmd, argv, true)
 	if err != nil {
 		r.Reporter.Errorf("Failed to parse flags: %v", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	args.Parameters = map[string]string{}
@@ -109,7 +110,7 @@ func run(cmd *cobra.Command, argv []string) {
 	err = r.OCMClient.UpdateManagedService(args)
 	if err != nil {
 		r.Reporter.Errorf("Failed to update service %q: %v", args.ID, err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	r.Reporter.Infof("Service %q is now updating. To check the status run 'rosa describe service --id %s'",
 		args.ID, args.ID)
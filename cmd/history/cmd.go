@@ -0,0 +1,228 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package history
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/journal"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/reporter"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+// Layout of the times in the table:
+const timeLayout = "2006-01-02 15:04:05"
+
+var args struct {
+	since    string
+	command  string
+	cluster  string
+	resource string
+	limit    int
+}
+
+var Cmd = NewHistoryCommand()
+
+func NewHistoryCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "history",
+		Short: "Show the journal of the commands that changed resources",
+		Long: "Shows the local journal of the commands that created, edited, deleted, upgraded, " +
+			"attached or detached resources, with the OCM resources and AWS ARNs that they changed. " +
+			"Secrets given in the command line are redacted. Use '--output json' or '--output yaml' " +
+			"to export the journal.",
+		Example: `  # Show the commands that changed resources in the last day
+  rosa history --since 24h
+
+  # Show the commands that changed the cluster named "mycluster"
+  rosa history --cluster mycluster
+
+  # Export the commands that created operator roles
+  rosa history --command "create operator-roles" --output json`,
+		Args: cobra.NoArgs,
+		Run:  run,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(
+		&args.since,
+		"since",
+		"",
+		"Only show the commands started after this time. It can be a duration, like '24h', "+
+			"a date, like '2024-05-01', or an RFC 3339 time.",
+	)
+	flags.StringVar(
+		&args.command,
+		"command",
+		"",
+		"Only show the commands that contain this text, like 'create operator-roles'.",
+	)
+	flags.StringVarP(
+		&args.cluster,
+		"cluster",
+		"c",
+		"",
+		"Only show the commands that changed the cluster with this name or identifier.",
+	)
+	flags.StringVar(
+		&args.resource,
+		"resource",
+		"",
+		"Only show the commands that changed a resource whose identifier, path or ARN "+
+			"contains this text.",
+	)
+	flags.IntVar(
+		&args.limit,
+		"limit",
+		0,
+		"Only show this number of the most recent commands. Zero shows all of them.",
+	)
+	output.AddFlag(cmd)
+	output.RegisterTablePrinter(printEntries)
+	return cmd
+}
+
+func run(_ *cobra.Command, _ []string) {
+	r := rosa.NewRuntime()
+
+	entries, err := History(time.Now())
+	if err != nil {
+		r.Reporter.Errorf("%v", err)
+		os.Exit(reporter.ExitCode())
+	}
+	if output.HasFlag() {
+		err = output.Print(entries)
+	} else if len(entries) == 0 {
+		r.Reporter.Infof("There are no journaled commands")
+	} else {
+		err = output.PrintTable(entries)
+	}
+	if err != nil {
+		r.Reporter.Errorf("%v", err)
+		os.Exit(reporter.ExitCode())
+	}
+}
+
+// History returns the journal entries selected by the command line flags. Durations given with the
+// '--since' flag are relative to the given time.
+func History(now time.Time) ([]*journal.Entry, error) {
+	filter := &journal.Filter{
+		Command:  args.command,
+		Cluster:  args.cluster,
+		Resource: args.resource,
+	}
+	if args.since != "" {
+		since, err := parseSince(args.since, now)
+		if err != nil {
+			return nil, err
+		}
+		filter.Since = since
+	}
+	if args.limit < 0 {
+		return nil, fmt.Errorf("Invalid limit '%d', it must be zero or positive", args.limit)
+	}
+	name, err := journal.File()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := journal.Read(name)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read journal file '%s': %v", name, err)
+	}
+	entries = filter.Apply(entries)
+	if args.limit > 0 && len(entries) > args.limit {
+		entries = entries[len(entries)-args.limit:]
+	}
+	return entries, nil
+}
+
+func parseSince(value string, now time.Time) (time.Time, error) {
+	duration, err := time.ParseDuration(value)
+	if err == nil {
+		return now.Add(-duration), nil
+	}
+	for _, layout := range []string{time.RFC3339, time.DateOnly} {
+		since, err := time.ParseInLocation(layout, value, time.Local)
+		if err == nil {
+			return since, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf(
+		"Invalid value '%s' for '--since', expected a duration like '24h', a date like '2024-05-01' "+
+			"or an RFC 3339 time", value)
+}
+
+func printEntries(entries []*journal.Entry, writer io.Writer) error {
+	table := tabwriter.NewWriter(writer, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(table, "TIME\tUSER\tCOMMAND\tCHANGES\n")
+	for _, entry := range entries {
+		changes := describeOperations(entry.Operations)
+		if len(changes) == 0 {
+			changes = []string{""}
+		}
+		fmt.Fprintf(table, "%s\t%s\t%s\t%s\n",
+			entry.Time.Local().Format(timeLayout),
+			entry.User,
+			commandLine(entry),
+			changes[0],
+		)
+		for _, change := range changes[1:] {
+			fmt.Fprintf(table, "\t\t\t%s\n", change)
+		}
+	}
+	return table.Flush()
+}
+
+// commandLine rebuilds the command line of an entry, with the flags sorted by name.
+func commandLine(entry *journal.Entry) string {
+	words := append([]string{entry.Command}, entry.Args...)
+	names := make([]string, 0, len(entry.Flags))
+	for name := range entry.Flags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		words = append(words, fmt.Sprintf("--%s=%s", name, entry.Flags[name]))
+	}
+	return strings.Join(words, " ")
+}
+
+// describeOperations returns a line for each operation, with the ARNs of AWS operations in lines
+// of their own.
+func describeOperations(operations []*journal.Operation) []string {
+	lines := []string{}
+	for _, operation := range operations {
+		line := fmt.Sprintf("%s %s", operation.Action, operation.Resource)
+		if operation.ID != "" && !strings.HasSuffix(operation.Resource, "/"+operation.ID) {
+			line = fmt.Sprintf("%s (%s)", line, operation.ID)
+		}
+		lines = append(lines, line)
+		for _, arn := range operation.ARNs {
+			lines = append(lines, "  "+arn)
+		}
+	}
+	return lines
+}
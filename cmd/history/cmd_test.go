@@ -0,0 +1,94 @@
+package history
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift/rosa/pkg/journal"
+)
+
+const journalContent = `{"session":"a","command":{"time":"2024-05-01T10:00:00Z","command":"rosa create cluster",` +
+	`"flags":{"cluster-name":"mycluster","sts":"true"}}}
+{"session":"a","operation":{"provider":"ocm","action":"POST","resource":"clusters_mgmt/v1/clusters","id":"123"}}
+{"session":"b","command":{"time":"2024-05-02T10:00:00Z","command":"rosa create operator-roles",` +
+	`"flags":{"cluster":"mycluster"}}}
+{"session":"b","operation":{"provider":"aws","action":"CreateRole","resource":"IAM","id":"my-role",` +
+	`"arns":["arn:aws:iam::123456789012:role/my-role"]}}
+{"session":"c","command":{"time":"2024-05-03T10:00:00Z","command":"rosa delete idp","args":["github"]}}
+`
+
+var _ = Describe("History", func() {
+	now := time.Date(2024, 5, 3, 12, 0, 0, 0, time.UTC)
+
+	BeforeEach(func() {
+		name := filepath.Join(GinkgoT().TempDir(), "journal.jsonl")
+		Expect(os.WriteFile(name, []byte(journalContent), 0600)).To(Succeed())
+		journal.SetFile(name)
+	})
+
+	AfterEach(func() {
+		journal.SetFile("")
+		args.since = ""
+		args.command = ""
+		args.cluster = ""
+		args.resource = ""
+		args.limit = 0
+	})
+
+	sessions := func() []string {
+		entries, err := History(now)
+		Expect(err).NotTo(HaveOccurred())
+		result := []string{}
+		for _, entry := range entries {
+			result = append(result, entry.Session)
+		}
+		return result
+	}
+
+	It("Returns all the entries without filters", func() {
+		Expect(sessions()).To(Equal([]string{"a", "b", "c"}))
+	})
+
+	It("Filters the entries", func() {
+		args.cluster = "mycluster"
+		Expect(sessions()).To(Equal([]string{"a", "b"}))
+		args.command = "operator-roles"
+		Expect(sessions()).To(Equal([]string{"b"}))
+	})
+
+	It("Accepts durations and dates for '--since'", func() {
+		args.since = "30h"
+		Expect(sessions()).To(Equal([]string{"b", "c"}))
+		args.since = "2024-05-03"
+		Expect(sessions()).To(Equal([]string{"c"}))
+		args.since = "yesterday"
+		_, err := History(now)
+		Expect(err).To(MatchError(ContainSubstring("Invalid value 'yesterday' for '--since'")))
+	})
+
+	It("Keeps the most recent entries when limited", func() {
+		args.limit = 2
+		Expect(sessions()).To(Equal([]string{"b", "c"}))
+	})
+
+	It("Prints the commands and their changes", func() {
+		entries, err := History(now)
+		Expect(err).NotTo(HaveOccurred())
+		buffer := &bytes.Buffer{}
+		Expect(printEntries(entries, buffer)).To(Succeed())
+		lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+		Expect(lines).To(HaveLen(5))
+		Expect(lines[0]).To(MatchRegexp(`^TIME\s+USER\s+COMMAND\s+CHANGES$`))
+		Expect(lines[1]).To(ContainSubstring("rosa create cluster --cluster-name=mycluster --sts=true"))
+		Expect(lines[1]).To(HaveSuffix("POST clusters_mgmt/v1/clusters (123)"))
+		Expect(lines[2]).To(HaveSuffix("CreateRole IAM (my-role)"))
+		Expect(lines[3]).To(HaveSuffix("  arn:aws:iam::123456789012:role/my-role"))
+		Expect(lines[4]).To(ContainSubstring("rosa delete idp github"))
+	})
+})
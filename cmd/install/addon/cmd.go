@@ -37,6 +37,7 @@ import (
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/interactive/confirm"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -107,7 +108,7 @@ func run(cmd *cobra.Command, argv []string) {
 	cluster := r.FetchCluster()
 	if cluster.State() != cmv1.ClusterStateReady {
 		r.Reporter.Errorf("Cluster '%s' is not yet ready", clusterKey)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	ensureAddonNotInstalled(r, cluster.ID(), addOnID)
@@ -115,7 +116,7 @@ func run(cmd *cobra.Command, argv []string) {
 	addOn, err := r.OCMClient.GetAddOn(addOnID)
 	if err != nil {
 		r.Reporter.Warnf("Failed to get add-on '%s'", addOnID)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	// Verify if addon requires STS authentication
@@ -140,11 +141,11 @@ func run(cmd *cobra.Command, argv []string) {
 					err = createAddonRole(r, roleName, cr, cmd, cluster)
 					if err != nil {
 						r.Reporter.Errorf("%s", err)
-						os.Exit(1)
+						os.Exit(reporter.ExitCode())
 					}
 				} else {
 					r.Reporter.Errorf("%s", err)
-					os.Exit(1)
+					os.Exit(reporter.ExitCode())
 				}
 			}
 			// TODO : verify the role has the right permissions
@@ -157,13 +158,13 @@ func run(cmd *cobra.Command, argv []string) {
 				Build()
 			if err != nil {
 				r.Reporter.Errorf("Failed to build operator role '%s': %s", roleName, err)
-				os.Exit(1)
+				os.Exit(reporter.ExitCode())
 			}
 
 			err = r.OCMClient.AddClusterOperatorRole(cluster, operatorRole)
 			if err != nil {
 				r.Reporter.Errorf("Failed to add operator role to cluster '%s': %s", clusterKey, err)
-				os.Exit(1)
+				os.Exit(reporter.ExitCode())
 			}
 		}
 	}
@@ -171,7 +172,7 @@ func run(cmd *cobra.Command, argv []string) {
 	addonParameters, err := r.OCMClient.GetAddOnParameters(cluster.ID(), addOnID)
 	if err != nil {
 		r.Reporter.Errorf("Failed to get add-on '%s' parameters: %v", addOnID, err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	var addonArguments []ocm.AddOnParam
@@ -213,20 +214,20 @@ func run(cmd *cobra.Command, argv []string) {
 				val, err = interactive.GetAddonArgument(*param, param.DefaultValue())
 				if err != nil {
 					r.Reporter.Errorf("%s", err)
-					os.Exit(1)
+					os.Exit(reporter.ExitCode())
 				}
 			}
 
 			val = strings.Trim(val, " ")
 			if len(options) > 0 && !helper.Contains(values, val) {
 				r.Reporter.Errorf("Expected %v to match one of the options /%v/", val, options)
-				os.Exit(1)
+				os.Exit(reporter.ExitCode())
 			}
 			if val != "" && param.Validation() != "" {
 				isValid, err := regexp.MatchString(param.Validation(), val)
 				if err != nil || !isValid {
 					r.Reporter.Errorf("Expected %v to match /%s/", val, param.Validation())
-					os.Exit(1)
+					os.Exit(reporter.ExitCode())
 				}
 			}
 			addonArguments = append(addonArguments, ocm.AddOnParam{Key: param.ID(), Val: val})
@@ -250,7 +251,7 @@ func run(cmd *cobra.Command, argv []string) {
 			})
 			if err != nil {
 				r.Reporter.Errorf("Expected a valid billing model: %s", err)
-				os.Exit(1)
+				os.Exit(reporter.ExitCode())
 			}
 		}
 	}
@@ -263,7 +264,7 @@ func run(cmd *cobra.Command, argv []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid account id: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 
@@ -276,7 +277,7 @@ func run(cmd *cobra.Command, argv []string) {
 	err = r.OCMClient.InstallAddOn(cluster.ID(), addOnID, addonArguments, billing)
 	if err != nil {
 		r.Reporter.Errorf("Failed to add add-on installation '%s' for cluster '%s': %v", addOnID, clusterKey, err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	r.Reporter.Infof("Add-on '%s' is now installing. To check the status run 'rosa list addons -c %s'",
 		addOnID, clusterKey)
@@ -290,7 +291,7 @@ func ensureAddonNotInstalled(r *rosa.Runtime, clusterID, addOnID string) {
 	installation, err := r.OCMClient.GetAddOnInstallation(clusterID, addOnID)
 	if err != nil && errors.GetType(err) != errors.NotFound {
 		r.Reporter.Errorf("An error occurred while trying to get addon installation : %v", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	if installation != nil {
 		r.Reporter.Warnf("Addon '%s' is already installed on cluster '%s'", addOnID, clusterID)
@@ -26,6 +26,7 @@ import (
 	"github.com/openshift/rosa/pkg/aws"
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/interactive/confirm"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -106,26 +107,26 @@ func run(cmd *cobra.Command, argv []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid user role ARN to link to a current account: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 	if roleArn != "" {
 		err = aws.ARNValidator(roleArn)
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid user role ARN to link to a current account: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 
 	role, err := r.AWSClient.GetRoleByARN(roleArn)
 	if err != nil {
 		r.Reporter.Errorf("There was a problem checking if role '%s' exists: %v", roleArn, err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	if *role.Arn != roleArn {
 		r.Reporter.Errorf("The role with '%s' cannot be found", roleArn)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	if !confirm.Prompt(true, "Link the '%s' role with account '%s'?", roleArn, accountID) {
@@ -138,11 +139,11 @@ func run(cmd *cobra.Command, argv []string) {
 			r.Reporter.Errorf("Only organization admin or the user that owns this account '%s' can run this command. "+
 				"Please ask someone with adequate permissions to run the following command \n\n"+
 				"\t rosa link user-role --role-arn %s --account-id %s", accountID, roleArn, accountID)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		r.Reporter.Errorf("Unable to link role ARN '%s' with the account id : '%s' : %v",
 			args.roleArn, accountID, err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	r.Reporter.Infof("Successfully linked role ARN '%s' with account '%s'", roleArn, accountID)
 }
@@ -27,6 +27,7 @@ import (
 
 	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -64,14 +65,14 @@ func run(_ *cobra.Command, _ []string) {
 	versionList, err := ocm.GetVersionMinorList(r.OCMClient)
 	if err != nil {
 		r.Reporter.Errorf("%s", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	_, err = r.OCMClient.ValidateVersion(args.version, versionList,
 		r.Cluster.Version().ChannelGroup(), r.Cluster.AWS().STS().RoleARN() == "", r.Cluster.Hypershift().Enabled())
 	if err != nil {
 		r.Reporter.Errorf("Version '%s' is invalid", args.version)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	var spin *spinner.Spinner
@@ -91,14 +92,14 @@ func run(_ *cobra.Command, _ []string) {
 
 	if err != nil {
 		r.Reporter.Errorf("Failed to get account roles: %v", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	if output.HasFlag() {
 		err = output.Print(accountRoles)
 		if err != nil {
 			r.Reporter.Errorf("%s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		os.Exit(0)
 	}
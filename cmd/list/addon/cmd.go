@@ -26,6 +26,7 @@ import (
 
 	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -64,14 +65,14 @@ func listAllAddOns(r *rosa.Runtime) {
 	addOnResources, err := r.OCMClient.GetAvailableAddOns()
 	if err != nil {
 		r.Reporter.Errorf("Failed to fetch add-ons: %v", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	if output.HasFlag() {
 		err = output.Print(addOnResources)
 		if err != nil {
 			r.Reporter.Errorf("%s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		os.Exit(0)
 	}
@@ -110,14 +111,14 @@ func listClusterAddOns(clusterKey string, r *rosa.Runtime) {
 				"must contain only letters, digits, dashes and underscores",
 			clusterKey,
 		)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	cluster := r.FetchCluster()
 	if cluster.State() != cmv1.ClusterStateReady &&
 		cluster.State() != cmv1.ClusterStateHibernating {
 		r.Reporter.Errorf("Cluster '%s' is not yet ready", clusterKey)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	// Load any existing Add-Ons for this cluster
@@ -125,14 +126,14 @@ func listClusterAddOns(clusterKey string, r *rosa.Runtime) {
 	clusterAddOns, err := r.OCMClient.GetClusterAddOns(cluster)
 	if err != nil {
 		r.Reporter.Errorf("Failed to get add-ons for cluster '%s': %v", clusterKey, err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	if output.HasFlag() {
 		err = output.Print(clusterAddOns)
 		if err != nil {
 			r.Reporter.Errorf("%s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		os.Exit(0)
 	}
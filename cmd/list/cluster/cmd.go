@@ -19,13 +19,16 @@ package cluster
 import (
 	"fmt"
 	"os"
+	"strings"
 	"text/tabwriter"
+	"time"
 
-	v1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/spf13/cobra"
 
 	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -35,69 +38,144 @@ var Cmd = &cobra.Command{
 	Short:   "List clusters",
 	Long:    "List clusters.",
 	Example: `  # List all clusters
-  rosa list clusters`,
+  rosa list clusters
+
+  # List all clusters including their region, version and creation date
+  rosa list clusters -o wide
+
+  # List the ready Hosted Control Plane clusters of version 4.14 in us-east-1, newest first
+  rosa list clusters --state ready --hosted-cp --version 4.14 --cluster-region us-east-1 --sort-by=-created
+
+  # List the Classic STS clusters created by an IAM user of another account of the organization
+  rosa list clusters --topology classic-sts --creator arn:aws:iam::123456789012:user/jdoe
+
+  # List the clusters whose name starts with 'dev-', using an additional OCM search query
+  rosa list clusters --name 'dev-*' --search "aws.sts.enabled = 'true'"
+
+  # Print only the identifiers of the clusters
+  rosa list clusters -o jsonpath='{range [*]}{.id}{"\n"}{end}'`,
 	Args: cobra.NoArgs,
 	Run:  run,
 }
 
-const clusterCount = 1000
-
 var args struct {
 	listAll        bool
 	accountRoleArn string
+	states         []string
+	region         string
+	hostedCP       bool
+	topology       string
+	creator        string
+	version        string
+	name           string
+	search         string
+	sortBy         string
 }
 
 func init() {
 	flags := Cmd.Flags()
 	flags.SortFlags = false
 
-	output.AddFlag(Cmd)
+	output.AddWideFlag(Cmd)
 	flags.BoolVarP(&args.listAll, "all", "a", false, "List all clusters across different AWS "+
 		"accounts under the same Red Hat organization")
 	flags.StringVar(&args.accountRoleArn, "account-role-arn", "", "List all clusters "+
 		"using the account role identified by the ARN")
+	flags.StringSliceVar(&args.states, "state", nil, fmt.Sprintf("List only the clusters in the given "+
+		"states. Format should be a comma-separated list of: %s", strings.Join(ocm.ClusterStates, ", ")))
+	flags.StringVar(&args.region, "cluster-region", "", "List only the clusters of the given region")
+	flags.BoolVar(&args.hostedCP, "hosted-cp", false, "List only the Hosted Control Plane clusters. "+
+		"Use '--hosted-cp=false' to list only the classic clusters")
+	flags.StringVar(&args.topology, "topology", "", fmt.Sprintf("List only the clusters with the given "+
+		"topology, one of %s", strings.Join(ocm.ClusterTopologies, ", ")))
+	flags.StringVar(&args.creator, "creator", "", "List only the clusters created by the given AWS "+
+		"account identifier, or by the IAM user or role with the given ARN, in any account of the "+
+		"Red Hat organization")
+	flags.StringVar(&args.version, "version", "", "List only the clusters whose OpenShift version "+
+		"starts with the given value, for example '4.14'")
+	flags.StringVar(&args.name, "name", "", "List only the clusters whose name matches the given "+
+		"pattern, where '*' matches any text and '?' any single character")
+	flags.StringVar(&args.search, "search", "", "List only the clusters that match the given query "+
+		"of the OCM search language, for example \"aws.sts.enabled = 'true'\"")
+	flags.StringVar(&args.sortBy, "sort-by", "", fmt.Sprintf("Sort the clusters by one of %s. "+
+		"Use a '-' prefix to sort in descending order", strings.Join(ocm.ClusterSortKeys(), ", ")))
+
+	Cmd.RegisterFlagCompletionFunc("state", stateCompletion)
+	Cmd.RegisterFlagCompletionFunc("topology", topologyCompletion)
+	Cmd.RegisterFlagCompletionFunc("sort-by", sortByCompletion)
 }
 
-func listClustersUsingAccountRole(creator *aws.Creator, runtime *rosa.Runtime) ([]*v1.Cluster, error) {
-	role, err := runtime.AWSClient.GetAccountRoleByArn(args.accountRoleArn)
-	if err != nil {
-		return []*v1.Cluster{}, err
-	}
+func stateCompletion(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	return ocm.ClusterStates, cobra.ShellCompDirectiveNoFileComp
+}
+
+func topologyCompletion(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	return ocm.ClusterTopologies, cobra.ShellCompDirectiveNoFileComp
+}
 
-	return runtime.OCMClient.GetClustersUsingAccountRole(creator, role, clusterCount)
+func sortByCompletion(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	return ocm.ClusterSortKeys(), cobra.ShellCompDirectiveNoFileComp
+}
+
+// buildFilter returns the filter that selects the clusters requested with the command line flags.
+func buildFilter(cmd *cobra.Command, r *rosa.Runtime) (*ocm.ClusterFilter, error) {
+	if cmd.Flags().Changed("hosted-cp") && args.topology != "" {
+		return nil, fmt.Errorf("Flags '--hosted-cp' and '--topology' can't be used together")
+	}
+	filter := &ocm.ClusterFilter{
+		States:   args.states,
+		Region:   args.region,
+		Topology: args.topology,
+		Creator:  args.creator,
+		Version:  args.version,
+		Name:     args.name,
+		Search:   args.search,
+		SortBy:   args.sortBy,
+	}
+	if args.accountRoleArn != "" {
+		role, err := r.AWSClient.GetAccountRoleByArn(args.accountRoleArn)
+		if err != nil {
+			return nil, err
+		}
+		filter.AccountRole = &role
+	}
+	if cmd.Flags().Changed("hosted-cp") {
+		filter.HostedCP = &args.hostedCP
+	}
+	return filter, nil
 }
 
-func run(_ *cobra.Command, _ []string) {
+func run(cmd *cobra.Command, _ []string) {
 	r := rosa.NewRuntime().WithAWS().WithOCM()
 	defer r.Cleanup()
 
 	// Retrieve the list of clusters:
+	// The clusters of other accounts are also listed when a creator is given, as it already
+	// restricts the result:
 	var creator *aws.Creator
-	if args.listAll {
+	if args.listAll || args.creator != "" {
 		creator = nil
 	} else {
 		creator = r.Creator
 	}
 
-	var clusters []*v1.Cluster
-	var err error
-
-	if args.accountRoleArn != "" {
-		clusters, err = listClustersUsingAccountRole(creator, r)
-	} else {
-		clusters, err = r.OCMClient.GetClusters(creator, clusterCount)
+	filter, err := buildFilter(cmd, r)
+	if err != nil {
+		r.Reporter.Errorf("Failed to get clusters: %v", err)
+		os.Exit(reporter.ExitCode())
 	}
 
+	clusters, err := r.OCMClient.ListClusters(creator, filter)
 	if err != nil {
 		r.Reporter.Errorf("Failed to get clusters: %v", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	if output.HasFlag() {
 		err = output.Print(clusters)
 		if err != nil {
 			r.Reporter.Errorf("%s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		os.Exit(0)
 	}
@@ -109,7 +187,11 @@ func run(_ *cobra.Command, _ []string) {
 
 	// Create the writer that will be used to print the tabulated results:
 	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintf(writer, "ID\tNAME\tSTATE\tTOPOLOGY\n")
+	if output.Wide() {
+		fmt.Fprintf(writer, "ID\tNAME\tSTATE\tTOPOLOGY\tREGION\tVERSION\tCREATED\n")
+	} else {
+		fmt.Fprintf(writer, "ID\tNAME\tSTATE\tTOPOLOGY\n")
+	}
 	for _, cluster := range clusters {
 		typeOutput := "Classic"
 		if cluster.AWS() != nil && cluster.AWS().STS() != nil && cluster.AWS().STS().Enabled() {
@@ -118,6 +200,20 @@ func run(_ *cobra.Command, _ []string) {
 		if cluster.Hypershift().Enabled() {
 			typeOutput = "Hosted CP"
 		}
+		if output.Wide() {
+			fmt.Fprintf(
+				writer,
+				"%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+				cluster.ID(),
+				cluster.Name(),
+				cluster.State(),
+				typeOutput,
+				cluster.Region().ID(),
+				cluster.OpenshiftVersion(),
+				cluster.CreationTimestamp().Format(time.RFC3339),
+			)
+			continue
+		}
 		fmt.Fprintf(
 			writer,
 			"%s\t%s\t%s\t%s\n",
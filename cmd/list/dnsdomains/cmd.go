@@ -26,6 +26,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -78,7 +79,7 @@ func run(_ *cobra.Command, _ []string) {
 	dnsDomains, err := r.OCMClient.ListDNSDomains(search)
 	if err != nil {
 		r.Reporter.Errorf("Failed to list DNS Domains: %v", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	if args.hostedCp {
@@ -89,7 +90,7 @@ func run(_ *cobra.Command, _ []string) {
 		err = output.Print(dnsDomains)
 		if err != nil {
 			r.Reporter.Errorf("%s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		os.Exit(0)
 	}
@@ -26,6 +26,7 @@ import (
 
 	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -42,7 +43,7 @@ var Cmd = &cobra.Command{
 
 func init() {
 	ocm.AddClusterFlag(Cmd)
-	output.AddFlag(Cmd)
+	output.AddWideFlag(Cmd)
 }
 
 func run(_ *cobra.Command, _ []string) {
@@ -55,12 +56,12 @@ func run(_ *cobra.Command, _ []string) {
 	if cluster.State() != cmv1.ClusterStateReady &&
 		cluster.State() != cmv1.ClusterStateHibernating {
 		r.Reporter.Errorf("Cluster '%s' is not yet ready", clusterKey)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	if cluster.ExternalAuthConfig().Enabled() {
 		r.Reporter.Errorf("Listing identity providers is not supported for clusters with external authentication configured.")
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	// Load any existing IDPs for this cluster
@@ -68,14 +69,14 @@ func run(_ *cobra.Command, _ []string) {
 	idps, err := r.OCMClient.GetIdentityProviders(cluster.ID())
 	if err != nil {
 		r.Reporter.Errorf("Failed to get identity providers for cluster '%s': %v", clusterKey, err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	if output.HasFlag() {
 		err = output.Print(idps)
 		if err != nil {
 			r.Reporter.Errorf("%s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		os.Exit(0)
 	}
@@ -87,9 +88,12 @@ func run(_ *cobra.Command, _ []string) {
 
 	// Create the writer that will be used to print the tabulated results:
 	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	if len(idps) == 1 && !ocm.HasAuthURLSupport(idps[0]) {
+	switch {
+	case output.Wide():
+		fmt.Fprintf(writer, "ID\t\tNAME\t\tTYPE\t\tAUTH URL\t\tMAPPING METHOD\n")
+	case len(idps) == 1 && !ocm.HasAuthURLSupport(idps[0]):
 		fmt.Fprintf(writer, "NAME\t\tTYPE\n")
-	} else {
+	default:
 		fmt.Fprintf(writer, "NAME\t\tTYPE\t\tAUTH URL\n")
 	}
 	for _, idp := range idps {
@@ -97,6 +101,11 @@ func run(_ *cobra.Command, _ []string) {
 		if err != nil {
 			r.Reporter.Warnf("Error building OAuth URL for %s: %v", idp.Name(), err)
 		}
+		if output.Wide() {
+			fmt.Fprintf(writer, "%s\t\t%s\t\t%s\t\t%s\t\t%s\n", idp.ID(), idp.Name(),
+				ocm.IdentityProviderType(idp), oauthURL, idp.MappingMethod())
+			continue
+		}
 		fmt.Fprintf(writer, "%s\t\t%s\t\t%s\n", idp.Name(), ocm.IdentityProviderType(idp), oauthURL)
 	}
 	writer.Flush()
@@ -30,6 +30,7 @@ import (
 	"github.com/openshift/rosa/pkg/interactive/confirm"
 	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -83,14 +84,14 @@ func run(cmd *cobra.Command, _ []string) {
 	versionList, err := ocm.GetVersionMinorList(r.OCMClient)
 	if err != nil {
 		r.Reporter.Errorf("%s", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	_, err = r.OCMClient.ValidateVersion(args.version, versionList,
 		r.Cluster.Version().ChannelGroup(), r.Cluster.AWS().STS().RoleARN() == "", r.Cluster.Hypershift().Enabled())
 	if err != nil {
 		r.Reporter.Errorf("Version '%s' is invalid", args.version)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	var spin *spinner.Spinner
@@ -109,7 +110,7 @@ func run(cmd *cobra.Command, _ []string) {
 		cluster, err := r.OCMClient.GetCluster(clusterKey, r.Creator)
 		if err != nil {
 			r.Reporter.Errorf("Failed to get cluster '%s': %v", clusterKey, err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		clusterId = cluster.ID()
 		args.prefix = cluster.AWS().STS().OperatorRolePrefix()
@@ -125,7 +126,7 @@ func run(cmd *cobra.Command, _ []string) {
 
 	if err != nil {
 		r.Reporter.Errorf("Failed to get operator roles: %v", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	if len(operatorsMap) == 0 {
@@ -143,14 +144,16 @@ func run(cmd *cobra.Command, _ []string) {
 		os.Exit(0)
 	}
 	if output.HasFlag() {
-		var resource interface{} = operatorsMap
+		// Without a prefix the roles of each prefix are printed as a separate document:
 		if args.prefix != "" {
-			resource = operatorsMap[args.prefix]
+			prefixes = []string{args.prefix}
 		}
-		err = output.Print(resource)
-		if err != nil {
-			r.Reporter.Errorf("%s", err)
-			os.Exit(1)
+		for _, prefix := range prefixes {
+			err = output.Print(operatorsMap[prefix])
+			if err != nil {
+				r.Reporter.Errorf("%s", err)
+				os.Exit(reporter.ExitCode())
+			}
 		}
 		os.Exit(0)
 	}
@@ -190,7 +193,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid OIDC Config ID: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 	if args.prefix != "" {
@@ -207,7 +210,7 @@ func run(cmd *cobra.Command, _ []string) {
 		if err != nil {
 			r.Reporter.Errorf("There was a problem checking if any clusters"+
 				" are using Operator Roles Prefix '%s' : %v", args.prefix, err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 
 		fmt.Fprintf(writer, "OPERATOR NAME\tOPERATOR NAMESPACE\tROLE NAME\t"+
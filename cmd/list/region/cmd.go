@@ -27,6 +27,7 @@ import (
 	"github.com/openshift/rosa/pkg/aws"
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -89,13 +90,13 @@ func run(cmd *cobra.Command, _ []string) {
 	callerIdentity, err := r.AWSClient.GetCallerIdentity()
 	if err != nil {
 		r.Reporter.Errorf("Failed to get caller identity: %v", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	isUsingAssumedRole, err := aws.IsArnAssumedRole(*callerIdentity.Arn)
 	if err != nil {
 		r.Reporter.Errorf("Failed to check if role is an assumed role: %v", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	if isUsingAssumedRole && r.Creator.IsSTS && !cmd.Flag(roleArnFlag).Changed {
@@ -108,7 +109,7 @@ func run(cmd *cobra.Command, _ []string) {
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid role arn value: %v", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 
@@ -117,7 +118,7 @@ func run(cmd *cobra.Command, _ []string) {
 	regions, err := r.OCMClient.GetRegions(args.roleARN, args.externalID)
 	if err != nil {
 		r.Reporter.Errorf("Failed to fetch regions: %v", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	// Filter out unwanted regions
@@ -143,14 +144,14 @@ func run(cmd *cobra.Command, _ []string) {
 		err = output.Print(availableRegions)
 		if err != nil {
 			r.Reporter.Errorf("%s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		os.Exit(0)
 	}
 
 	if len(availableRegions) == 0 {
 		r.Reporter.Warnf("There are no regions available for this AWS account")
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	// Create the writer that will be used to print the tabulated results:
@@ -2,7 +2,9 @@ package rhRegion
 
 import (
 	"fmt"
+	"io"
 	"os"
+	"sort"
 	"strings"
 	"text/tabwriter"
 
@@ -11,18 +13,27 @@ import (
 
 	"github.com/openshift/rosa/pkg/config"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
-var (
-	writer = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.TabIndent)
-	args   struct {
-		discoveryURL string
-	}
-)
+var args struct {
+	discoveryURL string
+}
 
 var Cmd = NewRhRegionCommand()
 
+func init() {
+	output.RegisterTablePrinter(printRhRegions)
+}
+
+// rhRegions contains the regions returned by the OCM API gateway, and the URL of that gateway.
+type rhRegions struct {
+	gatewayURL string
+	regions    map[string]sdk.Region
+}
+
 func NewRhRegionCommand() *cobra.Command {
 	Cmd := &cobra.Command{
 		Use:   "rh-regions",
@@ -44,6 +55,7 @@ ocm list rh-regions`,
 			"file or "+sdk.DefaultURL+" as a last resort. The value should be a complete URL "+
 			"or a valid URL alias: "+strings.Join(ocm.ValidOCMUrlAliases(), ", "),
 	)
+	output.AddFlag(Cmd)
 	return Cmd
 }
 
@@ -53,7 +65,7 @@ func run(cmd *cobra.Command, argv []string) {
 	err := ListRhRegions(args.discoveryURL, r)
 	if err != nil {
 		r.Reporter.Errorf("Failed to determine gateway URL: %v", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 }
 
@@ -68,21 +80,38 @@ func ListRhRegions(discoveryURL string, r *rosa.Runtime) error {
 		return fmt.Errorf("Failed to determine gateway URL: %v", err)
 	}
 
-	fmt.Fprintf(writer, "Discovery URL: %s\n\n", gatewayURL)
 	regions, err := sdk.GetRhRegions(gatewayURL)
 	if err != nil {
 		return fmt.Errorf("Failed to get OCM regions: %v", err)
 	}
 
+	if output.HasFlag() {
+		return output.Print(regions)
+	}
+
 	// If there are no regions, print a warning message and return early
 	if len(regions) == 0 {
 		r.Reporter.Warnf("No regions found")
 		return nil
 	}
-	fmt.Fprintf(writer, "RH Region\t\tGateway URL\n")
-	for regionName, region := range regions {
-		fmt.Fprintf(writer, "%s\t\t%v\n", regionName, region.URL)
+	return output.PrintTable(&rhRegions{
+		gatewayURL: gatewayURL,
+		regions:    regions,
+	})
+}
+
+func printRhRegions(regions *rhRegions, writer io.Writer) error {
+	names := make([]string, 0, len(regions.regions))
+	for name := range regions.regions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintf(writer, "Discovery URL: %s\n\n", regions.gatewayURL)
+	table := tabwriter.NewWriter(writer, 0, 0, 2, ' ', tabwriter.TabIndent)
+	fmt.Fprintf(table, "RH Region\t\tGateway URL\n")
+	for _, name := range names {
+		fmt.Fprintf(table, "%s\t\t%v\n", name, regions.regions[name].URL)
 	}
-	writer.Flush()
-	return nil
+	return table.Flush()
 }
@@ -1,14 +1,17 @@
 package rhRegion
 
 import (
+	"bytes"
 	"os"
 	"testing"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	sdk "github.com/openshift-online/ocm-sdk-go"
 
 	"github.com/openshift/rosa/pkg/config"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/output"
 )
 
 func TestRhRegionCommand(t *testing.T) {
@@ -42,3 +45,21 @@ var _ = Describe("Token", Ordered, func() {
 		})
 	})
 })
+
+var _ = Describe("Table printer", func() {
+	It("Prints the regions sorted by name", func() {
+		var buffer bytes.Buffer
+		err := output.WriteTable(&buffer, &rhRegions{
+			gatewayURL: "https://api.openshift.com",
+			regions: map[string]sdk.Region{
+				"rhdsa-ap-southeast-1": {URL: "https://api.ap-southeast-1.openshift.com"},
+				"aws.ap-southeast-1":   {URL: "https://api.aws.ap-southeast-1.openshift.com"},
+			},
+		})
+		Expect(err).To(BeNil())
+		Expect(buffer.String()).To(Equal("Discovery URL: https://api.openshift.com\n\n" +
+			"RH Region               Gateway URL\n" +
+			"aws.ap-southeast-1      https://api.aws.ap-southeast-1.openshift.com\n" +
+			"rhdsa-ap-southeast-1    https://api.ap-southeast-1.openshift.com\n"))
+	})
+})
@@ -30,6 +30,7 @@ import (
 	"github.com/openshift/rosa/pkg/machinepool"
 	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -68,8 +69,8 @@ func run(cmd *cobra.Command, _ []string) {
 	defer r.Cleanup()
 	err := runWithRuntime(r, cmd)
 	if err != nil {
-		r.Reporter.Errorf(err.Error())
-		os.Exit(1)
+		r.Reporter.Errorf("%v", err)
+		os.Exit(reporter.ExitCode())
 	}
 }
 
@@ -132,7 +133,7 @@ func runWithRuntime(r *rosa.Runtime, _ *cobra.Command) error {
 		err := output.Print(availableUpgrades)
 		if err != nil {
 			r.Reporter.Errorf("%s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 
 		os.Exit(0)
@@ -29,6 +29,7 @@ import (
 	"github.com/openshift/rosa/cmd/create/admin"
 	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -58,12 +59,12 @@ func run(_ *cobra.Command, _ []string) {
 	if cluster.State() != cmv1.ClusterStateReady &&
 		cluster.State() != cmv1.ClusterStateHibernating {
 		r.Reporter.Errorf("Cluster '%s' is not yet ready", clusterKey)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	if cluster.ExternalAuthConfig().Enabled() {
 		r.Reporter.Errorf("Listing cluster users is not supported for clusters with external authentication configured.")
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	var clusterAdmins []*cmv1.User
@@ -73,14 +74,14 @@ func run(_ *cobra.Command, _ []string) {
 	clusterAdmins, err = r.OCMClient.GetUsers(cluster.ID(), admin.ClusterAdminGroupname)
 	if err != nil {
 		r.Reporter.Errorf("Failed to get cluster-admins for cluster '%s': %v", clusterKey, err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	// Load dedicated-admins for this cluster
 	dedicatedAdmins, err := r.OCMClient.GetUsers(cluster.ID(), "dedicated-admins")
 	if err != nil {
 		r.Reporter.Errorf("Failed to get dedicated-admins for cluster '%s': %v", clusterKey, err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	if output.HasFlag() {
@@ -90,7 +91,7 @@ func run(_ *cobra.Command, _ []string) {
 		err := output.Print(userList)
 		if err != nil {
 			r.Reporter.Errorf("%s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 
 		os.Exit(0)
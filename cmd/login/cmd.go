@@ -61,6 +61,9 @@ var args struct {
 	env           string
 	token         string
 	insecure      bool
+	caFile        string
+	proxyURL      string
+	noProxy       string
 	useAuthCode   bool
 	useDeviceCode bool
 	rhRegion      string
@@ -79,7 +82,10 @@ var Cmd = &cobra.Command{
 		"\t5. Configuration file\n"+
 		"\t6. Command-line prompt\n", uiTokenPage),
 	Example: fmt.Sprintf(`  # Login to the OpenShift API with an existing token generated from %s
-  rosa login --token=$OFFLINE_ACCESS_TOKEN`, uiTokenPage),
+  rosa login --token=$OFFLINE_ACCESS_TOKEN
+
+  # Login to the staging environment and save the credentials in the 'staging' context
+  rosa login --url=staging --context=staging`, uiTokenPage),
 	Run:  run,
 	Args: cobra.NoArgs,
 }
@@ -140,6 +146,27 @@ func init() {
 		"Enables insecure communication with the server. This disables verification of TLS "+
 			"certificates and host names.",
 	)
+	flags.StringVar(
+		&args.caFile,
+		"ca-file",
+		"",
+		"File with a PEM encoded CA bundle trusted in addition to the system CAs, for example the "+
+			"CA of a TLS intercepting proxy. It applies to OCM, AWS and downloads.",
+	)
+	flags.StringVar(
+		&args.proxyURL,
+		"proxy-url",
+		"",
+		"URL of the proxy used to connect to OCM, AWS and downloads. Defaults to the proxy given "+
+			"in the 'HTTPS_PROXY' environment variable.",
+	)
+	flags.StringVar(
+		&args.noProxy,
+		"no-proxy",
+		"",
+		"Comma separated list of hosts, domains and CIDRs that are reached without the proxy. "+
+			"Defaults to the value of the 'NO_PROXY' environment variable.",
+	)
 	flags.BoolVar(
 		&args.useAuthCode,
 		"use-auth-code",
@@ -173,8 +200,8 @@ func run(cmd *cobra.Command, argv []string) {
 	defer r.Cleanup()
 	err := runWithRuntime(r, cmd, argv)
 	if err != nil {
-		r.Reporter.Errorf(err.Error())
-		os.Exit(1)
+		r.Reporter.Errorf("%v", err)
+		os.Exit(rprtr.ExitCode())
 	}
 }
 
@@ -199,13 +226,13 @@ func runWithRuntime(r *rosa.Runtime, cmd *cobra.Command, argv []string) error {
 	// Confirm that token is not passed with auth code flags
 	if (args.useAuthCode || args.useDeviceCode) && args.token != "" {
 		r.Reporter.Errorf("Token cannot be passed with '--use-auth-code' or '--use-device-code' commands")
-		os.Exit(1)
+		os.Exit(rprtr.ExitCode())
 	}
 
 	// FedRAMP does not support oauth code flow login yet
 	if fedramp.HasFlag(cmd) && (args.useAuthCode || args.useDeviceCode) {
 		r.Reporter.Errorf("This login method is currently not supported with FedRAMP")
-		os.Exit(1)
+		os.Exit(rprtr.ExitCode())
 	}
 
 	if args.useAuthCode {
@@ -252,8 +279,10 @@ func runWithRuntime(r *rosa.Runtime, cmd *cobra.Command, argv []string) error {
 	if err != nil {
 		return fmt.Errorf("Failed to load config file: %v", err)
 	}
-	if cfg == nil || config.IsNotValid(cfg) {
+	if cfg == nil {
 		cfg = new(config.Config)
+	} else if config.IsNotValid(cfg) {
+		cfg.Clear()
 	}
 
 	token := args.token
@@ -262,7 +291,7 @@ func runWithRuntime(r *rosa.Runtime, cmd *cobra.Command, argv []string) error {
 	err = CheckAndLogIntoFedramp(fedramp.HasFlag(cmd), fedramp.HasAdminFlag(cmd), cfg, token, r)
 	if err != nil {
 		r.Reporter.Errorf("%s", err.Error())
-		os.Exit(1)
+		os.Exit(rprtr.ExitCode())
 	}
 
 	haveReqs := token != "" || (args.clientID != "" && args.clientSecret != "")
@@ -311,7 +340,7 @@ func runWithRuntime(r *rosa.Runtime, cmd *cobra.Command, argv []string) error {
 	gatewayURL, err := ocm.ResolveGatewayUrl(env, cfg)
 	if err != nil {
 		r.Reporter.Errorf("Failed to resolve gateway URL: %v", err)
-		os.Exit(1)
+		os.Exit(rprtr.ExitCode())
 	}
 
 	var ok bool
@@ -369,7 +398,7 @@ func runWithRuntime(r *rosa.Runtime, cmd *cobra.Command, argv []string) error {
 		regValue, err := sdk.GetRhRegion(gatewayURL, args.rhRegion)
 		if err != nil {
 			r.Reporter.Errorf("Can't find region: %v", err)
-			os.Exit(1)
+			os.Exit(rprtr.ExitCode())
 		}
 		gatewayURL = fmt.Sprintf("https://%s", regValue.URL)
 	}
@@ -382,6 +411,27 @@ func runWithRuntime(r *rosa.Runtime, cmd *cobra.Command, argv []string) error {
 	cfg.URL = gatewayURL
 	cfg.Insecure = args.insecure
 	cfg.FedRAMP = fedramp.Enabled()
+	if cmd.Flags().Changed("ca-file") {
+		if args.caFile != "" {
+			args.caFile, err = config.NormalizeCAFile(args.caFile)
+			if err != nil {
+				return err
+			}
+		}
+		cfg.CAFile = args.caFile
+	}
+	if cmd.Flags().Changed("proxy-url") {
+		if args.proxyURL != "" {
+			err = config.ValidateProxyURL(args.proxyURL)
+			if err != nil {
+				return err
+			}
+		}
+		cfg.ProxyURL = args.proxyURL
+	}
+	if cmd.Flags().Changed("no-proxy") {
+		cfg.NoProxy = args.noProxy
+	}
 
 	if token != "" {
 		if config.IsEncryptedToken(token) {
@@ -449,7 +499,11 @@ func runWithRuntime(r *rosa.Runtime, cmd *cobra.Command, argv []string) error {
 		}
 	}
 
-	r.Reporter.Infof("Logged in as '%s' on '%s'", username, cfg.URL)
+	if cfg.Context() != "" {
+		r.Reporter.Infof("Logged in as '%s' on '%s' in context '%s'", username, cfg.URL, cfg.Context())
+	} else {
+		r.Reporter.Infof("Logged in as '%s' on '%s'", username, cfg.URL)
+	}
 	r.OCMClient.LogEvent("ROSALoginSuccess", map[string]string{
 		ocm.Response: ocm.Success,
 		ocm.Username: username,
@@ -28,17 +28,41 @@ import (
 var Cmd = &cobra.Command{
 	Use:   "logout",
 	Short: "Log out",
-	Long:  "Log out, removing the configuration file.",
-	Run:   run,
-	Args:  cobra.NoArgs,
+	Long: "Log out, removing the configuration file. If login contexts are in use only the " +
+		"current context, or the one selected with '--context', is removed.",
+	Run:  run,
+	Args: cobra.NoArgs,
 }
 
 func run(_ *cobra.Command, _ []string) {
 	reporter := rprtr.CreateReporter()
+	// Remove only the selected login context if there is one, and keep the rest of the contexts:
+	cfg, err := config.Load()
+	if err == nil && cfg != nil && cfg.Context() != "" {
+		if _, ok := cfg.Contexts[cfg.Context()]; !ok {
+			reporter.Errorf("Context '%s' doesn't exist", cfg.Context())
+			os.Exit(rprtr.ExitCode())
+		}
+		err = config.DeleteContext(cfg.Context())
+		if err != nil {
+			reporter.Errorf("Failed to remove context '%s': %v", cfg.Context(), err)
+			os.Exit(rprtr.ExitCode())
+		}
+		return
+	}
+	if err == nil && cfg != nil && len(cfg.Contexts) > 0 {
+		err = config.Save(&config.Config{Contexts: cfg.Contexts})
+		if err != nil {
+			reporter.Errorf("Failed to save config file: %v", err)
+			os.Exit(rprtr.ExitCode())
+		}
+		return
+	}
+
 	// Remove the configuration file:
-	err := config.Remove()
+	err = config.Remove()
 	if err != nil {
 		reporter.Errorf("Failed to remove config file: %v", err)
-		os.Exit(1)
+		os.Exit(rprtr.ExitCode())
 	}
 }
@@ -28,6 +28,7 @@ import (
 	errors "github.com/zgalor/weberr"
 
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -90,7 +91,7 @@ func run(cmd *cobra.Command, argv []string) {
 	cluster := r.FetchCluster()
 	if cluster.State() != cmv1.ClusterStateUninstalling && !watch {
 		r.Reporter.Warnf("Cluster '%s' is not currently uninstalling", clusterKey)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	if cluster.State() == cmv1.ClusterStateInstalling ||
@@ -99,7 +100,7 @@ func run(cmd *cobra.Command, argv []string) {
 		r.Reporter.Errorf("Cluster '%s' is in '%s' state and no uninstallation logs are available",
 			clusterKey, cluster.State(),
 		)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	// Get logs from Hive
@@ -109,7 +110,7 @@ func run(cmd *cobra.Command, argv []string) {
 			r.Reporter.Warnf("Logs for cluster '%s' are not available", clusterKey)
 		} else {
 			r.Reporter.Errorf("Failed to get logs for cluster '%s': %v", clusterKey, err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 	printLog(logs, nil)
@@ -134,16 +135,16 @@ func run(cmd *cobra.Command, argv []string) {
 
 			err = r.OCMClient.KeepTokensAlive()
 			if err != nil {
-				r.Reporter.Errorf(fmt.Sprintf("Failed to keep tokens alive for polling: %v", err))
-				os.Exit(1)
+				r.Reporter.Errorf("Failed to keep tokens alive for polling: %v", err)
+				os.Exit(reporter.ExitCode())
 			}
 
 			return false
 		})
 		if err != nil {
 			if errors.GetType(err) != errors.NotFound {
-				r.Reporter.Errorf(fmt.Sprintf("Failed to watch logs for cluster '%s': %v", clusterKey, err))
-				os.Exit(1)
+				r.Reporter.Errorf("Failed to watch logs for cluster '%s': %v", clusterKey, err)
+				os.Exit(reporter.ExitCode())
 			}
 		}
 		printLog(response, spin)
@@ -0,0 +1,41 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plugin
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/cmd/plugin/list"
+)
+
+func NewPluginCommand() *cobra.Command {
+	Cmd := &cobra.Command{
+		Use:   "plugin COMMAND",
+		Short: "Inspect the installed plugins",
+		Long: "Inspect the plugins, which are executables named 'rosa-<name>' in the PATH that run as " +
+			"'rosa <name>'.\n\n" +
+			"Plugins receive the active OCM URL and a fresh access token, the AWS profile and region " +
+			"and the debug flag in the ROSA_OCM_URL, ROSA_OCM_TOKEN, ROSA_AWS_PROFILE, " +
+			"ROSA_AWS_REGION and ROSA_DEBUG environment variables. Global flags, like '--debug', " +
+			"'--profile' or '--region', must precede the name of the plugin.",
+		Args: cobra.NoArgs,
+	}
+	Cmd.AddCommand(list.Cmd)
+	return Cmd
+}
+
+var Cmd = NewPluginCommand()
@@ -0,0 +1,82 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package list
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/plugin"
+	"github.com/openshift/rosa/pkg/reporter"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var Cmd = NewListPluginsCommand()
+
+func NewListPluginsCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the plugins found in the PATH",
+		Long: "Lists the executables named 'rosa-<name>' found in the PATH, in the order that they are " +
+			"searched, and warns about the ones that can't run.",
+		Example: `  # List the installed plugins
+  rosa plugin list`,
+		Args: cobra.NoArgs,
+		Run:  run,
+	}
+	output.AddFlag(cmd)
+	output.RegisterTablePrinter(printPlugins)
+	return cmd
+}
+
+func run(cmd *cobra.Command, _ []string) {
+	r := rosa.NewRuntime()
+
+	plugins := plugin.Find(cmd.Root())
+
+	var err error
+	if output.HasFlag() {
+		err = output.Print(plugins)
+	} else if len(plugins) == 0 {
+		r.Reporter.Infof("There are no plugins in the PATH")
+	} else {
+		err = output.PrintTable(plugins)
+		for _, found := range plugins {
+			for _, warning := range found.Warnings {
+				r.Reporter.Warnf("Plugin '%s' can't run: %s", found.Path, warning)
+			}
+		}
+	}
+	if err != nil {
+		r.Reporter.Errorf("%v", err)
+		os.Exit(reporter.ExitCode())
+	}
+}
+
+func printPlugins(plugins []*plugin.Plugin, writer io.Writer) error {
+	table := tabwriter.NewWriter(writer, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(table, "NAME\tPATH\n")
+	for _, found := range plugins {
+		fmt.Fprintf(table, "%s\t%s\n", found.Name, found.Path)
+	}
+	return table.Flush()
+}
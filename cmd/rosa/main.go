@@ -22,19 +22,26 @@ import (
 	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 
+	"github.com/openshift/rosa/cmd/apply"
 	"github.com/openshift/rosa/cmd/attach"
+	"github.com/openshift/rosa/cmd/cache"
+	"github.com/openshift/rosa/cmd/collect"
 	"github.com/openshift/rosa/cmd/completion"
 	"github.com/openshift/rosa/cmd/config"
 	"github.com/openshift/rosa/cmd/create"
 	"github.com/openshift/rosa/cmd/describe"
 	"github.com/openshift/rosa/cmd/detach"
+	"github.com/openshift/rosa/cmd/diagnose"
+	"github.com/openshift/rosa/cmd/diff"
 	"github.com/openshift/rosa/cmd/dlt"
 	"github.com/openshift/rosa/cmd/docs"
 	"github.com/openshift/rosa/cmd/download"
 	"github.com/openshift/rosa/cmd/edit"
 	"github.com/openshift/rosa/cmd/grant"
 	"github.com/openshift/rosa/cmd/hibernate"
+	"github.com/openshift/rosa/cmd/history"
 	"github.com/openshift/rosa/cmd/initialize"
 	"github.com/openshift/rosa/cmd/install"
 	"github.com/openshift/rosa/cmd/link"
@@ -42,6 +49,7 @@ import (
 	"github.com/openshift/rosa/cmd/login"
 	"github.com/openshift/rosa/cmd/logout"
 	"github.com/openshift/rosa/cmd/logs"
+	plugincmd "github.com/openshift/rosa/cmd/plugin"
 	"github.com/openshift/rosa/cmd/register"
 	"github.com/openshift/rosa/cmd/resume"
 	"github.com/openshift/rosa/cmd/revoke"
@@ -51,11 +59,19 @@ import (
 	"github.com/openshift/rosa/cmd/upgrade"
 	"github.com/openshift/rosa/cmd/verify"
 	"github.com/openshift/rosa/cmd/version"
+	"github.com/openshift/rosa/cmd/wait"
 	"github.com/openshift/rosa/cmd/whoami"
 	"github.com/openshift/rosa/pkg/arguments"
+	"github.com/openshift/rosa/pkg/cassette"
 	"github.com/openshift/rosa/pkg/color"
 	"github.com/openshift/rosa/pkg/info"
+	"github.com/openshift/rosa/pkg/interactive"
+	"github.com/openshift/rosa/pkg/journal"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/plugin"
 	"github.com/openshift/rosa/pkg/reporter"
+	"github.com/openshift/rosa/pkg/rosa"
+	"github.com/openshift/rosa/pkg/trace"
 	versionUtils "github.com/openshift/rosa/pkg/version"
 )
 
@@ -65,7 +81,7 @@ var root = &cobra.Command{
 	Long: "Command line tool for Red Hat OpenShift Service on AWS.\n" +
 		"For further documentation visit " +
 		"https://access.redhat.com/documentation/en-us/red_hat_openshift_service_on_aws\n",
-	PersistentPreRun: versionCheck,
+	PersistentPreRun: persistentPreRun,
 	Args:             cobra.NoArgs,
 }
 
@@ -74,28 +90,45 @@ func init() {
 	fs := root.PersistentFlags()
 	color.AddFlag(root)
 	arguments.AddDebugFlag(fs)
+	arguments.AddContextFlag(fs)
+	arguments.AddTimeoutFlag(fs)
+	arguments.AddErrorFormatFlag(fs)
+	arguments.AddCassetteFlags(fs)
+	arguments.AddNoCacheFlag(fs)
+	arguments.AddJournalFileFlag(fs)
+	arguments.AddTraceFileFlag(fs)
+	arguments.AddRetryFlags(fs)
+	interactive.AddAnswersFlags(fs)
 
 	// Register the subcommands:
+	root.AddCommand(apply.Cmd)
+	root.AddCommand(cache.Cmd)
+	root.AddCommand(collect.Cmd)
 	root.AddCommand(completion.Cmd)
 	root.AddCommand(create.Cmd)
 	root.AddCommand(describe.Cmd)
+	root.AddCommand(diagnose.Cmd)
+	root.AddCommand(diff.Cmd)
 	root.AddCommand(dlt.Cmd)
 	root.AddCommand(docs.Cmd)
 	root.AddCommand(download.Cmd)
 	root.AddCommand(edit.Cmd)
 	root.AddCommand(grant.Cmd)
+	root.AddCommand(history.Cmd)
 	root.AddCommand(list.Cmd)
 	root.AddCommand(initialize.Cmd)
 	root.AddCommand(install.Cmd)
 	root.AddCommand(login.Cmd)
 	root.AddCommand(logout.Cmd)
 	root.AddCommand(logs.Cmd)
+	root.AddCommand(plugincmd.Cmd)
 	root.AddCommand(register.Cmd)
 	root.AddCommand(revoke.Cmd)
 	root.AddCommand(uninstall.Cmd)
 	root.AddCommand(upgrade.Cmd)
 	root.AddCommand(verify.Cmd)
 	root.AddCommand(version.NewRosaVersionCommand())
+	root.AddCommand(wait.Cmd)
 	root.AddCommand(whoami.Cmd)
 	root.AddCommand(hibernate.GenerateCommand())
 	root.AddCommand(resume.GenerateCommand())
@@ -105,18 +138,108 @@ func init() {
 	root.AddCommand(config.Cmd)
 	root.AddCommand(attach.NewRosaAttachCommand())
 	root.AddCommand(detach.NewRosaDetachCommand())
+
+	// Complete the names of server-side resources from live data:
+	ocm.RegisterCompletions(root)
+
+	// Only errors in the command line exit with the invalid usage exit code:
+	markUsageErrors(root)
 }
 
 func main() {
+	// Run the plugin when the command line doesn't start with a built-in command:
+	if code, ok := runPlugin(os.Args[1:]); ok {
+		os.Exit(code)
+	}
+
 	// Execute the root command:
 	root.SetArgs(os.Args[1:])
 	err := root.Execute()
+	if traceErr := trace.Stop(err); traceErr != nil {
+		reporter.CreateReporter().Warnf("Failed to write the trace file: %v", traceErr)
+	}
 	if err != nil {
-		if !strings.Contains(err.Error(), "Did you mean this?") {
+		if reporter.IsJSONErrorFormat() {
+			reporter.CreateReporter().CodedErrorf(errorCode(err), "Failed to execute root command: %s", err)
+		} else if !strings.Contains(err.Error(), "Did you mean this?") {
 			fmt.Fprintf(os.Stderr, "Failed to execute root command: %s\n", err)
 		}
-		os.Exit(1)
+		os.Exit(exitCode(err))
+	}
+}
+
+// runPlugin runs the plugin named by the first words of the command line, if they aren't a built-in
+// command. The global flags that precede the name of the plugin apply to the environment passed to
+// it, and the rest of the command line is passed as its arguments.
+func runPlugin(argv []string) (int, bool) {
+	flags := pflag.NewFlagSet("plugin", pflag.ContinueOnError)
+	flags.AddFlagSet(root.PersistentFlags())
+	arguments.AddProfileFlag(flags)
+	arguments.AddRegionFlag(flags)
+	flagArgs, rest := plugin.SplitFlags(flags, argv)
+	if len(rest) == 0 || plugin.Builtin(root, rest[0]) {
+		return 0, false
+	}
+	path, args, found := plugin.Lookup(rest)
+	if !found || flags.Parse(flagArgs) != nil {
+		return 0, false
+	}
+	r, err := rosa.NewRuntimeE(rosa.RuntimeOptions{})
+	if err != nil {
+		reporter.CreateReporter().Errorf("%v", err)
+		return reporter.ExitCode(), true
+	}
+	env := plugin.Environment(r)
+	r.Cleanup()
+	code, err := plugin.Run(path, args, env)
+	if err != nil {
+		r.Reporter.Errorf("Failed to run plugin '%s': %v", path, err)
+		return reporter.ExitCode(), true
 	}
+	return code, true
+}
+
+func persistentPreRun(cmd *cobra.Command, argv []string) {
+	for _, validate := range []func() error{reporter.ValidateErrorFormat, cassette.ValidateFlags} {
+		err := validate()
+		if err != nil {
+			reporter.CreateReporter().CodedErrorf(reporter.ErrorCodeInvalidUsage, "%s", err)
+			os.Exit(reporter.ExitCode())
+		}
+	}
+	err := interactive.StartAnswers(cmd)
+	if err != nil {
+		reporter.CreateReporter().CodedErrorf(reporter.ErrorCodeInvalidUsage, "%s", err)
+		os.Exit(reporter.ExitCode())
+	}
+	err = startTrace(cmd)
+	if err != nil {
+		reporter.CreateReporter().Warnf("Failed to trace the command: %v", err)
+	}
+	// Replayed commands must not send anything to the network, and don't change anything that
+	// should be journaled:
+	if !cassette.Replaying() {
+		span := trace.Begin("Check version")
+		versionCheck(cmd, argv)
+		span.End(nil)
+		err = journal.Start(cmd, argv)
+		if err != nil {
+			reporter.CreateReporter().Warnf("Failed to journal the command: %v", err)
+		}
+	}
+}
+
+// startTrace starts tracing the command, when requested with the '--trace-file' flag. The names of
+// the flags are recorded, but not their values, as they may be secrets.
+func startTrace(cmd *cobra.Command) error {
+	flags := []string{}
+	cmd.Flags().Visit(func(flag *pflag.Flag) {
+		flags = append(flags, flag.Name)
+	})
+	return trace.Start(cmd.CommandPath(), map[string]interface{}{
+		"rosa.command": cmd.CommandPath(),
+		"rosa.flags":   flags,
+	})
 }
 
 func versionCheck(cmd *cobra.Command, _ []string) {
@@ -1,6 +1,9 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -10,6 +13,7 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/spf13/cobra"
 
+	"github.com/openshift/rosa/pkg/reporter"
 	. "github.com/openshift/rosa/pkg/test"
 )
 
@@ -53,6 +57,63 @@ var _ = Describe("ROSA Commands", func() {
 	})
 })
 
+var _ = Describe("Usage errors", func() {
+	var command *cobra.Command
+
+	BeforeEach(func() {
+		command = &cobra.Command{Use: "test"}
+		child := &cobra.Command{
+			Use:  "child",
+			Args: cobra.NoArgs,
+			RunE: func(_ *cobra.Command, _ []string) error {
+				return errors.New("failed to do it")
+			},
+		}
+		child.Flags().String("name", "", "")
+		child.Flags().String("id", "", "")
+		child.MarkFlagRequired("id")
+		command.AddCommand(child)
+		command.SilenceErrors = true
+		command.SilenceUsage = true
+		markUsageErrors(command)
+	})
+
+	execute := func(args ...string) error {
+		command.SetArgs(args)
+		return command.Execute()
+	}
+
+	It("Uses the invalid usage exit code for unknown flags", func() {
+		err := execute("child", "--id=1", "--unknown")
+		Expect(err).To(HaveOccurred())
+		Expect(errorCode(err)).To(Equal(reporter.ErrorCodeInvalidUsage))
+		Expect(exitCode(err)).To(Equal(2))
+	})
+
+	It("Uses the invalid usage exit code for unexpected arguments", func() {
+		err := execute("child", "--id=1", "extra")
+		Expect(err).To(HaveOccurred())
+		Expect(exitCode(err)).To(Equal(2))
+	})
+
+	It("Uses the invalid usage exit code for missing required flags", func() {
+		err := execute("child")
+		Expect(err).To(MatchError(ContainSubstring("required flag(s)")))
+		Expect(exitCode(err)).To(Equal(2))
+	})
+
+	It("Uses the generic exit code for errors returned by commands", func() {
+		err := execute("child", "--id=1")
+		Expect(err).To(MatchError("failed to do it"))
+		Expect(errorCode(err)).To(Equal(reporter.ErrorCodeGeneric))
+		Expect(exitCode(err)).To(Equal(1))
+	})
+
+	It("Classifies the errors returned by commands", func() {
+		Expect(exitCode(fmt.Errorf("failed: %w", context.DeadlineExceeded))).To(Equal(124))
+	})
+})
+
 func assertCommandArgs(command *cobra.Command) {
 	if len(command.Commands()) == 0 {
 		verifier := NewArgVerifier(structureTestDirectory, command)
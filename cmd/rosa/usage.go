@@ -0,0 +1,99 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the functions that tell errors in the command line apart from the errors
+// returned by the commands, so that only the former exit with the invalid usage exit code.
+
+package main
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/reporter"
+)
+
+// usageErrorPrefixes are the prefixes of the errors that cobra returns when validating the command
+// line without calling the flag error or argument validation functions.
+var usageErrorPrefixes = []string{
+	"unknown command ",
+	"required flag(s) ",
+	"if any flags in the group ",
+	"at least one of the flags in the group ",
+}
+
+// usageError is an error caused by an invalid command line, like an unknown flag or a wrong number
+// of arguments.
+type usageError struct {
+	err error
+}
+
+func (e *usageError) Error() string {
+	return e.err.Error()
+}
+
+func (e *usageError) Unwrap() error {
+	return e.err
+}
+
+// markUsageErrors makes the flag parsing and argument validation of the command and all its
+// subcommands return usage errors.
+func markUsageErrors(command *cobra.Command) {
+	command.SetFlagErrorFunc(func(_ *cobra.Command, err error) error {
+		return &usageError{err: err}
+	})
+	if command.Args != nil {
+		validate := command.Args
+		command.Args = func(cmd *cobra.Command, args []string) error {
+			err := validate(cmd, args)
+			if err != nil {
+				return &usageError{err: err}
+			}
+			return nil
+		}
+	}
+	for _, subcommand := range command.Commands() {
+		markUsageErrors(subcommand)
+	}
+}
+
+// errorCode returns the error code used to report an error returned by the root command when it
+// can't be classified from the error itself.
+func errorCode(err error) reporter.ErrorCode {
+	var usage *usageError
+	if errors.As(err, &usage) {
+		return reporter.ErrorCodeInvalidUsage
+	}
+	for _, prefix := range usageErrorPrefixes {
+		if strings.HasPrefix(err.Error(), prefix) {
+			return reporter.ErrorCodeInvalidUsage
+		}
+	}
+	return reporter.ErrorCodeGeneric
+}
+
+// exitCode returns the exit code of the process for an error returned by the root command. Errors
+// in the command line use the invalid usage exit code, and the rest are classified like the errors
+// reported by the commands.
+func exitCode(err error) int {
+	code := errorCode(err)
+	if code != reporter.ErrorCodeGeneric {
+		return code.ExitCode()
+	}
+	return reporter.NewErrorEnvelope(err.Error(), err).ExitCode
+}
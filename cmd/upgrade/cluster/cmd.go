@@ -33,6 +33,7 @@ import (
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/interactive/confirm"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -44,6 +45,9 @@ var args struct {
 	controlPlane             bool
 	schedule                 string
 	allowMinorVersionUpdates bool
+	plan                     bool
+	to                       string
+	execute                  bool
 }
 
 var nodeDrainOptions = []string{
@@ -64,7 +68,13 @@ var Cmd = &cobra.Command{
   rosa upgrade cluster --cluster=mycluster --interactive
 
   # Schedule a cluster upgrade within the hour
-  rosa upgrade cluster -c mycluster --version 4.12.20`,
+  rosa upgrade cluster -c mycluster --version 4.12.20
+
+  # Show the upgrades needed to take the cluster to the latest 4.16 version
+  rosa upgrade cluster -c mycluster --plan --to 4.16
+
+  # Run the upgrades of the plan one after the other
+  rosa upgrade cluster -c mycluster --plan --to 4.16.3 --execute`,
 	Run:  run,
 	Args: cobra.NoArgs,
 }
@@ -83,6 +93,30 @@ func init() {
 		"Version of OpenShift that the cluster will be upgraded to",
 	)
 
+	flags.BoolVar(
+		&args.plan,
+		"plan",
+		false,
+		"Show the sequence of upgrades needed to reach the version given with '--to', with the version "+
+			"gates, role upgrades and machine pool upgrades that each of them requires",
+	)
+
+	flags.StringVar(
+		&args.to,
+		"to",
+		"",
+		"Version of OpenShift that the upgrade plan reaches, for example '4.16.3', or '4.16' or '4.16.x' for "+
+			"the latest version of that minor that can be reached",
+	)
+
+	flags.BoolVar(
+		&args.execute,
+		"execute",
+		false,
+		"Run the upgrades of the plan one after the other, asking for confirmation before each of them "+
+			"and waiting for it to complete. Without a terminal '--yes' is required",
+	)
+
 	flags.StringVar(
 		&args.scheduleDate,
 		"schedule-date",
@@ -146,8 +180,8 @@ func run(cmd *cobra.Command, _ []string) {
 	defer r.Cleanup()
 	err := runWithRuntime(r, cmd)
 	if err != nil {
-		r.Reporter.Errorf(err.Error())
-		os.Exit(1)
+		r.Reporter.Errorf("%v", err)
+		os.Exit(reporter.ExitCode())
 	}
 }
 
@@ -181,6 +215,13 @@ func runWithRuntime(r *rosa.Runtime, cmd *cobra.Command) error {
 		return fmt.Errorf("The '--schedule' option is mutually exclusive with '--version'")
 	}
 
+	if !args.plan && (args.to != "" || args.execute) {
+		return fmt.Errorf("The '--to' and '--execute' options need to be used with '--plan'")
+	}
+	if args.plan {
+		return runPlan(r, cmd, cluster, clusterKey)
+	}
+
 	// Check cluster preconditions
 	if cluster.State() != cmv1.ClusterStateReady {
 		return fmt.Errorf("Cluster '%s' is not yet ready", clusterKey)
@@ -239,7 +280,7 @@ func runWithRuntime(r *rosa.Runtime, cmd *cobra.Command) error {
 		mode, err = interactive.GetOptionMode(cmd, mode, "IAM Roles/Policies upgrade mode")
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid role upgrade mode: %v", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 
@@ -547,7 +588,7 @@ func buildNodeDrainGracePeriod(r *rosa.Runtime, cmd *cobra.Command, cluster *cmv
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid node drain grace period: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 	isValidNodeDrainGracePeriod := false
@@ -560,13 +601,13 @@ func buildNodeDrainGracePeriod(r *rosa.Runtime, cmd *cobra.Command, cluster *cmv
 	if !isValidNodeDrainGracePeriod {
 		r.Reporter.Errorf("Expected a valid node drain grace period. Options are [%s]",
 			strings.Join(nodeDrainOptions, ", "))
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	nodeDrainParsed := strings.Split(nodeDrainGracePeriod, " ")
 	nodeDrainValue, err := strconv.ParseFloat(nodeDrainParsed[0], commonUtils.MaxByteSize)
 	if err != nil {
 		r.Reporter.Errorf("Expected a valid node drain grace period: %s", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	if nodeDrainParsed[1] == "hours" || nodeDrainParsed[1] == "hour" {
 		nodeDrainValue = nodeDrainValue * 60
@@ -265,6 +265,62 @@ var _ = Describe("Upgrade", Ordered, func() {
 		Expect(err.Error()).To(
 			ContainSubstring("node-drain-grace-period flag is not supported to hosted clusters"))
 	})
+	It("Fails if the plan options are used without '--plan'", func() {
+		args.schedule = ""
+		args.to = "4.14"
+		testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, hypershiftClusterReady))
+		err := runWithRuntime(testRuntime.RosaRuntime, Cmd)
+		Expect(err).ToNot(BeNil())
+		Expect(err.Error()).To(ContainSubstring("The '--to' and '--execute' options need to be used with '--plan'"))
+	})
+	It("Prints the upgrade plan", func() {
+		args.plan = true
+		args.to = "4.13.1"
+		testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, hypershiftClusterReadyWithUpdates))
+		// GET - /api/clusters_mgmt/v1/versions
+		testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, `{"kind": "VersionList", "page": 1,
+			"size": 1, "total": 1, "items": [{"kind": "Version", "id": "openshift-v4.13.1", "raw_id": "4.13.1",
+			"hosted_control_plane_enabled": true}]}`))
+		// GET - /api/clusters_mgmt/v1/clusters/24vf9iitg3p6tlml88iml6j6mu095mh8/node_pools
+		testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, `{"kind": "NodePoolList", "page": 1,
+			"size": 0, "total": 0, "items": []}`))
+		// POST -
+		// /api/clusters_mgmt/v1/clusters/24vf9iitg3p6tlml88iml6j6mu095mh8/control_plane/upgrade_policies?dryRun=true
+		testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusNoContent, ""))
+		stdout, _, err := test.RunWithOutputCapture(runWithRuntime, testRuntime.RosaRuntime, Cmd)
+		Expect(err).To(BeNil())
+		Expect(stdout).To(Equal("Upgrade plan for cluster '" + mockClusterReadyWithUpgrades.Name() + "' from version " +
+			"'4.13.0' to '4.13.1':\n" +
+			"1. Upgrade to version '4.13.1'\n"))
+		args.plan = false
+		args.to = ""
+	})
+	It("Doesn't execute the upgrade plan without a terminal unless confirmed in advance", func() {
+		args.plan = true
+		args.to = "4.13.1"
+		args.execute = true
+		testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, hypershiftClusterReadyWithUpdates))
+		// GET - /api/clusters_mgmt/v1/versions
+		testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, `{"kind": "VersionList", "page": 1,
+			"size": 1, "total": 1, "items": [{"kind": "Version", "id": "openshift-v4.13.1", "raw_id": "4.13.1",
+			"hosted_control_plane_enabled": true}]}`))
+		// GET - /api/clusters_mgmt/v1/clusters/24vf9iitg3p6tlml88iml6j6mu095mh8/node_pools
+		testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, `{"kind": "NodePoolList", "page": 1,
+			"size": 0, "total": 0, "items": []}`))
+		// POST -
+		// /api/clusters_mgmt/v1/clusters/24vf9iitg3p6tlml88iml6j6mu095mh8/control_plane/upgrade_policies?dryRun=true
+		testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusNoContent, ""))
+		_, _, err := test.RunWithOutputCapture(runWithRuntime, testRuntime.RosaRuntime, Cmd)
+		Expect(err).To(MatchError("The '--execute' option needs '--yes' to run the upgrades without a terminal"))
+		for _, request := range testRuntime.ApiServer.ReceivedRequests() {
+			if request.Method != http.MethodGet {
+				Expect(request.URL.Query().Get("dryRun")).To(Equal("true"))
+			}
+		}
+		args.plan = false
+		args.to = ""
+		args.execute = false
+	})
 })
 
 func formatControlPlaneUpgradePolicyList(upgradePolicies []*cmv1.ControlPlaneUpgradePolicy) string {
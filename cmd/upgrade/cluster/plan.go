@@ -0,0 +1,151 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the '--plan' mode of the 'rosa upgrade cluster' command, which shows the
+// upgrades needed to reach a version that isn't directly available and optionally runs them.
+
+package cluster
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/interactive"
+	"github.com/openshift/rosa/pkg/interactive/confirm"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/rosa"
+	"github.com/openshift/rosa/pkg/upgradeplan"
+	"github.com/openshift/rosa/pkg/wait"
+)
+
+func runPlan(r *rosa.Runtime, cmd *cobra.Command, cluster *cmv1.Cluster, clusterKey string) error {
+	if args.to == "" {
+		return fmt.Errorf("The '--plan' option needs the version to reach with '--to'")
+	}
+	if args.version != "" || args.schedule != "" || args.scheduleDate != "" || args.scheduleTime != "" {
+		return fmt.Errorf("The '--plan' option is mutually exclusive with '--version' and the scheduling " +
+			"options")
+	}
+
+	r.Reporter.Debugf("Calculating the plan to upgrade cluster '%s' to version '%s'", clusterKey, args.to)
+	plan, err := upgradeplan.Build(r, cluster, args.to)
+	if err != nil {
+		return fmt.Errorf("Failed to plan the upgrade of cluster '%s': %v", clusterKey, err)
+	}
+	plan.Print(os.Stdout)
+	if !args.execute {
+		return nil
+	}
+
+	if cluster.State() != cmv1.ClusterStateReady {
+		return fmt.Errorf("Cluster '%s' is not yet ready", clusterKey)
+	}
+	// Each step is confirmed in the terminal, otherwise the upgrades need to be confirmed in advance:
+	if !r.Reporter.IsTerminal() && !confirm.Yes() {
+		return fmt.Errorf("The '--execute' option needs '--yes' to run the upgrades without a terminal")
+	}
+	mode, err := interactive.GetMode()
+	if err != nil {
+		return err
+	}
+	_, isSTS := cluster.AWS().STS().GetRoleARN()
+	if isSTS && mode == "" {
+		mode, err = interactive.GetOptionMode(cmd, mode, "IAM Roles/Policies upgrade mode")
+		if err != nil {
+			return fmt.Errorf("Expected a valid role upgrade mode: %v", err)
+		}
+	}
+
+	for i, hop := range plan.Hops {
+		if i > 0 {
+			cluster, err = r.OCMClient.GetCluster(cluster.ID(), r.Creator)
+			if err != nil {
+				return fmt.Errorf("Failed to get cluster '%s': %v", clusterKey, err)
+			}
+		}
+		err = checkNodePoolConstraints(r, cluster, hop.Version)
+		if err != nil {
+			return err
+		}
+		if r.Reporter.IsTerminal() && !confirm.Confirm("upgrade cluster to version '%s' (step %d of %d)",
+			hop.Version, i+1, len(plan.Hops)) {
+			return nil
+		}
+		if isSTS {
+			checkSTSRolesCompatibility(r, cluster, mode, hop.Version, clusterKey)
+		}
+		if cluster.Hypershift().Enabled() {
+			scheduling := ocm.UpgradeScheduling{}
+			scheduling.NextRun, err = interactive.BuildManualUpgradeSchedule(cmd, "", "")
+			if err == nil {
+				err = createUpgradePolicyHypershift(r, clusterKey, cluster, hop.Version, scheduling)
+			}
+		} else {
+			err = createUpgradePolicyClassic(r, cmd, clusterKey, cluster, hop.Version, "", "")
+		}
+		if err != nil {
+			return fmt.Errorf("Failed to schedule upgrade for cluster '%s': %v", clusterKey, err)
+		}
+		r.Reporter.Infof("Upgrade of cluster '%s' to version '%s' scheduled, waiting for it to complete",
+			clusterKey, hop.Version)
+		err = wait.Until(r, fmt.Sprintf("the upgrade of cluster '%s' to version '%s'", clusterKey,
+			hop.Version), upgradeReached(r, cluster, hop.Version))
+		if err != nil {
+			return err
+		}
+		r.Reporter.Infof("Cluster '%s' upgraded to version '%s'", clusterKey, hop.Version)
+	}
+	return nil
+}
+
+// checkNodePoolConstraints returns an error if the control plane of a hosted cluster can't be
+// upgraded to the given version before some of its machine pools are upgraded.
+func checkNodePoolConstraints(r *rosa.Runtime, cluster *cmv1.Cluster, version string) error {
+	if !cluster.Hypershift().Enabled() {
+		return nil
+	}
+	nodePools, err := r.OCMClient.GetNodePools(cluster.ID())
+	if err != nil {
+		return fmt.Errorf("Failed to get machine pools of cluster '%s': %v", cluster.Name(), err)
+	}
+	constraints := upgradeplan.NodePoolConstraints(nodePools, version)
+	if len(constraints) > 0 {
+		return fmt.Errorf("The machine pools of cluster '%s' are too old for version '%s':\n%s",
+			cluster.Name(), version, strings.Join(constraints, "\n"))
+	}
+	return nil
+}
+
+// upgradeReached is met when the upgrade of the cluster has completed and the cluster reports the
+// given version.
+func upgradeReached(r *rosa.Runtime, cluster *cmv1.Cluster, version string) wait.Condition {
+	upgradeCompleted := wait.UpgradeCompleted(r, cluster)
+	return func() (bool, error) {
+		done, err := upgradeCompleted()
+		if err != nil || !done {
+			return false, err
+		}
+		current, err := r.OCMClient.GetCluster(cluster.ID(), r.Creator)
+		if err != nil {
+			return false, err
+		}
+		return upgradeplan.ClusterVersion(current) == version, nil
+	}
+}
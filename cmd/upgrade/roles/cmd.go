@@ -131,7 +131,7 @@ func run(cmd *cobra.Command, argv []string) {
 	mode, err := interactive.GetMode()
 	if err != nil {
 		reporter.Errorf("%s", err)
-		os.Exit(1)
+		os.Exit(rprtr.ExitCode())
 	}
 
 	clusterUpgradeVersion := args.clusterUpgradeVersion
@@ -139,7 +139,7 @@ func run(cmd *cobra.Command, argv []string) {
 	availableUpgrades, err := r.OCMClient.GetAvailableUpgrades(ocm.GetVersionID(cluster))
 	if err != nil {
 		r.Reporter.Errorf("Failed to find available upgrades: %v", err)
-		os.Exit(1)
+		os.Exit(rprtr.ExitCode())
 	}
 	if len(availableUpgrades) == 0 {
 		r.Reporter.Warnf("There are no available upgrades")
@@ -148,13 +148,13 @@ func run(cmd *cobra.Command, argv []string) {
 	err = ocmClient.CheckUpgradeClusterVersion(availableUpgrades, clusterUpgradeVersion, cluster)
 	if err != nil {
 		reporter.Errorf("%v", err)
-		os.Exit(1)
+		os.Exit(rprtr.ExitCode())
 	}
 
 	env, err := ocm.GetEnv()
 	if err != nil {
 		reporter.Errorf("Failed to determine OCM environment: %v", err)
-		os.Exit(1)
+		os.Exit(rprtr.ExitCode())
 	}
 
 	managedPolicies := cluster.AWS().STS().ManagedPolicies()
@@ -162,13 +162,13 @@ func run(cmd *cobra.Command, argv []string) {
 	unifiedPath, err := aws.GetPathFromAccountRole(cluster, aws.AccountRoles[aws.InstallerAccountRole].Name)
 	if err != nil {
 		r.Reporter.Errorf("Expected a valid path for '%s': %v", cluster.AWS().STS().RoleARN(), err)
-		os.Exit(1)
+		os.Exit(rprtr.ExitCode())
 	}
 
 	credRequests, err := ocmClient.GetCredRequests(cluster.Hypershift().Enabled())
 	if err != nil {
 		r.Reporter.Errorf("Error getting operator credential request from OCM %s", err)
-		os.Exit(1)
+		os.Exit(rprtr.ExitCode())
 	}
 
 	// Determine if interactive mode is needed
@@ -181,7 +181,7 @@ func run(cmd *cobra.Command, argv []string) {
 		mode, err = interactive.GetOptionMode(cmd, mode, "Roles upgrade mode")
 		if err != nil {
 			r.Reporter.Errorf("expected a valid Account role upgrade mode: %s", err)
-			os.Exit(1)
+			os.Exit(rprtr.ExitCode())
 		}
 	}
 
@@ -190,7 +190,7 @@ func run(cmd *cobra.Command, argv []string) {
 			r, cluster, credRequests, unifiedPath, mode, clusterUpgradeVersion)
 		if err != nil {
 			r.Reporter.Errorf("%s", err)
-			os.Exit(1)
+			os.Exit(rprtr.ExitCode())
 		}
 		r.Reporter.Infof("Cluster '%s' operator roles have attached managed policies. "+
 			"An upgrade isn't needed", cluster.Name())
@@ -203,19 +203,19 @@ func run(cmd *cobra.Command, argv []string) {
 	policyVersion, err = ocmClient.GetPolicyVersion(policyVersion, channelGroup)
 	if err != nil {
 		reporter.Errorf("Error getting version: %s", err)
-		os.Exit(1)
+		os.Exit(rprtr.ExitCode())
 	}
 
 	err = checkPolicyAndClusterVersionCompatibility(policyVersion, clusterUpgradeVersion)
 	if err != nil {
 		reporter.Errorf("%v", err)
-		os.Exit(1)
+		os.Exit(rprtr.ExitCode())
 	}
 
 	creator, err := awsClient.GetCreator()
 	if err != nil {
 		reporter.Errorf("Failed to get IAM credentials: %s", err)
-		os.Exit(1)
+		os.Exit(rprtr.ExitCode())
 	}
 
 	var spin *spinner.Spinner
@@ -239,7 +239,7 @@ func run(cmd *cobra.Command, argv []string) {
 	if err != nil {
 		reporter.Errorf("%s", err)
 		LogError(roles.RosaUpgradeAccRolesModeAuto, ocmClient, policyVersion, err, reporter)
-		os.Exit(1)
+		os.Exit(rprtr.ExitCode())
 	}
 
 	if spin != nil {
@@ -249,16 +249,16 @@ func run(cmd *cobra.Command, argv []string) {
 	rolePolicyBindings, err := ocmClient.ListRolePolicyBindings(cluster.ID(), true)
 	if err != nil {
 		if strings.Contains(err.Error(), ArbitraryPolicyNotAvail) {
-			reporter.Debugf(err.Error())
+			reporter.Debugf("%v", err)
 		} else {
 			reporter.Errorf("Failed to get rolePolicyBinding: %s", err)
-			os.Exit(1)
+			os.Exit(rprtr.ExitCode())
 		}
 	} else {
 		err = rolepolicybindings.CheckRolePolicyBindingStatus(rolePolicyBindings)
 		if err != nil {
 			reporter.Errorf("Error in rolePolicyBinding: %s", err)
-			os.Exit(1)
+			os.Exit(rprtr.ExitCode())
 		}
 	}
 
@@ -273,7 +273,7 @@ func run(cmd *cobra.Command, argv []string) {
 		accountRolePolicies, err := ocmClient.GetPolicies("")
 		if err != nil {
 			reporter.Errorf("Expected a valid role creation mode: %s", err)
-			os.Exit(1)
+			os.Exit(rprtr.ExitCode())
 		}
 
 		switch mode {
@@ -299,10 +299,10 @@ func run(cmd *cobra.Command, argv []string) {
 						if reporter.IsTerminal() {
 							reporter.Infof(generateClusterUpgradeInfo(clusterKey, args.clusterUpgradeVersion, mode))
 						}
-						os.Exit(1)
+						os.Exit(rprtr.ExitCode())
 					}
 					reporter.Errorf("Error upgrading the account role policies: %s", err)
-					os.Exit(1)
+					os.Exit(rprtr.ExitCode())
 				}
 			}
 		case interactive.ModeManual:
@@ -311,7 +311,7 @@ func run(cmd *cobra.Command, argv []string) {
 					aws.AccountRoles, creator.Partition)
 				if err != nil {
 					reporter.Errorf("There was an error generating the policy files: %s", err)
-					os.Exit(1)
+					os.Exit(rprtr.ExitCode())
 				}
 			}
 			if reporter.IsTerminal() {
@@ -331,13 +331,13 @@ func run(cmd *cobra.Command, argv []string) {
 			)
 			if err != nil {
 				reporter.Errorf("%s", err)
-				os.Exit(1)
+				os.Exit(rprtr.ExitCode())
 			}
 
 			fmt.Println(commands)
 		default:
 			reporter.Errorf("Invalid mode. Allowed values are %s", interactive.Modes)
-			os.Exit(1)
+			os.Exit(rprtr.ExitCode())
 		}
 	}
 
@@ -354,13 +354,13 @@ func run(cmd *cobra.Command, argv []string) {
 	if !hasOperatorRoles || len(operatorRoles) == 0 {
 		r.Reporter.Errorf("Cluster '%s' doesn't have any operator roles associated with it",
 			r.ClusterKey)
-		os.Exit(1)
+		os.Exit(rprtr.ExitCode())
 	}
 
 	operatorRolePolicyPrefix, err := aws.GetOperatorRolePolicyPrefixFromCluster(cluster, r.AWSClient)
 	if err != nil {
 		r.Reporter.Errorf("Error getting operator role policy prefix: %s", err)
-		os.Exit(1)
+		os.Exit(rprtr.ExitCode())
 	}
 
 	isOperatorPolicyUpgradeNeeded := false
@@ -374,13 +374,13 @@ func run(cmd *cobra.Command, argv []string) {
 	)
 	if err != nil {
 		r.Reporter.Errorf("%s", err)
-		os.Exit(1)
+		os.Exit(rprtr.ExitCode())
 	}
 
 	missingRolesInCS, err := ocmClient.FindMissingOperatorRolesForUpgrade(cluster, clusterUpgradeVersion, credRequests)
 	if err != nil {
 		r.Reporter.Errorf("Error finding operator roles for upgrade '%s'", err)
-		os.Exit(1)
+		os.Exit(rprtr.ExitCode())
 	}
 
 	if spin != nil {
@@ -401,7 +401,7 @@ func run(cmd *cobra.Command, argv []string) {
 	operatorRolePolicies, err := ocmClient.GetPolicies("OperatorRole")
 	if err != nil {
 		r.Reporter.Errorf("Expected a valid role creation mode: %s", err)
-		os.Exit(1)
+		os.Exit(rprtr.ExitCode())
 	}
 
 	if isOperatorPolicyUpgradeNeeded {
@@ -422,7 +422,7 @@ func run(cmd *cobra.Command, argv []string) {
 			if args.isInvokedFromClusterUpgrade && reporter.IsTerminal() {
 				reporter.Infof(generateClusterUpgradeInfo(clusterKey, args.clusterUpgradeVersion, mode))
 			}
-			os.Exit(1)
+			os.Exit(rprtr.ExitCode())
 		}
 	}
 
@@ -433,7 +433,7 @@ func run(cmd *cobra.Command, argv []string) {
 			exists, _, err := r.AWSClient.CheckRoleExists(roleName)
 			if err != nil {
 				r.Reporter.Errorf("Error when detecting checking missing operator IAM roles %s", err)
-				os.Exit(1)
+				os.Exit(rprtr.ExitCode())
 			}
 			if !exists {
 				err = createOperatorRole(
@@ -451,7 +451,7 @@ func run(cmd *cobra.Command, argv []string) {
 					if args.isInvokedFromClusterUpgrade && reporter.IsTerminal() {
 						reporter.Infof(generateClusterUpgradeInfo(clusterKey, args.clusterUpgradeVersion, mode))
 					}
-					os.Exit(1)
+					os.Exit(rprtr.ExitCode())
 				}
 				createdMissingRoles++
 			}
@@ -1031,7 +1031,7 @@ func createOperatorRole(
 		fmt.Println(commands)
 	default:
 		r.Reporter.Errorf("Invalid mode. Allowed values are %s", interactive.Modes)
-		os.Exit(1)
+		os.Exit(rprtr.ExitCode())
 	}
 	return nil
 }
@@ -17,6 +17,7 @@ limitations under the License.
 package network
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -32,6 +33,7 @@ import (
 	"github.com/openshift/rosa/pkg/helper"
 	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -143,8 +145,8 @@ func run(cmd *cobra.Command, _ []string) {
 	defer r.Cleanup()
 	err := runWithRuntime(r, cmd)
 	if err != nil {
-		r.Reporter.Errorf(err.Error())
-		os.Exit(1)
+		r.Reporter.Errorf("%v", err)
+		os.Exit(reporter.ExitCode())
 	}
 }
 
@@ -263,7 +265,15 @@ func runWithRuntime(r *rosa.Runtime, cmd *cobra.Command) error {
 			}
 
 			if len(args.subnetIDs) > 0 {
-				time.Sleep(delay)
+				select {
+				case <-r.Context.Done():
+					if spin != nil {
+						spin.Stop()
+					}
+					return fmt.Errorf("Stopped waiting for the network verification: %v",
+						context.Cause(r.Context))
+				case <-time.After(delay):
+				}
 			}
 		}
 
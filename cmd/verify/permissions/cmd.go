@@ -25,6 +25,7 @@ import (
 
 	"github.com/openshift/rosa/pkg/arguments"
 	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -57,7 +58,7 @@ func run(_ *cobra.Command, _ []string) {
 	region, err := aws.GetRegion(arguments.GetRegion())
 	if err != nil {
 		r.Reporter.Errorf("Error getting region: %v", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	// Create the AWS client:
@@ -71,7 +72,7 @@ func run(_ *cobra.Command, _ []string) {
 			r.OCMClient.LogEvent("ROSAInitCredentialsSTS", nil)
 		}
 		r.Reporter.Errorf("Error creating AWS client: %v", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	r.Reporter.Infof("Verifying permissions for non-STS clusters")
@@ -79,7 +80,7 @@ func run(_ *cobra.Command, _ []string) {
 	policies, err := r.OCMClient.GetPolicies("OSDSCPPolicy")
 	if err != nil {
 		r.Reporter.Errorf("Failed to get 'osdscppolicy' for '%s': %v", aws.AdminUserName, err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	ok, err := r.AWSClient.ValidateSCP(nil, policies)
 	if err != nil {
@@ -88,10 +89,10 @@ func run(_ *cobra.Command, _ []string) {
 			"SCP is not preventing this account from performing the required checks")
 		if strings.Contains(err.Error(), "Throttling: Rate exceeded") {
 			r.Reporter.Errorf("Throttling: Rate exceeded. Please wait 3-5 minutes before retrying.")
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		r.Reporter.Errorf("%v", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	if !ok {
 		r.OCMClient.LogEvent("ROSAVerifyPermissionsSCPInvalid", nil)
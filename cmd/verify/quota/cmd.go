@@ -25,6 +25,7 @@ import (
 
 	"github.com/openshift/rosa/pkg/arguments"
 	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -56,7 +57,7 @@ func run(_ *cobra.Command, _ []string) {
 	region, err := aws.GetRegion(arguments.GetRegion())
 	if err != nil {
 		r.Reporter.Errorf("Error getting region: %v", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	// Create the AWS client:
@@ -70,7 +71,7 @@ func run(_ *cobra.Command, _ []string) {
 			r.OCMClient.LogEvent("ROSAInitCredentialsSTS", nil)
 		}
 		r.Reporter.Errorf("Error creating AWS client: %v", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 
 	if r.Reporter.IsTerminal() {
@@ -81,7 +82,7 @@ func run(_ *cobra.Command, _ []string) {
 		r.OCMClient.LogEvent("ROSAVerifyQuotaInsufficient", nil)
 		r.Reporter.Errorf("Insufficient AWS quotas")
 		r.Reporter.Errorf("%v", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	if r.Reporter.IsTerminal() {
 		r.Reporter.Infof("AWS quota ok. " +
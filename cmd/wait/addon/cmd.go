@@ -0,0 +1,86 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package addon
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/reporter"
+	"github.com/openshift/rosa/pkg/rosa"
+	"github.com/openshift/rosa/pkg/wait"
+)
+
+const installed = "installed"
+
+var Cmd = &cobra.Command{
+	Use:     "addon ID",
+	Aliases: []string{"addons", "add-on", "add-ons"},
+	Short:   "Wait for an add-on to be installed on a cluster",
+	Long: "Wait for an add-on to be installed on a cluster. It fails if the installation fails or " +
+		"the add-on is being uninstalled.",
+	Example: `  # Wait for add-on "dbaas-operator" to be installed on cluster "mycluster"
+  rosa wait addon dbaas-operator -c mycluster --for=installed`,
+	Args: cobra.ExactArgs(1),
+	Run:  run,
+}
+
+var args struct {
+	condition string
+}
+
+func init() {
+	flags := Cmd.Flags()
+	ocm.AddClusterFlag(Cmd)
+	flags.StringVar(
+		&args.condition,
+		"for",
+		installed,
+		fmt.Sprintf("Condition to wait for. The only supported condition is '%s'.", installed),
+	)
+}
+
+func run(_ *cobra.Command, argv []string) {
+	r := rosa.NewRuntime()
+	defer r.Cleanup()
+
+	addOnID := argv[0]
+	if args.condition != installed {
+		r.Reporter.CodedErrorf(reporter.ErrorCodeInvalidUsage, "Invalid condition '%s', expected '%s'",
+			args.condition, installed)
+		os.Exit(reporter.ExitCode())
+	}
+	err := wait.ValidateInterval()
+	if err != nil {
+		r.Reporter.CodedErrorf(reporter.ErrorCodeInvalidUsage, "%s", err)
+		os.Exit(reporter.ExitCode())
+	}
+
+	r.WithAWS().WithOCM()
+	clusterKey := r.GetClusterKey()
+	cluster := r.FetchCluster()
+
+	err = wait.For(r, fmt.Sprintf("add-on '%s' to be installed", addOnID),
+		wait.AddOnInstalled(r, cluster, addOnID))
+	if err != nil {
+		os.Exit(reporter.ExitCode())
+	}
+	r.Reporter.Infof("Add-on '%s' is installed on cluster '%s'", addOnID, clusterKey)
+}
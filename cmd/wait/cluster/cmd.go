@@ -0,0 +1,142 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+	errors "github.com/zgalor/weberr"
+
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/reporter"
+	"github.com/openshift/rosa/pkg/rosa"
+	"github.com/openshift/rosa/pkg/wait"
+)
+
+const (
+	statePrefix = "state="
+	deleted     = "deleted"
+)
+
+var Cmd = &cobra.Command{
+	Use:   "cluster [ID|NAME]",
+	Short: "Wait for a cluster to reach a state or to be deleted",
+	Long:  "Wait for a cluster to reach a state or to be deleted.",
+	Example: `  # Wait for cluster "mycluster" to be ready
+  rosa wait cluster mycluster
+
+  # Wait for cluster "mycluster" to be hibernating
+  rosa wait cluster -c mycluster --for=state=hibernating
+
+  # Wait for cluster "mycluster" to be deleted
+  rosa wait cluster mycluster --for=deleted`,
+	Args: cobra.MaximumNArgs(1),
+	Run:  run,
+}
+
+var args struct {
+	condition string
+}
+
+func init() {
+	flags := Cmd.Flags()
+	ocm.AddOptionalClusterFlag(Cmd)
+	flags.StringVar(
+		&args.condition,
+		"for",
+		statePrefix+string(cmv1.ClusterStateReady),
+		fmt.Sprintf("Condition to wait for, either '%s' followed by one of %s, or '%s'.",
+			statePrefix, strings.Join(ocm.ClusterStates, ", "), deleted),
+	)
+	Cmd.RegisterFlagCompletionFunc("for", conditionCompletion)
+}
+
+func conditionCompletion(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	conditions := []string{deleted}
+	for _, state := range ocm.ClusterStates {
+		conditions = append(conditions, statePrefix+state)
+	}
+	return conditions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// parseCondition returns the state given with the '--for' flag, or an empty state when waiting for
+// the cluster to be deleted.
+func parseCondition(condition string) (cmv1.ClusterState, error) {
+	if condition == deleted {
+		return "", nil
+	}
+	state, ok := strings.CutPrefix(condition, statePrefix)
+	if !ok || !slices.Contains(ocm.ClusterStates, state) {
+		return "", fmt.Errorf("Invalid condition '%s', expected '%s' followed by one of %s, or '%s'",
+			condition, statePrefix, strings.Join(ocm.ClusterStates, ", "), deleted)
+	}
+	return cmv1.ClusterState(state), nil
+}
+
+func run(cmd *cobra.Command, argv []string) {
+	r := rosa.NewRuntime()
+	defer r.Cleanup()
+
+	state, err := parseCondition(args.condition)
+	if err == nil {
+		err = wait.ValidateInterval()
+	}
+	if err != nil {
+		r.Reporter.CodedErrorf(reporter.ErrorCodeInvalidUsage, "%s", err)
+		os.Exit(reporter.ExitCode())
+	}
+
+	if len(argv) == 1 && !cmd.Flag("cluster").Changed {
+		ocm.SetClusterKey(argv[0])
+	} else if !cmd.Flag("cluster").Changed {
+		r.Reporter.CodedErrorf(reporter.ErrorCodeInvalidUsage,
+			"The cluster is required, give it as the argument or with the '--cluster' flag")
+		os.Exit(reporter.ExitCode())
+	}
+
+	r.WithAWS().WithOCM()
+	clusterKey := r.GetClusterKey()
+
+	cluster, err := r.OCMClient.GetCluster(clusterKey, r.Creator)
+	if state == "" && errors.GetType(err) == errors.NotFound {
+		r.Reporter.Infof("Cluster '%s' doesn't exist", clusterKey)
+		return
+	}
+	if err != nil {
+		r.Reporter.Errorf("Failed to get cluster '%s': %v", clusterKey, err)
+		os.Exit(reporter.ExitCode())
+	}
+
+	if state == "" {
+		err = wait.For(r, fmt.Sprintf("cluster '%s' to be deleted", clusterKey), wait.ClusterDeleted(r, cluster))
+		if err != nil {
+			os.Exit(reporter.ExitCode())
+		}
+		r.Reporter.Infof("Cluster '%s' has been deleted", clusterKey)
+		return
+	}
+	err = wait.For(r, fmt.Sprintf("cluster '%s' to be %s", clusterKey, state), wait.ClusterState(r, cluster, state))
+	if err != nil {
+		os.Exit(reporter.ExitCode())
+	}
+	r.Reporter.Infof("Cluster '%s' is %s", clusterKey, state)
+}
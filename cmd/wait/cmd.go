@@ -0,0 +1,57 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package wait
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/cmd/wait/addon"
+	"github.com/openshift/rosa/cmd/wait/cluster"
+	"github.com/openshift/rosa/cmd/wait/machinepool"
+	"github.com/openshift/rosa/cmd/wait/upgrade"
+	"github.com/openshift/rosa/pkg/arguments"
+	"github.com/openshift/rosa/pkg/wait"
+)
+
+var Cmd = &cobra.Command{
+	Use:   "wait",
+	Short: "Wait for a condition on a cluster or one of its resources",
+	Long: "Wait until a cluster, machine pool, upgrade or add-on reaches the given condition. The " +
+		"command exits with code 0 when the condition is met, 10 when the resource reaches a state " +
+		"from which it can't be met, like a cluster in the 'error' state, and 124 when the duration " +
+		"given with the '--timeout' flag expires.",
+	Example: `  # Wait up to one hour for cluster "mycluster" to be ready
+  rosa wait cluster mycluster --for=state=ready --timeout=1h
+
+  # Wait for the replicas of machine pool "workers" to be ready, checking every minute
+  rosa wait machinepool workers -c mycluster --interval=1m`,
+	Args: cobra.NoArgs,
+}
+
+func init() {
+	Cmd.AddCommand(cluster.Cmd)
+	Cmd.AddCommand(machinepool.Cmd)
+	Cmd.AddCommand(upgrade.Cmd)
+	Cmd.AddCommand(addon.Cmd)
+
+	flags := Cmd.PersistentFlags()
+	arguments.AddProfileFlag(flags)
+	arguments.AddRegionFlag(flags)
+	wait.AddIntervalFlag(flags)
+	globallyAvailableCommands := []*cobra.Command{cluster.Cmd, machinepool.Cmd, upgrade.Cmd, addon.Cmd}
+	arguments.MarkRegionDeprecated(Cmd, globallyAvailableCommands)
+}
@@ -0,0 +1,92 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinepool
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/reporter"
+	"github.com/openshift/rosa/pkg/rosa"
+	"github.com/openshift/rosa/pkg/wait"
+)
+
+const replicasReady = "replicas-ready"
+
+var Cmd = &cobra.Command{
+	Use:     "machinepool ID",
+	Aliases: []string{"machinepools", "machine-pool", "machine-pools"},
+	Short:   "Wait for the replicas of a machine pool to be ready",
+	Long: "Wait for the replicas of a machine pool to be ready. When autoscaling is enabled the " +
+		"number of ready replicas must be between the minimum and the maximum. Only machine pools " +
+		"of Hosted Control Plane clusters report the replicas that are ready.",
+	Example: `  # Wait for the replicas of machine pool "workers" of cluster "mycluster" to be ready
+  rosa wait machinepool workers -c mycluster --for=replicas-ready`,
+	Args: cobra.ExactArgs(1),
+	Run:  run,
+}
+
+var args struct {
+	condition string
+}
+
+func init() {
+	flags := Cmd.Flags()
+	ocm.AddClusterFlag(Cmd)
+	flags.StringVar(
+		&args.condition,
+		"for",
+		replicasReady,
+		fmt.Sprintf("Condition to wait for. The only supported condition is '%s'.", replicasReady),
+	)
+}
+
+func run(_ *cobra.Command, argv []string) {
+	r := rosa.NewRuntime()
+	defer r.Cleanup()
+
+	machinePoolID := argv[0]
+	if args.condition != replicasReady {
+		r.Reporter.CodedErrorf(reporter.ErrorCodeInvalidUsage, "Invalid condition '%s', expected '%s'",
+			args.condition, replicasReady)
+		os.Exit(reporter.ExitCode())
+	}
+	err := wait.ValidateInterval()
+	if err != nil {
+		r.Reporter.CodedErrorf(reporter.ErrorCodeInvalidUsage, "%s", err)
+		os.Exit(reporter.ExitCode())
+	}
+
+	r.WithAWS().WithOCM()
+	clusterKey := r.GetClusterKey()
+	cluster := r.FetchCluster()
+	if !cluster.Hypershift().Enabled() {
+		r.Reporter.CodedErrorf(reporter.ErrorCodeInvalidUsage, "Waiting for the replicas of a machine "+
+			"pool is only supported for Hosted Control Plane clusters, and cluster '%s' is classic", clusterKey)
+		os.Exit(reporter.ExitCode())
+	}
+
+	err = wait.For(r, fmt.Sprintf("the replicas of machine pool '%s' to be ready", machinePoolID),
+		wait.NodePoolReplicasReady(r, cluster, machinePoolID))
+	if err != nil {
+		os.Exit(reporter.ExitCode())
+	}
+	r.Reporter.Infof("The replicas of machine pool '%s' on cluster '%s' are ready", machinePoolID, clusterKey)
+}
@@ -0,0 +1,84 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package upgrade
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/reporter"
+	"github.com/openshift/rosa/pkg/rosa"
+	"github.com/openshift/rosa/pkg/wait"
+)
+
+const completed = "completed"
+
+var Cmd = &cobra.Command{
+	Use:   "upgrade",
+	Short: "Wait for the upgrade of a cluster to complete",
+	Long: "Wait for the scheduled upgrade of a cluster, or of the control plane of a Hosted Control " +
+		"Plane cluster, to complete. It fails if the upgrade fails or is cancelled.",
+	Example: `  # Wait for the upgrade of cluster "mycluster" to complete
+  rosa wait upgrade -c mycluster --for=completed`,
+	Args: cobra.NoArgs,
+	Run:  run,
+}
+
+var args struct {
+	condition string
+}
+
+func init() {
+	flags := Cmd.Flags()
+	ocm.AddClusterFlag(Cmd)
+	flags.StringVar(
+		&args.condition,
+		"for",
+		completed,
+		fmt.Sprintf("Condition to wait for. The only supported condition is '%s'.", completed),
+	)
+}
+
+func run(_ *cobra.Command, _ []string) {
+	r := rosa.NewRuntime()
+	defer r.Cleanup()
+
+	if args.condition != completed {
+		r.Reporter.CodedErrorf(reporter.ErrorCodeInvalidUsage, "Invalid condition '%s', expected '%s'",
+			args.condition, completed)
+		os.Exit(reporter.ExitCode())
+	}
+	err := wait.ValidateInterval()
+	if err != nil {
+		r.Reporter.CodedErrorf(reporter.ErrorCodeInvalidUsage, "%s", err)
+		os.Exit(reporter.ExitCode())
+	}
+
+	r.WithAWS().WithOCM()
+	clusterKey := r.GetClusterKey()
+	cluster := r.FetchCluster()
+
+	err = wait.For(r, fmt.Sprintf("the upgrade of cluster '%s' to complete", clusterKey),
+		wait.UpgradeCompleted(r, cluster))
+	if err != nil {
+		os.Exit(reporter.ExitCode())
+	}
+	r.Reporter.Infof("Cluster '%s' has no pending upgrade", clusterKey)
+}
@@ -0,0 +1,396 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the kinds of objects supported by the 'apply' command, and how they are
+// listed, created, updated and deleted with the OCM client.
+
+package apply
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"strconv"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/ocm"
+)
+
+// kind describes how the objects of a kind are managed. Operations that the OCM client doesn't
+// support for the kind are nil.
+type kind struct {
+	// Name of the kind in the manifests:
+	name string
+
+	// Field of the spec that identifies the objects, empty for the kinds that have a single
+	// object per cluster:
+	key string
+
+	// Fields that the API accepts but never returns, like secrets, and that therefore can't be
+	// compared with the current objects:
+	writeOnly map[string]bool
+
+	// protected returns true for the objects that are never pruned, like the default ingress:
+	protected func(current map[string]interface{}) bool
+
+	list   func(client *ocm.Client, clusterID string) ([]map[string]interface{}, error)
+	create func(client *ocm.Client, clusterID string, spec []byte) error
+	update func(client *ocm.Client, clusterID string, current map[string]interface{}, patch []byte) error
+	delete func(client *ocm.Client, clusterID string, current map[string]interface{}) error
+}
+
+// kindList contains the supported kinds, in the order that they are applied.
+var kindList = []*kind{
+	{
+		name: "MachinePool",
+		key:  "id",
+		list: func(client *ocm.Client, clusterID string) ([]map[string]interface{}, error) {
+			return listObjects(client.GetMachinePools, clusterID, cmv1.MarshalMachinePool)
+		},
+		create: func(client *ocm.Client, clusterID string, spec []byte) error {
+			return withObject(spec, cmv1.UnmarshalMachinePool, func(pool *cmv1.MachinePool) error {
+				_, err := client.CreateMachinePool(clusterID, pool)
+				return err
+			})
+		},
+		update: func(client *ocm.Client, clusterID string, _ map[string]interface{}, patch []byte) error {
+			return withObject(patch, cmv1.UnmarshalMachinePool, func(pool *cmv1.MachinePool) error {
+				_, err := client.UpdateMachinePool(clusterID, pool)
+				return err
+			})
+		},
+		delete: func(client *ocm.Client, clusterID string, current map[string]interface{}) error {
+			return client.DeleteMachinePool(clusterID, field(current, "id"))
+		},
+	},
+	{
+		name: "NodePool",
+		key:  "id",
+		list: func(client *ocm.Client, clusterID string) ([]map[string]interface{}, error) {
+			return listObjects(client.GetNodePools, clusterID, cmv1.MarshalNodePool)
+		},
+		create: func(client *ocm.Client, clusterID string, spec []byte) error {
+			return withObject(spec, cmv1.UnmarshalNodePool, func(pool *cmv1.NodePool) error {
+				_, err := client.CreateNodePool(clusterID, pool)
+				return err
+			})
+		},
+		update: func(client *ocm.Client, clusterID string, _ map[string]interface{}, patch []byte) error {
+			return withObject(patch, cmv1.UnmarshalNodePool, func(pool *cmv1.NodePool) error {
+				_, err := client.UpdateNodePool(clusterID, pool)
+				return err
+			})
+		},
+		delete: func(client *ocm.Client, clusterID string, current map[string]interface{}) error {
+			return client.DeleteNodePool(clusterID, field(current, "id"))
+		},
+	},
+	{
+		name: "KubeletConfig",
+		key:  "name",
+		list: func(client *ocm.Client, clusterID string) ([]map[string]interface{}, error) {
+			return listObjects(func(clusterID string) ([]*cmv1.KubeletConfig, error) {
+				return client.ListKubeletConfigs(context.Background(), clusterID)
+			}, clusterID, cmv1.MarshalKubeletConfig)
+		},
+		create: func(client *ocm.Client, clusterID string, spec []byte) error {
+			return withObject(spec, cmv1.UnmarshalKubeletConfig, func(config *cmv1.KubeletConfig) error {
+				_, err := client.CreateKubeletConfig(clusterID, kubeletConfigArgs(config))
+				return err
+			})
+		},
+		update: func(client *ocm.Client, clusterID string, current map[string]interface{}, patch []byte) error {
+			merged, err := merge(current, patch)
+			if err != nil {
+				return err
+			}
+			return withObject(merged, cmv1.UnmarshalKubeletConfig, func(config *cmv1.KubeletConfig) error {
+				_, err := client.UpdateKubeletConfig(context.Background(), clusterID, config.ID(),
+					kubeletConfigArgs(config))
+				return err
+			})
+		},
+		delete: func(client *ocm.Client, clusterID string, current map[string]interface{}) error {
+			return client.DeleteKubeletConfigByName(context.Background(), clusterID, field(current, "name"))
+		},
+	},
+	{
+		name: "TuningConfig",
+		key:  "name",
+		list: func(client *ocm.Client, clusterID string) ([]map[string]interface{}, error) {
+			return listObjects(client.GetTuningConfigs, clusterID, cmv1.MarshalTuningConfig)
+		},
+		create: func(client *ocm.Client, clusterID string, spec []byte) error {
+			return withObject(spec, cmv1.UnmarshalTuningConfig, func(config *cmv1.TuningConfig) error {
+				_, err := client.CreateTuningConfig(clusterID, config)
+				return err
+			})
+		},
+		update: func(client *ocm.Client, clusterID string, _ map[string]interface{}, patch []byte) error {
+			return withObject(patch, cmv1.UnmarshalTuningConfig, func(config *cmv1.TuningConfig) error {
+				_, err := client.UpdateTuningConfig(clusterID, config)
+				return err
+			})
+		},
+		delete: func(client *ocm.Client, clusterID string, current map[string]interface{}) error {
+			return client.DeleteTuningConfig(clusterID, field(current, "id"))
+		},
+	},
+	{
+		name: "ClusterAutoscaler",
+		list: func(client *ocm.Client, clusterID string) ([]map[string]interface{}, error) {
+			return listObjects(func(clusterID string) ([]*cmv1.ClusterAutoscaler, error) {
+				autoscaler, err := client.GetClusterAutoscaler(clusterID)
+				if err != nil || autoscaler == nil {
+					return nil, err
+				}
+				return []*cmv1.ClusterAutoscaler{autoscaler}, nil
+			}, clusterID, cmv1.MarshalClusterAutoscaler)
+		},
+		create: func(client *ocm.Client, clusterID string, spec []byte) error {
+			return withObject(spec, cmv1.UnmarshalClusterAutoscaler, func(autoscaler *cmv1.ClusterAutoscaler) error {
+				_, err := client.CreateClusterAutoscaler(clusterID, autoscalerConfig(autoscaler))
+				return err
+			})
+		},
+		update: func(client *ocm.Client, clusterID string, current map[string]interface{}, patch []byte) error {
+			// The whole configuration is sent, so the patch is merged with the current one:
+			merged, err := merge(current, patch)
+			if err != nil {
+				return err
+			}
+			return withObject(merged, cmv1.UnmarshalClusterAutoscaler, func(autoscaler *cmv1.ClusterAutoscaler) error {
+				_, err := client.UpdateClusterAutoscaler(clusterID, autoscalerConfig(autoscaler))
+				return err
+			})
+		},
+		delete: func(client *ocm.Client, clusterID string, _ map[string]interface{}) error {
+			return client.DeleteClusterAutoscaler(clusterID)
+		},
+	},
+	{
+		name: "IdentityProvider",
+		key:  "name",
+		writeOnly: map[string]bool{
+			"bind_password": true,
+			"client_secret": true,
+			"password":      true,
+			"users":         true,
+		},
+		list: func(client *ocm.Client, clusterID string) ([]map[string]interface{}, error) {
+			return listObjects(client.GetIdentityProviders, clusterID, cmv1.MarshalIdentityProvider)
+		},
+		create: func(client *ocm.Client, clusterID string, spec []byte) error {
+			return withObject(spec, cmv1.UnmarshalIdentityProvider, func(idp *cmv1.IdentityProvider) error {
+				_, err := client.CreateIdentityProvider(clusterID, idp)
+				return err
+			})
+		},
+		delete: func(client *ocm.Client, clusterID string, current map[string]interface{}) error {
+			return client.DeleteIdentityProvider(clusterID, field(current, "id"))
+		},
+	},
+	{
+		name: "Ingress",
+		key:  "id",
+		protected: func(current map[string]interface{}) bool {
+			return current["default"] == true
+		},
+		list: func(client *ocm.Client, clusterID string) ([]map[string]interface{}, error) {
+			return listObjects(client.GetIngresses, clusterID, cmv1.MarshalIngress)
+		},
+		update: func(client *ocm.Client, clusterID string, _ map[string]interface{}, patch []byte) error {
+			return withObject(patch, cmv1.UnmarshalIngress, func(ingress *cmv1.Ingress) error {
+				_, err := client.UpdateIngress(clusterID, ingress)
+				return err
+			})
+		},
+		delete: func(client *ocm.Client, clusterID string, current map[string]interface{}) error {
+			return client.DeleteIngress(clusterID, field(current, "id"))
+		},
+	},
+	{
+		name: "ExternalAuthProvider",
+		key:  "id",
+		writeOnly: map[string]bool{
+			"secret": true,
+		},
+		list: func(client *ocm.Client, clusterID string) ([]map[string]interface{}, error) {
+			return listObjects(client.GetExternalAuths, clusterID, cmv1.MarshalExternalAuth)
+		},
+		create: func(client *ocm.Client, clusterID string, spec []byte) error {
+			return withObject(spec, cmv1.UnmarshalExternalAuth, func(auth *cmv1.ExternalAuth) error {
+				_, err := client.CreateExternalAuth(clusterID, auth)
+				return err
+			})
+		},
+		delete: func(client *ocm.Client, clusterID string, current map[string]interface{}) error {
+			return client.DeleteExternalAuth(clusterID, field(current, "id"))
+		},
+	},
+	{
+		// Break glass credentials can only be revoked all together, so they are never deleted:
+		name: "BreakGlassCredential",
+		key:  "username",
+		writeOnly: map[string]bool{
+			"expiration_timestamp": true,
+		},
+		list: func(client *ocm.Client, clusterID string) ([]map[string]interface{}, error) {
+			return listObjects(func(clusterID string) ([]*cmv1.BreakGlassCredential, error) {
+				credentials, err := client.GetBreakGlassCredentials(clusterID)
+				if err != nil {
+					return nil, err
+				}
+				var active []*cmv1.BreakGlassCredential
+				for _, credential := range credentials {
+					switch credential.Status() {
+					case cmv1.BreakGlassCredentialStatusCreated, cmv1.BreakGlassCredentialStatusIssued:
+						active = append(active, credential)
+					}
+				}
+				return active, nil
+			}, clusterID, cmv1.MarshalBreakGlassCredential)
+		},
+		create: func(client *ocm.Client, clusterID string, spec []byte) error {
+			return withObject(spec, cmv1.UnmarshalBreakGlassCredential,
+				func(credential *cmv1.BreakGlassCredential) error {
+					_, err := client.CreateBreakGlassCredential(clusterID, credential)
+					return err
+				})
+		},
+	},
+}
+
+// kinds contains the supported kinds indexed by name.
+var kinds = map[string]*kind{}
+
+func init() {
+	for _, kind := range kindList {
+		kinds[kind.name] = kind
+	}
+}
+
+// KindNames returns the names of the supported kinds.
+func KindNames() []string {
+	names := make([]string, len(kindList))
+	for i, kind := range kindList {
+		names[i] = kind.name
+	}
+	return names
+}
+
+// listObjects calls the given OCM client method and converts the returned objects to their
+// representation in the OCM API.
+func listObjects[T any](list func(string) ([]T, error), clusterID string,
+	marshal func(T, io.Writer) error) ([]map[string]interface{}, error) {
+	items, err := list(clusterID)
+	if err != nil {
+		return nil, err
+	}
+	objects := make([]map[string]interface{}, 0, len(items))
+	for _, item := range items {
+		var buffer bytes.Buffer
+		err = marshal(item, &buffer)
+		if err != nil {
+			return nil, err
+		}
+		object := map[string]interface{}{}
+		err = json.Unmarshal(buffer.Bytes(), &object)
+		if err != nil {
+			return nil, err
+		}
+		delete(object, "kind")
+		delete(object, "href")
+		objects = append(objects, object)
+	}
+	return objects, nil
+}
+
+// withObject converts the given representation in the OCM API to an object and passes it to the
+// given function.
+func withObject[T any](data []byte, unmarshal func(interface{}) (T, error), call func(T) error) error {
+	object, err := unmarshal(data)
+	if err != nil {
+		return err
+	}
+	return call(object)
+}
+
+// merge returns the current object with the top level fields of the patch replaced.
+func merge(current map[string]interface{}, patch []byte) ([]byte, error) {
+	merged := map[string]interface{}{}
+	err := json.Unmarshal(patch, &merged)
+	if err != nil {
+		return nil, err
+	}
+	for name, value := range current {
+		if _, ok := merged[name]; !ok {
+			merged[name] = value
+		}
+	}
+	return json.Marshal(merged)
+}
+
+func field(object map[string]interface{}, name string) string {
+	value, _ := object[name].(string)
+	return value
+}
+
+func kubeletConfigArgs(config *cmv1.KubeletConfig) ocm.KubeletConfigArgs {
+	return ocm.KubeletConfigArgs{
+		Name:         config.Name(),
+		PodPidsLimit: config.PodPidsLimit(),
+	}
+}
+
+func autoscalerConfig(autoscaler *cmv1.ClusterAutoscaler) *ocm.AutoscalerConfig {
+	limits := autoscaler.ResourceLimits()
+	scaleDown := autoscaler.ScaleDown()
+	config := &ocm.AutoscalerConfig{
+		BalanceSimilarNodeGroups:    autoscaler.BalanceSimilarNodeGroups(),
+		SkipNodesWithLocalStorage:   autoscaler.SkipNodesWithLocalStorage(),
+		LogVerbosity:                autoscaler.LogVerbosity(),
+		MaxPodGracePeriod:           autoscaler.MaxPodGracePeriod(),
+		PodPriorityThreshold:        autoscaler.PodPriorityThreshold(),
+		IgnoreDaemonsetsUtilization: autoscaler.IgnoreDaemonsetsUtilization(),
+		MaxNodeProvisionTime:        autoscaler.MaxNodeProvisionTime(),
+		BalancingIgnoredLabels:      autoscaler.BalancingIgnoredLabels(),
+		ResourceLimits: ocm.ResourceLimits{
+			MaxNodesTotal: limits.MaxNodesTotal(),
+			Cores:         ocm.ResourceRange{Min: limits.Cores().Min(), Max: limits.Cores().Max()},
+			Memory:        ocm.ResourceRange{Min: limits.Memory().Min(), Max: limits.Memory().Max()},
+		},
+		ScaleDown: ocm.ScaleDownConfig{
+			Enabled:           scaleDown.Enabled(),
+			UnneededTime:      scaleDown.UnneededTime(),
+			DelayAfterAdd:     scaleDown.DelayAfterAdd(),
+			DelayAfterDelete:  scaleDown.DelayAfterDelete(),
+			DelayAfterFailure: scaleDown.DelayAfterFailure(),
+		},
+	}
+	for _, gpu := range limits.GPUS() {
+		config.ResourceLimits.GPULimits = append(config.ResourceLimits.GPULimits, ocm.GPULimit{
+			Type:  gpu.Type(),
+			Range: ocm.ResourceRange{Min: gpu.Range().Min(), Max: gpu.Range().Max()},
+		})
+	}
+	if threshold, err := strconv.ParseFloat(scaleDown.UtilizationThreshold(), 64); err == nil {
+		config.ScaleDown.UtilizationThreshold = threshold
+	}
+	return config
+}
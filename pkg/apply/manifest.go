@@ -0,0 +1,160 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the functions that read the manifests given to the 'apply' command.
+
+package apply
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	yamlv3 "gopkg.in/yaml.v3"
+	"sigs.k8s.io/yaml"
+
+	"github.com/openshift/rosa/pkg/clusterspec"
+)
+
+// Manifest is an object of one of the supported kinds. The spec is the representation of the
+// object in the OCM API, for example 'instance_type' for machine pools.
+type Manifest struct {
+	APIVersion string                 `json:"apiVersion"`
+	Kind       string                 `json:"kind"`
+	Spec       map[string]interface{} `json:"spec"`
+
+	// Source is the file and document that the manifest was read from, used in messages:
+	Source string `json:"-"`
+}
+
+// Load reads the manifests from the given files, and from the files with the '.yaml', '.yml' and
+// '.json' extensions inside the given directories.
+func Load(paths []string) ([]*Manifest, error) {
+	var files []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			switch filepath.Ext(entry.Name()) {
+			case ".yaml", ".yml", ".json":
+				if !entry.IsDir() {
+					files = append(files, filepath.Join(path, entry.Name()))
+				}
+			}
+		}
+	}
+	sort.Strings(files)
+
+	var manifests []*Manifest
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		parsed, err := Parse(data, file)
+		if err != nil {
+			return nil, err
+		}
+		manifests = append(manifests, parsed...)
+	}
+	return manifests, nil
+}
+
+// Parse parses the manifests of a multi-document YAML file, or of a JSON file. Empty documents are
+// ignored.
+func Parse(data []byte, source string) ([]*Manifest, error) {
+	var manifests []*Manifest
+	decoder := yamlv3.NewDecoder(bytes.NewReader(data))
+	for index := 1; ; index++ {
+		// The documents are split with the YAML decoder and then converted to JSON, so that
+		// the manifests are decoded with the JSON field names:
+		var node yamlv3.Node
+		err := decoder.Decode(&node)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read '%s': %v", source, err)
+		}
+		if len(node.Content) == 0 || node.Content[0].Kind == yamlv3.ScalarNode && node.Content[0].Tag == "!!null" {
+			continue
+		}
+		document, err := yamlv3.Marshal(&node)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read '%s': %v", source, err)
+		}
+		manifest := &Manifest{
+			Source: fmt.Sprintf("%s#%d", source, index),
+		}
+		err = yaml.UnmarshalStrict(document, manifest)
+		if err != nil {
+			return nil, fmt.Errorf("invalid manifest '%s': %v", manifest.Source, err)
+		}
+		if manifest.APIVersion == "" && manifest.Kind == "" && manifest.Spec == nil {
+			continue
+		}
+		err = manifest.validate()
+		if err != nil {
+			return nil, fmt.Errorf("invalid manifest '%s': %v", manifest.Source, err)
+		}
+		manifests = append(manifests, manifest)
+	}
+	return manifests, nil
+}
+
+func (m *Manifest) validate() error {
+	if m.APIVersion != clusterspec.APIVersion {
+		return fmt.Errorf("unsupported API version '%s', expected '%s'", m.APIVersion, clusterspec.APIVersion)
+	}
+	kind := kinds[m.Kind]
+	if kind == nil {
+		return fmt.Errorf("unsupported kind '%s', expected one of %s", m.Kind, strings.Join(KindNames(), ", "))
+	}
+	if m.Spec == nil {
+		return fmt.Errorf("the spec is mandatory")
+	}
+	if kind.key != "" {
+		if name, ok := m.Spec[kind.key].(string); !ok || name == "" {
+			return fmt.Errorf("the '%s' of the %s is mandatory", kind.key, kind.name)
+		}
+	}
+	return nil
+}
+
+// name returns the value of the field that identifies the object, or an empty string for kinds
+// that have a single object per cluster.
+func (m *Manifest) name() string {
+	key := kinds[m.Kind].key
+	if key == "" {
+		return ""
+	}
+	return m.Spec[key].(string)
+}
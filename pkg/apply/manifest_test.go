@@ -0,0 +1,78 @@
+package apply
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+const manifests = `apiVersion: rosa.openshift.io/v1alpha1
+kind: MachinePool
+spec:
+  id: gpu
+  instance_type: g4dn.xlarge
+  replicas: 2
+---
+# Empty documents are ignored
+---
+apiVersion: rosa.openshift.io/v1alpha1
+kind: ClusterAutoscaler
+spec:
+  max_pod_grace_period: 300
+`
+
+var _ = Describe("Manifests", func() {
+	It("Parses multiple documents", func() {
+		parsed, err := Parse([]byte(manifests), "day2.yaml")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(parsed).To(HaveLen(2))
+		Expect(parsed[0].Kind).To(Equal("MachinePool"))
+		Expect(parsed[0].name()).To(Equal("gpu"))
+		Expect(parsed[0].Spec["replicas"]).To(BeEquivalentTo(2))
+		Expect(parsed[0].Source).To(Equal("day2.yaml#1"))
+		Expect(parsed[1].Kind).To(Equal("ClusterAutoscaler"))
+		Expect(parsed[1].name()).To(BeEmpty())
+		Expect(parsed[1].Source).To(Equal("day2.yaml#3"))
+	})
+
+	It("Rejects unsupported kinds", func() {
+		_, err := Parse([]byte("apiVersion: rosa.openshift.io/v1alpha1\nkind: Cluster\nspec: {}\n"), "day2.yaml")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(HavePrefix("invalid manifest 'day2.yaml#1': unsupported kind 'Cluster', " +
+			"expected one of MachinePool, NodePool"))
+	})
+
+	It("Rejects other versions", func() {
+		_, err := Parse([]byte("apiVersion: v1\nkind: MachinePool\nspec: {id: gpu}\n"), "day2.yaml")
+		Expect(err).To(MatchError("invalid manifest 'day2.yaml#1': unsupported API version 'v1', " +
+			"expected 'rosa.openshift.io/v1alpha1'"))
+	})
+
+	It("Requires the identifier of the object", func() {
+		_, err := Parse([]byte("apiVersion: rosa.openshift.io/v1alpha1\nkind: TuningConfig\n"+
+			"spec: {spec: {}}\n"), "day2.yaml")
+		Expect(err).To(MatchError("invalid manifest 'day2.yaml#1': the 'name' of the TuningConfig is mandatory"))
+	})
+
+	It("Rejects unknown fields", func() {
+		_, err := Parse([]byte("apiVersion: rosa.openshift.io/v1alpha1\nkind: MachinePool\n"+
+			"metadata: {}\nspec: {id: gpu}\n"), "day2.yaml")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring(`unknown field "metadata"`))
+	})
+
+	It("Loads the manifests of a directory", func() {
+		dir := GinkgoT().TempDir()
+		Expect(os.WriteFile(filepath.Join(dir, "b.yaml"), []byte(manifests), 0600)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(dir, "a.json"), []byte(`{"apiVersion": "rosa.openshift.io/v1alpha1", `+
+			`"kind": "TuningConfig", "spec": {"name": "tuned"}}`), 0600)).To(Succeed())
+		Expect(os.WriteFile(filepath.Join(dir, "README.md"), []byte("# Manifests"), 0600)).To(Succeed())
+		loaded, err := Load([]string{dir})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(loaded).To(HaveLen(3))
+		Expect(loaded[0].Kind).To(Equal("TuningConfig"))
+		Expect(loaded[1].Kind).To(Equal("MachinePool"))
+	})
+})
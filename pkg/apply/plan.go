@@ -0,0 +1,338 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the functions that compare the manifests with the objects of a cluster, and
+// that apply the resulting changes.
+
+package apply
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/openshift/rosa/pkg/ocm"
+)
+
+// Action is what is done to an object to make it match its manifest.
+type Action string
+
+const (
+	Create Action = "create"
+	Update Action = "update"
+	Delete Action = "delete"
+)
+
+// Difference is a field whose current value doesn't match the manifest. Nested fields are
+// separated with dots, like 'autoscaling.max_replicas'.
+type Difference struct {
+	Field   string      `json:"field"`
+	Current interface{} `json:"current,omitempty"`
+	Desired interface{} `json:"desired,omitempty"`
+}
+
+// Change is an object that will be created, updated or deleted.
+type Change struct {
+	Action      Action       `json:"action"`
+	Kind        string       `json:"kind"`
+	Name        string       `json:"name,omitempty"`
+	Differences []Difference `json:"differences,omitempty"`
+
+	// Error explains why the change can't be applied, for example because the kind of the object
+	// can't be updated:
+	Error string `json:"error,omitempty"`
+
+	kind    *kind
+	current map[string]interface{}
+	body    []byte
+}
+
+// Plan contains the changes needed to make a cluster match a set of manifests. Creations and
+// updates come first, in the order of the kinds, followed by the deletions of pruned objects.
+type Plan struct {
+	Changes []*Change `json:"changes"`
+}
+
+// NewPlan compares the given manifests with the objects of the cluster. When prune is true the
+// objects of the kinds used in the manifests that don't have a manifest are deleted.
+func NewPlan(client *ocm.Client, clusterID string, manifests []*Manifest, prune bool) (*Plan, error) {
+	byKind := map[string][]*Manifest{}
+	seen := map[string]string{}
+	for _, manifest := range manifests {
+		id := manifest.Kind + "/" + manifest.name()
+		if source, ok := seen[id]; ok {
+			return nil, fmt.Errorf("%s is defined in both '%s' and '%s'", describe(manifest.Kind, manifest.name()),
+				source, manifest.Source)
+		}
+		seen[id] = manifest.Source
+		byKind[manifest.Kind] = append(byKind[manifest.Kind], manifest)
+	}
+
+	plan := &Plan{}
+	var deletions []*Change
+	for _, kind := range kindList {
+		if len(byKind[kind.name]) == 0 {
+			continue
+		}
+		objects, err := kind.list(client, clusterID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get %s objects: %v", kind.name, err)
+		}
+		current := map[string]map[string]interface{}{}
+		for _, object := range objects {
+			current[field(object, kind.key)] = object
+		}
+		for _, manifest := range byKind[kind.name] {
+			change, err := plan.compare(kind, current[manifest.name()], manifest)
+			if err != nil {
+				return nil, err
+			}
+			if change != nil {
+				plan.Changes = append(plan.Changes, change)
+			}
+			delete(current, manifest.name())
+		}
+		if !prune {
+			continue
+		}
+		names := make([]string, 0, len(current))
+		for name := range current {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			object := current[name]
+			if kind.delete == nil || (kind.protected != nil && kind.protected(object)) {
+				continue
+			}
+			deletions = append(deletions, &Change{
+				Action:  Delete,
+				Kind:    kind.name,
+				Name:    name,
+				kind:    kind,
+				current: object,
+			})
+		}
+	}
+	plan.Changes = append(plan.Changes, deletions...)
+	return plan, nil
+}
+
+// compare returns the change needed to make the current object match the manifest, or nil if it
+// already does.
+func (p *Plan) compare(kind *kind, current map[string]interface{}, manifest *Manifest) (*Change, error) {
+	change := &Change{
+		Kind:    kind.name,
+		Name:    manifest.name(),
+		kind:    kind,
+		current: current,
+	}
+	if current == nil {
+		change.Action = Create
+		if kind.create == nil {
+			change.Error = fmt.Sprintf("%s objects can't be created, only updated", kind.name)
+		}
+		body, err := json.Marshal(manifest.Spec)
+		if err != nil {
+			return nil, err
+		}
+		change.body = body
+		return change, nil
+	}
+
+	change.Action = Update
+	change.Differences = Diff(current, manifest.Spec, kind.writeOnly)
+	if len(change.Differences) == 0 {
+		return nil, nil
+	}
+	if kind.update == nil {
+		change.Error = fmt.Sprintf("%s objects can't be updated, delete it to apply the changes", kind.name)
+	}
+
+	// Only the changed fields are sent, together with the identifier of the object:
+	patch := map[string]interface{}{}
+	for _, difference := range change.Differences {
+		name, _, _ := strings.Cut(difference.Field, ".")
+		patch[name] = manifest.Spec[name]
+	}
+	if id, ok := current["id"]; ok {
+		patch["id"] = id
+	}
+	body, err := json.Marshal(patch)
+	if err != nil {
+		return nil, err
+	}
+	change.body = body
+	return change, nil
+}
+
+// Diff returns the fields of the desired object whose values are different in the current object.
+// Fields that aren't in the desired object, like the ones populated by the server, are ignored, and
+// so are the given write only fields.
+func Diff(current, desired map[string]interface{}, writeOnly map[string]bool) []Difference {
+	return diff("", current, desired, writeOnly)
+}
+
+func diff(prefix string, current, desired map[string]interface{}, writeOnly map[string]bool) []Difference {
+	names := make([]string, 0, len(desired))
+	for name := range desired {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var differences []Difference
+	for _, name := range names {
+		if writeOnly[name] {
+			continue
+		}
+		desiredValue := withoutFields(desired[name], writeOnly)
+		currentValue := current[name]
+		desiredMap, desiredIsMap := desiredValue.(map[string]interface{})
+		currentMap, currentIsMap := currentValue.(map[string]interface{})
+		if desiredIsMap && currentIsMap {
+			differences = append(differences, diff(prefix+name+".", currentMap, desiredMap, writeOnly)...)
+			continue
+		}
+		if !reflect.DeepEqual(withoutFields(currentValue, writeOnly), desiredValue) {
+			differences = append(differences, Difference{
+				Field:   prefix + name,
+				Current: currentValue,
+				Desired: desiredValue,
+			})
+		}
+	}
+	return differences
+}
+
+// withoutFields returns a copy of the value without the given fields, at any depth.
+func withoutFields(value interface{}, fields map[string]bool) interface{} {
+	if len(fields) == 0 {
+		return value
+	}
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		result := map[string]interface{}{}
+		for name, item := range typed {
+			if !fields[name] {
+				result[name] = withoutFields(item, fields)
+			}
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(typed))
+		for i, item := range typed {
+			result[i] = withoutFields(item, fields)
+		}
+		return result
+	default:
+		return value
+	}
+}
+
+// Object describes the changed object, like "MachinePool 'gpu'".
+func (c *Change) Object() string {
+	return describe(c.Kind, c.Name)
+}
+
+// Empty returns true if the cluster already matches the manifests.
+func (p *Plan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+// Err returns an error describing the changes that can't be applied, if any.
+func (p *Plan) Err() error {
+	var errs []error
+	for _, change := range p.Changes {
+		if change.Error != "" {
+			errs = append(errs, fmt.Errorf("can't %s %s: %s", change.Action, change.Object(), change.Error))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Print writes the plan in a format similar to a diff: '+' for creations, '~' for updates, with
+// the changed fields, and '-' for deletions.
+func (p *Plan) Print(writer io.Writer) {
+	symbols := map[Action]string{
+		Create: "+",
+		Update: "~",
+		Delete: "-",
+	}
+	counts := map[Action]int{}
+	for _, change := range p.Changes {
+		counts[change.Action]++
+		fmt.Fprintf(writer, "%s %s %s\n", symbols[change.Action], change.Action, change.Object())
+		for _, difference := range change.Differences {
+			fmt.Fprintf(writer, "    %s: %s => %s\n", difference.Field,
+				FormatValue(difference.Current), FormatValue(difference.Desired))
+		}
+	}
+	fmt.Fprintf(writer, "Plan: %d to create, %d to update, %d to delete.\n",
+		counts[Create], counts[Update], counts[Delete])
+}
+
+// Apply applies the changes of the plan in order, calling the given function after each one. It
+// stops at the first change that fails.
+func (p *Plan) Apply(client *ocm.Client, clusterID string, applied func(*Change)) error {
+	err := p.Err()
+	if err != nil {
+		return err
+	}
+	for _, change := range p.Changes {
+		switch change.Action {
+		case Create:
+			err = change.kind.create(client, clusterID, change.body)
+		case Update:
+			err = change.kind.update(client, clusterID, change.current, change.body)
+		case Delete:
+			err = change.kind.delete(client, clusterID, change.current)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to %s %s: %v", change.Action, change.Object(), err)
+		}
+		if applied != nil {
+			applied(change)
+		}
+	}
+	return nil
+}
+
+func describe(kind, name string) string {
+	if name == "" {
+		return kind
+	}
+	return fmt.Sprintf("%s '%s'", kind, name)
+}
+
+// FormatValue returns the text used to display a value of a difference, '<none>' when missing.
+func FormatValue(value interface{}) string {
+	switch typed := value.(type) {
+	case nil:
+		return "<none>"
+	case string:
+		return typed
+	default:
+		data, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Sprint(value)
+		}
+		return string(data)
+	}
+}
@@ -0,0 +1,247 @@
+package apply
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	. "github.com/openshift-online/ocm-sdk-go/testing"
+
+	"github.com/openshift/rosa/pkg/test"
+)
+
+const (
+	clusterID        = "24vf9iitg3p6tlml88iml6j6mu095mh8"
+	machinePoolsPath = "/api/clusters_mgmt/v1/clusters/" + clusterID + "/machine_pools"
+	ingressesPath    = "/api/clusters_mgmt/v1/clusters/" + clusterID + "/ingresses"
+)
+
+func machinePool(id string, replicas int) *cmv1.MachinePool {
+	pool, err := cmv1.NewMachinePool().ID(id).InstanceType("m5.xlarge").Replicas(replicas).
+		Labels(map[string]string{"team": "sre"}).Build()
+	Expect(err).NotTo(HaveOccurred())
+	return pool
+}
+
+func formatIngress(ingress *cmv1.Ingress) string {
+	var buffer bytes.Buffer
+	Expect(cmv1.MarshalIngress(ingress, &buffer)).To(Succeed())
+	return buffer.String()
+}
+
+func parse(documents string) []*Manifest {
+	manifests, err := Parse([]byte(documents), "day2.yaml")
+	Expect(err).NotTo(HaveOccurred())
+	return manifests
+}
+
+// verifyBody checks that the body of the request contains the given fields.
+func verifyBody(fields map[string]interface{}) http.HandlerFunc {
+	return func(_ http.ResponseWriter, request *http.Request) {
+		data, err := io.ReadAll(request.Body)
+		Expect(err).NotTo(HaveOccurred())
+		body := map[string]interface{}{}
+		Expect(json.Unmarshal(data, &body)).To(Succeed())
+		for name, value := range fields {
+			Expect(body).To(HaveKeyWithValue(name, BeEquivalentTo(value)))
+		}
+	}
+}
+
+var _ = Describe("Plan", func() {
+	var t *test.TestingRuntime
+
+	BeforeEach(func() {
+		t = test.NewTestRuntime()
+	})
+
+	It("Creates, updates and prunes machine pools", func() {
+		t.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, test.FormatMachinePoolList(
+			[]*cmv1.MachinePool{machinePool("workers", 2), machinePool("old", 1)})))
+		plan, err := NewPlan(t.RosaRuntime.OCMClient, clusterID, parse(`
+apiVersion: rosa.openshift.io/v1alpha1
+kind: MachinePool
+spec:
+  id: workers
+  instance_type: m5.xlarge
+  replicas: 3
+---
+apiVersion: rosa.openshift.io/v1alpha1
+kind: MachinePool
+spec:
+  id: gpu
+  instance_type: g4dn.xlarge
+`), true)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plan.Err()).NotTo(HaveOccurred())
+		Expect(plan.Changes).To(HaveLen(3))
+		Expect(plan.Changes[0].Action).To(Equal(Update))
+		Expect(plan.Changes[0].Differences).To(Equal([]Difference{
+			{Field: "replicas", Current: float64(2), Desired: float64(3)},
+		}))
+		Expect(plan.Changes[1].Action).To(Equal(Create))
+		Expect(plan.Changes[2].Action).To(Equal(Delete))
+		Expect(plan.Changes[2].Name).To(Equal("old"))
+
+		var printed bytes.Buffer
+		plan.Print(&printed)
+		Expect(printed.String()).To(Equal("~ update MachinePool 'workers'\n" +
+			"    replicas: 2 => 3\n" +
+			"+ create MachinePool 'gpu'\n" +
+			"- delete MachinePool 'old'\n" +
+			"Plan: 1 to create, 1 to update, 1 to delete.\n"))
+
+		t.ApiServer.AppendHandlers(
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest(http.MethodPatch, machinePoolsPath+"/workers"),
+				verifyBody(map[string]interface{}{"id": "workers", "replicas": 3}),
+				RespondWithJSON(http.StatusOK, test.FormatResource(machinePool("workers", 3))),
+			),
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest(http.MethodPost, machinePoolsPath),
+				verifyBody(map[string]interface{}{"id": "gpu", "instance_type": "g4dn.xlarge"}),
+				RespondWithJSON(http.StatusCreated, test.FormatResource(machinePool("gpu", 0))),
+			),
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest(http.MethodDelete, machinePoolsPath+"/old"),
+				RespondWithJSON(http.StatusNoContent, ""),
+			),
+		)
+		var applied []string
+		err = plan.Apply(t.RosaRuntime.OCMClient, clusterID, func(change *Change) {
+			applied = append(applied, change.Object())
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(applied).To(Equal([]string{"MachinePool 'workers'", "MachinePool 'gpu'", "MachinePool 'old'"}))
+	})
+
+	It("Ignores the fields that aren't in the manifest", func() {
+		t.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, test.FormatMachinePoolList(
+			[]*cmv1.MachinePool{machinePool("workers", 2)})))
+		plan, err := NewPlan(t.RosaRuntime.OCMClient, clusterID, parse(`
+apiVersion: rosa.openshift.io/v1alpha1
+kind: MachinePool
+spec:
+  id: workers
+  replicas: 2
+  labels:
+    team: sre
+`), false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plan.Empty()).To(BeTrue())
+	})
+
+	It("Never prunes the default ingress", func() {
+		defaultIngress, err := cmv1.NewIngress().ID("a1b2").Default(true).
+			Listening(cmv1.ListeningMethodExternal).Build()
+		Expect(err).NotTo(HaveOccurred())
+		appsIngress, err := cmv1.NewIngress().ID("c3d4").Listening(cmv1.ListeningMethodExternal).Build()
+		Expect(err).NotTo(HaveOccurred())
+		internalIngress, err := cmv1.NewIngress().ID("e5f6").Listening(cmv1.ListeningMethodExternal).Build()
+		Expect(err).NotTo(HaveOccurred())
+		t.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, test.FormatIngressList(
+			[]*cmv1.Ingress{defaultIngress, appsIngress, internalIngress})))
+		plan, err := NewPlan(t.RosaRuntime.OCMClient, clusterID, parse(`
+apiVersion: rosa.openshift.io/v1alpha1
+kind: Ingress
+spec:
+  id: c3d4
+  listening: internal
+`), true)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plan.Changes).To(HaveLen(2))
+		Expect(plan.Changes[0].Object()).To(Equal("Ingress 'c3d4'"))
+		Expect(plan.Changes[0].Action).To(Equal(Update))
+		Expect(plan.Changes[1].Object()).To(Equal("Ingress 'e5f6'"))
+		Expect(plan.Changes[1].Action).To(Equal(Delete))
+
+		t.ApiServer.AppendHandlers(
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest(http.MethodPatch, ingressesPath+"/c3d4"),
+				verifyBody(map[string]interface{}{"listening": "internal"}),
+				RespondWithJSON(http.StatusOK, formatIngress(appsIngress)),
+			),
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest(http.MethodDelete, ingressesPath+"/e5f6"),
+				RespondWithJSON(http.StatusNoContent, ""),
+			),
+		)
+		Expect(plan.Apply(t.RosaRuntime.OCMClient, clusterID, nil)).To(Succeed())
+	})
+
+	It("Reports the changes that can't be applied", func() {
+		idp, err := cmv1.NewIdentityProvider().ID("idp1").Name("github").
+			Type(cmv1.IdentityProviderTypeGithub).
+			Github(cmv1.NewGithubIdentityProvider().ClientID("abc").Organizations("acme")).Build()
+		Expect(err).NotTo(HaveOccurred())
+		t.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, test.FormatIDPList(
+			[]*cmv1.IdentityProvider{idp})))
+		plan, err := NewPlan(t.RosaRuntime.OCMClient, clusterID, parse(`
+apiVersion: rosa.openshift.io/v1alpha1
+kind: IdentityProvider
+spec:
+  name: github
+  type: GithubIdentityProvider
+  github:
+    client_id: abc
+    client_secret: secret
+    organizations: [acme, example]
+`), false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(plan.Changes).To(HaveLen(1))
+		Expect(plan.Changes[0].Differences).To(Equal([]Difference{{
+			Field:   "github.organizations",
+			Current: []interface{}{"acme"},
+			Desired: []interface{}{"acme", "example"},
+		}}))
+		Expect(plan.Err()).To(MatchError("can't update IdentityProvider 'github': IdentityProvider objects " +
+			"can't be updated, delete it to apply the changes"))
+		Expect(plan.Apply(t.RosaRuntime.OCMClient, clusterID, nil)).To(MatchError(plan.Err()))
+		Expect(t.ApiServer.ReceivedRequests()).To(HaveLen(1))
+	})
+
+	It("Rejects objects defined twice", func() {
+		_, err := NewPlan(t.RosaRuntime.OCMClient, clusterID, parse(`
+apiVersion: rosa.openshift.io/v1alpha1
+kind: TuningConfig
+spec:
+  name: tuned
+---
+apiVersion: rosa.openshift.io/v1alpha1
+kind: TuningConfig
+spec:
+  name: tuned
+`), false)
+		Expect(err).To(MatchError("TuningConfig 'tuned' is defined in both 'day2.yaml#1' and 'day2.yaml#2'"))
+	})
+})
+
+var _ = Describe("Diff", func() {
+	It("Compares nested fields", func() {
+		current := map[string]interface{}{
+			"autoscaling": map[string]interface{}{"min_replicas": 1.0, "max_replicas": 3.0},
+			"status":      "ready",
+		}
+		desired := map[string]interface{}{
+			"autoscaling": map[string]interface{}{"max_replicas": 5.0},
+		}
+		Expect(Diff(current, desired, nil)).To(Equal([]Difference{
+			{Field: "autoscaling.max_replicas", Current: 3.0, Desired: 5.0},
+		}))
+	})
+
+	It("Ignores write only fields", func() {
+		current := map[string]interface{}{
+			"clients": []interface{}{map[string]interface{}{"id": "console"}},
+		}
+		desired := map[string]interface{}{
+			"clients": []interface{}{map[string]interface{}{"id": "console", "secret": "s3cr3t"}},
+		}
+		Expect(Diff(current, desired, map[string]bool{"secret": true})).To(BeEmpty())
+	})
+})
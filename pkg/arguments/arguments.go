@@ -29,7 +29,15 @@ import (
 
 	"github.com/openshift/rosa/pkg/aws/profile"
 	"github.com/openshift/rosa/pkg/aws/region"
+	"github.com/openshift/rosa/pkg/cache"
+	"github.com/openshift/rosa/pkg/cassette"
+	"github.com/openshift/rosa/pkg/config"
 	"github.com/openshift/rosa/pkg/debug"
+	"github.com/openshift/rosa/pkg/interrupt"
+	"github.com/openshift/rosa/pkg/journal"
+	"github.com/openshift/rosa/pkg/reporter"
+	"github.com/openshift/rosa/pkg/retry"
+	"github.com/openshift/rosa/pkg/trace"
 )
 
 const boolType string = "bool"
@@ -296,6 +304,46 @@ func AddDebugFlag(fs *pflag.FlagSet) {
 	debug.AddFlag(fs)
 }
 
+// AddContextFlag adds the '--context' flag to the given set of command line flags.
+func AddContextFlag(fs *pflag.FlagSet) {
+	config.AddContextFlag(fs)
+}
+
+// AddTimeoutFlag adds the '--timeout' flag to the given set of command line flags.
+func AddTimeoutFlag(fs *pflag.FlagSet) {
+	interrupt.AddTimeoutFlag(fs)
+}
+
+// AddErrorFormatFlag adds the '--error-format' flag to the given set of command line flags.
+func AddErrorFormatFlag(fs *pflag.FlagSet) {
+	reporter.AddErrorFormatFlag(fs)
+}
+
+// AddCassetteFlags adds the '--record' and '--replay' flags to the given set of command line flags.
+func AddCassetteFlags(fs *pflag.FlagSet) {
+	cassette.AddFlags(fs)
+}
+
+// AddNoCacheFlag adds the '--no-cache' flag to the given set of command line flags.
+func AddNoCacheFlag(fs *pflag.FlagSet) {
+	cache.AddFlag(fs)
+}
+
+// AddJournalFileFlag adds the '--journal-file' flag to the given set of command line flags.
+func AddJournalFileFlag(fs *pflag.FlagSet) {
+	journal.AddFlag(fs)
+}
+
+// AddTraceFileFlag adds the '--trace-file' flag to the given set of command line flags.
+func AddTraceFileFlag(fs *pflag.FlagSet) {
+	trace.AddFlag(fs)
+}
+
+// AddRetryFlags adds the '--retry-*' flags to the given set of command line flags.
+func AddRetryFlags(fs *pflag.FlagSet) {
+	retry.AddFlags(fs)
+}
+
 // AddProfileFlag adds the '--profile' flag to the given set of command line flags.
 func AddProfileFlag(fs *pflag.FlagSet) {
 	profile.AddFlag(fs)
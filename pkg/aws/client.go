@@ -18,6 +18,7 @@ package aws
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"io"
@@ -55,9 +56,12 @@ import (
 	"github.com/openshift/rosa/pkg/aws/profile"
 	regionflag "github.com/openshift/rosa/pkg/aws/region"
 	"github.com/openshift/rosa/pkg/aws/tags"
+	"github.com/openshift/rosa/pkg/cassette"
+	rosaconfig "github.com/openshift/rosa/pkg/config"
 	"github.com/openshift/rosa/pkg/fedramp"
 	"github.com/openshift/rosa/pkg/helper"
 	"github.com/openshift/rosa/pkg/info"
+	"github.com/openshift/rosa/pkg/interrupt"
 	"github.com/openshift/rosa/pkg/logging"
 	"github.com/openshift/rosa/pkg/reporter"
 )
@@ -96,6 +100,12 @@ const (
 	maxThrottleDelay = 5 * time.Second
 
 	IAMServiceRegion = "us-east-1"
+
+	// Key of the region in the metadata of the AWS cassette, and credentials used to sign the
+	// requests when replaying it:
+	cassetteRegion        = "region"
+	replayAccessKeyID     = "REPLAYACCESSKEYID"
+	replaySecretAccessKey = "replay"
 )
 
 // Client defines a client interface
@@ -231,9 +241,15 @@ type AccessKeyGetter interface {
 // ClientBuilder contains the information and logic needed to build a new AWS client.
 type ClientBuilder struct {
 	logger              *logrus.Logger
+	ctx                 context.Context
 	region              *string
 	credentials         *AccessKey
 	useLocalCredentials bool
+
+	// Configuration of the command line tool, and whether it has been given or loaded already,
+	// as it is nil when there is no configuration file:
+	config       *rosaconfig.Config
+	configLoaded bool
 }
 
 type awsClient struct {
@@ -250,15 +266,25 @@ type awsClient struct {
 	iamQuotaClient      client.ServiceQuotasApiClient
 	awsAccessKeys       *AccessKey
 	useLocalCredentials bool
+	ctx                 context.Context
 }
 
-func CreateNewClientOrExit(logger *logrus.Logger, reporter *reporter.Object) Client {
+// CreateNewClient creates an AWS client using the region and profile selected in the command line.
+func CreateNewClient(logger *logrus.Logger) (Client, error) {
 	awsClient, err := NewClient().
 		Logger(logger).
 		Build()
 	if err != nil {
-		reporter.Errorf("Failed to create AWS client: %v", err)
-		os.Exit(1)
+		return nil, fmt.Errorf("Failed to create AWS client: %w", err)
+	}
+	return awsClient, nil
+}
+
+func CreateNewClientOrExit(logger *logrus.Logger, r *reporter.Object) Client {
+	awsClient, err := CreateNewClient(logger)
+	if err != nil {
+		r.Errorf("%v", err)
+		os.Exit(reporter.ExitCode())
 	}
 
 	return awsClient
@@ -286,19 +312,19 @@ func New(
 
 ) Client {
 	return &awsClient{
-		cfg,
-		logger,
-		iamClient,
-		ec2Client,
-		orgClient,
-		s3Client,
-		smClient,
-		stsClient,
-		cfClient,
-		serviceQuotasClient,
-		iamQuotaClient,
-		awsAccessKeys,
-		useLocalCredentials,
+		cfg:                 cfg,
+		logger:              logger,
+		iamClient:           iamClient,
+		ec2Client:           ec2Client,
+		orgClient:           orgClient,
+		s3Client:            s3Client,
+		smClient:            smClient,
+		stsClient:           stsClient,
+		cfClient:            cfClient,
+		serviceQuotasClient: serviceQuotasClient,
+		iamQuotaClient:      iamQuotaClient,
+		awsAccessKeys:       awsAccessKeys,
+		useLocalCredentials: useLocalCredentials,
 	}
 }
 
@@ -323,32 +349,74 @@ func (b *ClientBuilder) UseLocalCredentials(value bool) *ClientBuilder {
 	return b
 }
 
+// Config sets the configuration of the command line tool that contains the network settings and the
+// retry policy. If it isn't set it is loaded from the configuration file.
+func (b *ClientBuilder) Config(value *rosaconfig.Config) *ClientBuilder {
+	b.config = value
+	b.configLoaded = true
+	return b
+}
+
+// loadConfig returns the configuration given with the Config method, or loads it from the
+// configuration file the first time that it is called.
+func (b *ClientBuilder) loadConfig() (*rosaconfig.Config, error) {
+	if !b.configLoaded {
+		cfg, err := rosaconfig.Load()
+		if err != nil {
+			return nil, err
+		}
+		b.config = cfg
+		b.configLoaded = true
+	}
+	return b.config, nil
+}
+
+// Context sets the context that cancels the requests and waits of the client. If it isn't set the
+// root context of the command line tool is used.
+func (b *ClientBuilder) Context(value context.Context) *ClientBuilder {
+	b.ctx = value
+	return b
+}
+
 // Create AWS session with a specific set of credentials
 func (b *ClientBuilder) BuildSessionWithOptionsCredentials(value *AccessKey,
 	logLevel aws.ClientLogMode) (aws.Config, error) {
-	cfg, err := config.LoadDefaultConfig(context.TODO(),
+	rosaCfg, err := b.loadConfig()
+	if err != nil {
+		return aws.Config{}, err
+	}
+	network, err := networkSettings(rosaCfg)
+	if err != nil {
+		return aws.Config{}, err
+	}
+	retryer, err := b.retryer(rosaCfg)
+	if err != nil {
+		return aws.Config{}, err
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	network(transport)
+	cassetteOptions, err := b.cassetteOptions(transport)
+	if err != nil {
+		return aws.Config{}, err
+	}
+	options := []func(*config.LoadOptions) error{
 		config.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(value.AccessKeyID,
 			value.SecretAccessKey, "")),
 		config.WithRegion(*b.region),
 		config.WithHTTPClient(&http.Client{
-			Transport: http.DefaultTransport,
+			Transport: transport,
 		}),
 		config.WithClientLogMode(logLevel),
 		config.WithAPIOptions([]func(stack *middleware.Stack) error{
 			smithyhttp.AddHeaderValue("User-Agent",
 				strings.Join([]string{info.DefaultUserAgent, info.DefaultVersion}, ";")),
+			b.bindContext,
+			journalOperations,
+			traceOperations,
 		}),
-		config.WithRetryer(func() aws.Retryer {
-			retryer := retry.AddWithMaxAttempts(retry.NewStandard(), numMaxRetries)
-			retryer = retry.AddWithMaxBackoffDelay(retryer, time.Second)
-
-			for _, code := range allErrorCodes {
-				retryer = retry.AddWithErrorCodes(retryer, code)
-			}
-
-			return retryer
-		}),
-	)
+		config.WithRetryer(retryer),
+	}
+	cfg, err := config.LoadDefaultConfig(context.TODO(), append(options, cassetteOptions...)...)
 	if err != nil {
 		return aws.Config{}, err
 	}
@@ -357,26 +425,38 @@ func (b *ClientBuilder) BuildSessionWithOptionsCredentials(value *AccessKey,
 }
 
 func (b *ClientBuilder) BuildSessionWithOptions(logLevel aws.ClientLogMode) (aws.Config, error) {
-	cfg, err := config.LoadDefaultConfig(context.TODO(),
+	rosaCfg, err := b.loadConfig()
+	if err != nil {
+		return aws.Config{}, err
+	}
+	network, err := networkSettings(rosaCfg)
+	if err != nil {
+		return aws.Config{}, err
+	}
+	retryer, err := b.retryer(rosaCfg)
+	if err != nil {
+		return aws.Config{}, err
+	}
+	httpClient := awshttp.NewBuildableClient().WithTransportOptions(network)
+	cassetteOptions, err := b.cassetteOptions(httpClient.GetTransport())
+	if err != nil {
+		return aws.Config{}, err
+	}
+	options := []func(*config.LoadOptions) error{
 		config.WithSharedConfigProfile(profile.Profile()),
 		config.WithRegion(*b.region),
-		config.WithHTTPClient(awshttp.NewBuildableClient().WithTransportOptions()),
+		config.WithHTTPClient(httpClient),
 		config.WithClientLogMode(logLevel),
 		config.WithAPIOptions([]func(stack *middleware.Stack) error{
 			smithyhttp.AddHeaderValue("User-Agent",
 				strings.Join([]string{info.DefaultUserAgent, info.DefaultVersion}, ";")),
+			b.bindContext,
+			journalOperations,
+			traceOperations,
 		}),
-		config.WithRetryer(func() aws.Retryer {
-			retryer := retry.AddWithMaxAttempts(retry.NewStandard(), numMaxRetries)
-			retryer = retry.AddWithMaxBackoffDelay(retryer, time.Second)
-
-			for _, code := range allErrorCodes {
-				retryer = retry.AddWithErrorCodes(retryer, code)
-			}
-
-			return retryer
-		}),
-	)
+		config.WithRetryer(retryer),
+	}
+	cfg, err := config.LoadDefaultConfig(context.TODO(), append(options, cassetteOptions...)...)
 	if err != nil {
 		return aws.Config{}, err
 	}
@@ -384,6 +464,67 @@ func (b *ClientBuilder) BuildSessionWithOptions(logLevel aws.ClientLogMode) (aws
 	return cfg, nil
 }
 
+// networkSettings returns the function that applies the proxy and the CA bundle of the given
+// configuration, that can be nil, to the transport of the AWS clients.
+func networkSettings(cfg *rosaconfig.Config) (func(*http.Transport), error) {
+	proxy, err := cfg.Proxy()
+	if err != nil {
+		return nil, err
+	}
+	pool, err := cfg.CertPool()
+	if err != nil {
+		return nil, err
+	}
+	return func(transport *http.Transport) {
+		transport.Proxy = proxy
+		if pool == nil {
+			return
+		}
+		if transport.TLSClientConfig == nil {
+			transport.TLSClientConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+		}
+		transport.TLSClientConfig.RootCAs = pool
+	}, nil
+}
+
+// cassetteOptions returns the options that record or replay the AWS traffic when requested with the
+// '--record' or '--replay' flags.
+func (b *ClientBuilder) cassetteOptions(transport http.RoundTripper) ([]func(*config.LoadOptions) error,
+	error) {
+	wrapper, err := cassette.TransportWrapper(cassette.AWS)
+	if err != nil || wrapper == nil {
+		return nil, err
+	}
+	options := []func(*config.LoadOptions) error{
+		config.WithHTTPClient(&http.Client{
+			Transport: wrapper(transport),
+		}),
+	}
+	if cassette.Replaying() {
+		// Replayed traffic doesn't need, and must not use, the credentials of the user:
+		options = append(options, config.WithCredentialsProvider(
+			credentials.NewStaticCredentialsProvider(replayAccessKeyID, replaySecretAccessKey, "")))
+	}
+	return options, nil
+}
+
+// bindContext adds the middleware that cancels the AWS requests, including their retries, when the
+// context of the builder is cancelled or the user interrupts the command.
+func (b *ClientBuilder) bindContext(stack *middleware.Stack) error {
+	return stack.Initialize.Add(middleware.InitializeMiddlewareFunc("BindContext",
+		func(ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (
+			middleware.InitializeOutput, middleware.Metadata, error) {
+			var cancel context.CancelFunc
+			if b.ctx != nil {
+				ctx, cancel = interrupt.BindTo(ctx, b.ctx)
+			} else {
+				ctx, cancel = interrupt.Bind(ctx)
+			}
+			defer cancel()
+			return next.HandleInitialize(ctx, in)
+		}), middleware.Before)
+}
+
 func (b *ClientBuilder) BuildSession() (aws.Config, error) {
 	var logLevel aws.ClientLogMode
 	logLevel = 0
@@ -411,7 +552,7 @@ func (b *ClientBuilder) Build() (Client, error) {
 	}
 
 	if b.region == nil || *b.region == "" {
-		region, err := GetRegion(regionflag.Region())
+		region, err := b.defaultRegion()
 		if err != nil {
 			return nil, err
 		}
@@ -459,6 +600,7 @@ func (b *ClientBuilder) Build() (Client, error) {
 		serviceQuotasClient: servicequotas.NewFromConfig(cfg),
 		iamQuotaClient:      servicequotas.NewFromConfig(iamCfg),
 		useLocalCredentials: b.useLocalCredentials,
+		ctx:                 b.ctx,
 	}
 
 	_, root, err := getClientDetails(c)
@@ -473,6 +615,41 @@ func (b *ClientBuilder) Build() (Client, error) {
 	return c, err
 }
 
+// defaultRegion returns the region given with the '--region' flag or configured in the environment.
+// When replaying a cassette the region used to record it is the default, and when recording it is
+// saved to the cassette.
+func (b *ClientBuilder) defaultRegion() (string, error) {
+	recorded, err := cassette.Open(cassette.AWS)
+	if err != nil {
+		return "", err
+	}
+	if cassette.Replaying() && regionflag.Region() == "" {
+		region := recorded.GetMetadata(cassetteRegion)
+		if region != "" {
+			return region, nil
+		}
+	}
+	region, err := GetRegion(regionflag.Region())
+	if err != nil {
+		return "", err
+	}
+	if cassette.Recording() && recorded.GetMetadata(cassetteRegion) == "" {
+		err = recorded.SetMetadata(cassetteRegion, region)
+		if err != nil {
+			return "", err
+		}
+	}
+	return region, nil
+}
+
+// context returns the context that cancels the waits of the client.
+func (c *awsClient) context() context.Context {
+	if c.ctx != nil {
+		return c.ctx
+	}
+	return interrupt.RootContext()
+}
+
 func (c *awsClient) GetIAMCredentials() (aws.Credentials, error) {
 	return c.cfg.Credentials.Retrieve(context.TODO())
 }
@@ -771,7 +948,7 @@ func (c *awsClient) ValidateCredentials() (bool, error) {
 	_, err := c.stsClient.GetCallerIdentity(context.Background(), &sts.GetCallerIdentityInput{})
 	if err != nil {
 		if strings.Contains(fmt.Sprintf("%s", err), "InvalidClientTokenId") {
-			awsErr := fmt.Errorf("Invalid AWS Credentials: %s.\n For help configuring your credentials, see %s",
+			awsErr := fmt.Errorf("Invalid AWS Credentials: %w.\n For help configuring your credentials, see %s",
 				err,
 				"https://docs.openshift.com/rosa/rosa_install_access_delete_clusters/rosa_getting_started_iam/"+
 					"rosa-config-aws-account.html#rosa-configuring-aws-account_rosa-config-aws-account")
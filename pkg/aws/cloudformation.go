@@ -29,6 +29,7 @@ import (
 	"github.com/aws/smithy-go"
 
 	"github.com/openshift/rosa/assets"
+	"github.com/openshift/rosa/pkg/interrupt"
 )
 
 func readCloudFormationTemplate(path string) (string, error) {
@@ -120,10 +121,12 @@ func (c *awsClient) CreateStack(cfTemplateBody, stackName string) (bool, error)
 		return false, err
 	}
 
-	err = waitForStackCreateComplete(context.Background(), c.cfClient, stackName)
+	done := interrupt.Track("CloudFormation stack '%s'", stackName)
+	err = waitForStackCreateComplete(c.context(), c.cfClient, stackName)
 	if err != nil {
 		return false, err
 	}
+	done()
 
 	return true, nil
 }
@@ -143,7 +146,7 @@ func (c *awsClient) UpdateStack(cfTemplateBody, stackName string) error {
 	}
 
 	// Wait for CloudFormation update to complete
-	err = waitForStackUpdateComplete(context.TODO(), c.cfClient, stackName)
+	err = waitForStackUpdateComplete(c.context(), c.cfClient, stackName)
 	if err != nil {
 		return err
 	}
@@ -193,7 +196,7 @@ func (c *awsClient) DeleteOsdCcsAdminUser(stackName string) error {
 	}
 
 	// Wait until cloudformation stack deletes
-	err = waitForStackDeleteComplete(context.Background(), c.cfClient, stackName)
+	err = waitForStackDeleteComplete(c.context(), c.cfClient, stackName)
 	if err != nil {
 		return err
 	}
@@ -184,16 +184,16 @@ func GetAWSClientForUserRegion(reporter *rprtr.Object, logger *logrus.Logger,
 	awsRegionInUserConfig, err := GetRegion(arguments.GetRegion())
 	if err != nil {
 		reporter.Errorf("Error getting region: %v", err)
-		os.Exit(1)
+		os.Exit(rprtr.ExitCode())
 	}
 	if awsRegionInUserConfig == "" {
 		reporter.Errorf("AWS Region not set")
-		os.Exit(1)
+		os.Exit(rprtr.ExitCode())
 	}
 	if !helper.Contains(supportedRegions, awsRegionInUserConfig) {
 		reporter.Errorf("Unsupported region '%s', available regions: %s",
 			awsRegionInUserConfig, helper.SliceToSortedString(supportedRegions))
-		os.Exit(1)
+		os.Exit(rprtr.ExitCode())
 	}
 
 	// Create the AWS client:
@@ -204,7 +204,7 @@ func GetAWSClientForUserRegion(reporter *rprtr.Object, logger *logrus.Logger,
 		Build()
 	if err != nil {
 		reporter.Errorf("Error creating aws client for stack validation: %v", err)
-		os.Exit(1)
+		os.Exit(rprtr.ExitCode())
 	}
 	regionUsedForInit, err := client.GetClusterRegionTagForUser(AdminUserName)
 	if err != nil || regionUsedForInit == "" {
@@ -215,7 +215,7 @@ func GetAWSClientForUserRegion(reporter *rprtr.Object, logger *logrus.Logger,
 		if !helper.Contains(supportedRegions, regionUsedForInit) {
 			reporter.Errorf("Unsupported region '%s', available regions: %s",
 				regionUsedForInit, helper.SliceToSortedString(supportedRegions))
-			os.Exit(1)
+			os.Exit(rprtr.ExitCode())
 		}
 		// Create the AWS client with the region used in the init
 		//So we can check for the stack in that region
@@ -226,7 +226,7 @@ func GetAWSClientForUserRegion(reporter *rprtr.Object, logger *logrus.Logger,
 			Build()
 		if err != nil {
 			reporter.Errorf("Error creating aws client for stack validation: %v", err)
-			os.Exit(1)
+			os.Exit(rprtr.ExitCode())
 		}
 		return awsClient
 	}
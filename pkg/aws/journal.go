@@ -0,0 +1,149 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the middleware that journals the AWS operations that change resources.
+
+package aws
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strings"
+
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/smithy-go/middleware"
+
+	"github.com/openshift/rosa/pkg/journal"
+)
+
+// Prefixes of the names of the AWS operations that change resources:
+var mutatingOperationPrefixes = []string{
+	"Add", "Attach", "Create", "Delete", "Detach", "Put", "Remove", "Tag", "Untag", "Update",
+}
+
+// Maximum depth of the nested structures where ARNs are searched:
+const maxARNDepth = 3
+
+// journalOperations adds the middleware that journals the successful operations that change
+// resources, with the ARNs that appear in their inputs and outputs. It runs after the middleware
+// that stores the name of the service and of the operation in the context.
+func journalOperations(stack *middleware.Stack) error {
+	return stack.Initialize.Add(middleware.InitializeMiddlewareFunc("JournalOperations",
+		func(ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (
+			middleware.InitializeOutput, middleware.Metadata, error) {
+			out, metadata, err := next.HandleInitialize(ctx, in)
+			name := awsmiddleware.GetOperationName(ctx)
+			if err != nil || !journal.Active() || !isMutatingOperation(name) {
+				return out, metadata, err
+			}
+			arns := map[string]bool{}
+			collectARNs(reflect.ValueOf(in.Parameters), arns, 0)
+			collectARNs(reflect.ValueOf(out.Result), arns, 0)
+			operation := &journal.Operation{
+				Provider: journal.AWS,
+				Action:   name,
+				Resource: awsmiddleware.GetServiceID(ctx),
+				ID:       resourceName(in.Parameters),
+			}
+			for arn := range arns {
+				operation.ARNs = append(operation.ARNs, arn)
+			}
+			sort.Strings(operation.ARNs)
+			journal.Record(operation)
+			return out, metadata, err
+		}), middleware.After)
+}
+
+func isMutatingOperation(name string) bool {
+	for _, prefix := range mutatingOperationPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// collectARNs adds to the set the values of the string fields whose names end with 'Arn' or 'ARN',
+// searching nested structures and slices.
+func collectARNs(value reflect.Value, arns map[string]bool, depth int) {
+	if depth > maxARNDepth {
+		return
+	}
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return
+		}
+		value = value.Elem()
+	}
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			collectARNs(value.Index(i), arns, depth+1)
+		}
+	case reflect.Struct:
+		for i := 0; i < value.NumField(); i++ {
+			field := value.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			if strings.HasSuffix(field.Name, "Arn") || strings.HasSuffix(field.Name, "ARN") {
+				if text := stringOf(value.Field(i)); strings.HasPrefix(text, "arn:") {
+					arns[text] = true
+				}
+				continue
+			}
+			collectARNs(value.Field(i), arns, depth+1)
+		}
+	}
+}
+
+// resourceName returns the name of the resource given in the input of an operation, like the
+// 'RoleName' of the IAM operations, as those that delete resources usually don't contain ARNs.
+func resourceName(input interface{}) string {
+	value := reflect.ValueOf(input)
+	for value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return ""
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return ""
+	}
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		if field.IsExported() && strings.HasSuffix(field.Name, "Name") {
+			if text := stringOf(value.Field(i)); text != "" {
+				return text
+			}
+		}
+	}
+	return ""
+}
+
+func stringOf(value reflect.Value) string {
+	if value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return ""
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.String {
+		return ""
+	}
+	return value.String()
+}
@@ -0,0 +1,44 @@
+package aws
+
+import (
+	"reflect"
+
+	awsSdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Journal middleware", func() {
+	It("Recognizes the operations that change resources", func() {
+		Expect(isMutatingOperation("CreateRole")).To(BeTrue())
+		Expect(isMutatingOperation("DetachRolePolicy")).To(BeTrue())
+		Expect(isMutatingOperation("GetRole")).To(BeFalse())
+		Expect(isMutatingOperation("ListAttachedRolePolicies")).To(BeFalse())
+	})
+
+	It("Collects the ARNs of the inputs and outputs", func() {
+		arns := map[string]bool{}
+		collectARNs(reflect.ValueOf(&iam.AttachRolePolicyInput{
+			RoleName:  awsSdk.String("my-role"),
+			PolicyArn: awsSdk.String("arn:aws:iam::123456789012:policy/my-policy"),
+		}), arns, 0)
+		collectARNs(reflect.ValueOf(&iam.CreateRoleOutput{
+			Role: &iamtypes.Role{
+				Arn:      awsSdk.String("arn:aws:iam::123456789012:role/my-role"),
+				RoleName: awsSdk.String("my-role"),
+			},
+		}), arns, 0)
+		Expect(arns).To(Equal(map[string]bool{
+			"arn:aws:iam::123456789012:policy/my-policy": true,
+			"arn:aws:iam::123456789012:role/my-role":     true,
+		}))
+	})
+
+	It("Returns the name of the resource of the input", func() {
+		Expect(resourceName(&iam.DeleteRoleInput{RoleName: awsSdk.String("my-role")})).To(Equal("my-role"))
+		Expect(resourceName(&iam.DeleteOpenIDConnectProviderInput{})).To(BeEmpty())
+	})
+})
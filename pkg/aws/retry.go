@@ -0,0 +1,88 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the retryer of the AWS clients, which follows the retry policy given in the
+// configuration and the '--retry-*' flags.
+
+package aws
+
+import (
+	"errors"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+	"github.com/sirupsen/logrus"
+
+	rosaconfig "github.com/openshift/rosa/pkg/config"
+	rosaretry "github.com/openshift/rosa/pkg/retry"
+)
+
+// defaultRetryPolicy is the policy used for the AWS APIs when the configuration and the flags don't
+// change it. It makes many attempts because the credentials of new IAM users take a while to be
+// usable.
+var defaultRetryPolicy = rosaretry.Policy{
+	MaxAttempts: numMaxRetries,
+	Backoff:     minRetryDelay,
+	MaxBackoff:  maxThrottleDelay,
+	Jitter:      rosaretry.DefaultPolicy.Jitter,
+}
+
+// retryer returns the function that creates the retryers of the AWS clients, using the retry policy
+// of the given configuration. They retry the standard retryable errors, throttling errors and
+// invalid client tokens.
+func (b *ClientBuilder) retryer(cfg *rosaconfig.Config) (func() aws.Retryer, error) {
+	policy, err := rosaretry.Current(cfg, defaultRetryPolicy)
+	if err != nil {
+		return nil, err
+	}
+	return func() aws.Retryer {
+		var retryer aws.Retryer = retry.NewStandard(func(options *retry.StandardOptions) {
+			options.MaxAttempts = policy.MaxAttempts
+			options.MaxBackoff = policy.MaxBackoff
+			options.Backoff = &backoff{
+				policy: policy,
+				logger: b.logger,
+			}
+		})
+		for _, code := range allErrorCodes {
+			retryer = retry.AddWithErrorCodes(retryer, code)
+		}
+		return retryer
+	}, nil
+}
+
+// backoff computes the time to wait before retrying an AWS operation, honouring the 'Retry-After'
+// header of the response when there is one.
+type backoff struct {
+	policy rosaretry.Policy
+	logger *logrus.Logger
+}
+
+func (b *backoff) BackoffDelay(attempt int, err error) (time.Duration, error) {
+	var retryAfter time.Duration
+	var responseErr *smithyhttp.ResponseError
+	if errors.As(err, &responseErr) && responseErr.Response != nil {
+		retryAfter = rosaretry.RetryAfter(responseErr.Response.Header, time.Now())
+	}
+	delay := b.policy.Delay(attempt, retryAfter)
+	if b.logger != nil {
+		b.logger.Debugf("AWS operation failed with '%v', retrying in %s (attempt %d of %d)",
+			err, delay.Round(time.Millisecond), attempt+1, b.policy.MaxAttempts)
+	}
+	return delay, nil
+}
@@ -0,0 +1,83 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the middleware that traces the AWS operations.
+
+package aws
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	"github.com/aws/smithy-go/middleware"
+
+	"github.com/openshift/rosa/pkg/trace"
+)
+
+// traceOperations adds the middleware that traces each operation, including all its attempts, with
+// the number of retries and the ARNs that appear in its input and output. It runs after the
+// middleware that stores the name of the service and of the operation in the context.
+func traceOperations(stack *middleware.Stack) error {
+	return stack.Initialize.Add(middleware.InitializeMiddlewareFunc("TraceOperations",
+		func(ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (
+			middleware.InitializeOutput, middleware.Metadata, error) {
+			service := awsmiddleware.GetServiceID(ctx)
+			name := awsmiddleware.GetOperationName(ctx)
+			span := trace.Call(service + "/" + name)
+			if span == nil {
+				return next.HandleInitialize(ctx, in)
+			}
+			span.Set("rpc.system", "aws-api")
+			span.Set("rpc.service", service)
+			span.Set("rpc.method", name)
+			if region := awsmiddleware.GetRegion(ctx); region != "" {
+				span.Set("cloud.region", region)
+			}
+			if resource := resourceName(in.Parameters); resource != "" {
+				span.Set("rosa.aws.resource", resource)
+			}
+			out, metadata, err := next.HandleInitialize(ctx, in)
+			if results, ok := retry.GetAttemptResults(metadata); ok && len(results.Results) > 0 {
+				span.Set("http.request.resend_count", len(results.Results)-1)
+			}
+			if requestID, ok := awsmiddleware.GetRequestIDMetadata(metadata); ok {
+				span.Set("aws.request_id", requestID)
+			}
+			var responseErr *awshttp.ResponseError
+			if errors.As(err, &responseErr) {
+				span.Set("http.response.status_code", responseErr.HTTPStatusCode())
+				span.Set("aws.request_id", responseErr.ServiceRequestID())
+			}
+			arns := map[string]bool{}
+			collectARNs(reflect.ValueOf(in.Parameters), arns, 0)
+			collectARNs(reflect.ValueOf(out.Result), arns, 0)
+			if len(arns) > 0 {
+				values := make([]string, 0, len(arns))
+				for arn := range arns {
+					values = append(values, arn)
+				}
+				sort.Strings(values)
+				span.Set("rosa.aws.arns", values)
+			}
+			span.End(err)
+			return out, metadata, err
+		}), middleware.After)
+}
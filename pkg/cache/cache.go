@@ -23,6 +23,7 @@ type RosaCache interface {
 	Set(k string, x interface{}, d time.Time)
 	Get(k string) (interface{}, bool)
 	Items() map[string]Item
+	Flush()
 	Dir() (string, error)
 }
 
@@ -94,6 +95,13 @@ func (c *rosaCache) Items() map[string]Item {
 	return m
 }
 
+// Flush removes all the items of the cache.
+func (c *rosaCache) Flush() {
+	c.mu.Lock()
+	c.items = make(map[string]Item)
+	c.mu.Unlock()
+}
+
 func (c *rosaCache) Dir() (string, error) {
 	configDir, hasEnvVar, err := getConfigDirectoryEnvVar()
 	if err != nil {
@@ -5,6 +5,7 @@
 //
 //	mockgen -source=cache.go -package=cache -destination=./cache_mock.go
 //
+
 // Package cache is a generated GoMock package.
 package cache
 
@@ -53,6 +54,18 @@ func (mr *MockRosaCacheMockRecorder) Dir() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Dir", reflect.TypeOf((*MockRosaCache)(nil).Dir))
 }
 
+// Flush mocks base method.
+func (m *MockRosaCache) Flush() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Flush")
+}
+
+// Flush indicates an expected call of Flush.
+func (mr *MockRosaCacheMockRecorder) Flush() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Flush", reflect.TypeOf((*MockRosaCache)(nil).Flush))
+}
+
 // Get mocks base method.
 func (m *MockRosaCache) Get(k string) (any, bool) {
 	m.ctrl.T.Helper()
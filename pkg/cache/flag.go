@@ -0,0 +1,46 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the '--no-cache' flag.
+
+package cache
+
+import (
+	"github.com/spf13/pflag"
+)
+
+var disabled bool
+
+// AddFlag adds the '--no-cache' flag to the given set of command line flags.
+func AddFlag(flags *pflag.FlagSet) {
+	flags.BoolVar(
+		&disabled,
+		"no-cache",
+		false,
+		"Ignore the cached OCM responses and fetch them again. The fetched responses are still "+
+			"stored in the cache.",
+	)
+}
+
+// Disabled returns true if the cached responses should be ignored.
+func Disabled() bool {
+	return disabled
+}
+
+// SetDisabled sets whether the cached responses should be ignored.
+func SetDisabled(value bool) {
+	disabled = value
+}
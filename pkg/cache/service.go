@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"time"
 )
 
 const (
@@ -20,6 +21,10 @@ type RosaCacheService interface {
 	LoadCache() (RosaCache, error)
 	Get(key string) (interface{}, bool)
 	Set(key string, value []string) error
+	GetBytes(key string) ([]byte, bool)
+	SetBytes(key string, value []byte, ttl time.Duration) error
+	Items() map[string]Item
+	Clear() error
 }
 
 var _ RosaCacheService = &rosaCacheService{}
@@ -83,6 +88,33 @@ func (r rosaCacheService) Set(key string, value []string) error {
 	return r.saveCache()
 }
 
+// GetBytes returns the encoded value stored with the given key, if it exists and hasn't expired.
+func (r rosaCacheService) GetBytes(key string) ([]byte, bool) {
+	value, ok := r.Cache.Get(key)
+	if !ok {
+		return nil, false
+	}
+	data, ok := value.([]byte)
+	return data, ok
+}
+
+// SetBytes stores an encoded value that expires after the given time to live, and saves the cache.
+func (r rosaCacheService) SetBytes(key string, value []byte, ttl time.Duration) error {
+	r.Cache.Set(key, value, time.Now().Add(ttl))
+	return r.saveCache()
+}
+
+// Items returns the values of the cache that haven't expired.
+func (r rosaCacheService) Items() map[string]Item {
+	return r.Cache.Items()
+}
+
+// Clear removes all the values of the cache, and saves it.
+func (r rosaCacheService) Clear() error {
+	r.Cache.Flush()
+	return r.saveCache()
+}
+
 func (r rosaCacheService) saveCache() error {
 	filePath, err := r.Cache.Dir()
 	if err != nil {
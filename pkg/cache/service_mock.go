@@ -5,11 +5,13 @@
 //
 //	mockgen -source=service.go -package=cache -destination=./service_mock.go
 //
+
 // Package cache is a generated GoMock package.
 package cache
 
 import (
 	reflect "reflect"
+	time "time"
 
 	gomock "go.uber.org/mock/gomock"
 )
@@ -37,6 +39,20 @@ func (m *MockRosaCacheService) EXPECT() *MockRosaCacheServiceMockRecorder {
 	return m.recorder
 }
 
+// Clear mocks base method.
+func (m *MockRosaCacheService) Clear() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Clear")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Clear indicates an expected call of Clear.
+func (mr *MockRosaCacheServiceMockRecorder) Clear() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Clear", reflect.TypeOf((*MockRosaCacheService)(nil).Clear))
+}
+
 // Get mocks base method.
 func (m *MockRosaCacheService) Get(key string) (any, bool) {
 	m.ctrl.T.Helper()
@@ -52,6 +68,35 @@ func (mr *MockRosaCacheServiceMockRecorder) Get(key any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRosaCacheService)(nil).Get), key)
 }
 
+// GetBytes mocks base method.
+func (m *MockRosaCacheService) GetBytes(key string) ([]byte, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBytes", key)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// GetBytes indicates an expected call of GetBytes.
+func (mr *MockRosaCacheServiceMockRecorder) GetBytes(key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBytes", reflect.TypeOf((*MockRosaCacheService)(nil).GetBytes), key)
+}
+
+// Items mocks base method.
+func (m *MockRosaCacheService) Items() map[string]Item {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Items")
+	ret0, _ := ret[0].(map[string]Item)
+	return ret0
+}
+
+// Items indicates an expected call of Items.
+func (mr *MockRosaCacheServiceMockRecorder) Items() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Items", reflect.TypeOf((*MockRosaCacheService)(nil).Items))
+}
+
 // LoadCache mocks base method.
 func (m *MockRosaCacheService) LoadCache() (RosaCache, error) {
 	m.ctrl.T.Helper()
@@ -80,3 +125,17 @@ func (mr *MockRosaCacheServiceMockRecorder) Set(key, value any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockRosaCacheService)(nil).Set), key, value)
 }
+
+// SetBytes mocks base method.
+func (m *MockRosaCacheService) SetBytes(key string, value []byte, ttl time.Duration) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetBytes", key, value, ttl)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetBytes indicates an expected call of SetBytes.
+func (mr *MockRosaCacheServiceMockRecorder) SetBytes(key, value, ttl any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBytes", reflect.TypeOf((*MockRosaCacheService)(nil).SetBytes), key, value, ttl)
+}
@@ -0,0 +1,110 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the typed access to the cache, where values are stored encoded and each key
+// has its own time to live.
+
+package cache
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Codec converts values of type T to and from the bytes stored in the cache. The list marshal and
+// unmarshal functions generated by the OCM SDK, like cmv1.MarshalVersionList and
+// cmv1.UnmarshalVersionList, can be used directly.
+type Codec[T any] struct {
+	Marshal   func(T, io.Writer) error
+	Unmarshal func(interface{}) (T, error)
+}
+
+// JSONCodec returns a codec that uses the standard JSON encoding, for types that aren't generated
+// by the OCM SDK.
+func JSONCodec[T any]() Codec[T] {
+	return Codec[T]{
+		Marshal: func(value T, writer io.Writer) error {
+			return json.NewEncoder(writer).Encode(value)
+		},
+		Unmarshal: func(source interface{}) (value T, err error) {
+			data, ok := source.([]byte)
+			if !ok {
+				err = fmt.Errorf("expected bytes but got %T", source)
+				return
+			}
+			err = json.Unmarshal(data, &value)
+			return
+		},
+	}
+}
+
+// Fetch returns the value stored in the cache with the given key. If there is no such value, it
+// has expired, or the cache is disabled with the '--no-cache' flag, it calls the fetch function
+// and stores the result for the given time to live. Failures to read or write the cache are
+// ignored, as the value can always be fetched again. A nil service disables the cache.
+func Fetch[T any](service RosaCacheService, key string, ttl time.Duration, codec Codec[T],
+	fetch func() (T, error)) (T, error) {
+	if service == nil {
+		return fetch()
+	}
+	if !Disabled() {
+		data, ok := service.GetBytes(key)
+		if ok {
+			value, err := codec.Unmarshal(data)
+			if err == nil {
+				return value, nil
+			}
+		}
+	}
+	value, err := fetch()
+	if err != nil {
+		return value, err
+	}
+	buffer := &bytes.Buffer{}
+	if codec.Marshal(value, buffer) == nil {
+		_ = service.SetBytes(key, buffer.Bytes(), ttl)
+	}
+	return value, nil
+}
+
+// Namespace calculates the prefix of the keys of the values fetched from the OCM environment with
+// the given URL by the given identity, so that switching between environments or accounts never
+// returns values fetched by another one.
+func Namespace(url string, identity string) string {
+	hash := sha256.Sum256([]byte(url + "|" + identity))
+	return hex.EncodeToString(hash[:])[:12]
+}
+
+// Key joins the namespace and the name of a value to form its key.
+func Key(namespace string, name string) string {
+	return namespace + "/" + name
+}
+
+// SplitKey returns the namespace and the name of a key. Keys stored without a namespace return an
+// empty namespace.
+func SplitKey(key string) (namespace string, name string) {
+	namespace, name, found := strings.Cut(key, "/")
+	if !found {
+		return "", key
+	}
+	return namespace, name
+}
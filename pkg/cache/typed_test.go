@@ -0,0 +1,99 @@
+package cache
+
+import (
+	"errors"
+	"time"
+
+	"go.uber.org/mock/gomock"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Fetch", func() {
+	var (
+		ctrl    *gomock.Controller
+		service *MockRosaCacheService
+		codec   Codec[[]string]
+		calls   int
+		fetch   func() ([]string, error)
+	)
+
+	BeforeEach(func() {
+		ctrl = gomock.NewController(GinkgoT())
+		service = NewMockRosaCacheService(ctrl)
+		codec = JSONCodec[[]string]()
+		calls = 0
+		fetch = func() ([]string, error) {
+			calls++
+			return []string{"4.16.0"}, nil
+		}
+	})
+
+	AfterEach(func() {
+		SetDisabled(false)
+		ctrl.Finish()
+	})
+
+	It("should return the cached value without fetching", func() {
+		service.EXPECT().GetBytes("ns/versions").Return([]byte(`["4.15.20"]`), true)
+
+		value, err := Fetch(service, "ns/versions", time.Hour, codec, fetch)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(value).To(Equal([]string{"4.15.20"}))
+		Expect(calls).To(BeZero())
+	})
+
+	It("should fetch and store the value when it isn't cached", func() {
+		service.EXPECT().GetBytes("ns/versions").Return(nil, false)
+		service.EXPECT().SetBytes("ns/versions", []byte("[\"4.16.0\"]\n"), time.Hour).Return(nil)
+
+		value, err := Fetch(service, "ns/versions", time.Hour, codec, fetch)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(value).To(Equal([]string{"4.16.0"}))
+		Expect(calls).To(Equal(1))
+	})
+
+	It("should skip the read but refresh the value when disabled", func() {
+		SetDisabled(true)
+		service.EXPECT().SetBytes("ns/versions", gomock.Any(), time.Hour).Return(nil)
+
+		value, err := Fetch(service, "ns/versions", time.Hour, codec, fetch)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(value).To(Equal([]string{"4.16.0"}))
+		Expect(calls).To(Equal(1))
+	})
+
+	It("should not store failed fetches", func() {
+		service.EXPECT().GetBytes("ns/versions").Return(nil, false)
+
+		_, err := Fetch(service, "ns/versions", time.Hour, codec, func() ([]string, error) {
+			return nil, errors.New("boom")
+		})
+		Expect(err).To(MatchError("boom"))
+	})
+
+	It("should fetch directly without a service", func() {
+		value, err := Fetch(nil, "ns/versions", time.Hour, codec, fetch)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(value).To(Equal([]string{"4.16.0"}))
+	})
+})
+
+var _ = Describe("Keys", func() {
+	It("should split the keys that it joins", func() {
+		namespace := Namespace("https://api.openshift.com", "user")
+		Expect(namespace).To(HaveLen(12))
+		Expect(Namespace("https://api.stage.openshift.com", "user")).NotTo(Equal(namespace))
+
+		ns, name := SplitKey(Key(namespace, "versions/rosa/stable"))
+		Expect(ns).To(Equal(namespace))
+		Expect(name).To(Equal("versions/rosa/stable"))
+	})
+
+	It("should return an empty namespace for plain keys", func() {
+		ns, name := SplitKey("versions")
+		Expect(ns).To(BeEmpty())
+		Expect(name).To(Equal("versions"))
+	})
+})
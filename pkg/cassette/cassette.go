@@ -0,0 +1,253 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the types used to store the HTTP interactions recorded with the '--record'
+// flag, and the logic to save them to and load them from the cassette files. Cassette files contain
+// one JSON document per line: a header with the version, followed by the metadata values and the
+// interactions in the order they were recorded, so that recording appends to the file instead of
+// rewriting it.
+
+package cassette
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"unicode/utf8"
+)
+
+// Names of the cassettes used for the traffic of each of the clients:
+const (
+	OCM = "ocm"
+	AWS = "aws"
+)
+
+// Cassette is the set of HTTP interactions recorded for one of the clients, together with the
+// metadata needed to create an equivalent client when replaying them.
+type Cassette struct {
+	Version      int
+	Metadata     map[string]string
+	Interactions []*Interaction
+
+	lock sync.Mutex
+	file string
+	used []bool
+}
+
+// Interaction is a request sent and the response received for it.
+type Interaction struct {
+	Request  *Request  `json:"request"`
+	Response *Response `json:"response"`
+}
+
+// Request contains the details of a recorded request, with the sensitive data redacted.
+type Request struct {
+	Method       string      `json:"method"`
+	URL          string      `json:"url"`
+	Header       http.Header `json:"header,omitempty"`
+	Body         string      `json:"body,omitempty"`
+	BodyEncoding string      `json:"body_encoding,omitempty"`
+}
+
+// Response contains the details of a recorded response, with the sensitive data redacted.
+type Response struct {
+	Status       int         `json:"status"`
+	Header       http.Header `json:"header,omitempty"`
+	Body         string      `json:"body,omitempty"`
+	BodyEncoding string      `json:"body_encoding,omitempty"`
+}
+
+// entry is each of the lines of a cassette file. Only one of the fields is set.
+type entry struct {
+	Version     int               `json:"version,omitempty"`
+	Metadata    map[string]string `json:"metadata,omitempty"`
+	Interaction *Interaction      `json:"interaction,omitempty"`
+}
+
+const (
+	version        = 2
+	base64Encoding = "base64"
+)
+
+// createCassette creates an empty cassette, writing its header to the given file.
+func createCassette(file string) (*Cassette, error) {
+	err := os.MkdirAll(filepath.Dir(file), 0700)
+	if err != nil {
+		return nil, err
+	}
+	err = os.WriteFile(file, nil, 0600)
+	if err != nil {
+		return nil, err
+	}
+	result := &Cassette{
+		Version:  version,
+		Metadata: map[string]string{},
+		file:     file,
+	}
+	err = result.append(&entry{Version: version})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// loadCassette loads the cassette stored in the given file.
+func loadCassette(file string) (*Cassette, error) {
+	reader, err := os.Open(file)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read cassette '%s': %v", file, err)
+	}
+	defer reader.Close()
+	result := &Cassette{
+		Metadata: map[string]string{},
+		file:     file,
+	}
+	decoder := json.NewDecoder(reader)
+	for {
+		line := &entry{}
+		err = decoder.Decode(line)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("Failed to parse cassette '%s': %v", file, err)
+		}
+		switch {
+		case line.Version != 0:
+			result.Version = line.Version
+		case line.Interaction != nil:
+			result.Interactions = append(result.Interactions, line.Interaction)
+		default:
+			for key, value := range line.Metadata {
+				result.Metadata[key] = value
+			}
+		}
+	}
+	if result.Version != version {
+		return nil, fmt.Errorf("Unsupported version %d of cassette '%s'", result.Version, file)
+	}
+	result.used = make([]bool, len(result.Interactions))
+	return result, nil
+}
+
+// SetMetadata sets a metadata value and saves it to the cassette.
+func (c *Cassette) SetMetadata(key string, value string) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.Metadata == nil {
+		c.Metadata = map[string]string{}
+	}
+	c.Metadata[key] = value
+	return c.append(&entry{Metadata: map[string]string{key: value}})
+}
+
+// GetMetadata returns a metadata value, or an empty string if it isn't set.
+func (c *Cassette) GetMetadata(key string) string {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.Metadata[key]
+}
+
+// add adds an interaction and appends it to the cassette file. Each interaction is written when it
+// happens because most commands exit the process directly when they fail.
+func (c *Cassette) add(interaction *Interaction) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.Interactions = append(c.Interactions, interaction)
+	return c.append(&entry{Interaction: interaction})
+}
+
+// find returns the recorded interaction that corresponds to the given request. Interactions are
+// used in the order they were recorded, preferring the ones with the same body. When all the
+// matching interactions have been used the last one is repeated, which is what polling loops need.
+func (c *Cassette) find(request *Request) *Interaction {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	candidates := []int{}
+	for i, interaction := range c.Interactions {
+		if interaction.Request.Method == request.Method && interaction.Request.URL == request.URL {
+			candidates = append(candidates, i)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+	selected := -1
+	for _, i := range candidates {
+		if !c.used[i] && c.Interactions[i].Request.Body == request.Body {
+			selected = i
+			break
+		}
+	}
+	if selected == -1 {
+		for _, i := range candidates {
+			if !c.used[i] {
+				selected = i
+				break
+			}
+		}
+	}
+	if selected == -1 {
+		selected = candidates[len(candidates)-1]
+	}
+	c.used[selected] = true
+	return c.Interactions[selected]
+}
+
+// append writes a line to the end of the cassette file.
+func (c *Cassette) append(line *entry) error {
+	buffer := &bytes.Buffer{}
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+	err := encoder.Encode(line)
+	if err != nil {
+		return err
+	}
+	file, err := os.OpenFile(c.file, os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+	_, err = file.Write(buffer.Bytes())
+	if err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// encodeBody returns the text stored in the cassette for the given body, and the encoding used
+// for it.
+func encodeBody(body []byte) (text string, encoding string) {
+	if utf8.Valid(body) {
+		return string(body), ""
+	}
+	return base64.StdEncoding.EncodeToString(body), base64Encoding
+}
+
+// decodeBody does the opposite of encodeBody.
+func decodeBody(text string, encoding string) ([]byte, error) {
+	if encoding == base64Encoding {
+		return base64.StdEncoding.DecodeString(text)
+	}
+	return []byte(text), nil
+}
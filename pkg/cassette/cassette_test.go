@@ -0,0 +1,170 @@
+package cassette
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Cassette", func() {
+	var server *ghttp.Server
+	var serverURL string
+	var dir string
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		serverURL = server.URL()
+		dir = GinkgoT().TempDir()
+	})
+
+	AfterEach(func() {
+		server.Close()
+		SetRecordDir("")
+		SetReplayDir("")
+	})
+
+	send := func(client *http.Client, method string, path string, contentType string, body string) (
+		int, string) {
+		request, err := http.NewRequest(method, serverURL+path, strings.NewReader(body))
+		Expect(err).NotTo(HaveOccurred())
+		request.Header.Set("Authorization", "Bearer my-secret-token")
+		if contentType != "" {
+			request.Header.Set("Content-Type", contentType)
+		}
+		response, err := client.Do(request)
+		Expect(err).NotTo(HaveOccurred())
+		defer response.Body.Close()
+		data, err := io.ReadAll(response.Body)
+		Expect(err).NotTo(HaveOccurred())
+		return response.StatusCode, string(data)
+	}
+
+	clientFor := func(name string) *http.Client {
+		wrapper, err := TransportWrapper(name)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(wrapper).NotTo(BeNil())
+		return &http.Client{Transport: wrapper(http.DefaultTransport)}
+	}
+
+	It("Does nothing when neither recording nor replaying", func() {
+		wrapper, err := TransportWrapper(OCM)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(wrapper).To(BeNil())
+	})
+
+	It("Rejects recording and replaying at the same time", func() {
+		SetRecordDir(dir)
+		SetReplayDir(dir)
+		Expect(ValidateFlags()).To(MatchError(
+			"The '--record' and '--replay' flags can't be used together"))
+	})
+
+	It("Records redacted interactions and replays them without the server", func() {
+		server.AppendHandlers(
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest(http.MethodPost, "/token"),
+				ghttp.RespondWith(http.StatusOK,
+					`{"access_token":"real-access","refresh_token":"real-refresh"}`,
+					http.Header{"Content-Type": []string{"application/json"}}),
+			),
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123"),
+				ghttp.RespondWith(http.StatusOK,
+					`{"id":"123","state":"installing"}`,
+					http.Header{"Content-Type": []string{"application/json"}}),
+			),
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123"),
+				ghttp.RespondWith(http.StatusOK,
+					`{"id":"123","state":"ready"}`,
+					http.Header{"Content-Type": []string{"application/json"}}),
+			),
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/credentials"),
+				ghttp.RespondWith(http.StatusOK,
+					`{"admin":{"user":"kubeadmin","password":"real-password"},"kubeconfig":"real"}`,
+					http.Header{"Content-Type": []string{"application/json"}}),
+			),
+		)
+		form := url.Values{
+			"grant_type":    []string{"refresh_token"},
+			"refresh_token": []string{"real-refresh"},
+			"client_secret": []string{"real-secret"},
+		}.Encode()
+
+		// Record:
+		SetRecordDir(dir)
+		client := clientFor(OCM)
+		_, body := send(client, http.MethodPost, "/token", "application/x-www-form-urlencoded", form)
+		Expect(body).To(ContainSubstring("real-access"))
+		_, body = send(client, http.MethodGet, "/api/clusters_mgmt/v1/clusters/123", "", "")
+		Expect(body).To(ContainSubstring("installing"))
+		_, body = send(client, http.MethodGet, "/api/clusters_mgmt/v1/clusters/123", "", "")
+		Expect(body).To(ContainSubstring("ready"))
+		_, body = send(client, http.MethodGet, "/api/clusters_mgmt/v1/credentials", "", "")
+		Expect(body).To(ContainSubstring("real-password"))
+
+		data, err := os.ReadFile(filepath.Join(dir, "ocm.jsonl"))
+		Expect(err).NotTo(HaveOccurred())
+		// The header and one line for each interaction:
+		Expect(strings.Count(string(data), "\n")).To(Equal(5))
+		for _, secret := range []string{"my-secret-token", "real-access", "real-refresh", "real-secret",
+			"real-password", `\"real\"`} {
+			Expect(string(data)).NotTo(ContainSubstring(secret))
+		}
+
+		// Replay, after stopping the server:
+		server.Close()
+		SetRecordDir("")
+		SetReplayDir(dir)
+		client = clientFor(OCM)
+		status, body := send(client, http.MethodPost, "/token", "application/x-www-form-urlencoded", form)
+		Expect(status).To(Equal(http.StatusOK))
+		Expect(body).To(ContainSubstring(FakeToken("Bearer")))
+		_, body = send(client, http.MethodGet, "/api/clusters_mgmt/v1/clusters/123", "", "")
+		Expect(body).To(ContainSubstring("installing"))
+		_, body = send(client, http.MethodGet, "/api/clusters_mgmt/v1/clusters/123", "", "")
+		Expect(body).To(ContainSubstring("ready"))
+		// Polling after the last recorded response repeats it:
+		_, body = send(client, http.MethodGet, "/api/clusters_mgmt/v1/clusters/123", "", "")
+		Expect(body).To(ContainSubstring("ready"))
+
+		request, err := http.NewRequest(http.MethodGet, serverURL+"/api/other", nil)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = client.Do(request)
+		Expect(err).To(MatchError(ContainSubstring("No interaction recorded in cassette")))
+	})
+
+	It("Redacts AWS secrets from XML responses and signed URLs", func() {
+		Expect(string(redactBody(
+			http.Header{"Content-Type": []string{"text/xml"}},
+			[]byte("<Credentials><AccessKeyId>AKIA</AccessKeyId>"+
+				"<SecretAccessKey>secret</SecretAccessKey></Credentials>"),
+		))).To(Equal("<Credentials><AccessKeyId>AKIA</AccessKeyId>" +
+			"<SecretAccessKey>***</SecretAccessKey></Credentials>"))
+		parsed, err := url.Parse("https://bucket.s3.amazonaws.com/key?X-Amz-Signature=abc&x=1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(redactURL(parsed)).To(Equal(
+			"https://bucket.s3.amazonaws.com/key?X-Amz-Signature=%2A%2A%2A&x=1"))
+	})
+
+	It("Stores the metadata", func() {
+		SetRecordDir(dir)
+		recorded, err := Open(AWS)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(recorded.SetMetadata("region", "us-west-2")).To(Succeed())
+
+		SetRecordDir("")
+		SetReplayDir(dir)
+		replayed, err := Open(AWS)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(replayed.GetMetadata("region")).To(Equal("us-west-2"))
+	})
+})
@@ -0,0 +1,129 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the '--record' and '--replay' flags, and the cassettes that they open.
+
+package cassette
+
+import (
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"sync"
+
+	"github.com/spf13/pflag"
+)
+
+var (
+	recordDir string
+	replayDir string
+)
+
+// AddFlags adds the '--record' and '--replay' flags to the given set of command line flags.
+func AddFlags(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&recordDir,
+		"record",
+		"",
+		"Record the OCM and AWS requests and responses, with credentials redacted, to cassettes "+
+			"in the given directory.",
+	)
+	flags.StringVar(
+		&replayDir,
+		"replay",
+		"",
+		"Replay the OCM and AWS responses recorded with '--record' in the given directory, "+
+			"without sending any request.",
+	)
+}
+
+// SetRecordDir sets the directory where the cassettes are recorded.
+func SetRecordDir(value string) {
+	recordDir = value
+}
+
+// SetReplayDir sets the directory where the cassettes are replayed from.
+func SetReplayDir(value string) {
+	replayDir = value
+}
+
+// Recording returns true if the traffic is being recorded.
+func Recording() bool {
+	return recordDir != ""
+}
+
+// Replaying returns true if the traffic is being replayed from cassettes.
+func Replaying() bool {
+	return replayDir != ""
+}
+
+// ValidateFlags checks the values given with the '--record' and '--replay' flags.
+func ValidateFlags() error {
+	if Recording() && Replaying() {
+		return fmt.Errorf("The '--record' and '--replay' flags can't be used together")
+	}
+	return nil
+}
+
+var (
+	cassettesLock sync.Mutex
+	cassettes     = map[string]*Cassette{}
+)
+
+// Open returns the cassette with the given name, or nil if the traffic is neither being recorded
+// nor replayed. All the clients of the same kind share the cassette, so that the interactions are
+// stored in the order they happened.
+func Open(name string) (*Cassette, error) {
+	cassettesLock.Lock()
+	defer cassettesLock.Unlock()
+	key := recordDir + "|" + replayDir + "|" + name
+	result, ok := cassettes[key]
+	if ok {
+		return result, nil
+	}
+	var err error
+	switch {
+	case Recording():
+		result, err = createCassette(filepath.Join(recordDir, name+".jsonl"))
+	case Replaying():
+		result, err = loadCassette(filepath.Join(replayDir, name+".jsonl"))
+	default:
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	cassettes[key] = result
+	return result, nil
+}
+
+// TransportWrapper returns a function that wraps transports so that the traffic is recorded to or
+// replayed from the cassette with the given name. It returns nil when the traffic is neither being
+// recorded nor replayed.
+func TransportWrapper(name string) (func(http.RoundTripper) http.RoundTripper, error) {
+	cassette, err := Open(name)
+	if err != nil || cassette == nil {
+		return nil, err
+	}
+	if Replaying() {
+		return func(http.RoundTripper) http.RoundTripper {
+			return &player{cassette: cassette}
+		}, nil
+	}
+	return func(next http.RoundTripper) http.RoundTripper {
+		return &recorder{cassette: cassette, next: next}
+	}, nil
+}
@@ -0,0 +1,146 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the round trippers that record the HTTP traffic to a cassette and that
+// replay it from a cassette without sending anything to the network.
+
+package cassette
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+)
+
+// recorder is a round tripper that sends the requests to the next round tripper and then records
+// them, with the responses, in the cassette.
+type recorder struct {
+	cassette *Cassette
+	next     http.RoundTripper
+}
+
+// Make sure that we implement the http.RoundTripper interface:
+var _ http.RoundTripper = &recorder{}
+
+func (r *recorder) RoundTrip(request *http.Request) (*http.Response, error) {
+	recorded, err := readRequest(request)
+	if err != nil {
+		return nil, err
+	}
+	response, err := r.next.RoundTrip(request)
+	if err != nil {
+		return nil, err
+	}
+	var body []byte
+	if response.Body != nil {
+		body, err = io.ReadAll(response.Body)
+		if err != nil {
+			return nil, err
+		}
+		err = response.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		response.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	text, encoding := encodeBody(redactBody(response.Header, body))
+	err = r.cassette.add(&Interaction{
+		Request: recorded,
+		Response: &Response{
+			Status:       response.StatusCode,
+			Header:       redactHeader(response.Header),
+			Body:         text,
+			BodyEncoding: encoding,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to save cassette '%s': %v", r.cassette.file, err)
+	}
+	return response, nil
+}
+
+// player is a round tripper that returns the responses recorded in the cassette for the requests,
+// without sending them.
+type player struct {
+	cassette *Cassette
+}
+
+// Make sure that we implement the http.RoundTripper interface:
+var _ http.RoundTripper = &player{}
+
+func (p *player) RoundTrip(request *http.Request) (*http.Response, error) {
+	recorded, err := readRequest(request)
+	if err != nil {
+		return nil, err
+	}
+	interaction := p.cassette.find(recorded)
+	if interaction == nil {
+		return nil, fmt.Errorf("No interaction recorded in cassette '%s' for %s %s",
+			p.cassette.file, recorded.Method, recorded.URL)
+	}
+	body, err := decodeBody(interaction.Response.Body, interaction.Response.BodyEncoding)
+	if err != nil {
+		return nil, err
+	}
+	header := interaction.Response.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	header.Set("Content-Length", strconv.Itoa(len(body)))
+	status := interaction.Response.Status
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       request,
+	}, nil
+}
+
+// readRequest reads the body of the request, replacing it with an equivalent one, and returns the
+// redacted details that are stored in the cassette and used to find the recorded interactions.
+func readRequest(request *http.Request) (*Request, error) {
+	var body []byte
+	if request.Body != nil && request.Body != http.NoBody {
+		var err error
+		body, err = io.ReadAll(request.Body)
+		if err != nil {
+			return nil, err
+		}
+		err = request.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		request.Body = io.NopCloser(bytes.NewReader(body))
+		request.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(body)), nil
+		}
+	}
+	text, encoding := encodeBody(redactBody(request.Header, body))
+	return &Request{
+		Method:       request.Method,
+		URL:          redactURL(request.URL),
+		Header:       redactHeader(request.Header),
+		Body:         text,
+		BodyEncoding: encoding,
+	}, nil
+}
@@ -0,0 +1,13 @@
+package clusterdiff
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestClusterDiff(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Cluster Diff Suite")
+}
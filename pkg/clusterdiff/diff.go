@@ -0,0 +1,218 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the comparison of two snapshots, as a list of differences for the JSON
+// output and as a unified diff of their YAML representations.
+
+package clusterdiff
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// contextLines is the number of unchanged lines around the changes in the unified diff.
+const contextLines = 3
+
+// Difference is a field that has different values in the two snapshots. The value is nil for the
+// snapshot that doesn't have the field.
+type Difference struct {
+	Path string      `json:"path"`
+	A    interface{} `json:"a"`
+	B    interface{} `json:"b"`
+}
+
+// Compare returns the differences between the two snapshots, sorted by path. Objects are compared
+// field by field and lists as a whole.
+func Compare(a, b Snapshot) []*Difference {
+	differences := []*Difference{}
+	compare("", map[string]interface{}(a), map[string]interface{}(b), &differences)
+	return differences
+}
+
+func compare(path string, a, b interface{}, differences *[]*Difference) {
+	aMap, aIsMap := a.(map[string]interface{})
+	bMap, bIsMap := b.(map[string]interface{})
+	if !aIsMap || !bIsMap {
+		if !reflect.DeepEqual(a, b) {
+			*differences = append(*differences, &Difference{Path: path, A: a, B: b})
+		}
+		return
+	}
+	names := []string{}
+	for name := range aMap {
+		names = append(names, name)
+	}
+	for name := range bMap {
+		if _, ok := aMap[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		child := name
+		if path != "" {
+			child = path + "." + name
+		}
+		compare(child, aMap[name], bMap[name], differences)
+	}
+}
+
+// Unified writes the unified diff of the YAML representations of the two snapshots, using the
+// given names in the headers. Nothing is written when the snapshots are the same.
+func Unified(writer io.Writer, nameA string, a Snapshot, nameB string, b Snapshot) error {
+	aData, err := yaml.Marshal(a)
+	if err != nil {
+		return err
+	}
+	bData, err := yaml.Marshal(b)
+	if err != nil {
+		return err
+	}
+	hunks := diffHunks(splitLines(string(aData)), splitLines(string(bData)))
+	if len(hunks) == 0 {
+		return nil
+	}
+	fmt.Fprintf(writer, "--- %s\n+++ %s\n", nameA, nameB)
+	for _, hunk := range hunks {
+		fmt.Fprint(writer, hunk)
+	}
+	return nil
+}
+
+func splitLines(text string) []string {
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+// edit is a line of the diff, prefixed with ' ' when it is in both texts, '-' when it is only in
+// the first and '+' when it is only in the second.
+type edit struct {
+	op   byte
+	line string
+}
+
+// diffEdits returns the edits that transform the first list of lines into the second, calculated
+// from their longest common subsequence.
+func diffEdits(a, b []string) []edit {
+	lengths := make([][]int, len(a)+1)
+	for i := range lengths {
+		lengths[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			} else {
+				lengths[i][j] = max(lengths[i+1][j], lengths[i][j+1])
+			}
+		}
+	}
+	edits := []edit{}
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			edits = append(edits, edit{' ', a[i]})
+			i++
+			j++
+		case lengths[i+1][j] >= lengths[i][j+1]:
+			edits = append(edits, edit{'-', a[i]})
+			i++
+		default:
+			edits = append(edits, edit{'+', b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		edits = append(edits, edit{'-', a[i]})
+	}
+	for ; j < len(b); j++ {
+		edits = append(edits, edit{'+', b[j]})
+	}
+	return edits
+}
+
+// diffHunks returns the hunks of the unified diff of the two lists of lines. Changes that are
+// close enough to share context lines are part of the same hunk.
+func diffHunks(a, b []string) []string {
+	edits := diffEdits(a, b)
+	hunks := []string{}
+	for start := 0; start < len(edits); {
+		if edits[start].op == ' ' {
+			start++
+			continue
+		}
+		first := max(start-contextLines, 0)
+		end := start
+		for end < len(edits) {
+			if edits[end].op != ' ' {
+				end++
+				continue
+			}
+			next := end
+			for next < len(edits) && edits[next].op == ' ' {
+				next++
+			}
+			if next == len(edits) || next-end > 2*contextLines {
+				break
+			}
+			end = next
+		}
+		last := min(end+contextLines, len(edits))
+		hunks = append(hunks, formatHunk(edits, first, last))
+		start = last
+	}
+	return hunks
+}
+
+func formatHunk(edits []edit, first, last int) string {
+	aStart, bStart := 0, 0
+	for _, edit := range edits[:first] {
+		if edit.op != '+' {
+			aStart++
+		}
+		if edit.op != '-' {
+			bStart++
+		}
+	}
+	aLength, bLength := 0, 0
+	body := &strings.Builder{}
+	for _, edit := range edits[first:last] {
+		if edit.op != '+' {
+			aLength++
+		}
+		if edit.op != '-' {
+			bLength++
+		}
+		fmt.Fprintf(body, "%c%s\n", edit.op, edit.line)
+	}
+	return fmt.Sprintf("@@ -%s +%s @@\n%s", hunkRange(aStart, aLength), hunkRange(bStart, bLength),
+		body.String())
+}
+
+// hunkRange returns the range of lines of a hunk. Line numbers start with one, and an empty range
+// refers to the line before it.
+func hunkRange(start, length int) string {
+	if length == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+	return fmt.Sprintf("%d,%d", start+1, length)
+}
@@ -0,0 +1,79 @@
+package clusterdiff
+
+import (
+	"bytes"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Diff", func() {
+	a := Snapshot{
+		"configuration": map[string]interface{}{
+			"version": "4.14.10",
+			"proxy":   map[string]interface{}{"noProxy": []interface{}{"example.com"}},
+		},
+		"machine_pools": map[string]interface{}{
+			"workers": map[string]interface{}{"instance_type": "m5.xlarge", "replicas": float64(3)},
+		},
+	}
+	b := Snapshot{
+		"configuration": map[string]interface{}{
+			"version": "4.14.12",
+			"proxy":   map[string]interface{}{"noProxy": []interface{}{"example.com"}},
+		},
+		"machine_pools": map[string]interface{}{
+			"workers": map[string]interface{}{"instance_type": "m5.xlarge", "replicas": float64(3)},
+			"gpu":     map[string]interface{}{"instance_type": "g4dn.xlarge"},
+		},
+	}
+
+	Describe("Compare", func() {
+		It("Returns the fields with different values sorted by path", func() {
+			Expect(Compare(a, b)).To(Equal([]*Difference{
+				{Path: "configuration.version", A: "4.14.10", B: "4.14.12"},
+				{Path: "machine_pools.gpu", A: nil, B: map[string]interface{}{"instance_type": "g4dn.xlarge"}},
+			}))
+		})
+
+		It("Returns no differences for the same snapshot", func() {
+			Expect(Compare(a, a)).To(BeEmpty())
+		})
+	})
+
+	Describe("Unified", func() {
+		It("Writes the changed lines with their context", func() {
+			out := &bytes.Buffer{}
+			Expect(Unified(out, "cluster-a", a, "cluster-b", b)).To(Succeed())
+			Expect(out.String()).To(Equal("--- cluster-a\n" +
+				"+++ cluster-b\n" +
+				"@@ -2,8 +2,10 @@\n" +
+				"   proxy:\n" +
+				"     noProxy:\n" +
+				"     - example.com\n" +
+				"-  version: 4.14.10\n" +
+				"+  version: 4.14.12\n" +
+				" machine_pools:\n" +
+				"+  gpu:\n" +
+				"+    instance_type: g4dn.xlarge\n" +
+				"   workers:\n" +
+				"     instance_type: m5.xlarge\n" +
+				"     replicas: 3\n"))
+		})
+
+		It("Splits distant changes in separate hunks", func() {
+			lines := []string{"1", "2", "3", "4", "5", "6", "7", "8", "9", "10"}
+			changed := []string{"0", "2", "3", "4", "5", "6", "7", "8", "9", "11"}
+			Expect(diffHunks(lines, changed)).To(Equal([]string{
+				"@@ -1,4 +1,4 @@\n-1\n+0\n 2\n 3\n 4\n",
+				"@@ -7,4 +7,4 @@\n 7\n 8\n 9\n-10\n+11\n",
+			}))
+		})
+
+		It("Writes nothing for the same snapshot", func() {
+			out := &bytes.Buffer{}
+			Expect(Unified(out, "cluster-a", a, "cluster-b", a)).To(Succeed())
+			Expect(out.String()).To(BeEmpty())
+		})
+	})
+})
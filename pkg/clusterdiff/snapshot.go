@@ -0,0 +1,274 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the snapshot of the configuration of a cluster compared by the 'rosa diff
+// clusters' command. The snapshot only contains the settings that can be the same in two clusters,
+// so identifiers, timestamps, status and other instance specific fields are removed.
+
+package clusterdiff
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/clusterspec"
+	"github.com/openshift/rosa/pkg/logging"
+	"github.com/openshift/rosa/pkg/ocm"
+)
+
+// Snapshot contains the configuration of a cluster, organized in sections. Objects that a cluster
+// can have many of, like machine pools, are indexed by the fields that identify them, so that they
+// are matched by name and not by position.
+type Snapshot map[string]interface{}
+
+// configurationFields contains the fields of the cluster spec that are part of the snapshot.
+// Fields like the name, the region, the tags or the roles are expected to be different.
+var configurationFields = []string{
+	"version",
+	"channelGroup",
+	"hostedCP",
+	"multiAZ",
+	"network",
+	"proxy",
+	"encryption",
+	"registry",
+	"autoscaler",
+	"defaultIngress",
+}
+
+// instanceFields contains the fields of the cluster spec that identify resources of one cluster.
+var instanceFields = map[string]bool{
+	"subnetIDs":                   true,
+	"availabilityZones":           true,
+	"additionalAllowedPrincipals": true,
+	"privateHostedZoneID":         true,
+	"sharedVPCRoleARN":            true,
+	"vpcEndpointRoleARN":          true,
+	"hcpInternalHostedZoneID":     true,
+	"kmsKeyARN":                   true,
+	"etcdEncryptionKMSARN":        true,
+}
+
+// ignoredFields contains the fields of the objects of the OCM API that are removed at any depth,
+// because they change from one cluster to another, or over time, even when the configuration is
+// the same.
+var ignoredFields = map[string]bool{
+	"kind":                          true,
+	"href":                          true,
+	"cluster":                       true,
+	"status":                        true,
+	"state":                         true,
+	"creation_timestamp":            true,
+	"updated_timestamp":             true,
+	"last_update_timestamp":         true,
+	"subnet":                        true,
+	"subnets":                       true,
+	"availability_zone":             true,
+	"availability_zones":            true,
+	"additional_security_group_ids": true,
+	"instance_profile":              true,
+}
+
+// Take collects the snapshot of the given cluster.
+func Take(client *ocm.Client, cluster *cmv1.Cluster) (Snapshot, error) {
+	snapshot := Snapshot{}
+
+	configuration, err := takeConfiguration(client, cluster)
+	if err != nil {
+		return nil, err
+	}
+	snapshot["configuration"] = configuration
+
+	if cluster.Hypershift().Enabled() {
+		snapshot["node_pools"], err = takeObjects(client.GetNodePools, cluster.ID(), cmv1.MarshalNodePool, idKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get machine pools: %v", err)
+		}
+	} else {
+		snapshot["machine_pools"], err = takeObjects(client.GetMachinePools, cluster.ID(),
+			cmv1.MarshalMachinePool, idKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get machine pools: %v", err)
+		}
+	}
+
+	snapshot["identity_providers"], err = takeObjects(client.GetIdentityProviders, cluster.ID(),
+		cmv1.MarshalIdentityProvider, func(object map[string]interface{}) string {
+			return fmt.Sprintf("%s (%s)", object["name"], object["type"])
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get identity providers: %v", err)
+	}
+
+	snapshot["kubelet_configs"], err = takeObjects(func(clusterID string) ([]*cmv1.KubeletConfig, error) {
+		return client.ListKubeletConfigs(context.Background(), clusterID)
+	}, cluster.ID(), cmv1.MarshalKubeletConfig, nameKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get kubelet configs: %v", err)
+	}
+
+	if cluster.Hypershift().Enabled() {
+		snapshot["tuning_configs"], err = takeObjects(client.GetTuningConfigs, cluster.ID(),
+			cmv1.MarshalTuningConfig, nameKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get tuning configs: %v", err)
+		}
+	}
+
+	snapshot["add_ons"], err = takeAddOns(client, cluster.ID())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get add-ons: %v", err)
+	}
+
+	return snapshot, nil
+}
+
+// takeConfiguration returns the fields of the cluster spec that are part of the snapshot.
+func takeConfiguration(client *ocm.Client, cluster *cmv1.Cluster) (map[string]interface{}, error) {
+	var autoscaler *cmv1.ClusterAutoscaler
+	if !cluster.Hypershift().Enabled() {
+		var err error
+		autoscaler, err = client.GetClusterAutoscaler(cluster.ID())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get autoscaler: %v", err)
+		}
+	}
+	ingresses, err := client.GetIngresses(cluster.ID())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ingresses: %v", err)
+	}
+	var defaultIngress *cmv1.Ingress
+	for _, ingress := range ingresses {
+		if ingress.Default() {
+			defaultIngress = ingress
+		}
+	}
+	return configurationFromSpec(clusterspec.FromCluster(cluster, autoscaler, defaultIngress))
+}
+
+func configurationFromSpec(spec *clusterspec.ClusterSpec) (map[string]interface{}, error) {
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return nil, err
+	}
+	fields := map[string]interface{}{}
+	err = json.Unmarshal(data, &fields)
+	if err != nil {
+		return nil, err
+	}
+	configuration := map[string]interface{}{}
+	for _, name := range configurationFields {
+		value, ok := fields[name]
+		if !ok {
+			continue
+		}
+		if section, ok := value.(map[string]interface{}); ok {
+			for field := range section {
+				if instanceFields[field] {
+					delete(section, field)
+				}
+			}
+		}
+		configuration[name] = value
+	}
+	return configuration, nil
+}
+
+// takeObjects calls the given OCM client method and returns the objects indexed by the key
+// calculated by the given function, without the ignored fields.
+func takeObjects[T any](list func(string) ([]T, error), clusterID string,
+	marshal func(T, io.Writer) error, key func(map[string]interface{}) string) (map[string]interface{}, error) {
+	items, err := list(clusterID)
+	if err != nil {
+		return nil, err
+	}
+	objects := map[string]interface{}{}
+	for _, item := range items {
+		var buffer bytes.Buffer
+		err = marshal(item, &buffer)
+		if err != nil {
+			return nil, err
+		}
+		object := map[string]interface{}{}
+		err = json.Unmarshal(buffer.Bytes(), &object)
+		if err != nil {
+			return nil, err
+		}
+		name := key(object)
+		delete(object, "id")
+		objects[name] = clean(object)
+	}
+	return objects, nil
+}
+
+// takeAddOns returns the installed add-ons with their version and parameters. The parameters are
+// compared by value, so the ones that contain secrets are removed.
+func takeAddOns(client *ocm.Client, clusterID string) (map[string]interface{}, error) {
+	installations, err := client.GetAddOnInstallations(clusterID)
+	if err != nil {
+		return nil, err
+	}
+	addOns := map[string]interface{}{}
+	for _, installation := range installations {
+		parameters := map[string]interface{}{}
+		for _, parameter := range installation.Parameters().Slice() {
+			if logging.SensitiveFields[parameter.Id()] {
+				continue
+			}
+			parameters[parameter.Id()] = parameter.Value()
+		}
+		addOn := map[string]interface{}{
+			"version": installation.AddonVersion().ID(),
+		}
+		if len(parameters) > 0 {
+			addOn["parameters"] = parameters
+		}
+		addOns[installation.Addon().ID()] = addOn
+	}
+	return addOns, nil
+}
+
+func idKey(object map[string]interface{}) string {
+	return fmt.Sprint(object["id"])
+}
+
+func nameKey(object map[string]interface{}) string {
+	return fmt.Sprint(object["name"])
+}
+
+// clean removes the ignored fields and the secrets from the given value, at any depth.
+func clean(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		for name, field := range typed {
+			if ignoredFields[name] || logging.SensitiveFields[name] {
+				delete(typed, name)
+				continue
+			}
+			typed[name] = clean(field)
+		}
+	case []interface{}:
+		for i, item := range typed {
+			typed[i] = clean(item)
+		}
+	}
+	return value
+}
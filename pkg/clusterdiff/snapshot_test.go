@@ -0,0 +1,88 @@
+package clusterdiff
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	. "github.com/openshift-online/ocm-sdk-go/testing"
+
+	"github.com/openshift/rosa/pkg/test"
+)
+
+var _ = Describe("Snapshot", func() {
+	var t *test.TestingRuntime
+
+	clusterPath := "/api/clusters_mgmt/v1/clusters/" + test.MockClusterID
+	emptyList := `{"kind": "List", "page": 1, "size": 0, "total": 0, "items": []}`
+
+	BeforeEach(func() {
+		t = test.NewTestRuntime()
+		t.ApiServer.SetUnhandledRequestStatusCode(http.StatusNotFound)
+	})
+
+	It("Takes the configuration and the objects of the cluster without instance specific fields", func() {
+		cluster := test.MockCluster(func(c *cmv1.ClusterBuilder) {
+			c.Version(cmv1.NewVersion().RawID("4.14.10").ChannelGroup("stable"))
+			c.AWS(cmv1.NewAWS().SubnetIDs("subnet-1"))
+			c.Network(cmv1.NewNetwork().Type("OVNKubernetes").MachineCIDR("10.0.0.0/16"))
+		})
+		t.ApiServer.RouteToHandler(http.MethodGet, clusterPath+"/autoscaler",
+			RespondWithJSON(http.StatusNotFound, `{"kind": "Error", "reason": "Autoscaler not found"}`))
+		t.ApiServer.RouteToHandler(http.MethodGet, clusterPath+"/ingresses",
+			RespondWithJSON(http.StatusOK, `{"kind": "IngressList", "page": 1, "size": 1, "total": 1,
+				"items": [{"kind": "Ingress", "id": "abc", "default": true, "route_wildcard_policy": "WildcardsAllowed",
+				"dns_name": "apps.mycluster.example.com"}]}`))
+		t.ApiServer.RouteToHandler(http.MethodGet, clusterPath+"/machine_pools",
+			RespondWithJSON(http.StatusOK, `{"kind": "MachinePoolList", "page": 1, "size": 1, "total": 1,
+				"items": [{"kind": "MachinePool", "id": "workers", "href": "/workers", "replicas": 3,
+				"instance_type": "m5.xlarge", "availability_zones": ["us-east-1a"], "subnets": ["subnet-1"]}]}`))
+		t.ApiServer.RouteToHandler(http.MethodGet, clusterPath+"/identity_providers",
+			RespondWithJSON(http.StatusOK, `{"kind": "IdentityProviderList", "page": 1, "size": 1, "total": 1,
+				"items": [{"kind": "IdentityProvider", "id": "123", "name": "github", "type": "GithubIdentityProvider",
+				"github": {"client_id": "my-client", "client_secret": "my-secret"}}]}`))
+		t.ApiServer.RouteToHandler(http.MethodGet, clusterPath+"/kubelet_configs",
+			RespondWithJSON(http.StatusOK, emptyList))
+		t.ApiServer.RouteToHandler(http.MethodGet, "/api/addons_mgmt/v1/clusters/"+test.MockClusterID+"/addons",
+			RespondWithJSON(http.StatusOK, `{"kind": "AddonInstallationList", "page": 1, "size": 1, "total": 1,
+				"items": [{"kind": "AddonInstallation", "id": "my-addon", "addon": {"id": "my-addon"},
+				"addon_version": {"id": "1.2.0"}, "state": "ready", "parameters": {"items": [
+				{"id": "size", "value": "large"}, {"id": "password", "value": "my-password"}]}}]}`))
+
+		snapshot, err := Take(t.RosaRuntime.OCMClient, cluster)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(snapshot).To(Equal(Snapshot{
+			"configuration": map[string]interface{}{
+				"version": "4.14.10",
+				"network": map[string]interface{}{
+					"type":        "OVNKubernetes",
+					"machineCIDR": "10.0.0.0/16",
+				},
+				"defaultIngress": map[string]interface{}{
+					"wildcardPolicy": "WildcardsAllowed",
+				},
+			},
+			"machine_pools": map[string]interface{}{
+				"workers": map[string]interface{}{
+					"replicas":      float64(3),
+					"instance_type": "m5.xlarge",
+				},
+			},
+			"identity_providers": map[string]interface{}{
+				"github (GithubIdentityProvider)": map[string]interface{}{
+					"name":   "github",
+					"type":   "GithubIdentityProvider",
+					"github": map[string]interface{}{"client_id": "my-client"},
+				},
+			},
+			"kubelet_configs": map[string]interface{}{},
+			"add_ons": map[string]interface{}{
+				"my-addon": map[string]interface{}{
+					"version":    "1.2.0",
+					"parameters": map[string]interface{}{"size": "large"},
+				},
+			},
+		}))
+	})
+})
@@ -0,0 +1,224 @@
+package clusterspec
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/pflag"
+	"sigs.k8s.io/yaml"
+)
+
+const specFile = `apiVersion: rosa.openshift.io/v1alpha1
+kind: ClusterSpec
+name: mycluster
+region: us-east-1
+hostedCP: true
+tags:
+  team: sre
+compute:
+  machineType: m5.xlarge
+  replicas: 3
+  labels:
+    tier: backend
+    app: api
+network:
+  subnetIDs:
+  - subnet-1
+  - subnet-2
+autoscaler:
+  maxCores: 100
+  scaleDown:
+    utilizationThreshold: 0.7
+registry:
+  allowedRegistries:
+  - quay.io
+  - registry.io
+`
+
+// newFlags returns flags like the ones of the 'create cluster' command used by the spec above.
+func newFlags() *pflag.FlagSet {
+	flags := pflag.NewFlagSet("create", pflag.ContinueOnError)
+	flags.String("cluster-name", "", "")
+	flags.String("region", "", "")
+	flags.Bool("hosted-cp", false, "")
+	flags.StringSlice("tags", nil, "")
+	flags.String("compute-machine-type", "", "")
+	flags.Int("replicas", 2, "")
+	flags.String("worker-mp-labels", "", "")
+	flags.StringSlice("subnet-ids", nil, "")
+	flags.Int("autoscaler-max-cores", 11520, "")
+	flags.Float64("autoscaler-scale-down-utilization-threshold", 0.5, "")
+	flags.String("registry-config-allowed-registries", "", "")
+	return flags
+}
+
+var _ = Describe("Cluster spec", func() {
+	Context("Parse", func() {
+		It("Parses a spec", func() {
+			spec, err := Parse([]byte(specFile))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(spec.Name).To(Equal("mycluster"))
+			Expect(spec.Compute.Replicas).To(Equal(3))
+			Expect(*spec.Autoscaler.MaxCores).To(Equal(100))
+			Expect(spec.Autoscaler.ScaleDown.UtilizationThreshold).To(HaveValue(Equal(0.7)))
+		})
+
+		It("Rejects other versions", func() {
+			_, err := Parse([]byte("apiVersion: rosa.openshift.io/v2\nkind: ClusterSpec\n"))
+			Expect(err).To(MatchError("unsupported API version 'rosa.openshift.io/v2', " +
+				"expected 'rosa.openshift.io/v1alpha1'"))
+		})
+
+		It("Rejects other kinds", func() {
+			_, err := Parse([]byte("apiVersion: rosa.openshift.io/v1alpha1\nkind: MachinePool\n"))
+			Expect(err).To(MatchError("unsupported kind 'MachinePool', expected 'ClusterSpec'"))
+		})
+
+		It("Rejects unknown fields", func() {
+			_, err := Parse([]byte(specFile + "replica: 3\n"))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(`unknown field "replica"`))
+		})
+	})
+
+	Context("Load", func() {
+		It("Includes the name of the file in errors", func() {
+			name := filepath.Join(GinkgoT().TempDir(), "cluster.yaml")
+			Expect(os.WriteFile(name, []byte("kind: ClusterSpec\n"), 0600)).To(Succeed())
+			_, err := Load(name)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(HavePrefix("invalid cluster spec file '" + name + "'"))
+		})
+	})
+
+	Context("Apply", func() {
+		var spec *ClusterSpec
+
+		BeforeEach(func() {
+			var err error
+			spec, err = Parse([]byte(specFile))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Sets the flags from the spec", func() {
+			flags := newFlags()
+			Expect(Apply(spec, flags)).To(Succeed())
+			Expect(flags.GetString("cluster-name")).To(Equal("mycluster"))
+			Expect(flags.GetBool("hosted-cp")).To(BeTrue())
+			Expect(flags.GetStringSlice("tags")).To(Equal([]string{"team:sre"}))
+			Expect(flags.GetInt("replicas")).To(Equal(3))
+			Expect(flags.GetString("worker-mp-labels")).To(Equal("app=api,tier=backend"))
+			Expect(flags.GetStringSlice("subnet-ids")).To(Equal([]string{"subnet-1", "subnet-2"}))
+			Expect(flags.GetInt("autoscaler-max-cores")).To(Equal(100))
+			Expect(flags.GetFloat64("autoscaler-scale-down-utilization-threshold")).To(Equal(0.7))
+			Expect(flags.GetString("registry-config-allowed-registries")).To(Equal("quay.io,registry.io"))
+			Expect(flags.Changed("replicas")).To(BeTrue())
+			Expect(flags.Changed("subnet-ids")).To(BeTrue())
+		})
+
+		It("Keeps the flags given in the command line", func() {
+			flags := newFlags()
+			Expect(flags.Parse([]string{"--replicas=5", "--subnet-ids=subnet-3"})).To(Succeed())
+			Expect(Apply(spec, flags)).To(Succeed())
+			Expect(flags.GetInt("replicas")).To(Equal(5))
+			Expect(flags.GetStringSlice("subnet-ids")).To(Equal([]string{"subnet-3"}))
+			Expect(flags.GetString("compute-machine-type")).To(Equal("m5.xlarge"))
+		})
+
+		It("Separates tags with spaces when they contain colons", func() {
+			spec.Tags = map[string]string{"url": "https://example.com"}
+			flags := newFlags()
+			Expect(Apply(spec, flags)).To(Succeed())
+			Expect(flags.GetStringSlice("tags")).To(Equal([]string{"url https://example.com"}))
+		})
+
+		It("Fails for fields without a flag", func() {
+			flags := newFlags()
+			spec.Network.PodCIDR = "10.128.0.0/14"
+			Expect(Apply(spec, flags)).To(MatchError("unknown flag 'pod-cidr' for field 'PodCIDR'"))
+		})
+
+		It("Fails for invalid values", func() {
+			flags := pflag.NewFlagSet("create", pflag.ContinueOnError)
+			flags.Int("cluster-name", 0, "")
+			err := Apply(&ClusterSpec{Name: "mycluster"}, flags)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(HavePrefix("invalid value for 'cluster-name'"))
+		})
+	})
+
+	Context("FromCluster", func() {
+		It("Describes the cluster", func() {
+			cluster, err := cmv1.NewCluster().
+				Name("mycluster").
+				Region(cmv1.NewCloudRegion().ID("us-east-1")).
+				Version(cmv1.NewVersion().RawID("4.15.2").ChannelGroup("stable")).
+				Hypershift(cmv1.NewHypershift().Enabled(true)).
+				MultiAZ(true).
+				Nodes(cmv1.NewClusterNodes().
+					Compute(3).
+					ComputeMachineType(cmv1.NewMachineType().ID("m5.xlarge")).
+					ComputeRootVolume(cmv1.NewRootVolume().AWS(cmv1.NewAWSVolume().Size(300)))).
+				AWS(cmv1.NewAWS().
+					SubnetIDs("subnet-1").
+					Tags(map[string]string{"team": "sre", "red-hat-managed": "true"}).
+					STS(cmv1.NewSTS().
+						RoleARN("arn:aws:iam::123:role/Installer").
+						OidcConfig(cmv1.NewOidcConfig().ID("oidc-1")))).
+				Build()
+			Expect(err).NotTo(HaveOccurred())
+			ingress, err := cmv1.NewIngress().
+				Default(true).
+				ExcludedNamespaces("stage").
+				RouteWildcardPolicy(cmv1.WildcardPolicyWildcardsAllowed).
+				Build()
+			Expect(err).NotTo(HaveOccurred())
+
+			spec := FromCluster(cluster, nil, ingress)
+			Expect(spec.APIVersion).To(Equal(APIVersion))
+			Expect(spec.Kind).To(Equal(Kind))
+			Expect(spec.Name).To(Equal("mycluster"))
+			Expect(spec.Version).To(Equal("4.15.2"))
+			Expect(spec.ChannelGroup).To(BeEmpty())
+			Expect(spec.HostedCP).To(BeTrue())
+			Expect(spec.MultiAZ).To(BeFalse())
+			Expect(spec.STS).To(BeTrue())
+			Expect(spec.Tags).To(Equal(map[string]string{"team": "sre"}))
+			Expect(spec.Compute).To(Equal(&Compute{MachineType: "m5.xlarge", Replicas: 3, DiskSize: "300GiB"}))
+			Expect(spec.Network).To(Equal(&Network{SubnetIDs: []string{"subnet-1"}}))
+			Expect(spec.Roles).To(Equal(&Roles{Installer: "arn:aws:iam::123:role/Installer"}))
+			Expect(spec.OIDC).To(Equal(&OIDC{ConfigID: "oidc-1"}))
+			Expect(spec.Encryption).To(BeNil())
+			Expect(spec.Proxy).To(BeNil())
+			Expect(spec.Autoscaler).To(BeNil())
+			Expect(spec.DefaultIngress).To(Equal(&DefaultIngress{
+				ExcludedNamespaces: []string{"stage"},
+				WildcardPolicy:     "WildcardsAllowed",
+			}))
+		})
+
+		It("Can be loaded again", func() {
+			cluster, err := cmv1.NewCluster().
+				Name("mycluster").
+				Proxy(cmv1.NewProxy().HTTPProxy("http://proxy:3128").NoProxy("a.com,b.com")).
+				Build()
+			Expect(err).NotTo(HaveOccurred())
+			autoscaler, err := cmv1.NewClusterAutoscaler().
+				LogVerbosity(2).
+				ScaleDown(cmv1.NewAutoscalerScaleDownConfig().UtilizationThreshold("0.4")).
+				Build()
+			Expect(err).NotTo(HaveOccurred())
+
+			data, err := yaml.Marshal(FromCluster(cluster, autoscaler, nil))
+			Expect(err).NotTo(HaveOccurred())
+			spec, err := Parse(data)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(spec.Proxy.NoProxy).To(Equal([]string{"a.com", "b.com"}))
+			Expect(spec.Autoscaler.LogVerbosity).To(HaveValue(Equal(2)))
+			Expect(spec.Autoscaler.ScaleDown.UtilizationThreshold).To(HaveValue(Equal(0.4)))
+		})
+	})
+})
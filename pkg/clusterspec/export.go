@@ -0,0 +1,250 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the functions that describe an existing cluster as a cluster spec.
+
+package clusterspec
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/ocm"
+)
+
+// redHatTagPrefix is the prefix of the tags added by the service, which can't be given by users.
+const redHatTagPrefix = "red-hat-"
+
+// FromCluster returns the spec that creates a cluster like the given one. The autoscaler and the
+// default ingress are optional. Values that only exist as file contents, like the additional trust
+// bundle, aren't included, as the spec refers to files.
+func FromCluster(cluster *cmv1.Cluster, autoscaler *cmv1.ClusterAutoscaler, ingress *cmv1.Ingress) *ClusterSpec {
+	spec := New()
+	spec.Name = cluster.Name()
+	spec.DomainPrefix = cluster.DomainPrefix()
+	spec.Region = cluster.Region().ID()
+	spec.Version = ocm.GetRawVersionId(cluster.Version().RawID())
+	if channelGroup := cluster.Version().ChannelGroup(); channelGroup != ocm.DefaultChannelGroup {
+		spec.ChannelGroup = channelGroup
+	}
+	spec.HostedCP = cluster.Hypershift().Enabled()
+	spec.STS = cluster.AWS().STS().RoleARN() != ""
+	spec.MultiAZ = cluster.MultiAZ() && !spec.HostedCP
+	spec.DisableMonitoring = cluster.DisableUserWorkloadMonitoring()
+	spec.BillingAccount = cluster.AWS().BillingAccountID()
+	spec.IMDSv2 = string(cluster.AWS().Ec2MetadataHttpTokens())
+	spec.AuditLogRoleARN = cluster.AWS().AuditLog().RoleArn()
+	for key, value := range cluster.AWS().Tags() {
+		if strings.HasPrefix(key, redHatTagPrefix) {
+			continue
+		}
+		if spec.Tags == nil {
+			spec.Tags = map[string]string{}
+		}
+		spec.Tags[key] = value
+	}
+
+	spec.Compute = computeFromCluster(cluster)
+	spec.Network = networkFromCluster(cluster)
+	spec.SecurityGroups = securityGroupsFromCluster(cluster)
+	spec.Roles = rolesFromCluster(cluster)
+	spec.OIDC = oidcFromCluster(cluster)
+	spec.Encryption = encryptionFromCluster(cluster)
+	spec.Proxy = proxyFromCluster(cluster)
+	spec.Autoscaler = autoscalerFromCluster(autoscaler)
+	spec.Registry = registryFromCluster(cluster)
+	spec.DefaultIngress = defaultIngressFromCluster(ingress)
+	return spec
+}
+
+func computeFromCluster(cluster *cmv1.Cluster) *Compute {
+	nodes := cluster.Nodes()
+	compute := &Compute{
+		MachineType: nodes.ComputeMachineType().ID(),
+		Labels:      nodes.ComputeLabels(),
+	}
+	if autoscaling, ok := nodes.GetAutoscaleCompute(); ok {
+		compute.Autoscaling = true
+		compute.MinReplicas = autoscaling.MinReplicas()
+		compute.MaxReplicas = autoscaling.MaxReplicas()
+	} else {
+		compute.Replicas = nodes.Compute()
+	}
+	if size := nodes.ComputeRootVolume().AWS().Size(); size != 0 {
+		compute.DiskSize = fmt.Sprintf("%dGiB", size)
+	}
+	return emptyToNil(compute)
+}
+
+func networkFromCluster(cluster *cmv1.Cluster) *Network {
+	network := cluster.Network()
+	aws := cluster.AWS()
+	return emptyToNil(&Network{
+		Type:                network.Type(),
+		MachineCIDR:         network.MachineCIDR(),
+		ServiceCIDR:         network.ServiceCIDR(),
+		PodCIDR:             network.PodCIDR(),
+		HostPrefix:          network.HostPrefix(),
+		Private:             cluster.API().Listening() == cmv1.ListeningMethodInternal && !aws.PrivateLink(),
+		PrivateLink:         aws.PrivateLink(),
+		SubnetIDs:           aws.SubnetIDs(),
+		AvailabilityZones:   cluster.Nodes().AvailabilityZones(),
+		AllowedPrincipals:   aws.AdditionalAllowedPrincipals(),
+		BaseDomain:          cluster.DNS().BaseDomain(),
+		PrivateHostedZoneID: aws.PrivateHostedZoneID(),
+		SharedVPCRoleARN:    aws.PrivateHostedZoneRoleARN(),
+		VPCEndpointRoleARN:  aws.VpcEndpointRoleArn(),
+		HCPInternalZoneID:   aws.HcpInternalCommunicationHostedZoneId(),
+	})
+}
+
+func securityGroupsFromCluster(cluster *cmv1.Cluster) *SecurityGroups {
+	aws := cluster.AWS()
+	return emptyToNil(&SecurityGroups{
+		Compute:      aws.AdditionalComputeSecurityGroupIds(),
+		Infra:        aws.AdditionalInfraSecurityGroupIds(),
+		ControlPlane: aws.AdditionalControlPlaneSecurityGroupIds(),
+	})
+}
+
+func rolesFromCluster(cluster *cmv1.Cluster) *Roles {
+	sts := cluster.AWS().STS()
+	return emptyToNil(&Roles{
+		Installer:           sts.RoleARN(),
+		Support:             sts.SupportRoleARN(),
+		ControlPlane:        sts.InstanceIAMRoles().MasterRoleARN(),
+		Worker:              sts.InstanceIAMRoles().WorkerRoleARN(),
+		ExternalID:          sts.ExternalID(),
+		OperatorRolesPrefix: sts.OperatorRolePrefix(),
+		PermissionsBoundary: sts.PermissionBoundary(),
+	})
+}
+
+func oidcFromCluster(cluster *cmv1.Cluster) *OIDC {
+	return emptyToNil(&OIDC{
+		ConfigID:              cluster.AWS().STS().OidcConfig().ID(),
+		ExternalAuthProviders: cluster.ExternalAuthConfig().Enabled(),
+	})
+}
+
+func encryptionFromCluster(cluster *cmv1.Cluster) *Encryption {
+	kmsKeyARN := cluster.AWS().KMSKeyArn()
+	return emptyToNil(&Encryption{
+		FIPS:                 cluster.FIPS(),
+		CustomerManagedKey:   kmsKeyARN != "",
+		KMSKeyARN:            kmsKeyARN,
+		EtcdEncryption:       cluster.EtcdEncryption(),
+		EtcdEncryptionKMSARN: cluster.AWS().EtcdEncryption().KMSKeyARN(),
+	})
+}
+
+func proxyFromCluster(cluster *cmv1.Cluster) *Proxy {
+	proxy := &Proxy{
+		HTTPProxy:  cluster.Proxy().HTTPProxy(),
+		HTTPSProxy: cluster.Proxy().HTTPSProxy(),
+	}
+	if noProxy := cluster.Proxy().NoProxy(); noProxy != "" {
+		proxy.NoProxy = strings.Split(noProxy, ",")
+	}
+	return emptyToNil(proxy)
+}
+
+func autoscalerFromCluster(autoscaler *cmv1.ClusterAutoscaler) *Autoscaler {
+	if autoscaler == nil {
+		return nil
+	}
+	limits := autoscaler.ResourceLimits()
+	result := &Autoscaler{
+		BalanceSimilarNodeGroups:    autoscaler.BalanceSimilarNodeGroups(),
+		SkipNodesWithLocalStorage:   autoscaler.SkipNodesWithLocalStorage(),
+		IgnoreDaemonsetsUtilization: autoscaler.IgnoreDaemonsetsUtilization(),
+		LogVerbosity:                intOrNil(autoscaler.GetLogVerbosity()),
+		MaxPodGracePeriod:           intOrNil(autoscaler.GetMaxPodGracePeriod()),
+		PodPriorityThreshold:        intOrNil(autoscaler.GetPodPriorityThreshold()),
+		MaxNodeProvisionTime:        autoscaler.MaxNodeProvisionTime(),
+		MaxNodesTotal:               intOrNil(limits.GetMaxNodesTotal()),
+		MinCores:                    intOrNil(limits.Cores().GetMin()),
+		MaxCores:                    intOrNil(limits.Cores().GetMax()),
+		MinMemory:                   intOrNil(limits.Memory().GetMin()),
+		MaxMemory:                   intOrNil(limits.Memory().GetMax()),
+		BalancingIgnoredLabels:      autoscaler.BalancingIgnoredLabels(),
+	}
+	for _, gpu := range limits.GPUS() {
+		result.GPULimits = append(result.GPULimits,
+			fmt.Sprintf("%s,%d,%d", gpu.Type(), gpu.Range().Min(), gpu.Range().Max()))
+	}
+	scaleDown := autoscaler.ScaleDown()
+	result.ScaleDown = emptyToNil(&ScaleDown{
+		Enabled:           scaleDown.Enabled(),
+		UnneededTime:      scaleDown.UnneededTime(),
+		DelayAfterAdd:     scaleDown.DelayAfterAdd(),
+		DelayAfterDelete:  scaleDown.DelayAfterDelete(),
+		DelayAfterFailure: scaleDown.DelayAfterFailure(),
+	})
+	if threshold, err := strconv.ParseFloat(scaleDown.UtilizationThreshold(), 64); err == nil {
+		if result.ScaleDown == nil {
+			result.ScaleDown = &ScaleDown{}
+		}
+		result.ScaleDown.UtilizationThreshold = &threshold
+	}
+	return emptyToNil(result)
+}
+
+func registryFromCluster(cluster *cmv1.Cluster) *Registry {
+	config := cluster.RegistryConfig()
+	registry := &Registry{
+		AllowedRegistries:  config.RegistrySources().AllowedRegistries(),
+		BlockedRegistries:  config.RegistrySources().BlockedRegistries(),
+		InsecureRegistries: config.RegistrySources().InsecureRegistries(),
+		PlatformAllowlist:  config.PlatformAllowlist().ID(),
+	}
+	for _, location := range config.AllowedRegistriesForImport() {
+		registry.AllowedForImport = append(registry.AllowedForImport,
+			fmt.Sprintf("%s:%t", location.DomainName(), location.Insecure()))
+	}
+	return emptyToNil(registry)
+}
+
+func defaultIngressFromCluster(ingress *cmv1.Ingress) *DefaultIngress {
+	if ingress == nil {
+		return nil
+	}
+	return emptyToNil(&DefaultIngress{
+		RouteSelectors:     ingress.RouteSelectors(),
+		ExcludedNamespaces: ingress.ExcludedNamespaces(),
+		WildcardPolicy:     string(ingress.RouteWildcardPolicy()),
+		NamespaceOwnership: string(ingress.RouteNamespaceOwnershipPolicy()),
+	})
+}
+
+// emptyToNil returns nil if the given section of a spec has no values, so that it is omitted.
+func emptyToNil[T any](section *T) *T {
+	if reflect.ValueOf(section).Elem().IsZero() {
+		return nil
+	}
+	return section
+}
+
+func intOrNil(value int, ok bool) *int {
+	if !ok {
+		return nil
+	}
+	return &value
+}
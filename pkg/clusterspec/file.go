@@ -0,0 +1,165 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the functions that load cluster spec files and apply them to the flags of
+// the 'create cluster' command.
+
+package clusterspec
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/pflag"
+	"sigs.k8s.io/yaml"
+)
+
+// Load reads and checks the cluster spec file with the given name. Unknown fields are rejected, so
+// that typos don't silently create a cluster different to the one described.
+func Load(name string) (*ClusterSpec, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cluster spec file '%s': %v", name, err)
+	}
+	spec, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cluster spec file '%s': %v", name, err)
+	}
+	return spec, nil
+}
+
+// Parse parses and checks the given YAML or JSON cluster spec.
+func Parse(data []byte) (*ClusterSpec, error) {
+	spec := &ClusterSpec{}
+	err := yaml.UnmarshalStrict(data, spec)
+	if err != nil {
+		return nil, err
+	}
+	if spec.APIVersion != APIVersion {
+		return nil, fmt.Errorf("unsupported API version '%s', expected '%s'", spec.APIVersion, APIVersion)
+	}
+	if spec.Kind != Kind {
+		return nil, fmt.Errorf("unsupported kind '%s', expected '%s'", spec.Kind, Kind)
+	}
+	return spec, nil
+}
+
+// New returns an empty cluster spec with the current version and kind.
+func New() *ClusterSpec {
+	return &ClusterSpec{
+		APIVersion: APIVersion,
+		Kind:       Kind,
+	}
+}
+
+// Apply sets the flags corresponding to the values given in the spec, as if they were given in
+// the command line. Flags that were given in the command line are left alone, so that they
+// override the values of the file.
+func Apply(spec *ClusterSpec, flags *pflag.FlagSet) error {
+	return apply(reflect.ValueOf(spec).Elem(), "", flags)
+}
+
+func apply(value reflect.Value, prefix string, flags *pflag.FlagSet) error {
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		fieldValue := value.Field(i)
+		name, ok := field.Tag.Lookup("flag")
+		if fieldValue.Kind() == reflect.Ptr && fieldValue.Type().Elem().Kind() == reflect.Struct {
+			if fieldValue.IsNil() {
+				continue
+			}
+			err := apply(fieldValue.Elem(), prefix+name, flags)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		if !ok || fieldValue.IsZero() {
+			continue
+		}
+		name = prefix + name
+		flag := flags.Lookup(name)
+		if flag == nil {
+			return fmt.Errorf("unknown flag '%s' for field '%s'", name, field.Name)
+		}
+		if flag.Changed {
+			continue
+		}
+		values := flagValues(fieldValue, field.Tag.Get("separator"))
+		if slice, ok := flag.Value.(pflag.SliceValue); ok {
+			err := slice.Replace(values)
+			if err != nil {
+				return fmt.Errorf("invalid value for '%s': %v", name, err)
+			}
+			flag.Changed = true
+			continue
+		}
+		err := flags.Set(name, strings.Join(values, ","))
+		if err != nil {
+			return fmt.Errorf("invalid value for '%s': %v", name, err)
+		}
+	}
+	return nil
+}
+
+// flagValues converts the value of a field to the textual values of the flag. Maps are converted
+// to 'key=value' pairs, or to pairs with the separator given in the 'separator' tag.
+func flagValues(value reflect.Value, separator string) []string {
+	if value.Kind() == reflect.Ptr {
+		value = value.Elem()
+	}
+	switch value.Kind() {
+	case reflect.Slice:
+		values := make([]string, value.Len())
+		for i := range values {
+			values[i] = value.Index(i).String()
+		}
+		return values
+	case reflect.Map:
+		pairs := map[string]string{}
+		for _, key := range value.MapKeys() {
+			pairs[key.String()] = value.MapIndex(key).String()
+		}
+		return joinPairs(pairs, separator)
+	case reflect.Float64:
+		return []string{strconv.FormatFloat(value.Float(), 'f', -1, 64)}
+	default:
+		return []string{fmt.Sprint(value.Interface())}
+	}
+}
+
+func joinPairs(pairs map[string]string, separator string) []string {
+	if separator == "" {
+		separator = "="
+	}
+	// Tags are split with a space when the keys or values contain the usual separator:
+	for key, value := range pairs {
+		if separator != "=" && strings.Contains(key+value, separator) {
+			separator = " "
+			break
+		}
+	}
+	values := make([]string, 0, len(pairs))
+	for key, value := range pairs {
+		values = append(values, key+separator+value)
+	}
+	sort.Strings(values)
+	return values
+}
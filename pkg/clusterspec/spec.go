@@ -0,0 +1,188 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the versioned schema of the cluster spec files used by the '--from-file' flag
+// of the 'create cluster' command, and printed by 'describe cluster -o spec'.
+//
+// Each field of the schema corresponds to a flag of the 'create cluster' command, given in the
+// 'flag' tag, so that the values of the file go through the same validations as the flags. The
+// 'flag' tag of a nested struct is a prefix added to the flags of its fields.
+
+package clusterspec
+
+// Version and kind of the schema of the cluster spec files:
+const (
+	APIVersion = "rosa.openshift.io/v1alpha1"
+	Kind       = "ClusterSpec"
+)
+
+// ClusterSpec describes a cluster, as it is given to the 'create cluster' command.
+type ClusterSpec struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+
+	Name         string `json:"name" flag:"cluster-name"`
+	DomainPrefix string `json:"domainPrefix,omitempty" flag:"domain-prefix"`
+	Region       string `json:"region,omitempty" flag:"region"`
+	Version      string `json:"version,omitempty" flag:"version"`
+	ChannelGroup string `json:"channelGroup,omitempty" flag:"channel-group"`
+	HostedCP     bool   `json:"hostedCP,omitempty" flag:"hosted-cp"`
+	STS          bool   `json:"sts,omitempty" flag:"sts"`
+	MultiAZ      bool   `json:"multiAZ,omitempty" flag:"multi-az"`
+
+	BillingAccount    string            `json:"billingAccount,omitempty" flag:"billing-account"`
+	DisableMonitoring bool              `json:"disableWorkloadMonitoring,omitempty" flag:"disable-workload-monitoring"`
+	IMDSv2            string            `json:"ec2MetadataHttpTokens,omitempty" flag:"ec2-metadata-http-tokens"`
+	AuditLogRoleARN   string            `json:"auditLogRoleARN,omitempty" flag:"audit-log-arn"`
+	Tags              map[string]string `json:"tags,omitempty" flag:"tags" separator:":"`
+
+	Compute        *Compute        `json:"compute,omitempty"`
+	Network        *Network        `json:"network,omitempty"`
+	SecurityGroups *SecurityGroups `json:"securityGroups,omitempty" flag:"additional-"`
+	Roles          *Roles          `json:"roles,omitempty"`
+	OIDC           *OIDC           `json:"oidc,omitempty"`
+	Encryption     *Encryption     `json:"encryption,omitempty"`
+	Proxy          *Proxy          `json:"proxy,omitempty"`
+	Autoscaler     *Autoscaler     `json:"autoscaler,omitempty" flag:"autoscaler-"`
+	Registry       *Registry       `json:"registry,omitempty" flag:"registry-config-"`
+	DefaultIngress *DefaultIngress `json:"defaultIngress,omitempty" flag:"default-ingress-"`
+}
+
+// Compute describes the default machine pool.
+type Compute struct {
+	MachineType string            `json:"machineType,omitempty" flag:"compute-machine-type"`
+	Replicas    int               `json:"replicas,omitempty" flag:"replicas"`
+	Autoscaling bool              `json:"autoscaling,omitempty" flag:"enable-autoscaling"`
+	MinReplicas int               `json:"minReplicas,omitempty" flag:"min-replicas"`
+	MaxReplicas int               `json:"maxReplicas,omitempty" flag:"max-replicas"`
+	Labels      map[string]string `json:"labels,omitempty" flag:"worker-mp-labels"`
+	DiskSize    string            `json:"diskSize,omitempty" flag:"worker-disk-size"`
+}
+
+// Network describes the networking of the cluster, including the shared VPC settings.
+type Network struct {
+	Type        string `json:"type,omitempty" flag:"network-type"`
+	MachineCIDR string `json:"machineCIDR,omitempty" flag:"machine-cidr"`
+	ServiceCIDR string `json:"serviceCIDR,omitempty" flag:"service-cidr"`
+	PodCIDR     string `json:"podCIDR,omitempty" flag:"pod-cidr"`
+	HostPrefix  int    `json:"hostPrefix,omitempty" flag:"host-prefix"`
+	Private     bool   `json:"private,omitempty" flag:"private"`
+	PrivateLink bool   `json:"privateLink,omitempty" flag:"private-link"`
+	NoCNI       bool   `json:"noCNI,omitempty" flag:"no-cni"`
+
+	SubnetIDs         []string `json:"subnetIDs,omitempty" flag:"subnet-ids"`
+	AvailabilityZones []string `json:"availabilityZones,omitempty" flag:"availability-zones"`
+	AllowedPrincipals []string `json:"additionalAllowedPrincipals,omitempty" flag:"additional-allowed-principals"`
+
+	BaseDomain          string `json:"baseDomain,omitempty" flag:"base-domain"`
+	PrivateHostedZoneID string `json:"privateHostedZoneID,omitempty" flag:"ingress-private-hosted-zone-id"`
+	SharedVPCRoleARN    string `json:"sharedVPCRoleARN,omitempty" flag:"route53-role-arn"`
+	VPCEndpointRoleARN  string `json:"vpcEndpointRoleARN,omitempty" flag:"vpc-endpoint-role-arn"`
+	HCPInternalZoneID   string `json:"hcpInternalHostedZoneID,omitempty" flag:"hcp-internal-communication-hosted-zone-id"`
+}
+
+// SecurityGroups describes the additional security groups of the nodes.
+type SecurityGroups struct {
+	Compute      []string `json:"compute,omitempty" flag:"compute-security-group-ids"`
+	Infra        []string `json:"infra,omitempty" flag:"infra-security-group-ids"`
+	ControlPlane []string `json:"controlPlane,omitempty" flag:"control-plane-security-group-ids"`
+}
+
+// Roles describes the account and operator roles of an STS cluster.
+type Roles struct {
+	Installer           string `json:"installer,omitempty" flag:"role-arn"`
+	Support             string `json:"support,omitempty" flag:"support-role-arn"`
+	ControlPlane        string `json:"controlPlane,omitempty" flag:"controlplane-iam-role-arn"`
+	Worker              string `json:"worker,omitempty" flag:"worker-iam-role-arn"`
+	ExternalID          string `json:"externalID,omitempty" flag:"external-id"`
+	OperatorRolesPrefix string `json:"operatorRolesPrefix,omitempty" flag:"operator-roles-prefix"`
+	PermissionsBoundary string `json:"permissionsBoundary,omitempty" flag:"permissions-boundary"`
+}
+
+// OIDC describes how the cluster authenticates.
+type OIDC struct {
+	ConfigID              string `json:"configID,omitempty" flag:"oidc-config-id"`
+	ExternalAuthProviders bool   `json:"externalAuthProvidersEnabled,omitempty" flag:"external-auth-providers-enabled"`
+}
+
+// Encryption describes the encryption of the disks and of etcd, and the FIPS mode.
+type Encryption struct {
+	FIPS                 bool   `json:"fips,omitempty" flag:"fips"`
+	CustomerManagedKey   bool   `json:"enableCustomerManagedKey,omitempty" flag:"enable-customer-managed-key"`
+	KMSKeyARN            string `json:"kmsKeyARN,omitempty" flag:"kms-key-arn"`
+	EtcdEncryption       bool   `json:"etcdEncryption,omitempty" flag:"etcd-encryption"`
+	EtcdEncryptionKMSARN string `json:"etcdEncryptionKMSARN,omitempty" flag:"etcd-encryption-kms-arn"`
+}
+
+// Proxy describes the cluster-wide proxy.
+type Proxy struct {
+	HTTPProxy             string   `json:"httpProxy,omitempty" flag:"http-proxy"`
+	HTTPSProxy            string   `json:"httpsProxy,omitempty" flag:"https-proxy"`
+	NoProxy               []string `json:"noProxy,omitempty" flag:"no-proxy"`
+	AdditionalTrustBundle string   `json:"additionalTrustBundleFile,omitempty" flag:"additional-trust-bundle-file"`
+}
+
+// Autoscaler describes the cluster autoscaler. The numbers are pointers because the defaults of
+// the flags aren't zero.
+type Autoscaler struct {
+	BalanceSimilarNodeGroups    bool `json:"balanceSimilarNodeGroups,omitempty" flag:"balance-similar-node-groups"`
+	SkipNodesWithLocalStorage   bool `json:"skipNodesWithLocalStorage,omitempty" flag:"skip-nodes-with-local-storage"`
+	IgnoreDaemonsetsUtilization bool `json:"ignoreDaemonsetsUtilization,omitempty" flag:"ignore-daemonsets-utilization"`
+
+	LogVerbosity         *int   `json:"logVerbosity,omitempty" flag:"log-verbosity"`
+	MaxPodGracePeriod    *int   `json:"maxPodGracePeriod,omitempty" flag:"max-pod-grace-period"`
+	PodPriorityThreshold *int   `json:"podPriorityThreshold,omitempty" flag:"pod-priority-threshold"`
+	MaxNodeProvisionTime string `json:"maxNodeProvisionTime,omitempty" flag:"max-node-provision-time"`
+
+	MaxNodesTotal *int     `json:"maxNodesTotal,omitempty" flag:"max-nodes-total"`
+	MinCores      *int     `json:"minCores,omitempty" flag:"min-cores"`
+	MaxCores      *int     `json:"maxCores,omitempty" flag:"max-cores"`
+	MinMemory     *int     `json:"minMemory,omitempty" flag:"min-memory"`
+	MaxMemory     *int     `json:"maxMemory,omitempty" flag:"max-memory"`
+	GPULimits     []string `json:"gpuLimits,omitempty" flag:"gpu-limit"`
+
+	BalancingIgnoredLabels []string `json:"balancingIgnoredLabels,omitempty" flag:"balancing-ignored-labels"`
+
+	ScaleDown *ScaleDown `json:"scaleDown,omitempty" flag:"scale-down-"`
+}
+
+// ScaleDown describes when the cluster autoscaler removes nodes.
+type ScaleDown struct {
+	Enabled              bool     `json:"enabled,omitempty" flag:"enabled"`
+	UnneededTime         string   `json:"unneededTime,omitempty" flag:"unneeded-time"`
+	UtilizationThreshold *float64 `json:"utilizationThreshold,omitempty" flag:"utilization-threshold"`
+	DelayAfterAdd        string   `json:"delayAfterAdd,omitempty" flag:"delay-after-add"`
+	DelayAfterDelete     string   `json:"delayAfterDelete,omitempty" flag:"delay-after-delete"`
+	DelayAfterFailure    string   `json:"delayAfterFailure,omitempty" flag:"delay-after-failure"`
+}
+
+// Registry describes the image registry configuration.
+type Registry struct {
+	AllowedRegistries       []string `json:"allowedRegistries,omitempty" flag:"allowed-registries"`
+	BlockedRegistries       []string `json:"blockedRegistries,omitempty" flag:"blocked-registries"`
+	InsecureRegistries      []string `json:"insecureRegistries,omitempty" flag:"insecure-registries"`
+	AllowedForImport        []string `json:"allowedRegistriesForImport,omitempty" flag:"allowed-registries-for-import"`
+	PlatformAllowlist       string   `json:"platformAllowlist,omitempty" flag:"platform-allowlist"`
+	AdditionalTrustedCAFile string   `json:"additionalTrustedCAFile,omitempty" flag:"additional-trusted-ca"`
+}
+
+// DefaultIngress describes the default ingress of the cluster.
+type DefaultIngress struct {
+	RouteSelectors     map[string]string `json:"routeSelectors,omitempty" flag:"route-selector"`
+	ExcludedNamespaces []string          `json:"excludedNamespaces,omitempty" flag:"excluded-namespaces"`
+	WildcardPolicy     string            `json:"wildcardPolicy,omitempty" flag:"wildcard-policy"`
+	NamespaceOwnership string            `json:"namespaceOwnershipPolicy,omitempty" flag:"namespace-ownership-policy"`
+}
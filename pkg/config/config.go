@@ -58,20 +58,40 @@ type Config struct {
 	UserAgent    string   `json:"user_agent,omitempty" doc:"OCM client UserAgent. Default value is used if not set."`
 	Version      string   `json:"version,omitempty" doc:"OCM client version. Default value is used if not set."`
 	FedRAMP      bool     `json:"fedramp,omitempty" doc:"Indicates FedRAMP."`
+	CAFile       string   `json:"ca_file,omitempty" doc:"PEM encoded CA bundle trusted in addition to the system CAs."`
+	ProxyURL     string   `json:"proxy_url,omitempty" doc:"URL of the proxy used to connect to OCM, AWS and downloads."`
+	NoProxy      string   `json:"no_proxy,omitempty" doc:"Comma separated hosts, domains and CIDRs that skip the proxy."`
+
+	RetryAttempts   int      `json:"retry_attempts,omitempty" doc:"Maximum attempts of throttled or failed API calls."`
+	RetryBackoff    string   `json:"retry_backoff,omitempty" doc:"Wait before the first retry, doubled for each one."`
+	RetryMaxBackoff string   `json:"retry_max_backoff,omitempty" doc:"Maximum time to wait between retries."`
+	RetryJitter     *float64 `json:"retry_jitter,omitempty" doc:"Fraction of the wait randomly added or subtracted."`
+
+	CurrentContext string             `json:"current_context,omitempty" doc:"Name of the active login context."`
+	Contexts       map[string]*Config `json:"contexts,omitempty" doc:"-"`
+
+	// context is the name of the login context that this configuration represents, and parent
+	// is the configuration it was selected from when that isn't the current context:
+	context string
+	parent  *Config
 }
 
-var DisallowedSetConfigProperties = []string{"scopes"}
+var DisallowedSetConfigProperties = []string{"scopes", "current_context"}
 
 func ConfigPropertiesNamesAndDocs() ([]string, []string) {
 	configType := reflect.ValueOf(Config{}).Type()
-	names := make([]string, configType.NumField())
-	docs := make([]string, configType.NumField())
+	names := make([]string, 0, configType.NumField())
+	docs := make([]string, 0, configType.NumField())
 	for i := 0; i < configType.NumField(); i++ {
-		tag := configType.Field(i).Tag
-		propName := strings.Split(tag.Get("json"), ",")[0]
-		names[i] = propName
-		propDoc := tag.Get("doc")
-		docs[i] = propDoc
+		field := configType.Field(i)
+		// Skip internal fields and the ones that can't be managed as a single property:
+		if !field.IsExported() || field.Tag.Get("doc") == "-" {
+			continue
+		}
+		propName := strings.Split(field.Tag.Get("json"), ",")[0]
+		names = append(names, propName)
+		propDoc := field.Tag.Get("doc")
+		docs = append(docs, propDoc)
 	}
 	return names, docs
 }
@@ -101,8 +121,19 @@ func GetAllowedConfigProperties() []string {
 	return allowedProperties
 }
 
-// Loads the configuration from the OS keyring if requested, load from the configuration file if not
+// Loads the configuration from the OS keyring if requested, load from the configuration file if not.
+// If a login context was selected with the '--context' flag the returned configuration is the one
+// of that context.
 func Load() (cfg *Config, err error) {
+	cfg, err = load()
+	if err != nil {
+		return nil, err
+	}
+	return selectContext(cfg, contextName)
+}
+
+// load returns the configuration as it is stored, without selecting any login context.
+func load() (cfg *Config, err error) {
 	if keyring, ok := IsKeyringManaged(); ok {
 		return loadFromOS(keyring)
 	}
@@ -175,7 +206,7 @@ func Save(cfg *Config) error {
 		return err
 	}
 
-	data, err := json.MarshalIndent(cfg, "", "  ")
+	data, err := json.MarshalIndent(cfg.persisted(), "", "  ")
 	if err != nil {
 		return fmt.Errorf("can't marshal config: %v", err)
 	}
@@ -383,6 +414,16 @@ func (c *Config) Connection() (connection *sdk.Connection, err error) {
 		builder.Tokens(tokens...)
 	}
 	builder.Insecure(c.Insecure)
+	if c.CAFile != "" {
+		builder.TrustedCAFile(c.CAFile)
+	}
+	wrapper, err := c.ProxyWrapper()
+	if err != nil {
+		return
+	}
+	if wrapper != nil {
+		builder.TransportWrapper(wrapper)
+	}
 
 	// Create the connection:
 	connection, err = builder.Build()
@@ -45,17 +45,25 @@ func TestConfig(t *testing.T) {
 
 var _ = Describe("Config", Ordered, func() {
 	propNamesAndDocs := map[string]string{
-		"access_token":  "Bearer access token.",
-		"client_id":     "OpenID client identifier.",
-		"client_secret": "OpenID client secret.",
-		"insecure":      "Enables insecure communication with the server.",
-		"refresh_token": "Offline or refresh token.",
-		"scopes":        "OpenID scope.",
-		"token_url":     "OpenID token URL.",
-		"url":           "URL of the API gateway.",
-		"user_agent":    "OCM client UserAgent. Default value is used if not set.",
-		"version":       "OCM client version. Default value is used if not set.",
-		"fedramp":       "Indicates FedRAMP.",
+		"access_token":      "Bearer access token.",
+		"client_id":         "OpenID client identifier.",
+		"client_secret":     "OpenID client secret.",
+		"insecure":          "Enables insecure communication with the server.",
+		"refresh_token":     "Offline or refresh token.",
+		"scopes":            "OpenID scope.",
+		"token_url":         "OpenID token URL.",
+		"url":               "URL of the API gateway.",
+		"user_agent":        "OCM client UserAgent. Default value is used if not set.",
+		"version":           "OCM client version. Default value is used if not set.",
+		"fedramp":           "Indicates FedRAMP.",
+		"ca_file":           "PEM encoded CA bundle trusted in addition to the system CAs.",
+		"proxy_url":         "URL of the proxy used to connect to OCM, AWS and downloads.",
+		"no_proxy":          "Comma separated hosts, domains and CIDRs that skip the proxy.",
+		"retry_attempts":    "Maximum attempts of throttled or failed API calls.",
+		"retry_backoff":     "Wait before the first retry, doubled for each one.",
+		"retry_max_backoff": "Maximum time to wait between retries.",
+		"retry_jitter":      "Fraction of the wait randomly added or subtracted.",
+		"current_context":   "Name of the active login context.",
 	}
 
 	It("Shows properties and docs for config", func() {
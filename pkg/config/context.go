@@ -0,0 +1,257 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the types and functions used to manage named login contexts. Each context
+// holds the tokens, URL and FedRAMP settings of one login, and they are stored side by side in the
+// configuration file or keyring. The top level settings of the configuration always mirror the
+// current context, so that tools unaware of contexts keep working.
+
+package config
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/spf13/pflag"
+)
+
+// DefaultContextName is the name given to a login that was made without a context when it needs
+// to be preserved before switching to another context.
+const DefaultContextName = "default"
+
+// contextName is the login context selected with the '--context' command line option.
+var contextName string
+
+// AddContextFlag adds the '--context' flag to the given set of command line flags.
+func AddContextFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&contextName,
+		"context",
+		"",
+		"Name of the login context to use instead of the current one. "+
+			"Use 'rosa config get-contexts' to list the available contexts.",
+	)
+}
+
+// ContextName returns the login context selected with the '--context' flag, if any.
+func ContextName() string {
+	return contextName
+}
+
+// SetContextName selects the login context used by Load.
+func SetContextName(name string) {
+	contextName = name
+}
+
+// LoginContext describes a named login context stored in the configuration.
+type LoginContext struct {
+	Name    string
+	Current bool
+	Config  *Config
+}
+
+// Context returns the name of the login context that this configuration represents. It is empty
+// when the configuration was created by a login without a context.
+func (c *Config) Context() string {
+	return c.context
+}
+
+// Clear removes all the settings of the configuration, but preserves the login context information
+// and the network and retry settings, which describe the network of the host rather than the
+// credentials.
+func (c *Config) Clear() {
+	*c = Config{
+		CAFile:          c.CAFile,
+		ProxyURL:        c.ProxyURL,
+		NoProxy:         c.NoProxy,
+		RetryAttempts:   c.RetryAttempts,
+		RetryBackoff:    c.RetryBackoff,
+		RetryMaxBackoff: c.RetryMaxBackoff,
+		RetryJitter:     c.RetryJitter,
+		CurrentContext:  c.CurrentContext,
+		Contexts:        c.Contexts,
+		context:         c.context,
+		parent:          c.parent,
+	}
+}
+
+// selectContext returns the configuration of the login context with the given name. If the context
+// doesn't exist yet an empty configuration is returned, so that it can be created by a login.
+func selectContext(cfg *Config, name string) (*Config, error) {
+	if name == "" {
+		if cfg != nil {
+			cfg.context = cfg.CurrentContext
+		}
+		return cfg, nil
+	}
+	if cfg == nil {
+		cfg = &Config{}
+	}
+	if name == cfg.CurrentContext {
+		cfg.context = name
+		return cfg, nil
+	}
+
+	selected := &Config{}
+	if stored, ok := cfg.Contexts[name]; ok && stored != nil {
+		*selected = *stored.settings()
+	}
+	selected.context = name
+	selected.Contexts = cfg.Contexts
+	if cfg.CurrentContext == "" && IsNotValid(cfg) {
+		// There is nothing to preserve at the top level, so the selected context becomes the
+		// current one:
+		selected.CurrentContext = name
+	} else {
+		selected.CurrentContext = cfg.CurrentContext
+		selected.parent = cfg
+	}
+	return selected, nil
+}
+
+// settings returns a copy of the configuration without any of the login context information.
+func (c *Config) settings() *Config {
+	result := *c
+	result.CurrentContext = ""
+	result.Contexts = nil
+	result.context = ""
+	result.parent = nil
+	return &result
+}
+
+// persisted returns the configuration as it needs to be stored. The settings of the login context
+// that this configuration represents are recorded with the rest of the contexts, and the top level
+// settings are only replaced when it is the current context.
+func (c *Config) persisted() *Config {
+	if c == nil || c.context == "" {
+		return c
+	}
+	result := *c
+	if c.parent != nil {
+		result = *c.parent
+	}
+	result.Contexts = make(map[string]*Config, len(c.Contexts)+1)
+	for name, stored := range c.Contexts {
+		result.Contexts[name] = stored
+	}
+	result.Contexts[c.context] = c.settings()
+	result.context = ""
+	result.parent = nil
+	return &result
+}
+
+// GetContexts returns the login contexts stored in the configuration, sorted by name.
+func GetContexts() ([]LoginContext, error) {
+	cfg, err := load()
+	if err != nil {
+		return nil, err
+	}
+	if cfg == nil {
+		return nil, nil
+	}
+	contexts := make([]LoginContext, 0, len(cfg.Contexts))
+	for name, stored := range cfg.Contexts {
+		if name == cfg.CurrentContext {
+			// The top level settings are the most recent ones for the current context:
+			stored = cfg.settings()
+		}
+		contexts = append(contexts, LoginContext{
+			Name:    name,
+			Current: name == cfg.CurrentContext,
+			Config:  stored,
+		})
+	}
+	sort.Slice(contexts, func(i, j int) bool {
+		return contexts[i].Name < contexts[j].Name
+	})
+	return contexts, nil
+}
+
+// UseContext makes the login context with the given name the current one.
+func UseContext(name string) error {
+	cfg, err := load()
+	if err != nil {
+		return err
+	}
+	if cfg == nil {
+		return fmt.Errorf("Context '%s' does not exist", name)
+	}
+	if name == cfg.CurrentContext {
+		return nil
+	}
+	stored, ok := cfg.Contexts[name]
+	if !ok || stored == nil {
+		return fmt.Errorf("Context '%s' does not exist", name)
+	}
+
+	contexts := make(map[string]*Config, len(cfg.Contexts)+1)
+	for key, value := range cfg.Contexts {
+		contexts[key] = value
+	}
+	switch {
+	case cfg.CurrentContext != "":
+		// Tokens may have been refreshed at the top level only:
+		contexts[cfg.CurrentContext] = cfg.settings()
+	case !IsNotValid(cfg):
+		// Keep the login that was made without a context so that it can be selected later:
+		if _, ok := contexts[DefaultContextName]; ok {
+			return fmt.Errorf("Can't switch context: the current login has no context and context '%s' "+
+				"already exists. Log in again with '--context' to name it", DefaultContextName)
+		}
+		contexts[DefaultContextName] = cfg.settings()
+	}
+
+	result := stored.settings()
+	result.CurrentContext = name
+	result.Contexts = contexts
+	return Save(result)
+}
+
+// DeleteContext removes the login context with the given name. If it is the current context the
+// top level settings are cleared as well, which leaves the user logged out.
+func DeleteContext(name string) error {
+	cfg, err := load()
+	if err != nil {
+		return err
+	}
+	if cfg == nil {
+		return fmt.Errorf("Context '%s' does not exist", name)
+	}
+	if _, ok := cfg.Contexts[name]; !ok {
+		return fmt.Errorf("Context '%s' does not exist", name)
+	}
+
+	var contexts map[string]*Config
+	for key, value := range cfg.Contexts {
+		if key == name {
+			continue
+		}
+		if contexts == nil {
+			contexts = map[string]*Config{}
+		}
+		contexts[key] = value
+	}
+
+	result := cfg
+	if name == cfg.CurrentContext {
+		result = &Config{}
+	}
+	result.Contexts = contexts
+	if result.Contexts == nil && IsNotValid(result) {
+		return Remove()
+	}
+	return Save(result)
+}
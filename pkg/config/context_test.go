@@ -0,0 +1,146 @@
+package config
+
+import (
+	"os"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Login contexts", Ordered, func() {
+	var tmpdir string
+	var err error
+
+	BeforeEach(func() {
+		tmpdir, err = os.MkdirTemp("/tmp", ".ocm-config-*")
+		Expect(err).NotTo(HaveOccurred())
+		os.Setenv("OCM_CONFIG", tmpdir+"/ocm_config.json")
+	})
+
+	AfterEach(func() {
+		SetContextName("")
+		os.Setenv("OCM_CONFIG", "")
+		os.RemoveAll(tmpdir)
+	})
+
+	saveContext := func(name string, url string) {
+		SetContextName(name)
+		defer SetContextName("")
+		cfg, err := Load()
+		Expect(err).NotTo(HaveOccurred())
+		cfg.URL = url
+		cfg.ClientID = "client"
+		cfg.TokenURL = "token-url"
+		cfg.AccessToken = name + "-token"
+		Expect(Save(cfg)).To(Succeed())
+	}
+
+	It("Makes the first context current", func() {
+		saveContext("prod", "https://api.openshift.com")
+
+		cfg, err := Load()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.Context()).To(Equal("prod"))
+		Expect(cfg.CurrentContext).To(Equal("prod"))
+		Expect(cfg.URL).To(Equal("https://api.openshift.com"))
+		Expect(cfg.Contexts).To(HaveKey("prod"))
+	})
+
+	It("Keeps the current context when saving another one", func() {
+		saveContext("prod", "https://api.openshift.com")
+		saveContext("stage", "https://api.stage.openshift.com")
+
+		cfg, err := Load()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.Context()).To(Equal("prod"))
+		Expect(cfg.URL).To(Equal("https://api.openshift.com"))
+
+		SetContextName("stage")
+		cfg, err = Load()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.Context()).To(Equal("stage"))
+		Expect(cfg.URL).To(Equal("https://api.stage.openshift.com"))
+		Expect(cfg.AccessToken).To(Equal("stage-token"))
+	})
+
+	It("Persists tokens to the selected context", func() {
+		saveContext("prod", "https://api.openshift.com")
+		saveContext("stage", "https://api.stage.openshift.com")
+
+		SetContextName("stage")
+		Expect(PersistTokens(nil, "new-access", "new-refresh")).To(Succeed())
+		SetContextName("")
+
+		cfg, err := Load()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.AccessToken).To(Equal("prod-token"))
+		Expect(cfg.Contexts["stage"].AccessToken).To(Equal("new-access"))
+		Expect(cfg.Contexts["stage"].RefreshToken).To(Equal("new-refresh"))
+	})
+
+	It("Switches and lists contexts", func() {
+		saveContext("prod", "https://api.openshift.com")
+		saveContext("stage", "https://api.stage.openshift.com")
+
+		Expect(UseContext("stage")).To(Succeed())
+		cfg, err := Load()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.Context()).To(Equal("stage"))
+		Expect(cfg.URL).To(Equal("https://api.stage.openshift.com"))
+
+		contexts, err := GetContexts()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(contexts).To(HaveLen(2))
+		Expect(contexts[0].Name).To(Equal("prod"))
+		Expect(contexts[0].Current).To(BeFalse())
+		Expect(contexts[1].Name).To(Equal("stage"))
+		Expect(contexts[1].Current).To(BeTrue())
+
+		err = UseContext("missing")
+		Expect(err).To(MatchError("Context 'missing' does not exist"))
+	})
+
+	It("Preserves a login without a context when switching", func() {
+		Expect(Save(&Config{
+			URL:         "https://api.openshift.com",
+			ClientID:    "client",
+			TokenURL:    "token-url",
+			AccessToken: "legacy-token",
+		})).To(Succeed())
+		saveContext("stage", "https://api.stage.openshift.com")
+
+		cfg, err := Load()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.Context()).To(BeEmpty())
+		Expect(cfg.AccessToken).To(Equal("legacy-token"))
+
+		Expect(UseContext("stage")).To(Succeed())
+		Expect(UseContext(DefaultContextName)).To(Succeed())
+		cfg, err = Load()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg.Context()).To(Equal(DefaultContextName))
+		Expect(cfg.AccessToken).To(Equal("legacy-token"))
+	})
+
+	It("Deletes contexts", func() {
+		saveContext("prod", "https://api.openshift.com")
+		saveContext("stage", "https://api.stage.openshift.com")
+
+		Expect(DeleteContext("prod")).To(Succeed())
+		cfg, err := Load()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(IsNotValid(cfg)).To(BeTrue())
+		Expect(cfg.Contexts).To(HaveKey("stage"))
+		Expect(cfg.Contexts).NotTo(HaveKey("prod"))
+
+		Expect(DeleteContext("stage")).To(Succeed())
+		cfg, err = Load()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cfg).To(BeNil())
+	})
+
+	It("Does not list contexts as config properties", func() {
+		Expect(GetAllConfigProperties()).NotTo(ContainElement("contexts"))
+		Expect(GetAllowedConfigProperties()).NotTo(ContainElement("current_context"))
+	})
+})
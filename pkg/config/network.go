@@ -0,0 +1,167 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the network settings of the configuration: the bundle of certificate
+// authorities trusted in addition to the ones of the system, and the proxy used to connect to OCM,
+// to AWS and to download files.
+
+package config
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+// Schemes supported in the URL of the proxy:
+var proxySchemes = map[string]bool{
+	"http":   true,
+	"https":  true,
+	"socks5": true,
+}
+
+// HasNetworkSettings checks if the configuration contains a CA bundle, a proxy or hosts that skip
+// the proxy.
+func (c *Config) HasNetworkSettings() bool {
+	return c != nil && (c.CAFile != "" || c.ProxyURL != "" || c.NoProxy != "")
+}
+
+// Proxy returns the function that selects the proxy for each request. The proxy URL and the hosts
+// that skip the proxy given in the configuration take precedence over the 'HTTPS_PROXY',
+// 'HTTP_PROXY' and 'NO_PROXY' environment variables.
+func (c *Config) Proxy() (func(*http.Request) (*url.URL, error), error) {
+	if c == nil || (c.ProxyURL == "" && c.NoProxy == "") {
+		return http.ProxyFromEnvironment, nil
+	}
+	settings := httpproxy.FromEnvironment()
+	if c.ProxyURL != "" {
+		err := ValidateProxyURL(c.ProxyURL)
+		if err != nil {
+			return nil, err
+		}
+		settings.HTTPProxy = c.ProxyURL
+		settings.HTTPSProxy = c.ProxyURL
+	}
+	if c.NoProxy != "" {
+		settings.NoProxy = c.NoProxy
+	}
+	proxy := settings.ProxyFunc()
+	return func(request *http.Request) (*url.URL, error) {
+		return proxy(request.URL)
+	}, nil
+}
+
+// CertPool returns the certificate authorities of the system together with the ones of the CA
+// bundle of the configuration, or nil if there is no CA bundle.
+func (c *Config) CertPool() (*x509.CertPool, error) {
+	if c == nil || c.CAFile == "" {
+		return nil, nil
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	data, err := os.ReadFile(c.CAFile)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read CA file '%s': %v", c.CAFile, err)
+	}
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("CA file '%s' doesn't contain any PEM encoded certificate", c.CAFile)
+	}
+	return pool, nil
+}
+
+// Transport returns a transport that uses the proxy and trusts the CA bundle of the configuration.
+// It doesn't disable the verification of certificates when the configuration is insecure, as that
+// only applies to the connection to OCM.
+func (c *Config) Transport() (*http.Transport, error) {
+	proxy, err := c.Proxy()
+	if err != nil {
+		return nil, err
+	}
+	pool, err := c.CertPool()
+	if err != nil {
+		return nil, err
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = proxy
+	transport.TLSClientConfig = &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		RootCAs:    pool,
+	}
+	return transport, nil
+}
+
+// NetworkTransport returns a transport that honours the network settings of the configuration, for
+// the clients that don't connect to OCM, like the downloads. When there is no configuration, or it
+// has no network settings, it returns the default transport.
+func (c *Config) NetworkTransport() (http.RoundTripper, error) {
+	if !c.HasNetworkSettings() {
+		return http.DefaultTransport, nil
+	}
+	return c.Transport()
+}
+
+// ProxyWrapper returns the transport wrapper that sets the proxy of the configuration in the
+// transports created by the OCM connection, which otherwise always take the proxy from the
+// environment. It returns nil when the configuration doesn't have a proxy or hosts that skip it.
+//
+// The connection applies the wrappers in the reverse order that they are added, so this has to be
+// added the last, so that it receives the transport created by the connection and not the one
+// returned by another wrapper.
+func (c *Config) ProxyWrapper() (func(http.RoundTripper) http.RoundTripper, error) {
+	if c == nil || (c.ProxyURL == "" && c.NoProxy == "") {
+		return nil, nil
+	}
+	proxy, err := c.Proxy()
+	if err != nil {
+		return nil, err
+	}
+	return func(wrapped http.RoundTripper) http.RoundTripper {
+		transport, ok := wrapped.(*http.Transport)
+		if ok {
+			transport.Proxy = proxy
+		}
+		return wrapped
+	}, nil
+}
+
+// NormalizeCAFile checks that the given file contains PEM encoded certificates, and returns its
+// absolute path, so that it can be used from any directory.
+func NormalizeCAFile(name string) (string, error) {
+	_, err := (&Config{CAFile: name}).CertPool()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Abs(name)
+}
+
+// ValidateProxyURL checks that the given URL is an absolute URL with a scheme supported for
+// proxies.
+func ValidateProxyURL(value string) error {
+	parsed, err := url.Parse(value)
+	if err != nil || parsed.Host == "" || !proxySchemes[parsed.Scheme] {
+		return fmt.Errorf("Proxy URL '%s' isn't valid: it must be an absolute URL with the 'http', "+
+			"'https' or 'socks5' scheme", value)
+	}
+	return nil
+}
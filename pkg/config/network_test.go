@@ -0,0 +1,123 @@
+package config
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Network settings", func() {
+	proxyFor := func(cfg *Config, address string) string {
+		proxy, err := cfg.Proxy()
+		Expect(err).NotTo(HaveOccurred())
+		request, err := http.NewRequest(http.MethodGet, address, nil)
+		Expect(err).NotTo(HaveOccurred())
+		result, err := proxy(request)
+		Expect(err).NotTo(HaveOccurred())
+		if result == nil {
+			return ""
+		}
+		return result.String()
+	}
+
+	It("Sends the requests through the proxy except for the excluded hosts", func() {
+		cfg := &Config{
+			ProxyURL: "http://proxy.example.com:3128",
+			NoProxy:  "internal.example.com,10.0.0.0/8",
+		}
+		Expect(cfg.HasNetworkSettings()).To(BeTrue())
+		Expect(proxyFor(cfg, "https://api.openshift.com/api")).To(Equal("http://proxy.example.com:3128"))
+		Expect(proxyFor(cfg, "https://iam.amazonaws.com")).To(Equal("http://proxy.example.com:3128"))
+		Expect(proxyFor(cfg, "https://internal.example.com/file")).To(BeEmpty())
+		Expect(proxyFor(cfg, "https://mirror.internal.example.com/file")).To(BeEmpty())
+		Expect(proxyFor(cfg, "https://10.1.2.3/file")).To(BeEmpty())
+	})
+
+	It("Rejects proxy URLs that aren't absolute or have unsupported schemes", func() {
+		Expect(ValidateProxyURL("http://proxy.example.com:3128")).To(Succeed())
+		Expect(ValidateProxyURL("socks5://proxy.example.com:1080")).To(Succeed())
+		Expect(ValidateProxyURL("proxy.example.com:3128")).NotTo(Succeed())
+		Expect(ValidateProxyURL("ftp://proxy.example.com")).NotTo(Succeed())
+		_, err := (&Config{ProxyURL: "proxy:3128"}).Proxy()
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("Trusts the certificate authorities of the CA file", func() {
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		defer server.Close()
+		dir := GinkgoT().TempDir()
+		name := filepath.Join(dir, "ca.pem")
+		data := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+		Expect(os.WriteFile(name, data, 0600)).To(Succeed())
+
+		_, err := (&http.Client{Transport: http.DefaultTransport}).Get(server.URL)
+		Expect(err).To(HaveOccurred())
+
+		transport, err := (&Config{CAFile: name}).Transport()
+		Expect(err).NotTo(HaveOccurred())
+		response, err := (&http.Client{Transport: transport}).Get(server.URL)
+		Expect(err).NotTo(HaveOccurred())
+		response.Body.Close()
+	})
+
+	It("Uses the default transport without network settings", func() {
+		var cfg *Config
+		transport, err := cfg.NetworkTransport()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(transport).To(BeIdenticalTo(http.DefaultTransport))
+
+		transport, err = (&Config{ProxyURL: "http://proxy.example.com:3128"}).NetworkTransport()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(transport).NotTo(BeIdenticalTo(http.DefaultTransport))
+	})
+
+	It("Sets the proxy in the transport that the wrapper receives", func() {
+		wrapper, err := (&Config{CAFile: "/etc/pki/corporate.pem"}).ProxyWrapper()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(wrapper).To(BeNil())
+
+		cfg := &Config{ProxyURL: "http://proxy.example.com:3128"}
+		wrapper, err = cfg.ProxyWrapper()
+		Expect(err).NotTo(HaveOccurred())
+		transport := &http.Transport{
+			Proxy:             http.ProxyFromEnvironment,
+			DisableKeepAlives: true,
+		}
+		Expect(wrapper(transport)).To(BeIdenticalTo(transport))
+		Expect(transport.DisableKeepAlives).To(BeTrue())
+		request, err := http.NewRequest(http.MethodGet, "https://api.openshift.com/api", nil)
+		Expect(err).NotTo(HaveOccurred())
+		proxy, err := transport.Proxy(request)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(proxy.String()).To(Equal("http://proxy.example.com:3128"))
+	})
+
+	It("Normalizes and validates the CA file", func() {
+		dir := GinkgoT().TempDir()
+		Expect(os.WriteFile(filepath.Join(dir, "empty.pem"), []byte("nothing"), 0600)).To(Succeed())
+		_, err := NormalizeCAFile(filepath.Join(dir, "empty.pem"))
+		Expect(err).To(MatchError(ContainSubstring("doesn't contain any PEM encoded certificate")))
+		_, err = NormalizeCAFile(filepath.Join(dir, "missing.pem"))
+		Expect(err).To(MatchError(ContainSubstring("Failed to read CA file")))
+	})
+
+	It("Preserves the network settings when the configuration is cleared", func() {
+		cfg := &Config{
+			AccessToken: "token",
+			CAFile:      "/etc/pki/corporate.pem",
+			ProxyURL:    "http://proxy.example.com:3128",
+			NoProxy:     ".internal",
+		}
+		cfg.Clear()
+		Expect(cfg).To(Equal(&Config{
+			CAFile:   "/etc/pki/corporate.pem",
+			ProxyURL: "http://proxy.example.com:3128",
+			NoProxy:  ".internal",
+		}))
+	})
+})
@@ -0,0 +1,429 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the checks run by the 'rosa diagnose cluster' command. Each check returns
+// its findings, or nothing when it doesn't apply to the cluster.
+
+package diagnose
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	errors "github.com/zgalor/weberr"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+type check struct {
+	name string
+	run  func(r *rosa.Runtime, cluster *cmv1.Cluster) ([]*Finding, error)
+}
+
+var checks = []check{
+	{name: "cluster-state", run: checkClusterState},
+	{name: "limited-support", run: checkLimitedSupport},
+	{name: "inflight-checks", run: checkInflightChecks},
+	{name: "upgrade", run: checkUpgrade},
+	{name: "machine-pools", run: checkMachinePools},
+	{name: "network-verification", run: checkNetworkVerification},
+	{name: "oidc-provider", run: checkOIDCProvider},
+	{name: "operator-roles", run: checkOperatorRoles},
+}
+
+func checkClusterState(_ *rosa.Runtime, cluster *cmv1.Cluster) ([]*Finding, error) {
+	state := cluster.State()
+	finding := &Finding{
+		Severity:    SeverityInfo,
+		Explanation: fmt.Sprintf("The cluster is in '%s' state", state),
+	}
+	switch state {
+	case cmv1.ClusterStateReady:
+		finding.Severity = SeverityOK
+		finding.Explanation = "The cluster is ready"
+	case cmv1.ClusterStateError:
+		finding.Severity = SeverityError
+		if code := cluster.Status().ProvisionErrorCode(); code != "" {
+			finding.Explanation += fmt.Sprintf(": %s %s", code, cluster.Status().ProvisionErrorMessage())
+		}
+		finding.Remediation = fmt.Sprintf("Review the installation logs with 'rosa logs install -c %s'.",
+			cluster.Name())
+	case cmv1.ClusterStateWaiting:
+		if ocm.IsSts(cluster) {
+			finding.Explanation = "The cluster is waiting for its operator roles and OIDC provider"
+			finding.Remediation = fmt.Sprintf("Create them with 'rosa create operator-roles -c %s' and "+
+				"'rosa create oidc-provider -c %s'.", cluster.Name(), cluster.Name())
+		}
+	case cmv1.ClusterStateUninstalling:
+		finding.Severity = SeverityWarning
+	default:
+		if description := cluster.Status().Description(); description != "" {
+			finding.Explanation += fmt.Sprintf(": %s", description)
+		}
+	}
+	return []*Finding{finding}, nil
+}
+
+func checkLimitedSupport(r *rosa.Runtime, cluster *cmv1.Cluster) ([]*Finding, error) {
+	reasons, err := r.OCMClient.GetLimitedSupportReasons(cluster.ID())
+	if err != nil {
+		return nil, err
+	}
+	if len(reasons) == 0 {
+		return []*Finding{{
+			Severity:    SeverityOK,
+			Explanation: "The cluster isn't in limited support",
+		}}, nil
+	}
+	findings := []*Finding{}
+	for _, reason := range reasons {
+		explanation := fmt.Sprintf("The cluster is in limited support: %s", reason.Summary())
+		if reason.Details() != "" {
+			explanation = fmt.Sprintf("%s. %s", strings.TrimSuffix(explanation, "."), reason.Details())
+		}
+		findings = append(findings, &Finding{
+			Severity:    SeverityError,
+			Explanation: explanation,
+			Remediation: "Fix the cause given in the reason. Limited support is removed once the cluster " +
+				"is supportable again, contact Red Hat support if the cause isn't clear.",
+		})
+	}
+	return findings, nil
+}
+
+func checkInflightChecks(r *rosa.Runtime, cluster *cmv1.Cluster) ([]*Finding, error) {
+	inflightChecks, err := r.OCMClient.GetInflightChecks(cluster.ID())
+	if err != nil {
+		return nil, err
+	}
+	if len(inflightChecks) == 0 {
+		return nil, nil
+	}
+	findings := []*Finding{}
+	passed := 0
+	for _, inflightCheck := range inflightChecks {
+		switch inflightCheck.State() {
+		case cmv1.InflightCheckStatePassed:
+			passed++
+		case cmv1.InflightCheckStateFailed:
+			explanation := fmt.Sprintf("Inflight check '%s' failed", inflightCheck.Name())
+			if inflightCheck.Details() != nil {
+				details, err := json.Marshal(inflightCheck.Details())
+				if err != nil {
+					return nil, err
+				}
+				explanation += fmt.Sprintf(": %s", details)
+			}
+			findings = append(findings, &Finding{
+				Severity:    SeverityWarning,
+				Explanation: explanation,
+				Remediation: fmt.Sprintf("Adjust the network configuration of the cluster and run "+
+					"'rosa verify network -c %s'.", cluster.Name()),
+			})
+		}
+	}
+	switch {
+	case passed == len(inflightChecks):
+		findings = append(findings, &Finding{
+			Severity:    SeverityOK,
+			Explanation: fmt.Sprintf("All %d inflight checks passed", passed),
+		})
+	case passed+len(findings) < len(inflightChecks):
+		findings = append(findings, &Finding{
+			Severity: SeverityInfo,
+			Explanation: fmt.Sprintf("%d inflight checks are still running",
+				len(inflightChecks)-passed-len(findings)),
+		})
+	}
+	return findings, nil
+}
+
+// upgradeFinding returns the finding for the upgrade policy of the given resource, with the given
+// remediation when the upgrade fails.
+func upgradeFinding(resource string, version string, state *cmv1.UpgradePolicyState,
+	remediation string) *Finding {
+	finding := &Finding{
+		Severity:    SeverityInfo,
+		Explanation: fmt.Sprintf("The upgrade of %s to version '%s' is %s", resource, version, state.Value()),
+	}
+	switch state.Value() {
+	case cmv1.UpgradePolicyStateValueCompleted:
+		finding.Severity = SeverityOK
+	case cmv1.UpgradePolicyStateValueStarted:
+		finding.Explanation = fmt.Sprintf("The upgrade of %s to version '%s' is in progress", resource, version)
+	case cmv1.UpgradePolicyStateValueDelayed:
+		finding.Severity = SeverityWarning
+		finding.Remediation = "The upgrade starts once the conditions given in the description are resolved."
+	case cmv1.UpgradePolicyStateValueFailed:
+		finding.Severity = SeverityError
+		finding.Remediation = remediation
+	}
+	if state.Description() != "" {
+		finding.Explanation += fmt.Sprintf(": %s", state.Description())
+	}
+	return finding
+}
+
+func checkUpgrade(r *rosa.Runtime, cluster *cmv1.Cluster) ([]*Finding, error) {
+	var version string
+	var state *cmv1.UpgradePolicyState
+	if cluster.Hypershift().Enabled() {
+		policy, err := r.OCMClient.GetControlPlaneScheduledUpgrade(cluster.ID())
+		if err != nil {
+			return nil, err
+		}
+		if policy != nil {
+			version, state = policy.Version(), policy.State()
+		}
+	} else {
+		policy, policyState, err := r.OCMClient.GetScheduledUpgrade(cluster.ID())
+		if err != nil {
+			return nil, err
+		}
+		if policy != nil {
+			version, state = policy.Version(), policyState
+		}
+	}
+	if state == nil {
+		return []*Finding{{
+			Severity:    SeverityOK,
+			Explanation: "There is no upgrade in progress or scheduled",
+		}}, nil
+	}
+	remediation := fmt.Sprintf("Schedule the upgrade again with 'rosa upgrade cluster -c %s', contact "+
+		"Red Hat support if it keeps failing.", cluster.Name())
+	return []*Finding{upgradeFinding("the cluster", version, state, remediation)}, nil
+}
+
+// checkMachinePools checks the replicas and the upgrades of the machine pools of Hosted Control
+// Plane clusters, the only ones that report the replicas that are ready.
+func checkMachinePools(r *rosa.Runtime, cluster *cmv1.Cluster) ([]*Finding, error) {
+	if !cluster.Hypershift().Enabled() {
+		return nil, nil
+	}
+	nodePools, err := r.OCMClient.GetNodePools(cluster.ID())
+	if err != nil {
+		return nil, err
+	}
+	findings := []*Finding{}
+	ready := 0
+	for _, nodePool := range nodePools {
+		current := nodePool.Status().CurrentReplicas()
+		desired := nodePool.Replicas()
+		if autoscaling, ok := nodePool.GetAutoscaling(); ok {
+			desired = autoscaling.MinReplica()
+		}
+		if current >= desired {
+			ready++
+		} else {
+			explanation := fmt.Sprintf("Machine pool '%s' has %d of %d replicas ready", nodePool.ID(),
+				current, desired)
+			if message := nodePool.Status().Message(); message != "" {
+				explanation += fmt.Sprintf(": %s", message)
+			}
+			findings = append(findings, &Finding{
+				Severity:    SeverityWarning,
+				Explanation: explanation,
+				Remediation: fmt.Sprintf("Check the machine pool with 'rosa describe machinepool %s -c %s'. "+
+					"Replicas that aren't ready are usually caused by AWS quotas, the free addresses of the "+
+					"subnet or the network configuration.", nodePool.ID(), cluster.Name()),
+			})
+		}
+
+		_, policy, err := r.OCMClient.GetHypershiftNodePoolUpgrade(cluster.ID(), cluster.Name(), nodePool.ID())
+		if err != nil {
+			return nil, err
+		}
+		if policy == nil || policy.State().Value() == cmv1.UpgradePolicyStateValueCompleted {
+			continue
+		}
+		remediation := fmt.Sprintf("Schedule the upgrade again with 'rosa upgrade machinepool %s -c %s', "+
+			"contact Red Hat support if it keeps failing.", nodePool.ID(), cluster.Name())
+		findings = append(findings, upgradeFinding(fmt.Sprintf("machine pool '%s'", nodePool.ID()),
+			policy.Version(), policy.State(), remediation))
+	}
+	if ready == len(nodePools) {
+		findings = append(findings, &Finding{
+			Severity:    SeverityOK,
+			Explanation: fmt.Sprintf("All %d machine pools have their replicas ready", ready),
+		})
+	}
+	return findings, nil
+}
+
+// checkNetworkVerification checks the last network verification of the subnets of clusters
+// installed in an existing VPC.
+func checkNetworkVerification(r *rosa.Runtime, cluster *cmv1.Cluster) ([]*Finding, error) {
+	subnets := cluster.AWS().SubnetIDs()
+	if len(subnets) == 0 {
+		return nil, nil
+	}
+	findings := []*Finding{}
+	unverified := []string{}
+	passed := 0
+	for _, subnet := range subnets {
+		verification, err := r.OCMClient.GetVerifyNetworkSubnet(subnet)
+		if errors.GetType(err) == errors.NotFound {
+			unverified = append(unverified, subnet)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch verification.State() {
+		case "passed":
+			passed++
+		case "failed":
+			findings = append(findings, &Finding{
+				Severity: SeverityWarning,
+				Explanation: fmt.Sprintf("The network verification of subnet '%s' failed: %s", subnet,
+					strings.Join(verification.Details(), ", ")),
+				Remediation: fmt.Sprintf("Allow the egress traffic that failed and run "+
+					"'rosa verify network -c %s' again.", cluster.Name()),
+			})
+		default:
+			findings = append(findings, &Finding{
+				Severity: SeverityInfo,
+				Explanation: fmt.Sprintf("The network verification of subnet '%s' is %s", subnet,
+					verification.State()),
+			})
+		}
+	}
+	if len(unverified) > 0 {
+		findings = append(findings, &Finding{
+			Severity:    SeverityInfo,
+			Explanation: fmt.Sprintf("Subnets '%s' haven't been verified", strings.Join(unverified, "', '")),
+			Remediation: fmt.Sprintf("Verify them with 'rosa verify network -c %s'.", cluster.Name()),
+		})
+	}
+	if passed == len(subnets) {
+		findings = append(findings, &Finding{
+			Severity:    SeverityOK,
+			Explanation: fmt.Sprintf("All %d subnets passed the network verification", passed),
+		})
+	}
+	return findings, nil
+}
+
+// otherAccount returns a finding explaining that the AWS checks were skipped when the cluster
+// doesn't belong to the AWS account of the credentials, as the roles and the OIDC provider of the
+// cluster can't be inspected from another account.
+func otherAccount(r *rosa.Runtime, cluster *cmv1.Cluster) *Finding {
+	parsed, err := arn.Parse(cluster.AWS().STS().RoleARN())
+	if err != nil || parsed.AccountID == r.Creator.AccountID {
+		return nil
+	}
+	return &Finding{
+		Severity: SeverityInfo,
+		Explanation: fmt.Sprintf("The check was skipped because the cluster belongs to AWS account '%s' "+
+			"and the AWS credentials to account '%s'", parsed.AccountID, r.Creator.AccountID),
+		Remediation: "Run the command with the credentials of the AWS account of the cluster.",
+	}
+}
+
+func checkOIDCProvider(r *rosa.Runtime, cluster *cmv1.Cluster) ([]*Finding, error) {
+	if !ocm.IsSts(cluster) {
+		return nil, nil
+	}
+	if finding := otherAccount(r, cluster); finding != nil {
+		return []*Finding{finding}, nil
+	}
+	issuerURL := cluster.AWS().STS().OIDCEndpointURL()
+	exists, err := r.AWSClient.HasOpenIDConnectProvider(issuerURL, r.Creator.Partition, r.Creator.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return []*Finding{{
+			Severity:    SeverityOK,
+			Explanation: fmt.Sprintf("The OIDC provider for '%s' exists", issuerURL),
+		}}, nil
+	}
+	remediation := fmt.Sprintf("Create it with 'rosa create oidc-provider -c %s'.", cluster.Name())
+	if ocm.IsOidcConfigReusable(cluster) {
+		remediation = fmt.Sprintf("Create it with 'rosa create oidc-provider --oidc-config-id %s'.",
+			cluster.AWS().STS().OidcConfig().ID())
+	}
+	return []*Finding{{
+		Severity: SeverityError,
+		Explanation: fmt.Sprintf("The OIDC provider for '%s' doesn't exist in AWS account '%s'", issuerURL,
+			r.Creator.AccountID),
+		Remediation: remediation,
+	}}, nil
+}
+
+// checkOperatorRoles checks that the operator roles of the cluster exist, and that they trust the
+// OIDC provider of the cluster and have policies compatible with its version.
+func checkOperatorRoles(r *rosa.Runtime, cluster *cmv1.Cluster) ([]*Finding, error) {
+	if !ocm.IsSts(cluster) {
+		return nil, nil
+	}
+	if finding := otherAccount(r, cluster); finding != nil {
+		return []*Finding{finding}, nil
+	}
+	findings := []*Finding{}
+	operatorRoles := []ocm.OperatorIAMRole{}
+	for _, operatorIAMRole := range cluster.AWS().STS().OperatorIAMRoles() {
+		roleName, err := aws.GetResourceIdFromARN(operatorIAMRole.RoleARN())
+		if err != nil {
+			return nil, err
+		}
+		exists, _, err := r.AWSClient.CheckRoleExists(roleName)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			findings = append(findings, &Finding{
+				Severity:    SeverityError,
+				Explanation: fmt.Sprintf("Operator role '%s' doesn't exist", operatorIAMRole.RoleARN()),
+				Remediation: fmt.Sprintf("Create it with 'rosa create operator-roles -c %s'.", cluster.Name()),
+			})
+			continue
+		}
+		operatorRole, err := ocm.NewOperatorIamRoleFromCmv1(operatorIAMRole)
+		if err != nil {
+			return nil, err
+		}
+		operatorRoles = append(operatorRoles, *operatorRole)
+	}
+	if len(findings) > 0 {
+		return findings, nil
+	}
+	expectedPath, err := aws.GetPathFromARN(cluster.AWS().STS().RoleARN())
+	if err != nil {
+		return nil, err
+	}
+	err = ocm.ValidateOperatorRolesMatchOidcProvider(r.Reporter, r.AWSClient, operatorRoles,
+		cluster.AWS().STS().OIDCEndpointURL(), ocm.GetVersionMinor(cluster.Version().RawID()), expectedPath,
+		cluster.AWS().STS().ManagedPolicies(), false)
+	if err != nil {
+		return []*Finding{{
+			Severity:    SeverityError,
+			Explanation: fmt.Sprintf("The operator roles don't match the cluster: %v", err),
+			Remediation: fmt.Sprintf("Fix the trust policy or the policies of the role, or create the "+
+				"operator roles again with 'rosa create operator-roles -c %s'.", cluster.Name()),
+		}}, nil
+	}
+	return []*Finding{{
+		Severity:    SeverityOK,
+		Explanation: fmt.Sprintf("The %d operator roles exist and trust the OIDC provider", len(operatorRoles)),
+	}}, nil
+}
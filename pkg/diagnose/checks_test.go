@@ -0,0 +1,168 @@
+package diagnose
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	. "github.com/openshift-online/ocm-sdk-go/testing"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/test"
+)
+
+var _ = Describe("Checks", func() {
+	var t *test.TestingRuntime
+
+	BeforeEach(func() {
+		t = test.NewTestRuntime()
+	})
+
+	Describe("Cluster state", func() {
+		It("Explains the provision error of a cluster in error state", func() {
+			cluster := test.MockCluster(func(c *cmv1.ClusterBuilder) {
+				c.State(cmv1.ClusterStateError)
+				c.Status(cmv1.NewClusterStatus().ProvisionErrorCode("OCM3055").
+					ProvisionErrorMessage("Subnets are missing tags"))
+			})
+			findings, err := checkClusterState(t.RosaRuntime, cluster)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(findings).To(Equal([]*Finding{{
+				Severity:    SeverityError,
+				Explanation: "The cluster is in 'error' state: OCM3055 Subnets are missing tags",
+				Remediation: "Review the installation logs with 'rosa logs install -c " + test.MockClusterName + "'.",
+			}}))
+		})
+	})
+
+	Describe("Inflight checks", func() {
+		It("Reports the failed inflight checks", func() {
+			t.ApiServer.AppendHandlers(
+				RespondWithJSON(http.StatusOK, `{"kind": "InflightCheckList", "page": 1, "size": 2, "total": 2,
+					"items": [{"kind": "InflightCheck", "id": "1", "name": "egress", "state": "failed",
+					"details": {"error": "Unable to reach quay.io"}},
+					{"kind": "InflightCheck", "id": "2", "name": "tags", "state": "passed"}]}`),
+			)
+			findings, err := checkInflightChecks(t.RosaRuntime, test.MockCluster(nil))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(findings).To(HaveLen(1))
+			Expect(findings[0].Severity).To(Equal(SeverityWarning))
+			Expect(findings[0].Explanation).To(Equal(`Inflight check 'egress' failed: ` +
+				`{"error":"Unable to reach quay.io"}`))
+		})
+	})
+
+	Describe("Machine pools", func() {
+		hostedCluster := func() *cmv1.Cluster {
+			return test.MockCluster(func(c *cmv1.ClusterBuilder) {
+				c.Hypershift(cmv1.NewHypershift().Enabled(true))
+			})
+		}
+		noPolicies := `{"kind": "NodePoolUpgradePolicyList", "page": 1, "size": 0, "total": 0, "items": []}`
+
+		It("Reports machine pools without enough replicas and failed upgrades", func() {
+			workers := `{"kind": "NodePool", "id": "workers", "replicas": 3,
+				"status": {"current_replicas": 1, "message": "Instances are launching"}}`
+			gpu := `{"kind": "NodePool", "id": "gpu", "autoscaling": {"min_replica": 1, "max_replica": 3},
+				"status": {"current_replicas": 1}}`
+			t.ApiServer.AppendHandlers(
+				RespondWithJSON(http.StatusOK, `{"kind": "NodePoolList", "page": 1, "size": 2, "total": 2,
+					"items": [`+workers+`, `+gpu+`]}`),
+				RespondWithJSON(http.StatusOK, workers),
+				RespondWithJSON(http.StatusOK, noPolicies),
+				RespondWithJSON(http.StatusOK, gpu),
+				RespondWithJSON(http.StatusOK, `{"kind": "NodePoolUpgradePolicyList", "page": 1, "size": 1,
+					"total": 1, "items": [{"kind": "NodePoolUpgradePolicy", "id": "policy",
+					"upgrade_type": "NodePool", "version": "4.14.10",
+					"state": {"value": "failed", "description": "Nodes didn't drain"}}]}`),
+			)
+			findings, err := checkMachinePools(t.RosaRuntime, hostedCluster())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(findings).To(HaveLen(2))
+			Expect(findings[0].Severity).To(Equal(SeverityWarning))
+			Expect(findings[0].Explanation).To(Equal("Machine pool 'workers' has 1 of 3 replicas ready: " +
+				"Instances are launching"))
+			Expect(findings[1].Severity).To(Equal(SeverityError))
+			Expect(findings[1].Explanation).To(Equal("The upgrade of machine pool 'gpu' to version '4.14.10' " +
+				"is failed: Nodes didn't drain"))
+		})
+
+		It("Doesn't apply to classic clusters", func() {
+			findings, err := checkMachinePools(t.RosaRuntime, test.MockCluster(nil))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(findings).To(BeEmpty())
+		})
+	})
+
+	Describe("Network verification", func() {
+		It("Reports failed and missing verifications of the subnets", func() {
+			cluster := test.MockCluster(func(c *cmv1.ClusterBuilder) {
+				c.AWS(cmv1.NewAWS().SubnetIDs("subnet-1", "subnet-2"))
+			})
+			t.ApiServer.AppendHandlers(
+				RespondWithJSON(http.StatusOK, `{"kind": "SubnetNetworkVerification", "id": "subnet-1",
+					"state": "failed", "details": ["api.openshift.com:443"]}`),
+				RespondWithJSON(http.StatusNotFound, `{"kind": "Error", "reason": "Not found"}`),
+			)
+			findings, err := checkNetworkVerification(t.RosaRuntime, cluster)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(findings).To(HaveLen(2))
+			Expect(findings[0].Severity).To(Equal(SeverityWarning))
+			Expect(findings[0].Explanation).To(Equal("The network verification of subnet 'subnet-1' failed: " +
+				"api.openshift.com:443"))
+			Expect(findings[1].Severity).To(Equal(SeverityInfo))
+			Expect(findings[1].Explanation).To(Equal("Subnets 'subnet-2' haven't been verified"))
+		})
+	})
+
+	Describe("OIDC provider and operator roles", func() {
+		var awsClient *aws.MockClient
+		var cluster *cmv1.Cluster
+
+		BeforeEach(func() {
+			awsClient = t.RosaRuntime.AWSClient.(*aws.MockClient)
+			t.RosaRuntime.Creator.Partition = "aws"
+			cluster = test.MockCluster(func(c *cmv1.ClusterBuilder) {
+				c.AWS(cmv1.NewAWS().STS(cmv1.NewSTS().
+					RoleARN("arn:aws:iam::123:role/Installer-Role").
+					OIDCEndpointURL("https://oidc.example.com/abc").
+					OperatorIAMRoles(cmv1.NewOperatorIAMRole().Name("ingress").
+						Namespace("openshift-ingress-operator").
+						RoleARN("arn:aws:iam::123:role/mycluster-openshift-ingress-operator"))))
+			})
+		})
+
+		It("Reports a missing OIDC provider", func() {
+			awsClient.EXPECT().HasOpenIDConnectProvider("https://oidc.example.com/abc", "aws", "123").
+				Return(false, nil)
+			findings, err := checkOIDCProvider(t.RosaRuntime, cluster)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(findings).To(Equal([]*Finding{{
+				Severity:    SeverityError,
+				Explanation: "The OIDC provider for 'https://oidc.example.com/abc' doesn't exist in AWS account '123'",
+				Remediation: "Create it with 'rosa create oidc-provider -c " + test.MockClusterName + "'.",
+			}}))
+		})
+
+		It("Reports missing operator roles", func() {
+			awsClient.EXPECT().CheckRoleExists("mycluster-openshift-ingress-operator").Return(false, "", nil)
+			findings, err := checkOperatorRoles(t.RosaRuntime, cluster)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(findings).To(HaveLen(1))
+			Expect(findings[0].Severity).To(Equal(SeverityError))
+			Expect(findings[0].Explanation).To(Equal("Operator role " +
+				"'arn:aws:iam::123:role/mycluster-openshift-ingress-operator' doesn't exist"))
+		})
+
+		It("Skips the checks when the cluster belongs to another AWS account", func() {
+			t.RosaRuntime.Creator.AccountID = "456"
+			findings, err := checkOIDCProvider(t.RosaRuntime, cluster)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(findings).To(HaveLen(1))
+			Expect(findings[0].Severity).To(Equal(SeverityInfo))
+			Expect(findings[0].Explanation).To(Equal("The check was skipped because the cluster belongs to " +
+				"AWS account '123' and the AWS credentials to account '456'"))
+		})
+	})
+})
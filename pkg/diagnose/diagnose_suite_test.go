@@ -0,0 +1,13 @@
+package diagnose
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestDiagnose(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Diagnose Suite")
+}
@@ -0,0 +1,146 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the report generated by the 'rosa diagnose cluster' command, which collects
+// the findings of all the checks with their severity and the suggested remediation.
+
+package diagnose
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+// Severity indicates how serious a finding is.
+type Severity string
+
+const (
+	SeverityOK      Severity = "ok"
+	SeverityInfo    Severity = "info"
+	SeverityWarning Severity = "warning"
+	SeverityError   Severity = "error"
+)
+
+// severities contains the severities from the least to the most serious.
+var severities = []Severity{SeverityOK, SeverityInfo, SeverityWarning, SeverityError}
+
+// summaryLabels contains the labels used for the number of findings of each severity.
+var summaryLabels = map[Severity]string{
+	SeverityOK:      "ok",
+	SeverityInfo:    "info",
+	SeverityWarning: "warnings",
+	SeverityError:   "errors",
+}
+
+func (s Severity) rank() int {
+	for i, severity := range severities {
+		if severity == s {
+			return i
+		}
+	}
+	return -1
+}
+
+// Finding is the result of a check. Checks that find nothing wrong also report a finding, with the
+// 'ok' severity, so that the report shows what was checked.
+type Finding struct {
+	Check       string   `json:"check"`
+	Severity    Severity `json:"severity"`
+	Explanation string   `json:"explanation"`
+	Remediation string   `json:"remediation,omitempty"`
+}
+
+// ClusterReport contains the findings of all the checks run on a cluster, from the most to the
+// least serious. The severity of the report is the one of its most serious finding.
+type ClusterReport struct {
+	ClusterID   string     `json:"cluster_id"`
+	ClusterName string     `json:"cluster_name"`
+	Severity    Severity   `json:"severity"`
+	Findings    []*Finding `json:"findings"`
+}
+
+// Diagnose runs all the checks on the given cluster. A check that can't be completed, for example
+// because the AWS credentials don't have the required permissions, doesn't stop the others: its
+// error is added to the report as a warning.
+func Diagnose(r *rosa.Runtime, cluster *cmv1.Cluster) *ClusterReport {
+	report := &ClusterReport{
+		ClusterID:   cluster.ID(),
+		ClusterName: cluster.Name(),
+		Severity:    SeverityOK,
+		Findings:    []*Finding{},
+	}
+	for _, check := range checks {
+		r.Reporter.Debugf("Running check '%s' on cluster '%s'", check.name, cluster.ID())
+		findings, err := check.run(r, cluster)
+		if err != nil {
+			findings = []*Finding{{
+				Severity:    SeverityWarning,
+				Explanation: fmt.Sprintf("The check couldn't be completed: %v", err),
+				Remediation: "Make sure that the OCM and AWS credentials have access to the cluster and run " +
+					"the command again.",
+			}}
+		}
+		for _, finding := range findings {
+			finding.Check = check.name
+			report.add(finding)
+		}
+	}
+	sort.SliceStable(report.Findings, func(i, j int) bool {
+		return report.Findings[i].Severity.rank() > report.Findings[j].Severity.rank()
+	})
+	return report
+}
+
+func (r *ClusterReport) add(finding *Finding) {
+	r.Findings = append(r.Findings, finding)
+	if finding.Severity.rank() > r.Severity.rank() {
+		r.Severity = finding.Severity
+	}
+}
+
+// Count returns the number of findings with the given severity.
+func (r *ClusterReport) Count(severity Severity) int {
+	count := 0
+	for _, finding := range r.Findings {
+		if finding.Severity == severity {
+			count++
+		}
+	}
+	return count
+}
+
+// Print writes the report in the format used when the '--output' flag isn't given.
+func (r *ClusterReport) Print(writer io.Writer) {
+	fmt.Fprintf(writer, "Diagnostics of cluster '%s' (%s):\n", r.ClusterName, r.ClusterID)
+	for _, finding := range r.Findings {
+		fmt.Fprintf(writer, "%-9s %s: %s\n", strings.ToUpper(string(finding.Severity)), finding.Check,
+			finding.Explanation)
+		if finding.Remediation != "" {
+			fmt.Fprintf(writer, "          Remediation: %s\n", finding.Remediation)
+		}
+	}
+	counts := []string{}
+	for i := len(severities) - 1; i >= 0; i-- {
+		counts = append(counts, fmt.Sprintf("%s: %d", summaryLabels[severities[i]], r.Count(severities[i])))
+	}
+	fmt.Fprintf(writer, "Summary: %s.\n", strings.Join(counts, ", "))
+}
@@ -0,0 +1,81 @@
+package diagnose
+
+import (
+	"bytes"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	. "github.com/openshift-online/ocm-sdk-go/testing"
+
+	"github.com/openshift/rosa/pkg/test"
+)
+
+var _ = Describe("ClusterReport", func() {
+	var t *test.TestingRuntime
+	var cluster *cmv1.Cluster
+
+	emptyList := `{"kind": "List", "page": 1, "size": 0, "total": 0, "items": []}`
+
+	BeforeEach(func() {
+		t = test.NewTestRuntime()
+		cluster = test.MockCluster(func(c *cmv1.ClusterBuilder) {
+			c.State(cmv1.ClusterStateReady)
+		})
+	})
+
+	It("Reports the checks that found nothing wrong", func() {
+		t.ApiServer.AppendHandlers(
+			RespondWithJSON(http.StatusOK, emptyList),
+			RespondWithJSON(http.StatusOK, emptyList),
+			RespondWithJSON(http.StatusOK, emptyList),
+		)
+		report := Diagnose(t.RosaRuntime, cluster)
+		Expect(report.Severity).To(Equal(SeverityOK))
+		Expect(report.Findings).To(Equal([]*Finding{
+			{Check: "cluster-state", Severity: SeverityOK, Explanation: "The cluster is ready"},
+			{Check: "limited-support", Severity: SeverityOK, Explanation: "The cluster isn't in limited support"},
+			{Check: "upgrade", Severity: SeverityOK, Explanation: "There is no upgrade in progress or scheduled"},
+		}))
+	})
+
+	It("Sorts the findings from the most serious and reports checks that can't be completed", func() {
+		t.ApiServer.AppendHandlers(
+			RespondWithJSON(http.StatusOK, `{"kind": "LimitedSupportReasonList", "page": 1, "size": 1,
+				"total": 1, "items": [{"kind": "LimitedSupportReason", "summary": "Cluster is unreachable",
+				"details": "The cluster API can't be reached."}]}`),
+			RespondWithJSON(http.StatusOK, emptyList),
+			RespondWithJSON(http.StatusForbidden, `{"kind": "Error", "reason": "Access denied"}`),
+		)
+		report := Diagnose(t.RosaRuntime, cluster)
+		Expect(report.Severity).To(Equal(SeverityError))
+		Expect(report.Findings).To(HaveLen(3))
+		Expect(report.Findings[0].Check).To(Equal("limited-support"))
+		Expect(report.Findings[0].Explanation).To(Equal("The cluster is in limited support: Cluster is " +
+			"unreachable. The cluster API can't be reached."))
+		Expect(report.Findings[1].Check).To(Equal("upgrade"))
+		Expect(report.Findings[1].Severity).To(Equal(SeverityWarning))
+		Expect(report.Findings[1].Explanation).To(HavePrefix("The check couldn't be completed: "))
+		Expect(report.Findings[2].Check).To(Equal("cluster-state"))
+	})
+
+	It("Prints the findings with their remediation and a summary", func() {
+		report := &ClusterReport{
+			ClusterID:   "123",
+			ClusterName: "mycluster",
+			Findings: []*Finding{
+				{Check: "oidc-provider", Severity: SeverityError, Explanation: "The OIDC provider doesn't exist",
+					Remediation: "Create it with 'rosa create oidc-provider -c mycluster'."},
+				{Check: "cluster-state", Severity: SeverityOK, Explanation: "The cluster is ready"},
+			},
+		}
+		out := &bytes.Buffer{}
+		report.Print(out)
+		Expect(out.String()).To(Equal("Diagnostics of cluster 'mycluster' (123):\n" +
+			"ERROR     oidc-provider: The OIDC provider doesn't exist\n" +
+			"          Remediation: Create it with 'rosa create oidc-provider -c mycluster'.\n" +
+			"OK        cluster-state: The cluster is ready\n" +
+			"Summary: errors: 1, warnings: 0, info: 0, ok: 1.\n"))
+	})
+})
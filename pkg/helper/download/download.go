@@ -9,12 +9,20 @@ import (
 	"strings"
 
 	"github.com/dustin/go-humanize"
+
+	"github.com/openshift/rosa/pkg/config"
 )
 
 // download will download a url to a local file. It's efficient because it will
 // write as it downloads and not load the whole file into memory. We pass an io.TeeReader
-// into Copy() to report progress on the download.
-func Download(url string, filename string) error {
+// into Copy() to report progress on the download. The proxy and CA bundle of the given
+// configuration, that can be nil, are honoured.
+func Download(cfg *config.Config, url string, filename string) error {
+	transport, err := cfg.NetworkTransport()
+	if err != nil {
+		return err
+	}
+
 	// Create the file, but give it a tmp file extension, this means we won't overwrite a
 	// file until it's downloaded, but we'll remove the tmp extension once downloaded.
 	out, err := os.Create(filename + ".tmp")
@@ -22,9 +30,10 @@ func Download(url string, filename string) error {
 		return err
 	}
 
-	// Get the data
+	// Get the data, honouring the proxy and CA bundle of the configuration:
+	client := &http.Client{Transport: transport}
 	// nolint:gosec
-	resp, err := http.Get(url)
+	resp, err := client.Get(url)
 	if err != nil {
 		out.Close()
 		return err
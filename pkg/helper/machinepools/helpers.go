@@ -16,6 +16,7 @@ import (
 
 	"github.com/openshift/rosa/pkg/aws"
 	"github.com/openshift/rosa/pkg/interactive"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -93,13 +94,13 @@ func GetTaints(cmd *cobra.Command, r *rosa.Runtime, existingTaints []*cmv1.Taint
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid comma-separated list of attributes: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 	taintBuilders, err := ParseTaints(inputTaints)
 	if err != nil {
 		r.Reporter.Errorf("%s", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	return taintBuilders
 }
@@ -209,7 +210,7 @@ func GetAwsTags(cmd *cobra.Command, r *rosa.Runtime, inputTags []string) map[str
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid set of tags: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		if len(tagsInput) > 0 {
 			tags = strings.Split(tagsInput, ",")
@@ -218,7 +219,7 @@ func GetAwsTags(cmd *cobra.Command, r *rosa.Runtime, inputTags []string) map[str
 	if len(tags) > 0 {
 		if err := aws.UserTagValidator(tags); err != nil {
 			r.Reporter.Errorf("%s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 		delim := aws.GetTagsDelimiter(tags)
 		for _, tag := range tags {
@@ -251,13 +252,13 @@ func GetLabelMap(cmd *cobra.Command, r *rosa.Runtime, existingLabels map[string]
 		})
 		if err != nil {
 			r.Reporter.Errorf("Expected a valid comma-separated list of attributes: %s", err)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 	labelMap, err := ParseLabels(inputLabels)
 	if err != nil {
 		r.Reporter.Errorf("%s", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
 	return labelMap
 }
@@ -0,0 +1,239 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the '--answers-file' and '--record-answers' flags. They answer the questions
+// of the interactive mode from a file, and save the answers given in a session to a file, so that
+// an interactive session can be replayed without a terminal.
+//
+// Questions are identified by a key: the name of the flag of the command whose usage is the help
+// of the question, or else the question itself in lowercase and with dashes. Questions that are
+// asked more than once get the number of the time they are asked appended, like 'subnet-ids-2'.
+
+package interactive
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2/core"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"sigs.k8s.io/yaml"
+)
+
+var (
+	answersFile string
+	recordFile  string
+
+	// command is the command that is running, answers are the ones loaded from the answers file,
+	// recorded are the ones saved to the record file, and asked counts how many times each key
+	// was asked:
+	command  *cobra.Command
+	answers  map[string]interface{}
+	recorded map[string]interface{}
+	asked    map[string]int
+)
+
+// Characters replaced by dashes in the keys derived from questions:
+var nonAlphanumeric = regexp.MustCompile("[^a-z0-9]+")
+
+// AddAnswersFlags adds the '--answers-file' and '--record-answers' flags to the given set of command
+// line flags.
+func AddAnswersFlags(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&answersFile,
+		"answers-file",
+		"",
+		"YAML file with the answers to the questions of the interactive mode, like the ones saved "+
+			"with '--record-answers'. It enables the interactive mode, and the questions without an "+
+			"answer are asked as usual.",
+	)
+	flags.StringVar(
+		&recordFile,
+		"record-answers",
+		"",
+		"File where the answers given to the questions of the interactive mode are saved, so that "+
+			"the session can be replayed with '--answers-file'. Passwords aren't saved.",
+	)
+}
+
+// StartAnswers loads the answers file and creates the record file, when requested, for the given
+// command.
+func StartAnswers(cmd *cobra.Command) error {
+	command = cmd
+	answers = nil
+	recorded = nil
+	asked = map[string]int{}
+	if answersFile != "" {
+		data, err := os.ReadFile(answersFile)
+		if err != nil {
+			return fmt.Errorf("Failed to read answers file: %v", err)
+		}
+		err = yaml.Unmarshal(data, &answers)
+		if err != nil {
+			return fmt.Errorf("Failed to parse answers file '%s': %v", answersFile, err)
+		}
+		Enable()
+	}
+	if recordFile != "" {
+		recorded = map[string]interface{}{}
+		return saveAnswers()
+	}
+	return nil
+}
+
+// questionKey returns the key of the given question, counting that it has been asked.
+func questionKey(input Input) string {
+	key := ""
+	if command != nil && input.Help != "" {
+		lookup := func(flag *pflag.Flag) {
+			if key == "" && flag.Usage == input.Help {
+				key = flag.Name
+			}
+		}
+		command.Flags().VisitAll(lookup)
+		command.InheritedFlags().VisitAll(lookup)
+	}
+	if key == "" {
+		key = strings.Trim(nonAlphanumeric.ReplaceAllString(strings.ToLower(input.Question), "-"), "-")
+	}
+	if asked == nil {
+		asked = map[string]int{}
+	}
+	asked[key]++
+	if asked[key] > 1 {
+		key = fmt.Sprintf("%s-%d", key, asked[key])
+	}
+	return key
+}
+
+// answer returns the answer to the question with the given key loaded from the answers file.
+func answer(key string) (interface{}, bool) {
+	value, ok := answers[key]
+	return value, ok
+}
+
+// checkAnswer checks the answer taken from the answers file with the validators of the question,
+// passing them the same value that the prompt would.
+func checkAnswer(key string, prompted interface{}, validators []Validator) error {
+	err := compose(validators)(prompted)
+	if err != nil {
+		return fmt.Errorf("Invalid answer for '%s' in answers file: %v", key, err)
+	}
+	return nil
+}
+
+// replay checks the answer taken from the answers file, and records it.
+func replay(key string, value interface{}, prompted interface{}, validators []Validator) error {
+	err := checkAnswer(key, prompted, validators)
+	if err != nil {
+		return err
+	}
+	record(key, value)
+	return nil
+}
+
+// record saves the answer to the question with the given key to the record file.
+func record(key string, value interface{}) {
+	if recorded == nil {
+		return
+	}
+	recorded[key] = value
+	// The file was already checked when the command started, and failing to write it shouldn't
+	// affect the command:
+	_ = saveAnswers()
+}
+
+// saveAnswers replaces the record file with the answers recorded so far. The file is rewritten
+// after each answer because commands often exit the process directly.
+func saveAnswers() error {
+	data, err := yaml.Marshal(recorded)
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(filepath.Dir(recordFile), 0700)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(recordFile, data, 0600)
+}
+
+// answerText converts an answer to the text that would have been typed.
+func answerText(value interface{}) string {
+	switch typed := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return typed
+	case float64:
+		return strconv.FormatFloat(typed, 'f', -1, 64)
+	}
+	return fmt.Sprint(value)
+}
+
+// answerOption converts an answer to the option of the list that would have been selected.
+func answerOption(key string, value interface{}, options []string) (core.OptionAnswer, error) {
+	text := answerText(value)
+	for i, option := range options {
+		if option == text {
+			return core.OptionAnswer{Value: option, Index: i}, nil
+		}
+	}
+	return core.OptionAnswer{}, fmt.Errorf("Invalid answer for '%s' in answers file: '%s' isn't one of "+
+		"the options: %s", key, text, strings.Join(options, ", "))
+}
+
+// answerOptions converts an answer, which is a list or a comma separated string, to the options of
+// the list that would have been selected.
+func answerOptions(key string, value interface{}, options []string) ([]core.OptionAnswer, error) {
+	var values []interface{}
+	switch typed := value.(type) {
+	case []interface{}:
+		values = typed
+	default:
+		text := answerText(value)
+		if text != "" {
+			for _, item := range strings.Split(text, ",") {
+				values = append(values, strings.TrimSpace(item))
+			}
+		}
+	}
+	result := []core.OptionAnswer{}
+	for _, item := range values {
+		option, err := answerOption(key, item, options)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, option)
+	}
+	return result, nil
+}
+
+// answerBool converts an answer to the boolean that would have been confirmed.
+func answerBool(key string, value interface{}) (bool, error) {
+	if typed, ok := value.(bool); ok {
+		return typed, nil
+	}
+	result, err := strconv.ParseBool(answerText(value))
+	if err != nil {
+		return false, fmt.Errorf("Invalid answer for '%s' in answers file: expected 'true' or 'false'", key)
+	}
+	return result, nil
+}
@@ -0,0 +1,116 @@
+package interactive
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+)
+
+var _ = Describe("Answers", func() {
+	var (
+		cmd *cobra.Command
+		dir string
+	)
+
+	BeforeEach(func() {
+		cmd = &cobra.Command{Use: "cluster"}
+		cmd.Flags().String("cluster-name", "", "Name of the cluster.")
+		cmd.Flags().StringSlice("availability-zones", nil, "Availability zones of the cluster.")
+		dir = GinkgoT().TempDir()
+	})
+
+	AfterEach(func() {
+		answersFile = ""
+		recordFile = ""
+		Expect(StartAnswers(nil)).To(Succeed())
+		SetEnabled(false)
+	})
+
+	writeAnswers := func(answers map[string]interface{}) {
+		data, err := yaml.Marshal(answers)
+		Expect(err).NotTo(HaveOccurred())
+		answersFile = filepath.Join(dir, "answers.yaml")
+		Expect(os.WriteFile(answersFile, data, 0600)).To(Succeed())
+	}
+
+	It("Derives the keys from the flags of the command or from the questions", func() {
+		Expect(StartAnswers(cmd)).To(Succeed())
+		Expect(questionKey(Input{Question: "Cluster name", Help: "Name of the cluster."})).To(
+			Equal("cluster-name"))
+		Expect(questionKey(Input{Question: "Create another identity provider?"})).To(
+			Equal("create-another-identity-provider"))
+		Expect(questionKey(Input{Question: "Create another identity provider?"})).To(
+			Equal("create-another-identity-provider-2"))
+	})
+
+	It("Answers the questions from the answers file", func() {
+		writeAnswers(map[string]interface{}{
+			"cluster-name":       "mycluster",
+			"availability-zones": []string{"us-east-1a", "us-east-1b"},
+			"compute-nodes":      3,
+			"multi-az":           true,
+			"channel-group":      "stable",
+		})
+		Expect(StartAnswers(cmd)).To(Succeed())
+		Expect(Enabled()).To(BeTrue())
+
+		name, err := GetString(Input{Question: "Cluster name", Help: "Name of the cluster.", Required: true})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(name).To(Equal("mycluster"))
+		zones, err := GetMultipleOptions(Input{
+			Question: "Availability zones",
+			Help:     "Availability zones of the cluster.",
+			Options:  []string{"us-east-1a", "us-east-1b", "us-east-1c"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(zones).To(Equal([]string{"us-east-1a", "us-east-1b"}))
+		nodes, err := GetInt(Input{Question: "Compute nodes"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nodes).To(Equal(3))
+		multiAZ, err := GetBool(Input{Question: "Multi AZ"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(multiAZ).To(BeTrue())
+		group, err := GetOption(Input{Question: "Channel group", Options: []string{"stable", "candidate"}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(group).To(Equal("stable"))
+	})
+
+	It("Rejects answers that the prompt wouldn't accept", func() {
+		writeAnswers(map[string]interface{}{
+			"cluster-name":  "",
+			"channel-group": "nightly",
+		})
+		Expect(StartAnswers(cmd)).To(Succeed())
+
+		_, err := GetString(Input{Question: "Cluster name", Help: "Name of the cluster.", Required: true})
+		Expect(err).To(MatchError(ContainSubstring("Invalid answer for 'cluster-name' in answers file")))
+		_, err = GetOption(Input{Question: "Channel group", Options: []string{"stable", "candidate"}})
+		Expect(err).To(MatchError("Invalid answer for 'channel-group' in answers file: 'nightly' isn't " +
+			"one of the options: Skip, stable, candidate"))
+	})
+
+	It("Records the answers so that they can be replayed", func() {
+		writeAnswers(map[string]interface{}{
+			"cluster-name":  "mycluster",
+			"compute-nodes": "5",
+			"password":      "secret",
+		})
+		recordFile = filepath.Join(dir, "recorded.yaml")
+		Expect(StartAnswers(cmd)).To(Succeed())
+
+		_, err := GetString(Input{Question: "Cluster name", Help: "Name of the cluster."})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = GetInt(Input{Question: "Compute nodes"})
+		Expect(err).NotTo(HaveOccurred())
+		_, err = GetPassword(Input{Question: "Password"})
+		Expect(err).NotTo(HaveOccurred())
+
+		data, err := os.ReadFile(recordFile)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(Equal("cluster-name: mycluster\ncompute-nodes: 5\n"))
+	})
+})
@@ -62,8 +62,18 @@ func GetString(input Input) (a string, err error) {
 	if input.Required {
 		input.Validators = append([]Validator{required}, input.Validators...)
 	}
+	key := questionKey(input)
+	if value, ok := answer(key); ok {
+		a = answerText(value)
+		err = replay(key, a, a, input.Validators)
+		a = transformer(a).(string)
+		return
+	}
 	err = survey.AskOne(prompt, &a, survey.WithValidator(compose(input.Validators)))
 	a = transformer(a).(string)
+	if err == nil {
+		record(key, a)
+	}
 	return
 }
 
@@ -91,14 +101,28 @@ func GetInt(input Input) (a int, err error) {
 	if input.Required {
 		input.Validators = append([]Validator{required}, input.Validators...)
 	}
-	err = survey.AskOne(prompt, &str, survey.WithValidator(compose(input.Validators)))
-	if err != nil {
-		return
+	key := questionKey(input)
+	if value, ok := answer(key); ok {
+		str = answerText(value)
+		err = checkAnswer(key, str, input.Validators)
+		if err != nil {
+			return
+		}
+	} else {
+		err = survey.AskOne(prompt, &str, survey.WithValidator(compose(input.Validators)))
+		if err != nil {
+			return
+		}
 	}
 	if str == "" {
+		record(key, str)
 		return
 	}
-	return parseInt(str)
+	a, err = parseInt(str)
+	if err == nil {
+		record(key, a)
+	}
+	return
 }
 
 func parseInt(str string) (num int, err error) {
@@ -131,14 +155,28 @@ func GetFloat(input Input) (a float64, err error) {
 	if input.Required {
 		input.Validators = append([]Validator{required}, input.Validators...)
 	}
-	err = survey.AskOne(prompt, &str, survey.WithValidator(compose(input.Validators)))
-	if err != nil {
-		return
+	key := questionKey(input)
+	if value, ok := answer(key); ok {
+		str = answerText(value)
+		err = checkAnswer(key, str, input.Validators)
+		if err != nil {
+			return
+		}
+	} else {
+		err = survey.AskOne(prompt, &str, survey.WithValidator(compose(input.Validators)))
+		if err != nil {
+			return
+		}
 	}
 	if str == "" {
+		record(key, str)
 		return
 	}
-	return parseFloat(str)
+	a, err = parseFloat(str)
+	if err == nil {
+		record(key, a)
+	}
+	return
 }
 
 func parseFloat(str string) (num float64, err error) {
@@ -167,7 +205,22 @@ func GetMultipleOptions(input Input) ([]string, error) {
 	if input.Required {
 		input.Validators = append([]Validator{required}, input.Validators...)
 	}
+	key := questionKey(input)
+	if value, ok := answer(key); ok {
+		var selected []core.OptionAnswer
+		selected, err = answerOptions(key, value, input.Options)
+		if err != nil {
+			return res, err
+		}
+		for _, option := range selected {
+			res = append(res, option.Value)
+		}
+		return res, replay(key, res, selected, input.Validators)
+	}
 	err = survey.AskOne(prompt, &res, survey.WithValidator(compose(input.Validators)))
+	if err == nil {
+		record(key, res)
+	}
 	return res, err
 }
 
@@ -218,9 +271,29 @@ func GetOption(input Input) (a string, err error) {
 	if input.Required {
 		input.Validators = append([]Validator{required}, input.Validators...)
 	}
+	key := questionKey(input)
+	if value, ok := answer(key); ok {
+		if !input.Required && answerText(value) == "" {
+			value = consts.SkipSelectionOption
+		}
+		var selected core.OptionAnswer
+		selected, err = answerOption(key, value, input.Options)
+		if err != nil {
+			return
+		}
+		a = selected.Value
+		if a == consts.SkipSelectionOption {
+			a = ""
+		}
+		err = replay(key, a, selected, input.Validators)
+		return
+	}
 	err = survey.AskOne(prompt, &a, survey.WithValidator(compose(input.Validators)))
 	if a == consts.SkipSelectionOption {
-		return "", nil
+		a = ""
+	}
+	if err == nil {
+		record(key, a)
 	}
 	return
 }
@@ -250,7 +323,19 @@ func GetBool(input Input) (a bool, err error) {
 	if input.Required {
 		input.Validators = append([]Validator{required}, input.Validators...)
 	}
+	key := questionKey(input)
+	if value, ok := answer(key); ok {
+		a, err = answerBool(key, value)
+		if err != nil {
+			return
+		}
+		err = replay(key, a, a, input.Validators)
+		return
+	}
 	err = survey.AskOne(prompt, &a, survey.WithValidator(compose(input.Validators)))
+	if err == nil {
+		record(key, a)
+	}
 	return
 }
 
@@ -278,7 +363,17 @@ func GetIPNet(input Input) (a net.IPNet, err error) {
 	if input.Required {
 		input.Validators = append([]Validator{required}, input.Validators...)
 	}
-	err = survey.AskOne(prompt, &str, survey.WithValidator(compose(input.Validators)), survey.WithValidator(IsCIDR))
+	key := questionKey(input)
+	if value, ok := answer(key); ok {
+		str = answerText(value)
+		err = replay(key, str, str, append(input.Validators, IsCIDR))
+	} else {
+		err = survey.AskOne(prompt, &str, survey.WithValidator(compose(input.Validators)),
+			survey.WithValidator(IsCIDR))
+		if err == nil {
+			record(key, str)
+		}
+	}
 	if err != nil {
 		return
 	}
@@ -309,6 +404,13 @@ func GetPassword(input Input) (a string, err error) {
 	if input.Required {
 		input.Validators = append([]Validator{required}, input.Validators...)
 	}
+	// Passwords can be answered from the answers file, but they are never recorded:
+	key := questionKey(input)
+	if value, ok := answer(key); ok {
+		a = answerText(value)
+		err = checkAnswer(key, a, input.Validators)
+		return
+	}
 	err = survey.AskOne(prompt, &a, survey.WithValidator(compose(input.Validators)))
 	return
 }
@@ -332,7 +434,16 @@ func GetCert(input Input) (a string, err error) {
 	if input.Required {
 		input.Validators = append([]Validator{required}, input.Validators...)
 	}
+	key := questionKey(input)
+	if value, ok := answer(key); ok {
+		a = answerText(value)
+		err = replay(key, a, a, append(input.Validators, IsCert))
+		return
+	}
 	err = survey.AskOne(prompt, &a, survey.WithValidator(compose(input.Validators)), survey.WithValidator(IsCert))
+	if err == nil {
+		record(key, a)
+	}
 	return
 }
 
@@ -0,0 +1,215 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the root context of the command line tool. It is cancelled when the process
+// receives SIGINT or SIGTERM, or when the duration given with the '--timeout' option expires, so
+// that long running operations and polling loops stop instead of ignoring the user.
+
+package interrupt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/spf13/pflag"
+
+	"github.com/openshift/rosa/pkg/reporter"
+)
+
+// ExitGracePeriod is the time that the process is given to stop by itself once the root context has
+// been cancelled. After that, or when another signal is received, the process exits immediately.
+const ExitGracePeriod = 5 * time.Second
+
+// ErrInterrupted is the cause of the cancellation of the root context when a signal is received.
+var ErrInterrupted = errors.New("interrupted")
+
+var timeout time.Duration
+
+// AddTimeoutFlag adds the '--timeout' flag to the given set of command line flags.
+func AddTimeoutFlag(flags *pflag.FlagSet) {
+	flags.DurationVar(
+		&timeout,
+		"timeout",
+		0,
+		"Maximum time that the command is allowed to run, for example '30m'. "+
+			"Zero means no limit.",
+	)
+}
+
+// Timeout returns the duration given with the '--timeout' flag.
+func Timeout() time.Duration {
+	return timeout
+}
+
+// SetTimeout sets the maximum duration of the command. It has no effect once the root context has
+// been created.
+func SetTimeout(value time.Duration) {
+	timeout = value
+}
+
+var (
+	rootOnce   sync.Once
+	rootCtx    context.Context
+	rootCancel context.CancelCauseFunc
+	reportOnce sync.Once
+)
+
+// RootContext returns the root context of the command. The context is created the first time this is
+// called, and from then on it is cancelled by SIGINT, SIGTERM or the expiration of the timeout.
+func RootContext() context.Context {
+	rootOnce.Do(func() {
+		rootCtx, rootCancel = newRoot(timeout)
+		context.AfterFunc(rootCtx, func() {
+			reportOnce.Do(func() {
+				report(reporter.CreateReporter(), context.Cause(rootCtx))
+			})
+		})
+		go watchSignals()
+	})
+	return rootCtx
+}
+
+func newRoot(timeout time.Duration) (context.Context, context.CancelCauseFunc) {
+	ctx, cancel := context.WithCancelCause(context.Background())
+	if timeout > 0 {
+		var cancelTimeout context.CancelFunc
+		ctx, cancelTimeout = context.WithTimeoutCause(ctx, timeout,
+			fmt.Errorf("timed out after %s", timeout))
+		return ctx, func(cause error) {
+			cancel(cause)
+			cancelTimeout()
+		}
+	}
+	return ctx, cancel
+}
+
+// watchSignals cancels the root context when a signal is received, and then gives the process the
+// grace period to stop by itself before exiting.
+func watchSignals() {
+	signals := make(chan os.Signal, 2)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	code := 124
+	select {
+	case received := <-signals:
+		code = exitCode(received)
+		rootCancel(ErrInterrupted)
+	case <-rootCtx.Done():
+	}
+	select {
+	case received := <-signals:
+		code = exitCode(received)
+	case <-time.After(ExitGracePeriod):
+	}
+	os.Exit(code)
+}
+
+func exitCode(received os.Signal) int {
+	if received == syscall.SIGTERM {
+		return 143
+	}
+	return 130
+}
+
+// Cause returns the reason why the root context was cancelled, or nil if it is still active.
+func Cause() error {
+	if rootCtx == nil {
+		return nil
+	}
+	return context.Cause(rootCtx)
+}
+
+// Bind returns a copy of the given context that is also cancelled when the root context is. The
+// returned function must be called to release the resources once the operation is done.
+func Bind(ctx context.Context) (context.Context, context.CancelFunc) {
+	return bind(ctx, RootContext())
+}
+
+// BindTo returns a copy of the given context that is also cancelled when the parent is. It is used
+// when the root context has been replaced, for example when the tool is used as a library.
+func BindTo(ctx context.Context, parent context.Context) (context.Context, context.CancelFunc) {
+	return bind(ctx, parent)
+}
+
+func bind(ctx context.Context, root context.Context) (context.Context, context.CancelFunc) {
+	result, cancel := context.WithCancelCause(ctx)
+	stop := context.AfterFunc(root, func() {
+		cancel(context.Cause(root))
+	})
+	return result, func() {
+		stop()
+		cancel(context.Canceled)
+	}
+}
+
+var (
+	pendingLock sync.Mutex
+	pendingNext int
+	pending     = map[int]string{}
+)
+
+// Track records a remote resource that is being created, so that the user is told about it if the
+// command is interrupted before it is complete. The returned function must be called once the
+// resource has been completely created.
+func Track(format string, args ...interface{}) (done func()) {
+	pendingLock.Lock()
+	defer pendingLock.Unlock()
+	id := pendingNext
+	pendingNext++
+	pending[id] = fmt.Sprintf(format, args...)
+	return func() {
+		pendingLock.Lock()
+		defer pendingLock.Unlock()
+		delete(pending, id)
+	}
+}
+
+// PendingResources returns the descriptions of the resources that are still being created, in the order
+// they were started.
+func PendingResources() []string {
+	pendingLock.Lock()
+	defer pendingLock.Unlock()
+	result := make([]string, 0, len(pending))
+	for id := 0; id < pendingNext; id++ {
+		if description, ok := pending[id]; ok {
+			result = append(result, description)
+		}
+	}
+	return result
+}
+
+func report(r *reporter.Object, cause error) {
+	resources := PendingResources()
+	if len(resources) == 0 {
+		r.Warnf("Operation %s", describe(cause))
+		return
+	}
+	r.Warnf("Operation %s. The following resources may have been left half created:\n  - %s",
+		describe(cause), strings.Join(resources, "\n  - "))
+}
+
+func describe(cause error) string {
+	if cause == nil || errors.Is(cause, ErrInterrupted) {
+		return "interrupted"
+	}
+	return cause.Error()
+}
@@ -0,0 +1,116 @@
+package interrupt
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Interrupt", func() {
+	Context("Root context", func() {
+		It("Expires after the timeout", func() {
+			ctx, cancel := newRoot(10 * time.Millisecond)
+			defer cancel(nil)
+			Eventually(ctx.Done()).Should(BeClosed())
+			Expect(context.Cause(ctx)).To(MatchError("timed out after 10ms"))
+		})
+
+		It("Has no deadline without a timeout", func() {
+			ctx, cancel := newRoot(0)
+			defer cancel(nil)
+			_, ok := ctx.Deadline()
+			Expect(ok).To(BeFalse())
+			cancel(ErrInterrupted)
+			Expect(context.Cause(ctx)).To(MatchError(ErrInterrupted))
+		})
+	})
+
+	Context("Bind", func() {
+		It("Cancels the bound context with the root", func() {
+			root, cancelRoot := context.WithCancelCause(context.Background())
+			ctx, cancel := bind(context.Background(), root)
+			defer cancel()
+			Expect(ctx.Err()).To(BeNil())
+			cancelRoot(ErrInterrupted)
+			Eventually(ctx.Done()).Should(BeClosed())
+			Expect(context.Cause(ctx)).To(MatchError(ErrInterrupted))
+		})
+
+		It("Doesn't cancel the root with the bound context", func() {
+			root, cancelRoot := context.WithCancel(context.Background())
+			defer cancelRoot()
+			_, cancel := bind(context.Background(), root)
+			cancel()
+			Expect(root.Err()).To(BeNil())
+		})
+	})
+
+	Context("Track", func() {
+		It("Lists the pending resources in order", func() {
+			first := Track("stack '%s'", "a")
+			second := Track("cluster '%s'", "b")
+			Expect(PendingResources()).To(Equal([]string{"stack 'a'", "cluster 'b'"}))
+			first()
+			Expect(PendingResources()).To(Equal([]string{"cluster 'b'"}))
+			second()
+			Expect(PendingResources()).To(BeEmpty())
+		})
+
+		It("Describes the cause", func() {
+			Expect(describe(ErrInterrupted)).To(Equal("interrupted"))
+			Expect(describe(errors.New("timed out after 1s"))).To(Equal("timed out after 1s"))
+		})
+	})
+
+	Context("Transport", func() {
+		var server *httptest.Server
+
+		BeforeEach(func() {
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/slow" {
+					<-r.Context().Done()
+					return
+				}
+				w.Write([]byte("ok"))
+			}))
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("Keeps the body readable until it is closed", func() {
+			root, cancelRoot := context.WithCancel(context.Background())
+			defer cancelRoot()
+			client := &http.Client{Transport: &transport{
+				wrapped: http.DefaultTransport,
+				root:    func() context.Context { return root },
+			}}
+			response, err := client.Get(server.URL)
+			Expect(err).NotTo(HaveOccurred())
+			defer response.Body.Close()
+			body, err := io.ReadAll(response.Body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(body)).To(Equal("ok"))
+		})
+
+		It("Cancels requests in flight when the root is cancelled", func() {
+			root, cancelRoot := context.WithCancelCause(context.Background())
+			client := &http.Client{Transport: &transport{
+				wrapped: http.DefaultTransport,
+				root:    func() context.Context { return root },
+			}}
+			time.AfterFunc(50*time.Millisecond, func() {
+				cancelRoot(ErrInterrupted)
+			})
+			_, err := client.Get(server.URL + "/slow")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
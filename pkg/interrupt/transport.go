@@ -0,0 +1,77 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the HTTP transport wrapper used to cancel the requests sent by the OCM and AWS
+// clients when the root context is cancelled, even if the caller didn't pass a context.
+
+package interrupt
+
+import (
+	"context"
+	"io"
+	"net/http"
+)
+
+// TransportWrapper returns a transport that binds every request to the root context.
+func TransportWrapper(wrapped http.RoundTripper) http.RoundTripper {
+	return &transport{
+		wrapped: wrapped,
+		root:    RootContext,
+	}
+}
+
+// NewTransportWrapper returns a function that wraps transports so that every request is bound to the
+// given context instead of the root context.
+func NewTransportWrapper(ctx context.Context) func(http.RoundTripper) http.RoundTripper {
+	return func(wrapped http.RoundTripper) http.RoundTripper {
+		return &transport{
+			wrapped: wrapped,
+			root: func() context.Context {
+				return ctx
+			},
+		}
+	}
+}
+
+type transport struct {
+	wrapped http.RoundTripper
+	root    func() context.Context
+}
+
+func (t *transport) RoundTrip(request *http.Request) (*http.Response, error) {
+	ctx, cancel := bind(request.Context(), t.root())
+	response, err := t.wrapped.RoundTrip(request.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	// The context must stay alive until the body has been read:
+	response.Body = &body{
+		ReadCloser: response.Body,
+		cancel:     cancel,
+	}
+	return response, nil
+}
+
+type body struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *body) Close() error {
+	defer b.cancel()
+	return b.ReadCloser.Close()
+}
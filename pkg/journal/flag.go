@@ -0,0 +1,60 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the '--journal-file' flag.
+
+package journal
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/pflag"
+)
+
+// Name of the journal file inside the 'rosa' configuration directory:
+const defaultName = "journal.jsonl"
+
+var file string
+
+// AddFlag adds the '--journal-file' flag to the given set of command line flags.
+func AddFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&file,
+		"journal-file",
+		"",
+		"File where the mutating commands are journaled, and that 'rosa history' reads. "+
+			"Defaults to 'rosa/journal.jsonl' inside the user configuration directory.",
+	)
+}
+
+// SetFile sets the file where the mutating commands are journaled.
+func SetFile(value string) {
+	file = value
+}
+
+// File returns the name of the journal file, either the one given with the '--journal-file' flag or
+// the default one inside the user configuration directory.
+func File() (string, error) {
+	if file != "" {
+		return file, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "rosa", defaultName), nil
+}
@@ -0,0 +1,385 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the journal of the commands that change resources. The journal is a file
+// where each line is a JSON record: one for the command itself, written when it starts, and one for
+// each OCM or AWS operation that it performs. Records are appended as they happen because most
+// commands finish calling os.Exit, so there is no later point where a complete entry could be
+// written.
+
+package journal
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/openshift/rosa/pkg/logging"
+)
+
+// Providers of the journaled operations:
+const (
+	OCM = "ocm"
+	AWS = "aws"
+)
+
+// String that replaces the values of the sensitive flags:
+const redactedValue = "***"
+
+// Entry describes a journaled command and the operations that it performed.
+type Entry struct {
+	Session    string            `json:"session"`
+	Time       time.Time         `json:"time"`
+	Command    string            `json:"command"`
+	Args       []string          `json:"args,omitempty"`
+	Flags      map[string]string `json:"flags,omitempty"`
+	User       string            `json:"user,omitempty"`
+	Host       string            `json:"host,omitempty"`
+	Operations []*Operation      `json:"operations,omitempty"`
+}
+
+// Operation describes a change made by a command. For OCM the action is the HTTP method and the
+// resource the path of the object relative to '/api/'. For AWS the action is the name of the API
+// operation and the resource the name of the service.
+type Operation struct {
+	Time     time.Time `json:"time"`
+	Provider string    `json:"provider"`
+	Action   string    `json:"action"`
+	Resource string    `json:"resource,omitempty"`
+	ID       string    `json:"id,omitempty"`
+	ARNs     []string  `json:"arns,omitempty"`
+}
+
+// record is a line of the journal file, containing either a command or an operation.
+type record struct {
+	Session   string     `json:"session"`
+	Command   *Entry     `json:"command,omitempty"`
+	Operation *Operation `json:"operation,omitempty"`
+}
+
+// mutatingVerbs are the first words of the commands that change resources.
+var mutatingVerbs = map[string]bool{
+	"apply":     true,
+	"attach":    true,
+	"create":    true,
+	"delete":    true,
+	"detach":    true,
+	"edit":      true,
+	"grant":     true,
+	"hibernate": true,
+	"init":      true,
+	"install":   true,
+	"link":      true,
+	"register":  true,
+	"resume":    true,
+	"revoke":    true,
+	"uninstall": true,
+	"unlink":    true,
+	"upgrade":   true,
+}
+
+// Flags whose values are redacted, either because their names contain one of these words or
+// because they are one of the flags that embed credentials in other values, like the users of the
+// HTPasswd identity providers. The credentials of the URLs given in the rest of the flags and in the
+// arguments, like the proxy URLs, are redacted as well:
+var (
+	sensitiveWords = []string{"password", "secret", "token", "private-key"}
+	sensitiveFlags = map[string]bool{"users": true}
+)
+
+var (
+	lock    sync.Mutex
+	current *Entry
+	target  string
+)
+
+// Mutating checks if the given command changes resources and should therefore be journaled.
+func Mutating(cmd *cobra.Command) bool {
+	words := strings.Fields(cmd.CommandPath())
+	return len(words) > 1 && mutatingVerbs[words[1]] && cmd.Runnable()
+}
+
+// Start journals the given command if it changes resources, so that the operations recorded
+// afterwards are attributed to it. Other commands are ignored.
+func Start(cmd *cobra.Command, args []string) error {
+	if !Mutating(cmd) {
+		return nil
+	}
+	name, err := File()
+	if err != nil {
+		return err
+	}
+	session, err := newSession()
+	if err != nil {
+		return err
+	}
+	entry := &Entry{
+		Session: session,
+		Time:    time.Now().UTC(),
+		Command: cmd.CommandPath(),
+		Args:    argsOf(args),
+		Flags:   flagsOf(cmd.Flags()),
+	}
+	if account, err := user.Current(); err == nil {
+		entry.User = account.Username
+	}
+	if host, err := os.Hostname(); err == nil {
+		entry.Host = host
+	}
+	err = write(name, &record{Session: session, Command: entry})
+	if err != nil {
+		return fmt.Errorf("Failed to write journal file '%s': %v", name, err)
+	}
+	lock.Lock()
+	defer lock.Unlock()
+	current = entry
+	target = name
+	return nil
+}
+
+// Stop stops journaling the operations of the current command.
+func Stop() {
+	lock.Lock()
+	defer lock.Unlock()
+	current = nil
+	target = ""
+}
+
+// Active checks if a command is being journaled.
+func Active() bool {
+	lock.Lock()
+	defer lock.Unlock()
+	return current != nil
+}
+
+// Record journals an operation of the current command. It does nothing if no command is being
+// journaled. Failures are ignored because the file was already writable when the command started,
+// and the operation has already happened.
+func Record(operation *Operation) {
+	lock.Lock()
+	defer lock.Unlock()
+	if current == nil {
+		return
+	}
+	if operation.Time.IsZero() {
+		operation.Time = time.Now().UTC()
+	}
+	_ = write(target, &record{Session: current.Session, Operation: operation})
+}
+
+// Read returns the entries of the given journal file sorted by time. A missing file is an empty
+// journal. Lines that can't be parsed, like the last one of a file truncated by a crash, are
+// skipped.
+func Read(name string) ([]*Entry, error) {
+	file, err := os.Open(name)
+	if errors.Is(err, os.ErrNotExist) {
+		return []*Entry{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	entries := []*Entry{}
+	sessions := map[string]*Entry{}
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := &record{}
+		if json.Unmarshal(scanner.Bytes(), line) != nil {
+			continue
+		}
+		switch {
+		case line.Command != nil:
+			line.Command.Session = line.Session
+			line.Command.Operations = nil
+			sessions[line.Session] = line.Command
+			entries = append(entries, line.Command)
+		case line.Operation != nil:
+			entry, ok := sessions[line.Session]
+			if ok {
+				entry.Operations = append(entry.Operations, line.Operation)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Time.Before(entries[j].Time)
+	})
+	return entries, nil
+}
+
+// Filter selects journal entries. Empty fields match all the entries.
+type Filter struct {
+	// Since excludes the entries of commands started before this time.
+	Since time.Time
+
+	// Command is a text that the command, like 'rosa create operator-roles', must contain.
+	Command string
+
+	// Cluster is the name or identifier of a cluster given with the '--cluster' or
+	// '--cluster-name' flags, or the identifier of a cluster changed in OCM.
+	Cluster string
+
+	// Resource is a text that the identifier, path or ARN of one of the operations must contain.
+	Resource string
+}
+
+// Apply returns the entries that match the filter.
+func (f *Filter) Apply(entries []*Entry) []*Entry {
+	result := []*Entry{}
+	for _, entry := range entries {
+		if f.matches(entry) {
+			result = append(result, entry)
+		}
+	}
+	return result
+}
+
+func (f *Filter) matches(entry *Entry) bool {
+	if !f.Since.IsZero() && entry.Time.Before(f.Since) {
+		return false
+	}
+	if f.Command != "" && !strings.Contains(entry.Command, f.Command) {
+		return false
+	}
+	if f.Cluster != "" && !matchesCluster(entry, f.Cluster) {
+		return false
+	}
+	if f.Resource != "" && !matchesResource(entry, f.Resource) {
+		return false
+	}
+	return true
+}
+
+func matchesCluster(entry *Entry, cluster string) bool {
+	if entry.Flags["cluster"] == cluster || entry.Flags["cluster-name"] == cluster {
+		return true
+	}
+	for _, operation := range entry.Operations {
+		if operation.Provider != OCM {
+			continue
+		}
+		if strings.Contains(operation.Resource+"/", "/clusters/"+cluster+"/") {
+			return true
+		}
+		if strings.HasSuffix(operation.Resource, "/clusters") && operation.ID == cluster {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesResource(entry *Entry, resource string) bool {
+	for _, operation := range entry.Operations {
+		if strings.Contains(operation.ID, resource) || strings.Contains(operation.Resource, resource) {
+			return true
+		}
+		for _, arn := range operation.ARNs {
+			if strings.Contains(arn, resource) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// argsOf returns the arguments of the command line with the credentials of the URLs redacted.
+func argsOf(args []string) []string {
+	if len(args) == 0 {
+		return nil
+	}
+	result := make([]string, len(args))
+	for i, arg := range args {
+		result[i] = logging.RedactURLs(arg)
+	}
+	return result
+}
+
+// flagsOf returns the flags explicitly given in the command line, with the values of the sensitive
+// ones and the credentials of the URLs redacted.
+func flagsOf(flags *pflag.FlagSet) map[string]string {
+	result := map[string]string{}
+	flags.Visit(func(flag *pflag.Flag) {
+		value := logging.RedactURLs(flag.Value.String())
+		if sensitive(flag.Name) {
+			value = redactedValue
+		}
+		result[flag.Name] = value
+	})
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
+
+func sensitive(name string) bool {
+	if sensitiveFlags[name] {
+		return true
+	}
+	for _, word := range sensitiveWords {
+		if strings.Contains(name, word) {
+			return true
+		}
+	}
+	return false
+}
+
+func newSession() (string, error) {
+	data := make([]byte, 8)
+	_, err := rand.Read(data)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(data), nil
+}
+
+// write appends a record to the journal file. The record is written with a single call so that
+// commands running concurrently don't interleave their lines.
+func write(name string, line *record) error {
+	data, err := json.Marshal(line)
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(filepath.Dir(name), 0700)
+	if err != nil {
+		return err
+	}
+	file, err := os.OpenFile(name, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	_, err = file.Write(append(data, '\n'))
+	if err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
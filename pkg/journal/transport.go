@@ -0,0 +1,116 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the transport that journals the requests that change OCM resources.
+
+package journal
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+)
+
+// Prefix of the paths of the OCM API:
+const apiPrefix = "/api/"
+
+var mutatingMethods = map[string]bool{
+	http.MethodDelete: true,
+	http.MethodPatch:  true,
+	http.MethodPost:   true,
+	http.MethodPut:    true,
+}
+
+// Last segments of the paths that use the POST method to ask questions instead of creating
+// resources:
+var inquiryNames = map[string]bool{
+	"access_token":           true,
+	"cluster_authorizations": true,
+	"network_verifications":  true,
+	"oidc_thumbprint":        true,
+	"quota_cost":             true,
+	"version_inquiry":        true,
+}
+
+type transport struct {
+	wrapped http.RoundTripper
+}
+
+// TransportWrapper journals the successful OCM requests that change resources, while a command is
+// being journaled.
+func TransportWrapper(wrapped http.RoundTripper) http.RoundTripper {
+	return &transport{wrapped: wrapped}
+}
+
+func (t *transport) RoundTrip(request *http.Request) (*http.Response, error) {
+	response, err := t.wrapped.RoundTrip(request)
+	if err != nil || response.StatusCode >= http.StatusBadRequest || !Active() {
+		return response, err
+	}
+	resource, ok := mutatedResource(request)
+	if !ok {
+		return response, nil
+	}
+	operation := &Operation{
+		Provider: OCM,
+		Action:   request.Method,
+		Resource: resource,
+	}
+	if request.Method == http.MethodPost {
+		operation.ID = createdID(response)
+	} else {
+		operation.ID = path.Base(resource)
+	}
+	Record(operation)
+	return response, nil
+}
+
+// mutatedResource returns the path, relative to '/api/', of the resource changed by the request.
+func mutatedResource(request *http.Request) (string, bool) {
+	if !mutatingMethods[request.Method] || !strings.HasPrefix(request.URL.Path, apiPrefix) {
+		return "", false
+	}
+	resource := strings.Trim(strings.TrimPrefix(request.URL.Path, apiPrefix), "/")
+	name := path.Base(resource)
+	if inquiryNames[name] || strings.HasSuffix(path.Dir(resource), "_inquiries") {
+		return "", false
+	}
+	return resource, true
+}
+
+// createdID returns the identifier of the object in the body of the response, leaving the body
+// intact for the caller.
+func createdID(response *http.Response) string {
+	if response.Body == nil {
+		return ""
+	}
+	data, err := io.ReadAll(response.Body)
+	response.Body.Close()
+	response.Body = io.NopCloser(bytes.NewReader(data))
+	if err != nil {
+		return ""
+	}
+	object := struct {
+		ID string `json:"id"`
+	}{}
+	if json.Unmarshal(data, &object) != nil {
+		return ""
+	}
+	return object.ID
+}
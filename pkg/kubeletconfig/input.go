@@ -18,6 +18,10 @@ const (
 		" for your Machine Pool to be recreated. " +
 		"This may cause outages to your applications. Do you wish to continue?"
 	hcpAbortMessage = "Edit of Machine Pool aborted."
+
+	// NodePoolNodeRecreateReason is displayed next to the changes that cause the Nodes of a Machine
+	// Pool to be recreated.
+	NodePoolNodeRecreateReason = "the nodes of the machine pool will be recreated"
 )
 
 type KubeletOperation string
@@ -325,16 +325,7 @@ func (d *RoundTripper) dumpBytes(what string, data []byte) {
 
 // redactSensitive replaces sensitive fields within a response with redactionStr.
 func (d *RoundTripper) redactSensitive(body *ordered.OrderedMap) {
-	iterator := body.EntriesIter()
-	for {
-		pair, ok := iterator()
-		if !ok {
-			break
-		}
-		if d.redact[pair.Key] {
-			body.Set(pair.Key, redactedReplacement)
-		}
-	}
+	RedactSensitive(body, d.redact)
 }
 
 // String that replaces redactedReplacement fields in messages sent to the log:
@@ -31,6 +31,8 @@ import (
 	ocmOutput "github.com/openshift/rosa/pkg/ocm/output"
 	mpOpts "github.com/openshift/rosa/pkg/options/machinepool"
 	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/preview"
+	"github.com/openshift/rosa/pkg/reporter"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
@@ -279,7 +281,7 @@ func (m *machinePool) CreateMachinePool(r *rosa.Runtime, cmd *cobra.Command, clu
 		cluster.AWS().STS().ExternalID(),
 	)
 	if err != nil {
-		return fmt.Errorf(fmt.Sprintf("%s", err))
+		return fmt.Errorf("%s", err)
 	}
 
 	if spin != nil {
@@ -668,7 +670,7 @@ func (m *machinePool) CreateNodePools(r *rosa.Runtime, cmd *cobra.Command, clust
 	if subnet != "" {
 		availabilityZone, err := r.AWSClient.GetSubnetAvailabilityZone(subnet)
 		if err != nil {
-			return fmt.Errorf(fmt.Sprintf("%s", err))
+			return fmt.Errorf("%s", err)
 		}
 		availabilityZonesFilter = []string{availabilityZone}
 	}
@@ -676,7 +678,7 @@ func (m *machinePool) CreateNodePools(r *rosa.Runtime, cmd *cobra.Command, clust
 	instanceTypeList, err := r.OCMClient.GetAvailableMachineTypesInRegion(cluster.Region().ID(),
 		availabilityZonesFilter, cluster.AWS().STS().RoleARN(), r.AWSClient, cluster.AWS().STS().ExternalID())
 	if err != nil {
-		return fmt.Errorf(fmt.Sprintf("%s", err))
+		return fmt.Errorf("%s", err)
 	}
 
 	if spin != nil {
@@ -794,7 +796,7 @@ func (m *machinePool) CreateNodePools(r *rosa.Runtime, cmd *cobra.Command, clust
 
 		err = ValidateKubeletConfig(inputKubeletConfigs)
 		if err != nil {
-			return fmt.Errorf(err.Error())
+			return fmt.Errorf("%v", err)
 		}
 
 		if len(inputKubeletConfigs) != 0 {
@@ -897,7 +899,7 @@ func (m *machinePool) CreateNodePools(r *rosa.Runtime, cmd *cobra.Command, clust
 	if nodeDrainGracePeriod != "" {
 		nodeDrainBuilder, err := machinepools.CreateNodeDrainGracePeriodBuilder(nodeDrainGracePeriod)
 		if err != nil {
-			return fmt.Errorf(err.Error())
+			return fmt.Errorf("%v", err)
 		}
 		npBuilder.NodeDrainGracePeriod(nodeDrainBuilder)
 	}
@@ -1496,11 +1498,21 @@ func editMachinePool(cmd *cobra.Command, machinePoolId string,
 		}
 	}
 
-	machinePool, err = mpBuilder.Build()
+	update, err := mpBuilder.Build()
 	if err != nil {
 		return fmt.Errorf("Failed to create machine pool for cluster '%s': %v", clusterKey, err)
 	}
 
+	changes, err := preview.Compare(fmt.Sprintf("machine pool '%s'", machinePool.ID()),
+		machinePool, update, cmv1.MarshalMachinePool)
+	if err != nil {
+		return fmt.Errorf("Failed to compare machine pool '%s': %v", machinePool.ID(), err)
+	}
+	if !changes.Review(r) {
+		return nil
+	}
+	machinePool = update
+
 	r.Reporter.Debugf("Updating machine pool '%s' on cluster '%s'", machinePool.ID(), clusterKey)
 	_, err = r.OCMClient.UpdateMachinePool(cluster.ID(), machinePool)
 	if err != nil {
@@ -1686,8 +1698,8 @@ func editNodePool(cmd *cobra.Command, nodePoolID string,
 		}
 		err = ValidateKubeletConfig(inputKubeletConfig)
 		if err != nil {
-			r.Reporter.Errorf(err.Error())
-			os.Exit(1)
+			r.Reporter.Errorf("%v", err)
+			os.Exit(reporter.ExitCode())
 		}
 		npBuilder.KubeletConfigs(inputKubeletConfig...)
 		isKubeletConfigSet = true
@@ -1718,7 +1730,7 @@ func editNodePool(cmd *cobra.Command, nodePoolID string,
 		if nodeDrainGracePeriod != "" {
 			nodeDrainBuilder, err := mpHelpers.CreateNodeDrainGracePeriodBuilder(nodeDrainGracePeriod)
 			if err != nil {
-				return fmt.Errorf(err.Error())
+				return fmt.Errorf("%v", err)
 			}
 			npBuilder.NodeDrainGracePeriod(nodeDrainBuilder)
 		}
@@ -1746,7 +1758,7 @@ func editNodePool(cmd *cobra.Command, nodePoolID string,
 			})
 			if err != nil {
 				r.Reporter.Errorf("Expected a valid value for max surge: %s", err)
-				os.Exit(1)
+				os.Exit(reporter.ExitCode())
 			}
 
 			maxUnavailable, err = interactive.GetString(interactive.Input{
@@ -1760,7 +1772,7 @@ func editNodePool(cmd *cobra.Command, nodePoolID string,
 			})
 			if err != nil {
 				r.Reporter.Errorf("Expected a valid value for max unavailable: %s", err)
-				os.Exit(1)
+				os.Exit(reporter.ExitCode())
 			}
 		}
 
@@ -1781,7 +1793,20 @@ func editNodePool(cmd *cobra.Command, nodePoolID string,
 		return fmt.Errorf("Failed to create machine pool for hosted cluster '%s': %v", clusterKey, err)
 	}
 
-	if isKubeletConfigSet && !promptForNodePoolNodeRecreate(
+	changes, err := preview.Compare(fmt.Sprintf("machine pool '%s'", nodePool.ID()),
+		nodePool, update, cmv1.MarshalNodePool)
+	if err != nil {
+		return fmt.Errorf("Failed to compare machine pool '%s': %v", nodePool.ID(), err)
+	}
+	if kubeletConfigsChanged(nodePool, update) {
+		changes.Disrupts("kubelet_configs", kubeletconfig.NodePoolNodeRecreateReason)
+	}
+	if !changes.Review(r) {
+		return nil
+	}
+
+	// In interactive mode the review above has already asked to accept the recreation of the nodes
+	if isKubeletConfigSet && !interactive.Enabled() && !promptForNodePoolNodeRecreate(
 		nodePool, update, kubeletconfig.PromptToAcceptNodePoolNodeRecreate, r) {
 		return nil
 	}
@@ -1823,17 +1848,25 @@ func promptForNodePoolNodeRecreate(
 	original *cmv1.NodePool,
 	update *cmv1.NodePool,
 	promptFunc func(r *rosa.Runtime) bool, r *rosa.Runtime) bool {
-	if len(original.KubeletConfigs()) != len(update.KubeletConfigs()) {
+	if kubeletConfigsChanged(original, update) {
 		return promptFunc(r)
 	}
+	return true
+}
+
+// kubeletConfigsChanged returns true if the update changes the KubeletConfigs of the nodepool.
+func kubeletConfigsChanged(original *cmv1.NodePool, update *cmv1.NodePool) bool {
+	if len(original.KubeletConfigs()) != len(update.KubeletConfigs()) {
+		return true
+	}
 
 	for _, s := range update.KubeletConfigs() {
 		if !slices.Contains(original.KubeletConfigs(), s) {
-			return promptFunc(r)
+			return true
 		}
 	}
 
-	return true
+	return false
 }
 
 func getNodePoolReplicas(cmd *cobra.Command,
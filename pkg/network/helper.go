@@ -22,8 +22,9 @@ const (
 )
 
 // logStackEvents fetches and logs stack events
-func logStackEvents(cfClient *cloudformation.Client, stackName string, logger *logrus.Logger) {
-	events, err := cfClient.DescribeStackEvents(context.TODO(), &cloudformation.DescribeStackEventsInput{
+func logStackEvents(ctx context.Context, cfClient *cloudformation.Client, stackName string,
+	logger *logrus.Logger) {
+	events, err := cfClient.DescribeStackEvents(ctx, &cloudformation.DescribeStackEventsInput{
 		StackName: aws.String(stackName),
 	})
 	if err != nil {
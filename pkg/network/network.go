@@ -10,11 +10,14 @@ import (
 	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
 	cfTypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
 	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/rosa/pkg/interrupt"
 )
 
 //go:generate mockgen -source=network.go -package=network -destination=network_mock.go
 type NetworkService interface {
-	CreateStack(templateFile *string, templateBody *[]byte, params map[string]string, tags map[string]string) error
+	CreateStack(ctx context.Context, templateFile *string, templateBody *[]byte,
+		params map[string]string, tags map[string]string) error
 }
 
 type network struct {
@@ -27,12 +30,12 @@ func NewNetworkService() NetworkService {
 }
 
 // CreateStack creates a CloudFormation stack
-func (s *network) CreateStack(templateFile *string, templateBody *[]byte,
+func (s *network) CreateStack(ctx context.Context, templateFile *string, templateBody *[]byte,
 	params map[string]string, tags map[string]string) error {
 	// Load the AWS configuration
 	logger := logrus.New()
 	logger.SetLevel(logrus.DebugLevel)
-	cfg, err := config.LoadDefaultConfig(context.TODO(), config.WithRegion(params["Region"]))
+	cfg, err := config.LoadDefaultConfig(ctx, config.WithRegion(params["Region"]))
 	if err != nil {
 		return fmt.Errorf("unable to load SDK config, %v", err)
 	}
@@ -66,7 +69,7 @@ func (s *network) CreateStack(templateFile *string, templateBody *[]byte,
 
 	// Create the stack
 	logger.Info("Creating CloudFormation stack")
-	_, err = cfClient.CreateStack(context.TODO(), &cloudformation.CreateStackInput{
+	_, err = cfClient.CreateStack(ctx, &cloudformation.CreateStackInput{
 		StackName:    aws.String(params["Name"]),
 		TemplateBody: aws.String(template),
 		Parameters:   cfParams,
@@ -80,20 +83,27 @@ func (s *network) CreateStack(templateFile *string, templateBody *[]byte,
 		deleteHelperMessage(logger, params, err)
 		return fmt.Errorf("failed to create stack, %v", err)
 	}
+	done := interrupt.Track("CloudFormation stack '%s'", params["Name"])
 
 	// Fetch and log stack events periodically
+	eventsCtx, stopEvents := context.WithCancel(ctx)
+	defer stopEvents()
 	go func() {
 		ticker := time.NewTicker(10 * time.Second)
 		defer ticker.Stop()
 		for {
-			<-ticker.C
-			logStackEvents(cfClient, params["Name"], logger)
+			select {
+			case <-eventsCtx.Done():
+				return
+			case <-ticker.C:
+				logStackEvents(eventsCtx, cfClient, params["Name"], logger)
+			}
 		}
 	}()
 
 	// Wait until the stack is created
 	waiter := cloudformation.NewStackCreateCompleteWaiter(cfClient)
-	err = waiter.Wait(context.TODO(), &cloudformation.DescribeStacksInput{
+	err = waiter.Wait(ctx, &cloudformation.DescribeStacksInput{
 		StackName: aws.String(params["Name"]),
 	}, 10*time.Minute, func(o *cloudformation.StackCreateCompleteWaiterOptions) {
 		o.MinDelay = 30 * time.Second
@@ -105,9 +115,11 @@ func (s *network) CreateStack(templateFile *string, templateBody *[]byte,
 		logger.Infof(helperMsg)
 		return fmt.Errorf("failed to wait for stack creation, %v", err)
 	}
+	done()
+	stopEvents()
 
 	// Describe the stack resources
-	describeStackResourcesOutput, err := cfClient.DescribeStackResources(context.TODO(),
+	describeStackResourcesOutput, err := cfClient.DescribeStackResources(ctx,
 		&cloudformation.DescribeStackResourcesInput{
 			StackName: aws.String(params["Name"]),
 		})
@@ -9,6 +9,7 @@
 package network
 
 import (
+	context "context"
 	reflect "reflect"
 
 	gomock "go.uber.org/mock/gomock"
@@ -38,15 +39,15 @@ func (m *MockNetworkService) EXPECT() *MockNetworkServiceMockRecorder {
 }
 
 // CreateStack mocks base method.
-func (m *MockNetworkService) CreateStack(templateFile *string, templateBody *[]byte, params, tags map[string]string) error {
+func (m *MockNetworkService) CreateStack(ctx context.Context, templateFile *string, templateBody *[]byte, params, tags map[string]string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateStack", templateFile, templateBody, params, tags)
+	ret := m.ctrl.Call(m, "CreateStack", ctx, templateFile, templateBody, params, tags)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // CreateStack indicates an expected call of CreateStack.
-func (mr *MockNetworkServiceMockRecorder) CreateStack(templateFile, templateBody, params, tags any) *gomock.Call {
+func (mr *MockNetworkServiceMockRecorder) CreateStack(ctx, templateFile, templateBody, params, tags any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateStack", reflect.TypeOf((*MockNetworkService)(nil).CreateStack), templateFile, templateBody, params, tags)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateStack", reflect.TypeOf((*MockNetworkService)(nil).CreateStack), ctx, templateFile, templateBody, params, tags)
 }
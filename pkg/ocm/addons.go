@@ -148,6 +148,22 @@ func (c *Client) GetAddOnInstallation(clusterID, addOnID string) (*asv1.AddonIns
 	return response.Body(), nil
 }
 
+func (c *Client) GetAddOnInstallations(clusterID string) ([]*asv1.AddonInstallation, error) {
+	response, err := c.ocm.AddonsMgmt().V1().
+		Clusters().
+		Cluster(clusterID).
+		Addons().
+		List().
+		Page(1).
+		Size(-1).
+		Send()
+	if err != nil {
+		return nil, handleErr(response.Error(), err)
+	}
+
+	return response.Items().Slice(), nil
+}
+
 func (c *Client) UpdateAddOnInstallation(clusterID, addOnID string, params []AddOnParam) error {
 	addOnInstallationBuilder := asv1.NewAddonInstallation().
 		Addon(asv1.NewAddon().ID(addOnID))
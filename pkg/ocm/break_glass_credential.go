@@ -66,7 +66,7 @@ func (c *Client) PollKubeconfig(
 	pollInterval time.Duration,
 	timeout time.Duration) (kubeconfig string, err error) {
 
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	ctx, cancel := context.WithTimeout(c.context(), timeout)
 	defer func() {
 		cancel()
 	}()
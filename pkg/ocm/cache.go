@@ -0,0 +1,125 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the caching of the responses to slow lookups that rarely change, like the
+// versions, regions, machine types and STS policies.
+
+package ocm
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/cache"
+	"github.com/openshift/rosa/pkg/config"
+)
+
+// Time to live of the cached responses:
+const (
+	versionsTTL           = 1 * time.Hour
+	regionsTTL            = 1 * time.Hour
+	machineTypesTTL       = 1 * time.Hour
+	stsPoliciesTTL        = 24 * time.Hour
+	credentialRequestsTTL = 24 * time.Hour
+)
+
+// namespaceFor calculates the namespace of the cached responses of the given configuration, from
+// the URL of the environment and the identity of the user.
+func namespaceFor(cfg *config.Config, accessToken string) string {
+	identity := cfg.ClientID
+	token, err := config.ParseToken(accessToken)
+	if err == nil {
+		claims, _ := token.Claims.(jwt.MapClaims)
+		for _, claim := range []string{"sub", "preferred_username", "username"} {
+			value, ok := claims[claim].(string)
+			if ok && value != "" {
+				identity = value
+				break
+			}
+		}
+	}
+	return cache.Namespace(valueOrDefault(cfg.URL, sdk.DefaultURL), identity)
+}
+
+// cacheService returns the service that stores the cached responses, or nil if the client doesn't
+// cache responses.
+func (c *Client) cacheService() cache.RosaCacheService {
+	if c.cacheNamespace == "" {
+		return nil
+	}
+	c.cacheOnce.Do(func() {
+		// The service is usable even if the existing file can't be loaded, it will be
+		// overwritten:
+		c.cache, _ = cache.NewRosaCacheService()
+	})
+	return c.cache
+}
+
+// cached returns the response stored in the cache with the given name for the namespace of the
+// client, or calls the fetch function and stores its result.
+func cached[T any](c *Client, name string, ttl time.Duration, codec cache.Codec[T],
+	fetch func() (T, error)) (T, error) {
+	return cache.Fetch(c.cacheService(), cache.Key(c.cacheNamespace, name), ttl, codec, fetch)
+}
+
+// bodyHash calculates a hash of the body sent in an inquiry, so that it can be part of the name of
+// the cached response without storing the credentials that it may contain.
+func bodyHash[T any](body T, marshal func(T, io.Writer) error) string {
+	buffer := &bytes.Buffer{}
+	err := marshal(body, buffer)
+	if err != nil {
+		buffer.Reset()
+		fmt.Fprintf(buffer, "%#v", body)
+	}
+	hash := sha256.Sum256(buffer.Bytes())
+	return hex.EncodeToString(hash[:])[:16]
+}
+
+var (
+	versionListCodec = cache.Codec[[]*cmv1.Version]{
+		Marshal:   cmv1.MarshalVersionList,
+		Unmarshal: cmv1.UnmarshalVersionList,
+	}
+	cloudRegionListCodec = cache.Codec[[]*cmv1.CloudRegion]{
+		Marshal:   cmv1.MarshalCloudRegionList,
+		Unmarshal: cmv1.UnmarshalCloudRegionList,
+	}
+	machineTypeListCodec = cache.Codec[[]*cmv1.MachineType]{
+		Marshal:   cmv1.MarshalMachineTypeList,
+		Unmarshal: cmv1.UnmarshalMachineTypeList,
+	}
+	stsPolicyListCodec = cache.Codec[[]*cmv1.AWSSTSPolicy]{
+		Marshal:   cmv1.MarshalAWSSTSPolicyList,
+		Unmarshal: cmv1.UnmarshalAWSSTSPolicyList,
+	}
+	credentialRequestListCodec = cache.Codec[[]*cmv1.STSCredentialRequest]{
+		Marshal:   cmv1.MarshalSTSCredentialRequestList,
+		Unmarshal: cmv1.UnmarshalSTSCredentialRequestList,
+	}
+)
+
+// CacheNamespace returns the namespace of the responses cached for the given configuration.
+func CacheNamespace(cfg *config.Config) string {
+	return namespaceFor(cfg, cfg.AccessToken)
+}
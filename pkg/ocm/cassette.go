@@ -0,0 +1,77 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ocm
+
+import (
+	sdk "github.com/openshift-online/ocm-sdk-go"
+
+	"github.com/openshift/rosa/pkg/cassette"
+	"github.com/openshift/rosa/pkg/config"
+)
+
+// Keys of the metadata saved in the OCM cassette:
+const (
+	cassetteURL      = "url"
+	cassetteTokenURL = "token_url"
+	cassetteClientID = "client_id"
+)
+
+// recordConfig saves to the OCM cassette the details of the configuration needed to replay it.
+func recordConfig(cfg *config.Config) error {
+	if !cassette.Recording() {
+		return nil
+	}
+	recorded, err := cassette.Open(cassette.OCM)
+	if err != nil {
+		return err
+	}
+	values := map[string]string{
+		cassetteURL:      valueOrDefault(cfg.URL, sdk.DefaultURL),
+		cassetteTokenURL: valueOrDefault(cfg.TokenURL, sdk.DefaultTokenURL),
+		cassetteClientID: valueOrDefault(cfg.ClientID, sdk.DefaultClientID),
+	}
+	for key, value := range values {
+		err = recorded.SetMetadata(key, value)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// replayConfig creates the configuration used to replay the OCM cassette. It contains tokens that
+// never expire, so that the connection doesn't try to refresh them.
+func replayConfig() (*config.Config, error) {
+	recorded, err := cassette.Open(cassette.OCM)
+	if err != nil {
+		return nil, err
+	}
+	return &config.Config{
+		URL:          recorded.GetMetadata(cassetteURL),
+		TokenURL:     recorded.GetMetadata(cassetteTokenURL),
+		ClientID:     recorded.GetMetadata(cassetteClientID),
+		AccessToken:  cassette.FakeToken("Bearer"),
+		RefreshToken: cassette.FakeToken("Refresh"),
+	}, nil
+}
+
+func valueOrDefault(value string, defaultValue string) string {
+	if value == "" {
+		return defaultValue
+	}
+	return value
+}
@@ -21,27 +21,44 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"sync"
 	"time"
 
 	sdk "github.com/openshift-online/ocm-sdk-go"
 	"github.com/sirupsen/logrus"
+	errors "github.com/zgalor/weberr"
 
+	"github.com/openshift/rosa/pkg/cache"
+	"github.com/openshift/rosa/pkg/cassette"
 	"github.com/openshift/rosa/pkg/config"
 	"github.com/openshift/rosa/pkg/fedramp"
 	"github.com/openshift/rosa/pkg/info"
+	"github.com/openshift/rosa/pkg/interrupt"
+	"github.com/openshift/rosa/pkg/journal"
 	"github.com/openshift/rosa/pkg/logging"
 	"github.com/openshift/rosa/pkg/reporter"
+	"github.com/openshift/rosa/pkg/retry"
+	"github.com/openshift/rosa/pkg/trace"
 )
 
 type Client struct {
-	ocm *sdk.Connection
+	ocm      *sdk.Connection
+	ctx      context.Context
+	inMemory bool
+
+	// Responses are only cached when the namespace is set:
+	cacheNamespace string
+	cacheOnce      sync.Once
+	cache          cache.RosaCacheService
 }
 
 // ClientBuilder contains the information and logic needed to build a connection to OCM. Don't
 // create instances of this type directly; use the NewClient function instead.
 type ClientBuilder struct {
-	logger *logrus.Logger
-	cfg    *config.Config
+	logger   *logrus.Logger
+	cfg      *config.Config
+	ctx      context.Context
+	inMemory bool
 }
 
 // NewClient creates a builder that can then be used to configure and build an OCM connection.
@@ -56,13 +73,22 @@ func NewClientWithConnection(connection *sdk.Connection) *Client {
 	}
 }
 
-func CreateNewClientOrExit(logger *logrus.Logger, reporter *reporter.Object) *Client {
+// CreateNewClient creates an OCM client using the configuration file.
+func CreateNewClient(logger *logrus.Logger) (*Client, error) {
 	client, err := NewClient().
 		Logger(logger).
 		Build()
 	if err != nil {
-		reporter.Errorf("Failed to create OCM connection: %v", err)
-		os.Exit(1)
+		return nil, fmt.Errorf("Failed to create OCM connection: %v", err)
+	}
+	return client, nil
+}
+
+func CreateNewClientOrExit(logger *logrus.Logger, r *reporter.Object) *Client {
+	client, err := CreateNewClient(logger)
+	if err != nil {
+		r.Errorf("%v", err)
+		os.Exit(reporter.ExitCode())
 	}
 
 	return client
@@ -81,9 +107,30 @@ func (b *ClientBuilder) Config(value *config.Config) *ClientBuilder {
 	return b
 }
 
+// Context sets the context that cancels the requests and polling loops of the connection. If it
+// isn't set the root context of the command line tool is used.
+func (b *ClientBuilder) Context(value context.Context) *ClientBuilder {
+	b.ctx = value
+	return b
+}
+
+// InMemory sets whether the tokens refreshed by the connection are only updated in the given
+// configuration, instead of being saved to the configuration file.
+func (b *ClientBuilder) InMemory(value bool) *ClientBuilder {
+	b.inMemory = value
+	return b
+}
+
 // Build uses the information stored in the builder to create a new OCM connection.
 func (b *ClientBuilder) Build() (result *Client, err error) {
-	if b.cfg == nil {
+	if cassette.Replaying() {
+		// Replayed traffic doesn't need, and must not use, the credentials of the user:
+		b.cfg, err = replayConfig()
+		if err != nil {
+			return nil, err
+		}
+		b.inMemory = true
+	} else if b.cfg == nil {
 		// Load the configuration file:
 		b.cfg, err = config.Load()
 		if err != nil {
@@ -91,7 +138,13 @@ func (b *ClientBuilder) Build() (result *Client, err error) {
 			return nil, err
 		}
 		if b.cfg == nil {
-			err = fmt.Errorf("Not logged in, run the 'rosa login' command")
+			err = errors.Unauthorized.Errorf("Not logged in, run the 'rosa login' command")
+			return nil, err
+		}
+		if b.cfg.Context() != "" && config.IsNotValid(b.cfg) {
+			err = errors.Unauthorized.Errorf(
+				"Not logged in to context '%s', run the 'rosa login --context %s' command",
+				b.cfg.Context(), b.cfg.Context())
 			return nil, err
 		}
 	}
@@ -153,6 +206,56 @@ func (b *ClientBuilder) Build() (result *Client, err error) {
 	}
 	builder.Insecure(b.cfg.Insecure)
 
+	// Cancel the requests in flight when the user interrupts the command:
+	if b.ctx != nil {
+		builder.TransportWrapper(interrupt.NewTransportWrapper(b.ctx))
+	} else {
+		builder.TransportWrapper(interrupt.TransportWrapper)
+	}
+
+	// Record or replay the traffic when requested with the '--record' or '--replay' flags:
+	err = recordConfig(b.cfg)
+	if err != nil {
+		return nil, err
+	}
+	wrapper, err := cassette.TransportWrapper(cassette.OCM)
+	if err != nil {
+		return nil, err
+	}
+	if wrapper != nil {
+		builder.TransportWrapper(wrapper)
+	}
+
+	// Journal the requests that change resources:
+	builder.TransportWrapper(journal.TransportWrapper)
+
+	// Retry the requests that are throttled or fail transiently. The retries of the connection are
+	// disabled because they don't honour the 'Retry-After' header:
+	policy, err := retry.Current(b.cfg, retry.DefaultPolicy)
+	if err != nil {
+		return
+	}
+	builder.RetryLimit(0)
+	builder.TransportWrapper(retry.NewTransportWrapper(policy, b.logger))
+
+	// Trace the requests when requested with the '--trace-file' flag:
+	builder.TransportWrapper(trace.TransportWrapper)
+
+	// Trust the CA bundle of the configuration in addition to the certificate authorities of the
+	// system:
+	if b.cfg.CAFile != "" {
+		builder.TrustedCAFile(b.cfg.CAFile)
+	}
+
+	// Use the proxy of the configuration. This has to be the last wrapper added:
+	wrapper, err = b.cfg.ProxyWrapper()
+	if err != nil {
+		return
+	}
+	if wrapper != nil {
+		builder.TransportWrapper(wrapper)
+	}
+
 	// Create the connection:
 	conn, err := builder.Build()
 	if err != nil {
@@ -168,15 +271,37 @@ func (b *ClientBuilder) Build() (result *Client, err error) {
 	}
 
 	// Persist tokens in the configuration file, the SDK may have refreshed them
-	err = config.PersistTokens(b.cfg, accessToken, refreshToken)
-	if err != nil {
-		b.logger.Warn(context.TODO(),
-			fmt.Sprintf("error creating connection. Can't persist tokens to config: %v", err))
+	if b.inMemory {
+		b.cfg.AccessToken = accessToken
+		b.cfg.RefreshToken = refreshToken
+	} else {
+		err = config.PersistTokens(b.cfg, accessToken, refreshToken)
+		if err != nil {
+			b.logger.Warn(context.TODO(),
+				fmt.Sprintf("error creating connection. Can't persist tokens to config: %v", err))
+		}
 	}
 
-	return &Client{
-		ocm: conn,
-	}, nil
+	result = &Client{
+		ocm:      conn,
+		ctx:      b.ctx,
+		inMemory: b.inMemory,
+	}
+
+	// Recorded and replayed traffic must not depend on what was cached before:
+	if !cassette.Recording() && !cassette.Replaying() {
+		result.cacheNamespace = namespaceFor(b.cfg, accessToken)
+	}
+
+	return result, nil
+}
+
+// context returns the context that cancels the polling loops of the client.
+func (c *Client) context() context.Context {
+	if c.ctx != nil {
+		return c.ctx
+	}
+	return interrupt.RootContext()
 }
 
 func (c *Client) Close() error {
@@ -201,6 +326,10 @@ func (c *Client) KeepTokensAlive() error {
 		return fmt.Errorf("Can't get new tokens: %v", err)
 	}
 
+	if c.inMemory {
+		return nil
+	}
+
 	err = config.PersistTokens(nil, accessToken, refreshToken)
 	if err != nil {
 		c.ocm.Logger().Warn(context.TODO(),
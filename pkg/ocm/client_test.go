@@ -2,7 +2,9 @@ package ocm
 
 import (
 	"net/http"
+	"net/http/httptest"
 	"os"
+	"sync/atomic"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -11,6 +13,7 @@ import (
 	sdk "github.com/openshift-online/ocm-sdk-go"
 	"github.com/openshift-online/ocm-sdk-go/logging"
 	. "github.com/openshift-online/ocm-sdk-go/testing"
+	"github.com/sirupsen/logrus"
 
 	"github.com/openshift/rosa/pkg/config"
 )
@@ -112,4 +115,40 @@ var _ = Describe("OCM Client", Ordered, func() {
 			Expect(myconf.RefreshToken).To(Equal(newRefreshToken))
 		})
 	})
+
+	When("The configuration has a proxy", func() {
+		It("Sends the requests through the proxy and the rest of the transports", func() {
+			var requests atomic.Int32
+			proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				Expect(r.URL.Host).To(Equal("api.example.invalid"))
+				if requests.Add(1) == 1 {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_, err := w.Write([]byte(`{"kind":"ClusterList","items":[]}`))
+				Expect(err).NotTo(HaveOccurred())
+			}))
+			defer proxy.Close()
+			logger := logrus.New()
+			logger.SetOutput(GinkgoWriter)
+			client, err := NewClient().
+				Logger(logger).
+				Config(&config.Config{
+					URL:             "http://api.example.invalid",
+					AccessToken:     MakeTokenString("Bearer", 15*time.Minute),
+					ProxyURL:        proxy.URL,
+					RetryBackoff:    "1ms",
+					RetryMaxBackoff: "1ms",
+				}).
+				InMemory(true).
+				Build()
+			Expect(err).NotTo(HaveOccurred())
+			defer client.Close()
+			response, err := client.ocm.Get().Path("/api/clusters_mgmt/v1/clusters").Send()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.Status()).To(Equal(http.StatusOK))
+			Expect(requests.Load()).To(BeEquivalentTo(2))
+		})
+	})
 })
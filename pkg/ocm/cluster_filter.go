@@ -0,0 +1,238 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the filter used to select and sort the clusters listed by 'rosa list clusters'.
+
+package ocm
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	ocmConsts "github.com/openshift-online/ocm-common/pkg/ocm/consts"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/aws"
+)
+
+// ClusterStates are the states that can be used to filter clusters.
+var ClusterStates = []string{
+	string(cmv1.ClusterStateError),
+	string(cmv1.ClusterStateHibernating),
+	string(cmv1.ClusterStateInstalling),
+	string(cmv1.ClusterStatePending),
+	string(cmv1.ClusterStatePoweringDown),
+	string(cmv1.ClusterStateReady),
+	string(cmv1.ClusterStateResuming),
+	string(cmv1.ClusterStateUninstalling),
+	string(cmv1.ClusterStateUnknown),
+	string(cmv1.ClusterStateValidating),
+	string(cmv1.ClusterStateWaiting),
+}
+
+// Topologies that can be used to filter clusters:
+const (
+	TopologyClassic    = "classic"
+	TopologyClassicSTS = "classic-sts"
+	TopologyHostedCP   = "hosted-cp"
+)
+
+// ClusterTopologies are the topologies that can be used to filter clusters.
+var ClusterTopologies = []string{
+	TopologyClassic,
+	TopologyClassicSTS,
+	TopologyHostedCP,
+}
+
+// clusterTopologyConditions maps the topologies to the conditions of the search language that
+// select them.
+var clusterTopologyConditions = map[string]string{
+	TopologyClassic:    "hypershift.enabled = 'false' AND aws.sts.enabled = 'false'",
+	TopologyClassicSTS: "hypershift.enabled = 'false' AND aws.sts.enabled = 'true'",
+	TopologyHostedCP:   "hypershift.enabled = 'true'",
+}
+
+// accountIDRE matches the identifiers of AWS accounts.
+var accountIDRE = regexp.MustCompile(`^\d{12}$`)
+
+// clusterSortFields maps the names accepted to sort clusters to the fields of the search language.
+var clusterSortFields = map[string]string{
+	"id":      "id",
+	"name":    "name",
+	"state":   "state",
+	"region":  "region.id",
+	"version": "openshift_version",
+	"created": "creation_timestamp",
+}
+
+// ClusterSortKeys returns the names that can be used to sort clusters, in alphabetical order.
+func ClusterSortKeys() []string {
+	keys := make([]string, 0, len(clusterSortFields))
+	for key := range clusterSortFields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// ClusterFilter contains the criteria used to select and sort clusters. Empty fields don't
+// restrict the result.
+type ClusterFilter struct {
+	// AccountRole selects the clusters that use the given account role.
+	AccountRole *aws.Role
+
+	// States selects the clusters that are in any of the given states.
+	States []string
+
+	// Region selects the clusters of the given region.
+	Region string
+
+	// HostedCP selects the Hosted Control Plane clusters when true, and the classic ones when false.
+	HostedCP *bool
+
+	// Topology is one of ClusterTopologies, and selects the clusters with that topology.
+	Topology string
+
+	// Creator selects the clusters created by the given AWS account, when it is an account
+	// identifier, or by the given IAM user or role, when it is an ARN.
+	Creator string
+
+	// Version selects the clusters whose version starts with the given text, for example '4.14'.
+	Version string
+
+	// Name selects the clusters whose name matches the given glob pattern, where '*' matches any
+	// text and '?' any character.
+	Name string
+
+	// Search is an additional query in the OCM search language.
+	Search string
+
+	// SortBy is one of the keys returned by ClusterSortKeys. A '-' prefix sorts in descending order.
+	SortBy string
+}
+
+// query returns the conditions of the filter in the search language, appended to the given query.
+func (f *ClusterFilter) query(query string) (string, error) {
+	conditions := []string{query}
+	if len(f.States) > 0 {
+		states := make([]string, len(f.States))
+		for i, state := range f.States {
+			state = strings.ToLower(strings.TrimSpace(state))
+			if !slices.Contains(ClusterStates, state) {
+				return "", fmt.Errorf("Invalid cluster state '%s', expected one of %s",
+					state, strings.Join(ClusterStates, ", "))
+			}
+			states[i] = quote(state)
+		}
+		conditions = append(conditions, fmt.Sprintf("state IN (%s)", strings.Join(states, ", ")))
+	}
+	if f.Region != "" {
+		conditions = append(conditions, fmt.Sprintf("region.id = %s", quote(f.Region)))
+	}
+	if f.HostedCP != nil {
+		conditions = append(conditions, fmt.Sprintf("hypershift.enabled = '%t'", *f.HostedCP))
+	}
+	if f.Topology != "" {
+		topology := strings.ToLower(strings.TrimSpace(f.Topology))
+		condition, ok := clusterTopologyConditions[topology]
+		if !ok {
+			return "", fmt.Errorf("Invalid cluster topology '%s', expected one of %s",
+				topology, strings.Join(ClusterTopologies, ", "))
+		}
+		conditions = append(conditions, condition)
+	}
+	if f.Creator != "" {
+		condition, err := creatorCondition(f.Creator)
+		if err != nil {
+			return "", err
+		}
+		conditions = append(conditions, condition)
+	}
+	if f.Version != "" {
+		conditions = append(conditions, fmt.Sprintf("openshift_version LIKE %s", quote(f.Version+"%")))
+	}
+	if f.Name != "" {
+		pattern := strings.NewReplacer("*", "%", "?", "_").Replace(f.Name)
+		conditions = append(conditions, fmt.Sprintf("name LIKE %s", quote(pattern)))
+	}
+	if f.Search != "" {
+		conditions = append(conditions, fmt.Sprintf("(%s)", f.Search))
+	}
+	return strings.Join(conditions, " AND "), nil
+}
+
+// creatorCondition returns the condition of the search language that selects the clusters created
+// by the given AWS account identifier or ARN of an IAM user or role.
+func creatorCondition(creator string) (string, error) {
+	creator = strings.TrimSpace(creator)
+	if accountIDRE.MatchString(creator) {
+		return fmt.Sprintf("(properties.%s LIKE '%%:%s:%%' OR aws.sts.role_arn LIKE '%%:%s:%%')",
+			ocmConsts.CreatorArn, creator, creator), nil
+	}
+	if arn.IsARN(creator) {
+		return fmt.Sprintf("properties.%s = %s", ocmConsts.CreatorArn, quote(creator)), nil
+	}
+	return "", fmt.Errorf("Invalid creator '%s', expected an AWS account identifier or the ARN of an "+
+		"IAM user or role", creator)
+}
+
+// order returns the order of the filter in the search language.
+func (f *ClusterFilter) order() (string, error) {
+	if f.SortBy == "" {
+		return "", nil
+	}
+	key := strings.TrimPrefix(f.SortBy, "-")
+	field, ok := clusterSortFields[key]
+	if !ok {
+		return "", fmt.Errorf("Invalid sort key '%s', expected one of %s",
+			key, strings.Join(ClusterSortKeys(), ", "))
+	}
+	if strings.HasPrefix(f.SortBy, "-") {
+		return field + " desc", nil
+	}
+	return field + " asc", nil
+}
+
+// quote returns the given text as a literal of the search language.
+func quote(text string) string {
+	return "'" + strings.ReplaceAll(text, "'", "''") + "'"
+}
+
+// ListClusters returns all the clusters created by the given AWS account, or by any account of
+// the organization when the creator is nil, that match the filter.
+func (c *Client) ListClusters(creator *aws.Creator, filter *ClusterFilter) ([]*cmv1.Cluster, error) {
+	query := getClusterFilter(creator)
+	if filter.AccountRole != nil {
+		var err error
+		query, err = getAccountRoleClusterFilter(creator, *filter.AccountRole)
+		if err != nil {
+			return nil, err
+		}
+	}
+	query, err := filter.query(query)
+	if err != nil {
+		return nil, err
+	}
+	order, err := filter.order()
+	if err != nil {
+		return nil, err
+	}
+	return c.queryClusters(query, order, 0)
+}
@@ -0,0 +1,195 @@
+package ocm
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	"github.com/openshift-online/ocm-sdk-go/logging"
+	. "github.com/openshift-online/ocm-sdk-go/testing"
+)
+
+var _ = Describe("ClusterFilter", func() {
+	Describe("query", func() {
+		It("Returns the base query when the filter is empty", func() {
+			filter := &ClusterFilter{}
+			Expect(filter.query("product.id = 'rosa'")).To(Equal("product.id = 'rosa'"))
+		})
+
+		It("Appends the conditions of all the fields", func() {
+			hostedCP := true
+			filter := &ClusterFilter{
+				States:   []string{"ready", " Installing"},
+				Region:   "us-east-1",
+				HostedCP: &hostedCP,
+				Version:  "4.14",
+				Name:     "dev-*-?",
+				Search:   "aws.sts.enabled = 'true'",
+			}
+			Expect(filter.query("product.id = 'rosa'")).To(Equal("product.id = 'rosa'" +
+				" AND state IN ('ready', 'installing')" +
+				" AND region.id = 'us-east-1'" +
+				" AND hypershift.enabled = 'true'" +
+				" AND openshift_version LIKE '4.14%'" +
+				" AND name LIKE 'dev-%-_'" +
+				" AND (aws.sts.enabled = 'true')"))
+		})
+
+		It("Selects the classic clusters", func() {
+			hostedCP := false
+			filter := &ClusterFilter{HostedCP: &hostedCP}
+			Expect(filter.query("product.id = 'rosa'")).To(Equal(
+				"product.id = 'rosa' AND hypershift.enabled = 'false'"))
+		})
+
+		It("Selects the clusters of each topology", func() {
+			filter := &ClusterFilter{Topology: "classic"}
+			Expect(filter.query("product.id = 'rosa'")).To(Equal("product.id = 'rosa'" +
+				" AND hypershift.enabled = 'false' AND aws.sts.enabled = 'false'"))
+			filter = &ClusterFilter{Topology: "Classic-STS"}
+			Expect(filter.query("product.id = 'rosa'")).To(Equal("product.id = 'rosa'" +
+				" AND hypershift.enabled = 'false' AND aws.sts.enabled = 'true'"))
+			filter = &ClusterFilter{Topology: "hosted-cp"}
+			Expect(filter.query("product.id = 'rosa'")).To(Equal(
+				"product.id = 'rosa' AND hypershift.enabled = 'true'"))
+		})
+
+		It("Fails with an unknown topology", func() {
+			filter := &ClusterFilter{Topology: "sts"}
+			_, err := filter.query("product.id = 'rosa'")
+			Expect(err).To(MatchError("Invalid cluster topology 'sts', expected one of classic, " +
+				"classic-sts, hosted-cp"))
+		})
+
+		It("Selects the clusters created by an account", func() {
+			filter := &ClusterFilter{Creator: "123456789012"}
+			Expect(filter.query("product.id = 'rosa'")).To(Equal("product.id = 'rosa'" +
+				" AND (properties.rosa_creator_arn LIKE '%:123456789012:%'" +
+				" OR aws.sts.role_arn LIKE '%:123456789012:%')"))
+		})
+
+		It("Selects the clusters created by a user", func() {
+			filter := &ClusterFilter{Creator: "arn:aws:iam::123456789012:user/jdoe"}
+			Expect(filter.query("product.id = 'rosa'")).To(Equal("product.id = 'rosa'" +
+				" AND properties.rosa_creator_arn = 'arn:aws:iam::123456789012:user/jdoe'"))
+		})
+
+		It("Fails with an invalid creator", func() {
+			filter := &ClusterFilter{Creator: "jdoe"}
+			_, err := filter.query("product.id = 'rosa'")
+			Expect(err).To(MatchError("Invalid creator 'jdoe', expected an AWS account identifier or " +
+				"the ARN of an IAM user or role"))
+		})
+
+		It("Escapes the quotes of the values", func() {
+			filter := &ClusterFilter{Name: "it's"}
+			Expect(filter.query("product.id = 'rosa'")).To(Equal("product.id = 'rosa' AND name LIKE 'it''s'"))
+		})
+
+		It("Fails with an unknown state", func() {
+			filter := &ClusterFilter{States: []string{"ready", "broken"}}
+			_, err := filter.query("product.id = 'rosa'")
+			Expect(err).To(MatchError(HavePrefix("Invalid cluster state 'broken', expected one of error, ")))
+		})
+	})
+
+	Describe("order", func() {
+		It("Is empty by default", func() {
+			filter := &ClusterFilter{}
+			Expect(filter.order()).To(BeEmpty())
+		})
+
+		It("Sorts in ascending order", func() {
+			filter := &ClusterFilter{SortBy: "version"}
+			Expect(filter.order()).To(Equal("openshift_version asc"))
+		})
+
+		It("Sorts in descending order", func() {
+			filter := &ClusterFilter{SortBy: "-created"}
+			Expect(filter.order()).To(Equal("creation_timestamp desc"))
+		})
+
+		It("Fails with an unknown key", func() {
+			filter := &ClusterFilter{SortBy: "-size"}
+			_, err := filter.order()
+			Expect(err).To(MatchError(
+				"Invalid sort key 'size', expected one of created, id, name, region, state, version"))
+		})
+	})
+
+	Describe("ListClusters", func() {
+		var apiServer *ghttp.Server
+		var ocmClient *Client
+
+		BeforeEach(func() {
+			apiServer = MakeTCPServer()
+			logger, err := logging.NewGoLoggerBuilder().Debug(false).Build()
+			Expect(err).NotTo(HaveOccurred())
+			connection, err := sdk.NewConnectionBuilder().
+				Logger(logger).
+				Tokens(MakeTokenString("Bearer", 15*time.Minute)).
+				URL(apiServer.URL()).
+				Build()
+			Expect(err).NotTo(HaveOccurred())
+			ocmClient = &Client{ocm: connection}
+		})
+
+		AfterEach(func() {
+			apiServer.Close()
+		})
+
+		// page returns a handler that verifies the page requested and responds with the given number
+		// of clusters, out of the given total.
+		page := func(number, size, total int) http.HandlerFunc {
+			items := make([]string, size)
+			for i := range items {
+				items[i] = fmt.Sprintf(`{"kind": "Cluster", "id": "cluster-%d-%d"}`, number, i)
+			}
+			return ghttp.CombineHandlers(
+				ghttp.VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters"),
+				ghttp.VerifyFormKV("page", strconv.Itoa(number)),
+				ghttp.VerifyFormKV("size", "100"),
+				ghttp.VerifyFormKV("order", "name desc"),
+				ghttp.VerifyFormKV("search", "product.id = 'rosa' AND state IN ('ready')"),
+				RespondWithJSON(http.StatusOK, fmt.Sprintf(
+					`{"kind": "ClusterList", "page": %d, "size": %d, "total": %d, "items": [%s]}`,
+					number, size, total, strings.Join(items, ", "),
+				)),
+			)
+		}
+
+		It("Requests all the pages of the result", func() {
+			apiServer.AppendHandlers(page(1, 100, 250), page(2, 100, 250), page(3, 50, 250))
+			clusters, err := ocmClient.ListClusters(nil, &ClusterFilter{
+				States: []string{"ready"},
+				SortBy: "-name",
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(clusters).To(HaveLen(250))
+			Expect(apiServer.ReceivedRequests()).To(HaveLen(3))
+		})
+
+		It("Stops when the total is reached", func() {
+			apiServer.AppendHandlers(page(1, 100, 200), page(2, 100, 200))
+			clusters, err := ocmClient.ListClusters(nil, &ClusterFilter{
+				States: []string{"ready"},
+				SortBy: "-name",
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(clusters).To(HaveLen(200))
+			Expect(apiServer.ReceivedRequests()).To(HaveLen(2))
+		})
+
+		It("Doesn't send requests when the filter is invalid", func() {
+			_, err := ocmClient.ListClusters(nil, &ClusterFilter{SortBy: "size"})
+			Expect(err).To(HaveOccurred())
+			Expect(apiServer.ReceivedRequests()).To(BeEmpty())
+		})
+	})
+})
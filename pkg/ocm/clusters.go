@@ -17,9 +17,9 @@ limitations under the License.
 package ocm
 
 import (
+	"context"
 	"fmt"
 	"net"
-	"os"
 	"reflect"
 	"strconv"
 	"time"
@@ -302,33 +302,42 @@ func (c *Client) GetClustersUsingAccountRole(aws *aws.Creator, role aws.Role, co
 		return nil, err
 	}
 
-	return c.queryClusters(query, count)
+	return c.queryClusters(query, "", count)
 }
 
-func (c *Client) queryClusters(query string, count int) (clusters []*cmv1.Cluster, err error) {
+// clusterPageSize is the number of clusters requested in each page when listing clusters.
+const clusterPageSize = 100
+
+// queryClusters returns the clusters that match the query, sorted by the given order. A count of
+// zero returns all of them, requesting as many pages as needed.
+func (c *Client) queryClusters(query string, order string, count int) (clusters []*cmv1.Cluster, err error) {
 
 	if count < 0 {
 		err = errors.Errorf("Invalid Cluster count")
 		return
 	}
 
-	request := c.ocm.ClustersMgmt().V1().Clusters().List().Search(query)
+	size := clusterPageSize
+	if count > 0 && count < size {
+		size = count
+	}
+	request := c.ocm.ClustersMgmt().V1().Clusters().List().Search(query).Size(size)
+	if order != "" {
+		request = request.Order(order)
+	}
 	page := 1
 	for {
-		clusterRequestList := request.Page(page)
-		if count > 0 {
-			clusterRequestList = clusterRequestList.Size(count)
-		}
-		response, err := clusterRequestList.Send()
+		response, err := request.Page(page).Send()
 		if err != nil {
 			return clusters, err
 		}
 
 		response.Items().Each(func(cluster *cmv1.Cluster) bool {
 			clusters = append(clusters, cluster)
-			return true
+			return count == 0 || len(clusters) < count
 		})
-		if response.Size() != count {
+		if count > 0 && len(clusters) >= count || response.Size() < size ||
+			response.Total() > 0 && len(clusters) >= response.Total() {
 			break
 		}
 		page++
@@ -338,7 +347,7 @@ func (c *Client) queryClusters(query string, count int) (clusters []*cmv1.Cluste
 
 // Pass 0 to get all clusters
 func (c *Client) GetClusters(creator *aws.Creator, count int) (clusters []*cmv1.Cluster, err error) {
-	return c.queryClusters(getClusterFilter(creator), count)
+	return c.queryClusters(getClusterFilter(creator), "", count)
 }
 
 func (c *Client) GetAllClusters(creator *aws.Creator) (clusters []*cmv1.Cluster, err error) {
@@ -542,8 +551,11 @@ func (c *Client) GetClusterState(clusterID string) (cmv1.ClusterState, error) {
 		Status().
 		Get().
 		Send()
-	if err != nil || response.Body() == nil {
-		return cmv1.ClusterState(""), err
+	if err != nil {
+		return cmv1.ClusterState(""), handleErr(response.Error(), err)
+	}
+	if response.Body() == nil {
+		return cmv1.ClusterState(""), nil
 	}
 	return response.Body().State(), nil
 }
@@ -581,6 +593,60 @@ func (c *Client) UpdateCluster(clusterKey string, creator *aws.Creator, config S
 		return err
 	}
 
+	clusterSpec, err := c.BuildClusterUpdate(config)
+	if err != nil {
+		return err
+	}
+
+	// SDN -> OVN Migration
+	if config.NetworkType == NetworkTypes[1] {
+		// Create a request body for the specific cluster migration.
+		requestBuilder := v1.ClusterMigrationBuilder{}
+		requestBuilder.Type(v1.ClusterMigrationTypeSdnToOvn) // Type is required
+
+		if len(config.OvnInternalSubnetConfiguration) > 0 {
+			// Create a builder for the specific migration type's configuration if necessary
+			sdnToOvnBuilder := &v1.SdnToOvnClusterMigrationBuilder{}
+			if _, ok := config.OvnInternalSubnetConfiguration[JoinIpv4]; ok {
+				sdnToOvnBuilder.JoinIpv4(config.OvnInternalSubnetConfiguration[JoinIpv4])
+			}
+			if _, ok := config.OvnInternalSubnetConfiguration[TransitIpv4]; ok {
+				sdnToOvnBuilder.TransitIpv4(config.OvnInternalSubnetConfiguration[TransitIpv4])
+			}
+			if _, ok := config.OvnInternalSubnetConfiguration[MasqueradeIpv4]; ok {
+				sdnToOvnBuilder.MasqueradeIpv4(config.OvnInternalSubnetConfiguration[MasqueradeIpv4])
+			}
+			requestBuilder.SdnToOvn(sdnToOvnBuilder)
+		}
+
+		requestBody, err := requestBuilder.Build()
+		if err != nil {
+			return errors.UserWrapf(err, "Unable to create cluster migration request")
+		}
+
+		// Send the request to add a cluster migration.
+		response, err := c.ocm.ClustersMgmt().V1().Clusters().
+			Cluster(cluster.ID()).Migrations().Add().Body(requestBody).Send()
+		if err != nil {
+			return handleErr(response.Error(), err)
+		}
+	}
+
+	response, err := c.ocm.ClustersMgmt().V1().Clusters().
+		Cluster(cluster.ID()).
+		Update().
+		Body(clusterSpec).
+		Send()
+	if err != nil {
+		return handleErr(response.Error(), err)
+	}
+
+	return nil
+}
+
+// BuildClusterUpdate builds the patch that UpdateCluster sends to apply the given configuration to a
+// cluster. The migration of the network type isn't part of the patch, as it is requested separately.
+func (c *Client) BuildClusterUpdate(config Spec) (*cmv1.Cluster, error) {
 	clusterBuilder := cmv1.NewCluster()
 
 	// Update expiration timestamp
@@ -621,40 +687,6 @@ func (c *Client) UpdateCluster(clusterKey string, creator *aws.Creator, config S
 		clusterBuilder = clusterBuilder.DisableUserWorkloadMonitoring(*config.DisableWorkloadMonitoring)
 	}
 
-	// SDN -> OVN Migration
-	if config.NetworkType == NetworkTypes[1] {
-		// Create a request body for the specific cluster migration.
-		requestBuilder := v1.ClusterMigrationBuilder{}
-		requestBuilder.Type(v1.ClusterMigrationTypeSdnToOvn) // Type is required
-
-		if len(config.OvnInternalSubnetConfiguration) > 0 {
-			// Create a builder for the specific migration type's configuration if necessary
-			sdnToOvnBuilder := &v1.SdnToOvnClusterMigrationBuilder{}
-			if _, ok := config.OvnInternalSubnetConfiguration[JoinIpv4]; ok {
-				sdnToOvnBuilder.JoinIpv4(config.OvnInternalSubnetConfiguration[JoinIpv4])
-			}
-			if _, ok := config.OvnInternalSubnetConfiguration[TransitIpv4]; ok {
-				sdnToOvnBuilder.TransitIpv4(config.OvnInternalSubnetConfiguration[TransitIpv4])
-			}
-			if _, ok := config.OvnInternalSubnetConfiguration[MasqueradeIpv4]; ok {
-				sdnToOvnBuilder.MasqueradeIpv4(config.OvnInternalSubnetConfiguration[MasqueradeIpv4])
-			}
-			requestBuilder.SdnToOvn(sdnToOvnBuilder)
-		}
-
-		requestBody, err := requestBuilder.Build()
-		if err != nil {
-			return errors.UserWrapf(err, "Unable to create cluster migration request")
-		}
-
-		// Send the request to add a cluster migration.
-		response, err := c.ocm.ClustersMgmt().V1().Clusters().
-			Cluster(cluster.ID()).Migrations().Add().Body(requestBody).Send()
-		if err != nil {
-			return handleErr(response.Error(), err)
-		}
-	}
-
 	if config.HTTPProxy != nil || config.HTTPSProxy != nil || config.NoProxy != nil {
 		clusterProxyBuilder := cmv1.NewProxy()
 		if config.HTTPProxy != nil {
@@ -680,7 +712,7 @@ func (c *Client) UpdateCluster(clusterKey string, creator *aws.Creator, config S
 
 	registryConfigBuilder, err := BuildRegistryConfig(config)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if registryConfigBuilder != nil {
 		clusterBuilder.RegistryConfig(registryConfigBuilder)
@@ -702,21 +734,7 @@ func (c *Client) UpdateCluster(clusterKey string, creator *aws.Creator, config S
 		clusterBuilder.AWS(awsBuilder)
 	}
 
-	clusterSpec, err := clusterBuilder.Build()
-	if err != nil {
-		return err
-	}
-
-	response, err := c.ocm.ClustersMgmt().V1().Clusters().
-		Cluster(cluster.ID()).
-		Update().
-		Body(clusterSpec).
-		Send()
-	if err != nil {
-		return handleErr(response.Error(), err)
-	}
-
-	return nil
+	return clusterBuilder.Build()
 }
 
 func (c *Client) DeleteCluster(clusterKey string, bestEffort bool,
@@ -766,20 +784,22 @@ func (c *Client) EnsureNoPendingClusters(awsCreator *aws.Creator) error {
 	for {
 		pendingCluster, err := c.GetPendingClusterForARN(awsCreator)
 		if err != nil {
-			reporter.Errorf("Error getting cluster using ARN '%s'", awsCreator.ARN)
-			os.Exit(1)
-		}
-		if time.Now().After(deadline) {
-			reporter.Errorf("Timeout waiting for the cluster '%s' installation. Try again in a few minutes",
-				pendingCluster.ID())
-			os.Exit(1)
+			return fmt.Errorf("Error getting cluster using ARN '%s'", awsCreator.ARN)
 		}
 		if pendingCluster == nil {
 			break
-		} else {
-			reporter.Infof("Waiting for cluster '%s' with the same creator ARN to start installing",
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("Timeout waiting for the cluster '%s' installation. Try again in a few minutes",
 				pendingCluster.ID())
-			time.Sleep(30 * time.Second)
+		}
+		reporter.Infof("Waiting for cluster '%s' with the same creator ARN to start installing",
+			pendingCluster.ID())
+		select {
+		case <-c.context().Done():
+			return fmt.Errorf("Stopped waiting for the cluster '%s' installation: %v",
+				pendingCluster.ID(), context.Cause(c.context()))
+		case <-time.After(30 * time.Second):
 		}
 	}
 	return nil
@@ -0,0 +1,413 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the dynamic shell completion of the flags and arguments that name server-side
+// resources, like clusters, machine pools or identity providers. The completions are cached for a
+// short time so that pressing tab repeatedly doesn't send a request each time.
+
+package ocm
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/cache"
+	"github.com/openshift/rosa/pkg/config"
+	"github.com/openshift/rosa/pkg/logging"
+)
+
+// Time to live of the cached completions. It is short because these resources change often, but
+// long enough to cover the tab presses needed to write a command line:
+const completionTTL = 1 * time.Minute
+
+// Default channel group of the completed versions:
+const defaultChannelGroup = "stable"
+
+// completer returns the name used to cache the completions for the given command line and the
+// function that fetches them, or a nil function if they can't be calculated, for example because
+// the cluster hasn't been given yet.
+type completer func(cmd *cobra.Command, args []string) (name string, fetch func(c *Client) ([]string, error))
+
+// flagCompleters are the completers of the flags with these names, in any command that doesn't have
+// its own completer in commandFlagCompleters.
+var flagCompleters = map[string]completer{
+	clusterFlagName:   clusterCompleter,
+	"idp":             idpCompleter,
+	"ingress":         ingressCompleter,
+	"kubelet-config":  kubeletConfigCompleter,
+	"kubelet-configs": kubeletConfigCompleter,
+	"machinepool":     machinePoolCompleter,
+	"oidc-config-id":  oidcConfigCompleter,
+	"region":          regionCompleter,
+	"tuning-configs":  tuningConfigCompleter,
+	"version":         versionCompleter,
+}
+
+// commandFlagCompleters are the completers of flags whose values depend on the command, indexed by
+// the path of the command without the name of the root command, and then by the name of the flag.
+var commandFlagCompleters = map[string]map[string]completer{
+	"create account-roles": {
+		"version": policyVersionCompleter,
+	},
+	"list account-roles": {
+		"version": policyVersionCompleter,
+	},
+	"upgrade account-roles": {
+		"version": policyVersionCompleter,
+	},
+	"upgrade operator-roles": {
+		"version": policyVersionCompleter,
+	},
+	"upgrade roles": {
+		"cluster-version": clusterUpgradeCompleter,
+		"policy-version":  policyVersionCompleter,
+	},
+	"upgrade cluster": {
+		"to":      clusterUpgradeCompleter,
+		"version": clusterUpgradeCompleter,
+	},
+	"upgrade machinepool": {
+		"version": nodePoolUpgradeCompleter,
+	},
+}
+
+// argCompleters are the completers of the first argument of the commands with these names, when
+// they are subcommands of the commands in argVerbs.
+var (
+	argCompleters = map[string]completer{
+		"idp":            idpCompleter,
+		"ingress":        ingressCompleter,
+		"kubeletconfig":  kubeletConfigCompleter,
+		"machinepool":    machinePoolCompleter,
+		"tuning-configs": tuningConfigCompleter,
+	}
+	argVerbs = map[string]bool{
+		"delete":   true,
+		"describe": true,
+		"edit":     true,
+		"upgrade":  true,
+		"wait":     true,
+	}
+)
+
+// RegisterCompletions registers the dynamic completion of the flags and arguments of the given
+// command and its subcommands. Flags and arguments that already have completion functions keep
+// them.
+func RegisterCompletions(cmd *cobra.Command) {
+	completers := map[string]completer{}
+	for name, complete := range flagCompleters {
+		completers[name] = complete
+	}
+	for name, complete := range commandFlagCompleters[commandPath(cmd)] {
+		completers[name] = complete
+	}
+	for name, complete := range completers {
+		if cmd.Flags().Lookup(name) == nil && cmd.PersistentFlags().Lookup(name) == nil {
+			continue
+		}
+		// Fails if the flag already has a completion function, which is what we want:
+		_ = cmd.RegisterFlagCompletionFunc(name, completionFunc(complete))
+	}
+	complete, ok := argCompleters[cmd.Name()]
+	if ok && cmd.HasParent() && argVerbs[cmd.Parent().Name()] && cmd.ValidArgsFunction == nil &&
+		len(cmd.ValidArgs) == 0 {
+		function := completionFunc(complete)
+		cmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string,
+			cobra.ShellCompDirective) {
+			if len(args) > 0 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			return function(cmd, args, toComplete)
+		}
+	}
+	for _, child := range cmd.Commands() {
+		RegisterCompletions(child)
+	}
+}
+
+// commandPath returns the path of the command without the name of the root command, for example
+// 'upgrade cluster'.
+func commandPath(cmd *cobra.Command) string {
+	names := []string{}
+	for ; cmd.HasParent(); cmd = cmd.Parent() {
+		names = append([]string{cmd.Name()}, names...)
+	}
+	return strings.Join(names, " ")
+}
+
+// completionFunc converts a completer into a cobra completion function. Values of flags that
+// accept comma separated lists are completed after the last comma.
+func completionFunc(complete completer) func(*cobra.Command, []string, string) ([]string,
+	cobra.ShellCompDirective) {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		name, fetch := complete(cmd, args)
+		if fetch == nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		values, err := completions(name, fetch)
+		if err != nil {
+			cobra.CompDebugln(err.Error(), false)
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		prefix := ""
+		if index := strings.LastIndex(toComplete, ","); index != -1 {
+			prefix = toComplete[:index+1]
+			toComplete = toComplete[index+1:]
+		}
+		result := []string{}
+		for _, value := range values {
+			if strings.HasPrefix(value, toComplete) {
+				result = append(result, prefix+value)
+			}
+		}
+		return result, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// completions returns the cached completions with the given name, or fetches them. The cache is
+// checked before connecting to OCM, because connecting may need to refresh the tokens.
+func completions(name string, fetch func(c *Client) ([]string, error)) ([]string, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, err
+	}
+	if cfg == nil {
+		return nil, errors.New("Not logged in")
+	}
+	service, _ := cache.NewRosaCacheService()
+	key := cache.Key(CacheNamespace(cfg), "completions/"+name)
+	return cache.Fetch(service, key, completionTTL, cache.JSONCodec[[]string](), func() ([]string, error) {
+		client, err := NewClient().Logger(logging.NewLogger()).Config(cfg).Build()
+		if err != nil {
+			return nil, err
+		}
+		defer client.Close()
+		values, err := fetch(client)
+		if err != nil {
+			return nil, err
+		}
+		sort.Strings(values)
+		return values, nil
+	})
+}
+
+// clusterKeyOf returns the name or identifier of the cluster given in the command line, if it is
+// valid.
+func clusterKeyOf(cmd *cobra.Command) string {
+	flag := cmd.Flag(clusterFlagName)
+	if flag == nil || flag.Value.String() == "" || !IsValidClusterKey(flag.Value.String()) {
+		return ""
+	}
+	return flag.Value.String()
+}
+
+// clusterResources returns a completer of resources of the cluster given in the command line.
+func clusterResources(kind string, list func(c *Client, cluster *cmv1.Cluster) ([]string, error)) completer {
+	return func(cmd *cobra.Command, _ []string) (string, func(c *Client) ([]string, error)) {
+		key := clusterKeyOf(cmd)
+		if key == "" {
+			return "", nil
+		}
+		return kind + "/" + key, func(c *Client) ([]string, error) {
+			cluster, err := c.GetCluster(key, nil)
+			if err != nil {
+				return nil, err
+			}
+			return list(c, cluster)
+		}
+	}
+}
+
+func clusterCompleter(_ *cobra.Command, _ []string) (string, func(c *Client) ([]string, error)) {
+	return "clusters", func(c *Client) ([]string, error) {
+		clusters, err := c.queryClusters(getClusterFilter(nil), "", 0)
+		if err != nil {
+			return nil, err
+		}
+		names := []string{}
+		for _, cluster := range clusters {
+			names = append(names, cluster.Name())
+		}
+		return names, nil
+	}
+}
+
+var machinePoolCompleter = clusterResources("machine_pools",
+	func(c *Client, cluster *cmv1.Cluster) ([]string, error) {
+		ids := []string{}
+		if cluster.Hypershift().Enabled() {
+			pools, err := c.GetNodePools(cluster.ID())
+			for _, pool := range pools {
+				ids = append(ids, pool.ID())
+			}
+			return ids, err
+		}
+		pools, err := c.GetMachinePools(cluster.ID())
+		for _, pool := range pools {
+			ids = append(ids, pool.ID())
+		}
+		return ids, err
+	})
+
+var idpCompleter = clusterResources("identity_providers",
+	func(c *Client, cluster *cmv1.Cluster) ([]string, error) {
+		idps, err := c.GetIdentityProviders(cluster.ID())
+		names := []string{}
+		for _, idp := range idps {
+			names = append(names, idp.Name())
+		}
+		return names, err
+	})
+
+var ingressCompleter = clusterResources("ingresses",
+	func(c *Client, cluster *cmv1.Cluster) ([]string, error) {
+		ingresses, err := c.GetIngresses(cluster.ID())
+		ids := []string{}
+		for _, ingress := range ingresses {
+			ids = append(ids, ingress.ID())
+		}
+		return ids, err
+	})
+
+var kubeletConfigCompleter = clusterResources("kubelet_configs",
+	func(c *Client, cluster *cmv1.Cluster) ([]string, error) {
+		configs, err := c.ListKubeletConfigs(c.context(), cluster.ID())
+		names := []string{}
+		for _, kubeletConfig := range configs {
+			names = append(names, kubeletConfig.Name())
+		}
+		return names, err
+	})
+
+var tuningConfigCompleter = clusterResources("tuning_configs",
+	func(c *Client, cluster *cmv1.Cluster) ([]string, error) {
+		configs, err := c.GetTuningConfigs(cluster.ID())
+		names := []string{}
+		for _, tuningConfig := range configs {
+			names = append(names, tuningConfig.Name())
+		}
+		return names, err
+	})
+
+func oidcConfigCompleter(_ *cobra.Command, _ []string) (string, func(c *Client) ([]string, error)) {
+	return "oidc_configs", func(c *Client) ([]string, error) {
+		response, err := c.ocm.ClustersMgmt().V1().OidcConfigs().List().Page(1).Size(-1).Send()
+		if err != nil {
+			return nil, handleErr(response.Error(), err)
+		}
+		ids := []string{}
+		for _, oidcConfig := range response.Items().Slice() {
+			ids = append(ids, oidcConfig.ID())
+		}
+		return ids, nil
+	}
+}
+
+func regionCompleter(_ *cobra.Command, _ []string) (string, func(c *Client) ([]string, error)) {
+	return "regions", func(c *Client) ([]string, error) {
+		response, err := c.ocm.ClustersMgmt().V1().CloudProviders().CloudProvider("aws").Regions().
+			List().Page(1).Size(-1).Send()
+		if err != nil {
+			return nil, handleErr(response.Error(), err)
+		}
+		ids := []string{}
+		for _, region := range response.Items().Slice() {
+			if region.Enabled() {
+				ids = append(ids, region.ID())
+			}
+		}
+		return ids, nil
+	}
+}
+
+// channelGroupOf returns the channel group given in the command line, or the default one.
+func channelGroupOf(cmd *cobra.Command) string {
+	if flag := cmd.Flag("channel-group"); flag != nil && flag.Value.String() != "" {
+		return flag.Value.String()
+	}
+	return defaultChannelGroup
+}
+
+// versionCompleter completes the OpenShift versions of the channel group given in the command line.
+func versionCompleter(cmd *cobra.Command, _ []string) (string, func(c *Client) ([]string, error)) {
+	channelGroup := channelGroupOf(cmd)
+	return "versions/" + channelGroup, func(c *Client) ([]string, error) {
+		versions, err := c.GetVersions(channelGroup, false)
+		if err != nil {
+			return nil, err
+		}
+		ids := []string{}
+		for _, version := range versions {
+			ids = append(ids, version.RawID())
+		}
+		return ids, nil
+	}
+}
+
+// policyVersionCompleter completes the minor versions, like '4.14', used by the policies of the
+// account and operator roles.
+func policyVersionCompleter(cmd *cobra.Command, _ []string) (string, func(c *Client) ([]string, error)) {
+	channelGroup := channelGroupOf(cmd)
+	return "policy_versions/" + channelGroup, func(c *Client) ([]string, error) {
+		minors, err := c.GetVersionsList(channelGroup, false)
+		if err != nil {
+			return nil, err
+		}
+		seen := map[string]bool{}
+		result := []string{}
+		for _, minor := range minors {
+			if !seen[minor] {
+				seen[minor] = true
+				result = append(result, minor)
+			}
+		}
+		return result, nil
+	}
+}
+
+// clusterUpgradeCompleter completes the versions that the cluster given in the command line can be
+// upgraded to.
+var clusterUpgradeCompleter = clusterResources("available_upgrades",
+	func(_ *Client, cluster *cmv1.Cluster) ([]string, error) {
+		return cluster.Version().AvailableUpgrades(), nil
+	})
+
+// nodePoolUpgradeCompleter completes the versions that the machine pool given as the first argument
+// can be upgraded to.
+func nodePoolUpgradeCompleter(cmd *cobra.Command, args []string) (string, func(c *Client) ([]string,
+	error)) {
+	if len(args) == 0 {
+		return "", nil
+	}
+	nodePoolID := args[0]
+	name, fetch := clusterResources("node_pool_upgrades",
+		func(c *Client, cluster *cmv1.Cluster) ([]string, error) {
+			nodePool, exists, err := c.GetNodePool(cluster.ID(), nodePoolID)
+			if err != nil || !exists {
+				return nil, err
+			}
+			return GetNodePoolAvailableUpgrades(nodePool), nil
+		})(cmd, args)
+	if fetch == nil {
+		return "", nil
+	}
+	return name + "/" + nodePoolID, fetch
+}
@@ -0,0 +1,175 @@
+package ocm
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	. "github.com/openshift-online/ocm-sdk-go/testing"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/config"
+	"github.com/openshift/rosa/pkg/constants"
+)
+
+var _ = Describe("Completion", func() {
+	var apiServer *ghttp.Server
+	var root, edit, machinepool, upgradeAccountRoles, upgradeMachinePool *cobra.Command
+
+	completeArgs := func(cmd *cobra.Command, flag string, args []string, toComplete string) []string {
+		function, ok := cmd.GetFlagCompletionFunc(flag)
+		Expect(ok).To(BeTrue())
+		values, directive := function(cmd, args, toComplete)
+		Expect(directive).To(Equal(cobra.ShellCompDirectiveNoFileComp))
+		return values
+	}
+
+	complete := func(cmd *cobra.Command, flag string, toComplete string) []string {
+		return completeArgs(cmd, flag, nil, toComplete)
+	}
+
+	BeforeEach(func() {
+		apiServer = MakeTCPServer()
+		home := GinkgoT().TempDir()
+		GinkgoT().Setenv("HOME", home)
+		GinkgoT().Setenv(constants.OcmConfig, filepath.Join(home, "ocm.json"))
+		cfg, err := json.Marshal(&config.Config{
+			URL:         apiServer.URL(),
+			AccessToken: MakeTokenString("Bearer", 15*time.Minute),
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(os.WriteFile(filepath.Join(home, "ocm.json"), cfg, 0600)).To(Succeed())
+
+		root = &cobra.Command{Use: "rosa"}
+		edit = &cobra.Command{Use: "edit"}
+		edit.PersistentFlags().String("region", "", "")
+		machinepool = &cobra.Command{Use: "machinepool ID", Run: func(*cobra.Command, []string) {}}
+		AddClusterFlag(machinepool)
+		machinepool.Flags().String("tuning-configs", "", "")
+		edit.AddCommand(machinepool)
+		root.AddCommand(edit)
+		upgrade := &cobra.Command{Use: "upgrade"}
+		upgradeAccountRoles = &cobra.Command{Use: "account-roles", Run: func(*cobra.Command, []string) {}}
+		upgradeAccountRoles.Flags().String("version", "", "")
+		upgradeAccountRoles.Flags().String("channel-group", "", "")
+		upgrade.AddCommand(upgradeAccountRoles)
+		upgradeMachinePool = &cobra.Command{Use: "machinepool ID", Run: func(*cobra.Command, []string) {}}
+		AddClusterFlag(upgradeMachinePool)
+		upgradeMachinePool.Flags().String("version", "", "")
+		upgrade.AddCommand(upgradeMachinePool)
+		root.AddCommand(upgrade)
+		RegisterCompletions(root)
+	})
+
+	AfterEach(func() {
+		apiServer.Close()
+	})
+
+	It("Completes the regions and caches them", func() {
+		apiServer.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/cloud_providers/aws/regions"),
+			RespondWithJSON(http.StatusOK, `{
+				"kind": "CloudRegionList",
+				"items": [
+					{"id": "us-west-2", "enabled": true},
+					{"id": "us-east-1", "enabled": true},
+					{"id": "us-east-2", "enabled": false}
+				]
+			}`),
+		))
+		Expect(complete(edit, "region", "us-")).To(Equal([]string{"us-east-1", "us-west-2"}))
+		Expect(complete(machinepool, "region", "us-e")).To(Equal([]string{"us-east-1"}))
+		Expect(apiServer.ReceivedRequests()).To(HaveLen(1))
+	})
+
+	It("Completes the resources of the cluster given in the command line", func() {
+		apiServer.AppendHandlers(
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters"),
+				RespondWithJSON(http.StatusOK, `{
+					"kind": "ClusterList",
+					"total": 1,
+					"items": [{"kind": "Cluster", "id": "123", "name": "my-cluster"}]
+				}`),
+			),
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123/tuning_configs"),
+				RespondWithJSON(http.StatusOK, `{
+					"kind": "TuningConfigList",
+					"items": [{"id": "1", "name": "tuned-b"}, {"id": "2", "name": "tuned-a"}]
+				}`),
+			),
+		)
+		Expect(complete(machinepool, "tuning-configs", "")).To(BeEmpty())
+		Expect(apiServer.ReceivedRequests()).To(BeEmpty())
+
+		Expect(machinepool.Flags().Set(clusterFlagName, "my-cluster")).To(Succeed())
+		Expect(complete(machinepool, "tuning-configs", "tuned-a,tu")).To(Equal([]string{
+			"tuned-a,tuned-a", "tuned-a,tuned-b",
+		}))
+	})
+
+	It("Completes the first argument of the commands that take a resource", func() {
+		Expect(machinepool.ValidArgsFunction).NotTo(BeNil())
+		values, directive := machinepool.ValidArgsFunction(machinepool, []string{"workers"}, "")
+		Expect(values).To(BeEmpty())
+		Expect(directive).To(Equal(cobra.ShellCompDirectiveNoFileComp))
+	})
+
+	It("Completes the policy versions of the account roles", func() {
+		apiServer.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/versions"),
+			RespondWithJSON(http.StatusOK, `{
+				"kind": "VersionList",
+				"page": 1,
+				"size": 3,
+				"total": 3,
+				"items": [
+					{"id": "openshift-v4.15.2", "raw_id": "4.15.2", "channel_group": "stable"},
+					{"id": "openshift-v4.15.1", "raw_id": "4.15.1", "channel_group": "stable"},
+					{"id": "openshift-v4.14.9", "raw_id": "4.14.9", "channel_group": "stable"}
+				]
+			}`),
+		))
+		Expect(complete(upgradeAccountRoles, "version", "")).To(Equal([]string{"4.14", "4.15"}))
+	})
+
+	It("Completes the versions that the machine pool can be upgraded to", func() {
+		apiServer.AppendHandlers(
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters"),
+				RespondWithJSON(http.StatusOK, `{
+					"kind": "ClusterList",
+					"total": 1,
+					"items": [{"kind": "Cluster", "id": "123", "name": "my-cluster"}]
+				}`),
+			),
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/clusters/123/node_pools/workers"),
+				RespondWithJSON(http.StatusOK, `{
+					"kind": "NodePool",
+					"id": "workers",
+					"version": {
+						"id": "openshift-v4.14.9",
+						"available_upgrades": ["4.14.10", "4.15.2"]
+					}
+				}`),
+			),
+		)
+		Expect(upgradeMachinePool.Flags().Set(clusterFlagName, "my-cluster")).To(Succeed())
+		Expect(completeArgs(upgradeMachinePool, "version", nil, "")).To(BeEmpty())
+		Expect(completeArgs(upgradeMachinePool, "version", []string{"workers"}, "4.15")).To(Equal(
+			[]string{"4.15.2"}))
+	})
+
+	It("Doesn't complete anything when not logged in", func() {
+		GinkgoT().Setenv(constants.OcmConfig, filepath.Join(GinkgoT().TempDir(), "missing.json"))
+		Expect(complete(edit, "region", "")).To(BeEmpty())
+		Expect(apiServer.ReceivedRequests()).To(BeEmpty())
+	})
+})
@@ -0,0 +1,171 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the loading of fixtures and the fixtures that the server contains by
+// default.
+
+package fake
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// Load stores the objects of a fixtures document. The document is a YAML or JSON object where
+// the names of the fields are the paths of the objects and the values are the objects themselves:
+//
+//	clusters_mgmt/v1/clusters/123:
+//	  name: my-cluster
+//	  state: ready
+//	  product:
+//	    id: rosa
+//	clusters_mgmt/v1/clusters/123/machine_pools/worker:
+//	  replicas: 2
+//
+// The objects are stored in the order of their paths, replacing existing objects with the same
+// path.
+func (s *Server) Load(data []byte) error {
+	data, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return fmt.Errorf("Failed to parse fixtures: %v", err)
+	}
+	fixtures := map[string]map[string]interface{}{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	err = decoder.Decode(&fixtures)
+	if err != nil {
+		return fmt.Errorf("Failed to parse fixtures: %v", err)
+	}
+	paths := make([]string, 0, len(fixtures))
+	for path := range fixtures {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for _, path := range paths {
+		object := fixtures[path]
+		if object == nil {
+			object = map[string]interface{}{}
+		}
+		err = s.put(strings.Trim(path, "/"), object)
+		if err != nil {
+			return fmt.Errorf("Failed to load fixture '%s': %v", path, err)
+		}
+	}
+	return nil
+}
+
+// LoadFile stores the objects of the fixtures file with the given name. See Load for the format
+// of the file.
+func (s *Server) LoadFile(name string) error {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return err
+	}
+	return s.Load(data)
+}
+
+// defaultFixtures are the objects that the server contains when it is created.
+var defaultFixtures = []byte(`
+accounts_mgmt/v1/current_account:
+  id: fake-account
+  username: fake-user
+  email: fake-user@example.com
+  first_name: Fake
+  last_name: User
+  organization:
+    kind: Organization
+    id: fake-organization
+    href: /api/accounts_mgmt/v1/organizations/fake-organization
+    external_id: "12345678"
+    name: Fake Organization
+
+accounts_mgmt/v1/organizations/fake-organization:
+  external_id: "12345678"
+  name: Fake Organization
+  capabilities:
+  - name: capability.organization.hibernate_cluster
+    value: "true"
+    inherited: false
+
+clusters_mgmt/v1/versions/openshift-v4.14.30:
+  raw_id: 4.14.30
+  enabled: true
+  rosa_enabled: true
+  hosted_control_plane_enabled: true
+  channel_group: stable
+  available_upgrades:
+  - 4.15.20
+  - 4.16.0
+
+clusters_mgmt/v1/versions/openshift-v4.15.20:
+  raw_id: 4.15.20
+  enabled: true
+  rosa_enabled: true
+  hosted_control_plane_enabled: true
+  channel_group: stable
+  available_upgrades:
+  - 4.16.0
+
+clusters_mgmt/v1/versions/openshift-v4.16.0:
+  raw_id: 4.16.0
+  enabled: true
+  default: true
+  rosa_enabled: true
+  hosted_control_plane_enabled: true
+  channel_group: stable
+  available_upgrades: []
+
+clusters_mgmt/v1/cloud_providers/aws:
+  name: aws
+  display_name: AWS
+
+clusters_mgmt/v1/cloud_providers/aws/regions/us-east-1:
+  display_name: US East, N. Virginia
+  enabled: true
+  supports_multi_az: true
+  supports_hypershift: true
+  ccs_only: false
+  cloud_provider:
+    kind: CloudProviderLink
+    id: aws
+
+clusters_mgmt/v1/cloud_providers/aws/regions/us-west-2:
+  display_name: US West, Oregon
+  enabled: true
+  supports_multi_az: true
+  supports_hypershift: true
+  ccs_only: false
+  cloud_provider:
+    kind: CloudProviderLink
+    id: aws
+
+addons_mgmt/v1/addons/cluster-logging-operator:
+  name: Cluster Logging Operator
+  description: Fake add-on used for testing.
+  enabled: true
+  install_mode: own_namespace
+  target_namespace: openshift-logging
+  resource_name: addon-cluster-logging-operator
+  resource_cost: 1
+`)
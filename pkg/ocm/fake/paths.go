@@ -0,0 +1,230 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the functions that classify the paths of the API and the objects that they
+// point to.
+
+package fake
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+type targetKind int
+
+const (
+	collectionTarget targetKind = iota
+	itemTarget
+	singletonTarget
+	actionTarget
+)
+
+// target describes what a path points to. For items 'parent' is the path of the collection that
+// contains the item and 'id' its identifier.
+type target struct {
+	kind   targetKind
+	path   string
+	parent string
+	id     string
+	name   string
+}
+
+// singletonNames are the names of the path segments that point to a single object instead of to
+// a collection.
+var singletonNames = map[string]bool{
+	"autoscaler":             true,
+	"control_plane":          true,
+	"credentials":            true,
+	"current_account":        true,
+	"delete_protection":      true,
+	"external_configuration": true,
+	"kubelet_config":         true,
+	"state":                  true,
+	"status":                 true,
+}
+
+// actionNames are the names of the path segments that trigger an action on the object that
+// contains them.
+var actionNames = map[string]bool{
+	"hibernate": true,
+	"resume":    true,
+}
+
+// kindNames contains the kinds of the objects that can't be calculated from the name of the
+// collection or singleton. The keys are the name of the service followed by the name of the
+// collection or singleton, optionally preceded by the name of the collection that contains it.
+var kindNames = map[string]string{
+	"addons_mgmt/addons":                           "Addon",
+	"addons_mgmt/clusters/addons":                  "AddonInstallation",
+	"clusters_mgmt/clusters/addons":                "AddOnInstallation",
+	"clusters_mgmt/node_pools/upgrade_policies":    "NodePoolUpgradePolicy",
+	"clusters_mgmt/control_plane/upgrade_policies": "ControlPlaneUpgradePolicy",
+	"accounts_mgmt/current_account":                "Account",
+	"clusters_mgmt/autoscaler":                     "ClusterAutoscaler",
+	"clusters_mgmt/regions":                        "CloudRegion",
+	"clusters_mgmt/state":                          "UpgradePolicyState",
+	"clusters_mgmt/status":                         "ClusterStatus",
+	"clusters_mgmt/ingresses":                      "Ingress",
+	"clusters_mgmt/dns_domains":                    "DNSDomain",
+	"accounts_mgmt/quota_cost":                     "QuotaCost",
+}
+
+// parsePath classifies the given path, relative to '/api/'. The first two segments are the name
+// of the service and the version. The rest alternate the name of a collection and the identifier
+// of an item, except for the names of singletons and actions, which aren't followed by an
+// identifier.
+func parsePath(path string) (*target, error) {
+	segments := strings.Split(path, "/")
+	if len(segments) < 3 {
+		return nil, fmt.Errorf("Path '%s' not found", path)
+	}
+	for _, segment := range segments {
+		if segment == "" {
+			return nil, fmt.Errorf("Path '%s' not found", path)
+		}
+	}
+	i := 2
+	for {
+		name := segments[i]
+		last := i == len(segments)-1
+		switch {
+		case singletonNames[name] && last:
+			return &target{kind: singletonTarget, path: path, name: name}, nil
+		case singletonNames[name]:
+			i++
+			continue
+		case actionNames[name] && last:
+			return &target{kind: actionTarget, path: path, name: name}, nil
+		case actionNames[name]:
+			return nil, fmt.Errorf("Path '%s' not found", path)
+		case last:
+			return &target{kind: collectionTarget, path: path, name: name}, nil
+		case i+1 == len(segments)-1:
+			return &target{
+				kind:   itemTarget,
+				path:   path,
+				parent: strings.Join(segments[:i+1], "/"),
+				id:     segments[i+1],
+				name:   name,
+			}, nil
+		}
+		i += 2
+	}
+}
+
+// ownerOf returns the path of the nearest item that contains the object or collection with the
+// given path, or an empty string if it is a top level object or collection. The objects inside the
+// 'addons_mgmt/v1/clusters' collection belong to the clusters of 'clusters_mgmt/v1/clusters'.
+func ownerOf(path string) string {
+	index := strings.LastIndex(path, "/")
+	if index == -1 {
+		return ""
+	}
+	owner := path[:index]
+	segments := strings.Split(owner, "/")
+	if len(segments) < 3 {
+		return ""
+	}
+	target, err := parsePath(owner)
+	if err != nil || target.kind != itemTarget {
+		return ownerOf(owner)
+	}
+	if segments[0] == "addons_mgmt" && len(segments) == 4 && segments[2] == "clusters" {
+		return "clusters_mgmt/v1/clusters/" + segments[3]
+	}
+	return owner
+}
+
+// kindOf returns the kind of the objects of the collection or of the singleton with the given
+// path. For example, the kind of 'clusters_mgmt/v1/clusters/123/machine_pools' is 'MachinePool'.
+func kindOf(path string) string {
+	segments := strings.Split(path, "/")
+	name := segments[len(segments)-1]
+	if len(segments) > 2 {
+		if len(segments) > 4 {
+			parent := segments[len(segments)-3]
+			if singletonNames[segments[len(segments)-2]] {
+				parent = segments[len(segments)-2]
+			}
+			kind, ok := kindNames[segments[0]+"/"+parent+"/"+name]
+			if ok {
+				return kind
+			}
+		}
+		if kind, ok := kindNames[segments[0]+"/"+name]; ok {
+			return kind
+		}
+	}
+	if !singletonNames[name] {
+		switch {
+		case strings.HasSuffix(name, "ies"):
+			name = strings.TrimSuffix(name, "ies") + "y"
+		case strings.HasSuffix(name, "s"):
+			name = strings.TrimSuffix(name, "s")
+		}
+	}
+	result := &strings.Builder{}
+	for _, word := range strings.Split(name, "_") {
+		if word == "" {
+			continue
+		}
+		result.WriteString(strings.ToUpper(word[:1]))
+		result.WriteString(word[1:])
+	}
+	return result.String()
+}
+
+// normalize converts an object to the representation used to store it, where numbers are
+// json.Number values and nested objects are maps.
+func normalize(object map[string]interface{}) map[string]interface{} {
+	return deepCopy(object)
+}
+
+// deepCopy returns a copy of the object that shares nothing with the original.
+func deepCopy(object map[string]interface{}) map[string]interface{} {
+	data, err := json.Marshal(object)
+	if err != nil {
+		panic(fmt.Sprintf("Failed to copy object: %v", err))
+	}
+	result := map[string]interface{}{}
+	decoder := json.NewDecoder(strings.NewReader(string(data)))
+	decoder.UseNumber()
+	err = decoder.Decode(&result)
+	if err != nil {
+		panic(fmt.Sprintf("Failed to copy object: %v", err))
+	}
+	return result
+}
+
+// merge applies a patch to an object. Nested objects are merged recursively, null values remove
+// the field and any other value replaces the existing one.
+func merge(object map[string]interface{}, patch map[string]interface{}) {
+	for name, value := range patch {
+		if value == nil {
+			delete(object, name)
+			continue
+		}
+		nestedPatch, isMap := value.(map[string]interface{})
+		nestedObject, wasMap := object[name].(map[string]interface{})
+		if isMap && wasMap {
+			merge(nestedObject, nestedPatch)
+			continue
+		}
+		object[name] = value
+	}
+}
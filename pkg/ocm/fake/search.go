@@ -0,0 +1,442 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the evaluation of the subset of the OCM search language that is used by
+// the 'search' and 'order' parameters of the collections.
+
+package fake
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// predicate is a compiled search expression.
+type predicate func(object map[string]interface{}) bool
+
+// parseSearch compiles a search expression like the ones accepted by the OCM API, for example
+// "product.id = 'rosa' AND (id = 'abc' OR name LIKE 'my%')". An empty expression matches all the
+// objects.
+func parseSearch(text string) (predicate, error) {
+	if strings.TrimSpace(text) == "" {
+		return func(map[string]interface{}) bool { return true }, nil
+	}
+	tokens, err := tokenize(text)
+	if err != nil {
+		return nil, err
+	}
+	parser := &searchParser{tokens: tokens}
+	result, err := parser.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if !parser.done() {
+		return nil, fmt.Errorf("Unexpected '%s' in search '%s'", parser.peek().text, text)
+	}
+	return result, nil
+}
+
+type tokenKind int
+
+const (
+	identToken tokenKind = iota
+	stringToken
+	symbolToken
+)
+
+type token struct {
+	kind tokenKind
+	text string
+}
+
+func tokenize(text string) ([]token, error) {
+	tokens := []token{}
+	runes := []rune(text)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '\'':
+			value := &strings.Builder{}
+			i++
+			for {
+				if i >= len(runes) {
+					return nil, fmt.Errorf("Unterminated string in search '%s'", text)
+				}
+				if runes[i] == '\'' {
+					if i+1 < len(runes) && runes[i+1] == '\'' {
+						value.WriteRune('\'')
+						i += 2
+						continue
+					}
+					i++
+					break
+				}
+				value.WriteRune(runes[i])
+				i++
+			}
+			tokens = append(tokens, token{kind: stringToken, text: value.String()})
+		case r == '(' || r == ')' || r == ',':
+			tokens = append(tokens, token{kind: symbolToken, text: string(r)})
+			i++
+		case r == '=' || r == '!' || r == '<' || r == '>':
+			operator := string(r)
+			if i+1 < len(runes) && (runes[i+1] == '=' || (r == '<' && runes[i+1] == '>')) {
+				operator += string(runes[i+1])
+			}
+			if operator == "!" {
+				return nil, fmt.Errorf("Unexpected '!' in search '%s'", text)
+			}
+			tokens = append(tokens, token{kind: symbolToken, text: operator})
+			i += len(operator)
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("_.-", r):
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) ||
+				strings.ContainsRune("_.-", runes[i])) {
+				i++
+			}
+			tokens = append(tokens, token{kind: identToken, text: string(runes[start:i])})
+		default:
+			return nil, fmt.Errorf("Unexpected '%c' in search '%s'", r, text)
+		}
+	}
+	return tokens, nil
+}
+
+type searchParser struct {
+	tokens []token
+	next   int
+}
+
+func (p *searchParser) done() bool {
+	return p.next >= len(p.tokens)
+}
+
+func (p *searchParser) peek() token {
+	if p.done() {
+		return token{}
+	}
+	return p.tokens[p.next]
+}
+
+// accept consumes the next token if it is the given keyword or symbol.
+func (p *searchParser) accept(text string) bool {
+	next := p.peek()
+	if next.kind != stringToken && strings.EqualFold(next.text, text) && !p.done() {
+		p.next++
+		return true
+	}
+	return false
+}
+
+func (p *searchParser) expect(text string) error {
+	if !p.accept(text) {
+		return fmt.Errorf("Expected '%s' but found '%s'", text, p.peek().text)
+	}
+	return nil
+}
+
+func (p *searchParser) parseOr() (predicate, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept("or") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		first := left
+		left = func(object map[string]interface{}) bool {
+			return first(object) || right(object)
+		}
+	}
+	return left, nil
+}
+
+func (p *searchParser) parseAnd() (predicate, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept("and") {
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		first := left
+		left = func(object map[string]interface{}) bool {
+			return first(object) && right(object)
+		}
+	}
+	return left, nil
+}
+
+func (p *searchParser) parseUnary() (predicate, error) {
+	if p.accept("not") {
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return func(object map[string]interface{}) bool {
+			return !operand(object)
+		}, nil
+	}
+	if p.accept("(") {
+		result, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		return result, p.expect(")")
+	}
+	return p.parseComparison()
+}
+
+func (p *searchParser) parseComparison() (predicate, error) {
+	field := p.peek()
+	if field.kind != identToken {
+		return nil, fmt.Errorf("Expected field name but found '%s'", field.text)
+	}
+	p.next++
+	negated := p.accept("not")
+	switch {
+	case p.accept("is"):
+		negated = p.accept("not")
+		err := p.expect("null")
+		if err != nil {
+			return nil, err
+		}
+		return func(object map[string]interface{}) bool {
+			_, ok := lookup(object, field.text)
+			return ok == negated
+		}, nil
+	case p.accept("in"):
+		err := p.expect("(")
+		if err != nil {
+			return nil, err
+		}
+		values := []string{}
+		for {
+			value, err := p.parseValue()
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+			if !p.accept(",") {
+				break
+			}
+		}
+		err = p.expect(")")
+		if err != nil {
+			return nil, err
+		}
+		return func(object map[string]interface{}) bool {
+			for _, value := range values {
+				if compare(object, field.text, "=", value) {
+					return !negated
+				}
+			}
+			return negated
+		}, nil
+	case p.accept("like"), p.accept("ilike"):
+		insensitive := strings.EqualFold(p.tokens[p.next-1].text, "ilike")
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		pattern := likePattern(value, insensitive)
+		return func(object map[string]interface{}) bool {
+			actual, ok := lookup(object, field.text)
+			if !ok {
+				return false
+			}
+			return pattern.MatchString(stringValue(actual)) != negated
+		}, nil
+	}
+	if negated {
+		return nil, fmt.Errorf("Expected 'in' or 'like' after 'not' but found '%s'", p.peek().text)
+	}
+	operator := p.peek()
+	if operator.kind != symbolToken || operator.text == "(" || operator.text == ")" || operator.text == "," {
+		return nil, fmt.Errorf("Expected operator after '%s' but found '%s'", field.text, operator.text)
+	}
+	p.next++
+	value, err := p.parseValue()
+	if err != nil {
+		return nil, err
+	}
+	return func(object map[string]interface{}) bool {
+		return compare(object, field.text, operator.text, value)
+	}, nil
+}
+
+func (p *searchParser) parseValue() (string, error) {
+	value := p.peek()
+	if value.kind == symbolToken || p.done() {
+		return "", fmt.Errorf("Expected value but found '%s'", value.text)
+	}
+	p.next++
+	return value.text, nil
+}
+
+// lookup returns the value of the field with the given dot separated path.
+func lookup(object map[string]interface{}, path string) (interface{}, bool) {
+	var current interface{} = object
+	for _, name := range strings.Split(path, ".") {
+		fields, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		current, ok = fields[name]
+		if !ok || current == nil {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// stringValue converts a value of a JSON document to the text used to compare it.
+func stringValue(value interface{}) string {
+	switch typed := value.(type) {
+	case string:
+		return typed
+	case bool:
+		return strconv.FormatBool(typed)
+	case json.Number:
+		return typed.String()
+	case float64:
+		return strconv.FormatFloat(typed, 'f', -1, 64)
+	default:
+		data, _ := json.Marshal(typed)
+		return string(data)
+	}
+}
+
+// compare evaluates a comparison between the field of the object and the literal value. Boolean
+// fields also accept the 't' and 'f' literals, and numbers are compared numerically.
+func compare(object map[string]interface{}, path string, operator string, value string) bool {
+	actual, ok := lookup(object, path)
+	if !ok {
+		return operator == "!=" || operator == "<>"
+	}
+	if _, isBool := actual.(bool); isBool {
+		switch strings.ToLower(value) {
+		case "t":
+			value = "true"
+		case "f":
+			value = "false"
+		}
+	}
+	result := compareValues(stringValue(actual), value)
+	switch operator {
+	case "=":
+		return result == 0
+	case "!=", "<>":
+		return result != 0
+	case "<":
+		return result < 0
+	case "<=":
+		return result <= 0
+	case ">":
+		return result > 0
+	case ">=":
+		return result >= 0
+	}
+	return false
+}
+
+func compareValues(left string, right string) int {
+	leftNumber, leftErr := strconv.ParseFloat(left, 64)
+	rightNumber, rightErr := strconv.ParseFloat(right, 64)
+	if leftErr == nil && rightErr == nil {
+		switch {
+		case leftNumber < rightNumber:
+			return -1
+		case leftNumber > rightNumber:
+			return 1
+		}
+		return 0
+	}
+	return strings.Compare(left, right)
+}
+
+func likePattern(value string, insensitive bool) *regexp.Regexp {
+	expression := &strings.Builder{}
+	if insensitive {
+		expression.WriteString("(?i)")
+	}
+	expression.WriteString("^")
+	for _, r := range value {
+		switch r {
+		case '%':
+			expression.WriteString(".*")
+		case '_':
+			expression.WriteString(".")
+		default:
+			expression.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	expression.WriteString("$")
+	return regexp.MustCompile(expression.String())
+}
+
+// sortObjects sorts the objects according to an order expression like the ones accepted by the
+// OCM API, for example "default desc, id desc".
+func sortObjects(objects []map[string]interface{}, order string) error {
+	type criterion struct {
+		field      string
+		descending bool
+	}
+	criteria := []criterion{}
+	for _, part := range strings.Split(order, ",") {
+		words := strings.Fields(part)
+		switch {
+		case len(words) == 0:
+			continue
+		case len(words) == 1:
+			criteria = append(criteria, criterion{field: words[0]})
+		case len(words) == 2 && (strings.EqualFold(words[1], "asc") || strings.EqualFold(words[1], "desc")):
+			criteria = append(criteria, criterion{
+				field:      words[0],
+				descending: strings.EqualFold(words[1], "desc"),
+			})
+		default:
+			return fmt.Errorf("Invalid order '%s'", order)
+		}
+	}
+	sort.SliceStable(objects, func(i, j int) bool {
+		for _, criterion := range criteria {
+			left, _ := lookup(objects[i], criterion.field)
+			right, _ := lookup(objects[j], criterion.field)
+			result := compareValues(stringValue(left), stringValue(right))
+			if result == 0 {
+				continue
+			}
+			if criterion.descending {
+				return result > 0
+			}
+			return result < 0
+		}
+		return false
+	})
+	return nil
+}
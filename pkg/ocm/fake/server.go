@@ -0,0 +1,509 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fake contains an in-memory implementation of the parts of the clusters_mgmt,
+// accounts_mgmt and addons_mgmt APIs used by the command line tool. It keeps the objects created,
+// updated and deleted by the requests, and moves clusters, upgrade policies and add-on
+// installations through their states, so that complete workflows can be tested without a real
+// OCM environment:
+//
+//	server := fake.NewServer()
+//	defer server.Close()
+//	client, err := server.Client()
+//	...
+//	cluster, err := client.CreateCluster(spec)
+//	server.Advance()
+//	server.Advance()
+//	cluster, err = client.GetClusterByID(cluster.ID(), nil)
+//	// cluster.State() is now 'ready'
+package fake
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	sdk "github.com/openshift-online/ocm-sdk-go"
+
+	"github.com/openshift/rosa/pkg/cassette"
+	"github.com/openshift/rosa/pkg/ocm"
+)
+
+// Server is the fake OCM server. Objects are identified by their paths relative to '/api/', for
+// example 'clusters_mgmt/v1/clusters/123/machine_pools/worker'.
+type Server struct {
+	lock          sync.Mutex
+	server        *httptest.Server
+	collections   map[string]*collection
+	singletons    map[string]map[string]interface{}
+	advanceOnRead bool
+	lastID        int
+	lastOperation int
+	requests      []string
+}
+
+// collection is the set of objects stored in a collection, in the order they were created.
+type collection struct {
+	ids     []string
+	objects map[string]map[string]interface{}
+}
+
+// NewServer creates and starts a fake server loaded with the default fixtures, which contain the
+// current account, its organization, some versions and some regions.
+func NewServer() *Server {
+	s := &Server{
+		collections: map[string]*collection{},
+		singletons:  map[string]map[string]interface{}{},
+	}
+	err := s.Load(defaultFixtures)
+	if err != nil {
+		panic(fmt.Sprintf("Failed to load default fixtures: %v", err))
+	}
+	s.server = httptest.NewServer(s)
+	return s
+}
+
+// URL returns the URL of the server.
+func (s *Server) URL() string {
+	return s.server.URL
+}
+
+// Close stops the server.
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// Connection creates an OCM SDK connection to the server.
+func (s *Server) Connection() (*sdk.Connection, error) {
+	return sdk.NewConnectionBuilder().
+		Tokens(cassette.FakeToken("Bearer")).
+		URL(s.URL()).
+		Build()
+}
+
+// Client creates an OCM client that sends its requests to the server.
+func (s *Server) Client() (*ocm.Client, error) {
+	connection, err := s.Connection()
+	if err != nil {
+		return nil, err
+	}
+	return ocm.NewClientWithConnection(connection), nil
+}
+
+// SetAdvanceOnRead configures the server so that it calls Advance before answering each GET request.
+// This is useful to test commands that poll until an object reaches a state.
+func (s *Server) SetAdvanceOnRead(value bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.advanceOnRead = value
+}
+
+// Advance moves all the clusters, upgrade policies and add-on installations to their next state.
+func (s *Server) Advance() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.advance()
+}
+
+// Object returns a copy of the object with the given path.
+func (s *Server) Object(path string) (map[string]interface{}, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	object := s.get(strings.Trim(path, "/"))
+	if object == nil {
+		return nil, false
+	}
+	return deepCopy(object), true
+}
+
+// Objects returns copies of the objects of the collection with the given path.
+func (s *Server) Objects(path string) []map[string]interface{} {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	result := []map[string]interface{}{}
+	for _, object := range s.list(strings.Trim(path, "/")) {
+		result = append(result, deepCopy(object))
+	}
+	return result
+}
+
+// Put stores an object with the given path, replacing the existing one. The path can be the path
+// of an object of a collection or the path of a singleton like 'accounts_mgmt/v1/current_account'.
+func (s *Server) Put(path string, object map[string]interface{}) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.put(strings.Trim(path, "/"), normalize(object))
+}
+
+// Delete removes the object with the given path, and all the objects nested inside it, without
+// going through the uninstall or delete states.
+func (s *Server) Delete(path string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.remove(strings.Trim(path, "/"))
+}
+
+// Requests returns the method and path of each request received, for example 'GET
+// /api/clusters_mgmt/v1/clusters'.
+func (s *Server) Requests() []string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return append([]string{}, s.requests...)
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.requests = append(s.requests, r.Method+" "+r.URL.Path)
+	if !strings.HasPrefix(r.URL.Path, "/api/") {
+		s.sendError(w, "", http.StatusNotFound, "Path '%s' not found", r.URL.Path)
+		return
+	}
+	path := strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/"), "/")
+	target, err := parsePath(path)
+	if err != nil {
+		s.sendError(w, path, http.StatusNotFound, "%v", err)
+		return
+	}
+	if r.Method == http.MethodGet && s.advanceOnRead {
+		s.advance()
+	}
+	switch {
+	case r.Method == http.MethodGet && target.kind == collectionTarget:
+		s.serveList(w, r, target)
+	case r.Method == http.MethodGet && target.kind != actionTarget:
+		s.serveGet(w, target)
+	case r.Method == http.MethodPost && target.kind == collectionTarget:
+		s.serveAdd(w, r, target)
+	case r.Method == http.MethodPost && target.kind == actionTarget:
+		s.serveAction(w, target)
+	case r.Method == http.MethodPatch && (target.kind == itemTarget || target.kind == singletonTarget):
+		s.serveUpdate(w, r, target)
+	case r.Method == http.MethodDelete && (target.kind == itemTarget || target.kind == singletonTarget):
+		s.serveDelete(w, target)
+	default:
+		s.sendError(w, path, http.StatusMethodNotAllowed, "Method '%s' isn't supported for path '%s'",
+			r.Method, r.URL.Path)
+	}
+}
+
+func (s *Server) serveList(w http.ResponseWriter, r *http.Request, target *target) {
+	query := r.URL.Query()
+	match, err := parseSearch(query.Get("search"))
+	if err != nil {
+		s.sendError(w, target.path, http.StatusBadRequest, "%v", err)
+		return
+	}
+	items := []map[string]interface{}{}
+	for _, object := range s.list(target.path) {
+		if match(object) {
+			items = append(items, object)
+		}
+	}
+	err = sortObjects(items, query.Get("order"))
+	if err != nil {
+		s.sendError(w, target.path, http.StatusBadRequest, "%v", err)
+		return
+	}
+	total := len(items)
+	page, err := intParameter(query.Get("page"), 1)
+	if err != nil || page < 1 {
+		s.sendError(w, target.path, http.StatusBadRequest, "Invalid page '%s'", query.Get("page"))
+		return
+	}
+	size, err := intParameter(query.Get("size"), 100)
+	if err != nil {
+		s.sendError(w, target.path, http.StatusBadRequest, "Invalid size '%s'", query.Get("size"))
+		return
+	}
+	if size >= 0 {
+		start := (page - 1) * size
+		if start > len(items) {
+			start = len(items)
+		}
+		end := start + size
+		if end > len(items) {
+			end = len(items)
+		}
+		items = items[start:end]
+	}
+	s.send(w, http.StatusOK, map[string]interface{}{
+		"kind":  kindOf(target.path) + "List",
+		"href":  "/api/" + target.path,
+		"page":  page,
+		"size":  len(items),
+		"total": total,
+		"items": items,
+	})
+}
+
+func (s *Server) serveGet(w http.ResponseWriter, target *target) {
+	object := s.get(target.path)
+	if object == nil {
+		s.sendNotFound(w, target)
+		return
+	}
+	s.send(w, http.StatusOK, object)
+}
+
+func (s *Server) serveAdd(w http.ResponseWriter, r *http.Request, target *target) {
+	object, err := readObject(r)
+	if err != nil {
+		s.sendError(w, target.path, http.StatusBadRequest, "%v", err)
+		return
+	}
+	dryRun := r.URL.Query().Get("dryRun") == "true"
+	status, err := s.create(target.path, object, dryRun)
+	if err != nil {
+		s.sendError(w, target.path, status, "%v", err)
+		return
+	}
+	if dryRun {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	s.send(w, http.StatusCreated, object)
+}
+
+func (s *Server) serveUpdate(w http.ResponseWriter, r *http.Request, target *target) {
+	object := s.get(target.path)
+	if object == nil {
+		s.sendNotFound(w, target)
+		return
+	}
+	patch, err := readObject(r)
+	if err != nil {
+		s.sendError(w, target.path, http.StatusBadRequest, "%v", err)
+		return
+	}
+	delete(patch, "id")
+	delete(patch, "kind")
+	delete(patch, "href")
+	merge(object, patch)
+	s.send(w, http.StatusOK, object)
+}
+
+func (s *Server) serveDelete(w http.ResponseWriter, target *target) {
+	object := s.get(target.path)
+	if object == nil {
+		s.sendNotFound(w, target)
+		return
+	}
+	err := s.checkDeletion(target.path, object)
+	if err != nil {
+		s.sendError(w, target.path, http.StatusBadRequest, "%v", err)
+		return
+	}
+	if !s.startDeletion(target.path, object) {
+		s.remove(target.path)
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) serveAction(w http.ResponseWriter, target *target) {
+	owner := ownerOf(target.path)
+	object := s.get(owner)
+	if object == nil {
+		s.sendError(w, target.path, http.StatusNotFound, "Resource '%s' not found", target.path)
+		return
+	}
+	err := s.act(owner, object, target.name)
+	if err != nil {
+		s.sendError(w, target.path, http.StatusBadRequest, "%v", err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) sendNotFound(w http.ResponseWriter, target *target) {
+	if target.kind == itemTarget {
+		s.sendError(w, target.path, http.StatusNotFound, "%s '%s' not found", kindOf(target.parent), target.id)
+		return
+	}
+	s.sendError(w, target.path, http.StatusNotFound, "Resource '%s' not found", target.path)
+}
+
+// sendError sends an error in the format used by the OCM API.
+func (s *Server) sendError(w http.ResponseWriter, path string, status int, format string,
+	args ...interface{}) {
+	service := strings.SplitN(path, "/", 2)[0]
+	if service == "" {
+		service = "api"
+	}
+	prefix := strings.ToUpper(strings.ReplaceAll(service, "_", "-"))
+	s.lastOperation++
+	s.send(w, status, map[string]interface{}{
+		"kind":         "Error",
+		"id":           strconv.Itoa(status),
+		"href":         fmt.Sprintf("/api/%s/v1/errors/%d", service, status),
+		"code":         fmt.Sprintf("%s-%d", prefix, status),
+		"reason":       fmt.Sprintf(format, args...),
+		"operation_id": fmt.Sprintf("%032x", s.lastOperation),
+	})
+}
+
+func (s *Server) send(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	_ = encoder.Encode(body)
+}
+
+func readObject(r *http.Request) (map[string]interface{}, error) {
+	object := map[string]interface{}{}
+	if r.Body == nil {
+		return object, nil
+	}
+	decoder := json.NewDecoder(r.Body)
+	decoder.UseNumber()
+	err := decoder.Decode(&object)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse request body: %v", err)
+	}
+	return object, nil
+}
+
+func intParameter(value string, defaultValue int) (int, error) {
+	if value == "" {
+		return defaultValue, nil
+	}
+	return strconv.Atoi(value)
+}
+
+// get returns the object with the given path, or nil if it doesn't exist.
+func (s *Server) get(path string) map[string]interface{} {
+	target, err := parsePath(path)
+	if err != nil {
+		return nil
+	}
+	switch target.kind {
+	case itemTarget:
+		items := s.collections[target.parent]
+		if items == nil {
+			return nil
+		}
+		return items.objects[target.id]
+	case singletonTarget:
+		return s.singletons[target.path]
+	}
+	return nil
+}
+
+// list returns the objects of the collection with the given path, in the order they were created.
+func (s *Server) list(path string) []map[string]interface{} {
+	result := []map[string]interface{}{}
+	items := s.collections[path]
+	if items == nil {
+		return result
+	}
+	for _, id := range items.ids {
+		result = append(result, items.objects[id])
+	}
+	return result
+}
+
+// put stores the object with the given path, setting its 'kind', 'id' and 'href' fields.
+func (s *Server) put(path string, object map[string]interface{}) error {
+	target, err := parsePath(path)
+	if err != nil {
+		return err
+	}
+	switch target.kind {
+	case itemTarget:
+		object["kind"] = kindOf(target.parent)
+		object["id"] = target.id
+		object["href"] = "/api/" + path
+		items := s.collections[target.parent]
+		if items == nil {
+			items = &collection{objects: map[string]map[string]interface{}{}}
+			s.collections[target.parent] = items
+		}
+		if items.objects[target.id] == nil {
+			items.ids = append(items.ids, target.id)
+		}
+		items.objects[target.id] = object
+	case singletonTarget:
+		if _, ok := object["kind"]; !ok {
+			object["kind"] = kindOf(path)
+		}
+		object["href"] = "/api/" + path
+		s.singletons[path] = object
+	default:
+		return fmt.Errorf("Path '%s' is a collection", path)
+	}
+	return nil
+}
+
+// remove deletes the object with the given path and all the objects nested inside it.
+func (s *Server) remove(path string) {
+	target, err := parsePath(path)
+	if err != nil {
+		return
+	}
+	if target.kind == itemTarget {
+		items := s.collections[target.parent]
+		if items != nil && items.objects[target.id] != nil {
+			delete(items.objects, target.id)
+			for i, id := range items.ids {
+				if id == target.id {
+					items.ids = append(items.ids[:i], items.ids[i+1:]...)
+					break
+				}
+			}
+		}
+	}
+	delete(s.singletons, path)
+	for nested := range s.collections {
+		if strings.HasPrefix(nested, path+"/") {
+			delete(s.collections, nested)
+		}
+	}
+	for nested := range s.singletons {
+		if strings.HasPrefix(nested, path+"/") {
+			delete(s.singletons, nested)
+		}
+	}
+}
+
+// newID generates a new identifier. Identifiers are generated in sequence so that tests can
+// predict them.
+func (s *Server) newID() string {
+	s.lastID++
+	return fmt.Sprintf("%032x", s.lastID)
+}
+
+// sortedPaths returns the paths of the collections sorted, so that the objects are always
+// processed in the same order.
+func (s *Server) sortedPaths() []string {
+	paths := make([]string, 0, len(s.collections))
+	for path := range s.collections {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+func now() string {
+	return time.Now().UTC().Format(time.RFC3339)
+}
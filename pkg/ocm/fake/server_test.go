@@ -0,0 +1,216 @@
+package fake
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/ocm"
+)
+
+var _ = Describe("Server", func() {
+	var server *Server
+	var client *ocm.Client
+	var creator *aws.Creator
+
+	BeforeEach(func() {
+		var err error
+		server = NewServer()
+		client, err = server.Client()
+		Expect(err).NotTo(HaveOccurred())
+		creator = &aws.Creator{
+			ARN:       "arn:aws:iam::123456789012:user/fake",
+			AccountID: "123456789012",
+		}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	specFor := func(name string) ocm.Spec {
+		dryRun := false
+		return ocm.Spec{
+			Name:       name,
+			Region:     "us-east-1",
+			AWSCreator: creator,
+			IsSTS:      true,
+			RoleARN:    "arn:aws:iam::123456789012:role/ManagedOpenShift-Installer-Role",
+			DryRun:     &dryRun,
+		}
+	}
+
+	createCluster := func(name string) *cmv1.Cluster {
+		cluster, err := client.CreateCluster(specFor(name))
+		Expect(err).NotTo(HaveOccurred())
+		return cluster
+	}
+
+	It("Runs a cluster through create, machine pool, upgrade and delete", func() {
+		cluster := createCluster("my-cluster")
+		Expect(cluster.State()).To(Equal(cmv1.ClusterStatePending))
+		Expect(cluster.Subscription().ID()).NotTo(BeEmpty())
+
+		server.Advance()
+		state, err := client.GetClusterState(cluster.ID())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(state).To(Equal(cmv1.ClusterStateInstalling))
+
+		server.Advance()
+		cluster, err = client.GetCluster("my-cluster", creator)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cluster.State()).To(Equal(cmv1.ClusterStateReady))
+		Expect(cluster.ExternalID()).NotTo(BeEmpty())
+		Expect(cluster.Console().URL()).To(ContainSubstring("my-cluster"))
+
+		machinePool, err := cmv1.NewMachinePool().ID("extra").Replicas(3).InstanceType("m5.xlarge").Build()
+		Expect(err).NotTo(HaveOccurred())
+		_, err = client.CreateMachinePool(cluster.ID(), machinePool)
+		Expect(err).NotTo(HaveOccurred())
+		machinePools, err := client.GetMachinePools(cluster.ID())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(machinePools).To(HaveLen(1))
+		Expect(machinePools[0].Replicas()).To(Equal(3))
+
+		policy, err := cmv1.NewUpgradePolicy().
+			UpgradeType(cmv1.UpgradeTypeOSD).
+			ScheduleType(cmv1.ScheduleTypeManual).
+			Version("4.16.0").
+			Build()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(client.ScheduleUpgrade(cluster.ID(), policy)).To(Succeed())
+		_, policyState, err := client.GetScheduledUpgrade(cluster.ID())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(policyState.Value()).To(Equal(cmv1.UpgradePolicyStateValuePending))
+		for i := 0; i < 3; i++ {
+			server.Advance()
+		}
+		_, policyState, err = client.GetScheduledUpgrade(cluster.ID())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(policyState.Value()).To(Equal(cmv1.UpgradePolicyStateValueCompleted))
+		server.Advance()
+		policies, err := client.GetUpgradePolicies(cluster.ID())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(policies).To(BeEmpty())
+		cluster, err = client.GetClusterByID(cluster.ID(), creator)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(cluster.Version().RawID()).To(Equal("4.16.0"))
+
+		_, err = client.DeleteCluster("my-cluster", false, creator)
+		Expect(err).NotTo(HaveOccurred())
+		state, err = client.GetClusterState(cluster.ID())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(state).To(Equal(cmv1.ClusterStateUninstalling))
+		server.Advance()
+		_, err = client.GetCluster("my-cluster", creator)
+		Expect(err).To(MatchError(ContainSubstring("There is no cluster with identifier or name 'my-cluster'")))
+		_, ok := server.Object("clusters_mgmt/v1/clusters/" + cluster.ID() + "/machine_pools/extra")
+		Expect(ok).To(BeFalse())
+		subscription, ok := server.Object("accounts_mgmt/v1/subscriptions/" + cluster.Subscription().ID())
+		Expect(ok).To(BeTrue())
+		Expect(subscription["status"]).To(Equal("Deprovisioned"))
+	})
+
+	It("Installs and uninstalls add-ons", func() {
+		cluster := createCluster("my-cluster")
+		billing := ocm.AddOnBilling{BillingModel: "standard"}
+		Expect(client.InstallAddOn(cluster.ID(), "cluster-logging-operator", nil, billing)).To(Succeed())
+		installation, err := client.GetAddOnInstallation(cluster.ID(), "cluster-logging-operator")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(installation.State())).To(Equal("installing"))
+		server.Advance()
+		installation, err = client.GetAddOnInstallation(cluster.ID(), "cluster-logging-operator")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(installation.State())).To(Equal("ready"))
+
+		Expect(client.UninstallAddOn(cluster.ID(), "cluster-logging-operator")).To(Succeed())
+		server.Advance()
+		_, ok := server.Object("addons_mgmt/v1/clusters/" + cluster.ID() + "/addons/cluster-logging-operator")
+		Expect(ok).To(BeFalse())
+
+		err = client.InstallAddOn(cluster.ID(), "does-not-exist", nil, billing)
+		Expect(err).To(MatchError(ContainSubstring("Addon 'does-not-exist' not found")))
+	})
+
+	It("Rejects duplicated names and deletion of protected clusters", func() {
+		cluster := createCluster("my-cluster")
+		_, err := client.CreateCluster(specFor("my-cluster"))
+		Expect(err).To(MatchError(ContainSubstring("Cluster name 'my-cluster' already exists")))
+
+		protection, err := cmv1.NewDeleteProtection().Enabled(true).Build()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(client.UpdateClusterDeletionProtection(cluster.ID(), protection)).To(Succeed())
+		_, err = client.DeleteCluster(cluster.ID(), false, creator)
+		Expect(err).To(MatchError(ContainSubstring("delete protection is enabled")))
+	})
+
+	It("Hibernates and resumes clusters", func() {
+		cluster := createCluster("my-cluster")
+		Expect(client.HibernateCluster(cluster.ID())).To(MatchError(ContainSubstring("can't be hibernated")))
+		server.Advance()
+		server.Advance()
+
+		Expect(client.HibernateCluster(cluster.ID())).To(Succeed())
+		server.Advance()
+		state, err := client.GetClusterState(cluster.ID())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(state).To(Equal(cmv1.ClusterStateHibernating))
+		Expect(client.ResumeCluster(cluster.ID())).To(Succeed())
+		server.Advance()
+		state, err = client.GetClusterState(cluster.ID())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(state).To(Equal(cmv1.ClusterStateReady))
+	})
+
+	It("Advances the states when polled", func() {
+		cluster := createCluster("my-cluster")
+		server.SetAdvanceOnRead(true)
+		Eventually(func() (cmv1.ClusterState, error) {
+			return client.GetClusterState(cluster.ID())
+		}).Should(Equal(cmv1.ClusterStateReady))
+	})
+
+	It("Filters, sorts and pages collections", func() {
+		Expect(server.Load([]byte(`
+clusters_mgmt/v1/clusters/a:
+  name: alpha
+  product: {id: rosa}
+clusters_mgmt/v1/clusters/b:
+  name: beta
+  product: {id: osd}
+clusters_mgmt/v1/clusters/c:
+  name: gamma
+  product: {id: rosa}
+`))).To(Succeed())
+		connection, err := server.Connection()
+		Expect(err).NotTo(HaveOccurred())
+		defer connection.Close()
+		response, err := connection.ClustersMgmt().V1().Clusters().List().
+			Search("product.id = 'rosa'").
+			Order("name desc").
+			Size(1).
+			Send()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(response.Total()).To(Equal(2))
+		Expect(response.Items().Slice()).To(HaveLen(1))
+		Expect(response.Items().Get(0).Name()).To(Equal("gamma"))
+
+		_, err = connection.ClustersMgmt().V1().Clusters().List().Search("name = ").Send()
+		Expect(err).To(HaveOccurred())
+		Expect(server.Requests()).To(ContainElement("GET /api/clusters_mgmt/v1/clusters"))
+	})
+
+	It("Returns errors in the format of the API", func() {
+		connection, err := server.Connection()
+		Expect(err).NotTo(HaveOccurred())
+		defer connection.Close()
+		response, err := connection.ClustersMgmt().V1().Clusters().Cluster("missing").Get().Send()
+		Expect(err).To(HaveOccurred())
+		Expect(response.Status()).To(Equal(http.StatusNotFound))
+		Expect(response.Error().Reason()).To(Equal("Cluster 'missing' not found"))
+		Expect(response.Error().Code()).To(Equal("CLUSTERS-MGMT-404"))
+	})
+})
@@ -0,0 +1,353 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the behaviour of the objects that aren't simply stored: what happens when
+// they are created or deleted, and how they move through their states. Clusters go from 'pending'
+// to 'installing' to 'ready', and from 'uninstalling' to deleted. Upgrade policies go from
+// 'pending' to 'scheduled', 'started' and 'completed', and then they are removed. Add-on
+// installations go from 'installing' to 'ready', and from 'deleting' to deleted.
+
+package fake
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	asv1 "github.com/openshift-online/ocm-sdk-go/addonsmgmt/v1"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+const (
+	clustersPath      = "clusters_mgmt/v1/clusters"
+	subscriptionsPath = "accounts_mgmt/v1/subscriptions"
+	addonsPath        = "addons_mgmt/v1/addons"
+	currentAccount    = "accounts_mgmt/v1/current_account"
+	baseDomain        = "fake.openshiftapps.com"
+)
+
+// Subscription statuses used by the accounts_mgmt service.
+const (
+	subscriptionReserved      = "Reserved"
+	subscriptionActive        = "Active"
+	subscriptionDeprovisioned = "Deprovisioned"
+)
+
+// create validates and stores a new object of the collection with the given path. The object is
+// updated in place with the fields set by the server. When dryRun is true the object is only
+// validated. If the object can't be created it returns the HTTP status and the error to send.
+func (s *Server) create(path string, object map[string]interface{}, dryRun bool) (int, error) {
+	owner := ownerOf(path)
+	if owner != "" && s.get(owner) == nil {
+		ownerTarget, err := parsePath(owner)
+		if err != nil {
+			return http.StatusNotFound, err
+		}
+		return http.StatusNotFound, fmt.Errorf("%s '%s' not found", kindOf(ownerTarget.parent), ownerTarget.id)
+	}
+	name := path[strings.LastIndex(path, "/")+1:]
+	id := stringField(object, "id")
+	switch {
+	case path == clustersPath:
+		clusterName := stringField(object, "name")
+		if clusterName == "" {
+			return http.StatusBadRequest, fmt.Errorf("Cluster name is required")
+		}
+		for _, existing := range s.list(clustersPath) {
+			if stringField(existing, "name") == clusterName {
+				return http.StatusBadRequest, fmt.Errorf("Cluster name '%s' already exists", clusterName)
+			}
+		}
+		id = s.newID()
+	case name == "addons":
+		id = stringField(object, "addon.id")
+		if id == "" {
+			return http.StatusBadRequest, fmt.Errorf("Add-on identifier is required")
+		}
+		if s.get(addonsPath+"/"+id) == nil {
+			return http.StatusNotFound, fmt.Errorf("Addon '%s' not found", id)
+		}
+	case name == "upgrade_policies":
+		if stringField(object, "version") == "" && stringField(object, "schedule_type") !=
+			string(cmv1.ScheduleTypeAutomatic) {
+			return http.StatusBadRequest, fmt.Errorf("Upgrade version is required")
+		}
+		id = s.newID()
+	case id == "":
+		id = s.newID()
+	}
+	if s.get(path+"/"+id) != nil {
+		return http.StatusConflict, fmt.Errorf("%s '%s' already exists", kindOf(path), id)
+	}
+	if dryRun {
+		return http.StatusNoContent, nil
+	}
+	itemPath := path + "/" + id
+	err := s.put(itemPath, object)
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+	switch {
+	case path == clustersPath:
+		s.createCluster(itemPath, object)
+	case name == "addons":
+		object["state"] = string(asv1.AddonInstallationStateInstalling)
+	case name == "upgrade_policies":
+		if stringField(object, "schedule_type") == "" {
+			object["schedule_type"] = string(cmv1.ScheduleTypeManual)
+		}
+		if stringField(object, "next_run") == "" {
+			object["next_run"] = now()
+		}
+		s.setPolicyState(itemPath, object, cmv1.UpgradePolicyStateValuePending)
+	}
+	return http.StatusCreated, nil
+}
+
+// createCluster fills the fields that the server sets when a cluster is created, and creates the
+// subscription, the status and the delete protection of the cluster.
+func (s *Server) createCluster(path string, cluster map[string]interface{}) {
+	id := cluster["id"].(string)
+	if stringField(cluster, "product.id") == "" {
+		cluster["product"] = map[string]interface{}{"kind": "ProductLink", "id": "rosa"}
+	}
+	if stringField(cluster, "dns.base_domain") == "" {
+		cluster["dns"] = map[string]interface{}{"base_domain": baseDomain}
+	}
+	cluster["creation_timestamp"] = now()
+	plan := "MOA"
+	if stringField(cluster, "hypershift.enabled") == "true" {
+		plan = "MOA-HostedControlPlane"
+	}
+	subscriptionID := s.newID()
+	subscription := map[string]interface{}{
+		"cluster_id":   id,
+		"display_name": cluster["name"],
+		"managed":      true,
+		"plan":         map[string]interface{}{"kind": "Plan", "id": plan},
+		"status":       subscriptionReserved,
+		"created_at":   now(),
+	}
+	if account := s.get(currentAccount); account != nil {
+		subscription["creator"] = map[string]interface{}{"kind": "AccountLink", "id": account["id"]}
+		subscription["organization_id"] = stringField(account, "organization.id")
+	}
+	_ = s.put(subscriptionsPath+"/"+subscriptionID, subscription)
+	cluster["subscription"] = map[string]interface{}{
+		"kind": "SubscriptionLink",
+		"id":   subscriptionID,
+		"href": "/api/" + subscriptionsPath + "/" + subscriptionID,
+	}
+	_ = s.put(path+"/delete_protection", map[string]interface{}{"enabled": false})
+	s.setClusterState(path, cluster, cmv1.ClusterStatePending)
+}
+
+// checkDeletion returns an error if the object with the given path can't be deleted.
+func (s *Server) checkDeletion(path string, object map[string]interface{}) error {
+	target, err := parsePath(path)
+	if err != nil {
+		return err
+	}
+	switch {
+	case target.parent == clustersPath:
+		protection := s.get(path + "/delete_protection")
+		if protection != nil && stringField(protection, "enabled") == "true" {
+			return fmt.Errorf("Cluster '%s' can't be deleted because delete protection is enabled",
+				target.id)
+		}
+	case target.name == "upgrade_policies":
+		if stringField(object, "state.value") == string(cmv1.UpgradePolicyStateValueStarted) {
+			return fmt.Errorf("Upgrade policy '%s' can't be deleted because the upgrade is in progress",
+				target.id)
+		}
+	}
+	return nil
+}
+
+// startDeletion moves the object to the state that precedes its removal. It returns false if the
+// object should be removed immediately.
+func (s *Server) startDeletion(path string, object map[string]interface{}) bool {
+	target, err := parsePath(path)
+	if err != nil {
+		return false
+	}
+	switch {
+	case target.parent == clustersPath:
+		s.setClusterState(path, object, cmv1.ClusterStateUninstalling)
+		return true
+	case target.name == "addons":
+		object["state"] = string(asv1.AddonInstallationStateDeleting)
+		return true
+	}
+	return false
+}
+
+// act executes the action with the given name on the object with the given path.
+func (s *Server) act(path string, object map[string]interface{}, action string) error {
+	target, err := parsePath(path)
+	if err != nil {
+		return err
+	}
+	if target.parent != clustersPath {
+		return fmt.Errorf("Action '%s' isn't supported for %s '%s'", action, kindOf(target.parent), target.id)
+	}
+	state := cmv1.ClusterState(stringField(object, "state"))
+	switch action {
+	case "hibernate":
+		if state != cmv1.ClusterStateReady {
+			return fmt.Errorf("Cluster '%s' is in state '%s' and can't be hibernated", target.id, state)
+		}
+		s.setClusterState(path, object, cmv1.ClusterStatePoweringDown)
+	case "resume":
+		if state != cmv1.ClusterStateHibernating {
+			return fmt.Errorf("Cluster '%s' is in state '%s' and can't be resumed", target.id, state)
+		}
+		s.setClusterState(path, object, cmv1.ClusterStateResuming)
+	}
+	return nil
+}
+
+// advance moves all the objects that have states to their next state.
+func (s *Server) advance() {
+	for _, path := range s.sortedPaths() {
+		items := s.collections[path]
+		if items == nil {
+			// Removed together with the object that contained it:
+			continue
+		}
+		name := path[strings.LastIndex(path, "/")+1:]
+		for _, id := range append([]string{}, items.ids...) {
+			object := items.objects[id]
+			if object == nil {
+				continue
+			}
+			itemPath := path + "/" + id
+			switch {
+			case path == clustersPath:
+				s.advanceCluster(itemPath, object)
+			case name == "addons":
+				s.advanceAddon(itemPath, object)
+			case name == "upgrade_policies":
+				s.advancePolicy(itemPath, object)
+			}
+		}
+	}
+}
+
+func (s *Server) advanceCluster(path string, cluster map[string]interface{}) {
+	switch cmv1.ClusterState(stringField(cluster, "state")) {
+	case cmv1.ClusterStatePending:
+		s.setClusterState(path, cluster, cmv1.ClusterStateInstalling)
+	case cmv1.ClusterStateInstalling:
+		name := stringField(cluster, "name")
+		domain := stringField(cluster, "dns.base_domain")
+		externalID := s.newID()
+		cluster["external_id"] = fmt.Sprintf("%s-%s-%s-%s-%s", externalID[0:8], externalID[8:12],
+			externalID[12:16], externalID[16:20], externalID[20:])
+		cluster["api"] = map[string]interface{}{
+			"url":       fmt.Sprintf("https://api.%s.%s:6443", name, domain),
+			"listening": "external",
+		}
+		cluster["console"] = map[string]interface{}{
+			"url": fmt.Sprintf("https://console-openshift-console.apps.%s.%s", name, domain),
+		}
+		s.setSubscriptionStatus(cluster, subscriptionActive)
+		s.setClusterState(path, cluster, cmv1.ClusterStateReady)
+	case cmv1.ClusterStatePoweringDown:
+		s.setClusterState(path, cluster, cmv1.ClusterStateHibernating)
+	case cmv1.ClusterStateResuming:
+		s.setClusterState(path, cluster, cmv1.ClusterStateReady)
+	case cmv1.ClusterStateUninstalling:
+		s.setSubscriptionStatus(cluster, subscriptionDeprovisioned)
+		s.remove(path)
+		s.remove("addons_mgmt/v1/clusters/" + cluster["id"].(string))
+	}
+}
+
+func (s *Server) advanceAddon(path string, installation map[string]interface{}) {
+	switch asv1.AddonInstallationState(stringField(installation, "state")) {
+	case asv1.AddonInstallationStateInstalling:
+		installation["state"] = string(asv1.AddonInstallationStateReady)
+	case asv1.AddonInstallationStateDeleting:
+		s.remove(path)
+	}
+}
+
+func (s *Server) advancePolicy(path string, policy map[string]interface{}) {
+	switch cmv1.UpgradePolicyStateValue(stringField(policy, "state.value")) {
+	case cmv1.UpgradePolicyStateValuePending:
+		s.setPolicyState(path, policy, cmv1.UpgradePolicyStateValueScheduled)
+	case cmv1.UpgradePolicyStateValueScheduled:
+		s.setPolicyState(path, policy, cmv1.UpgradePolicyStateValueStarted)
+	case cmv1.UpgradePolicyStateValueStarted:
+		version := stringField(policy, "version")
+		if owner := s.get(ownerOf(path)); owner != nil && version != "" {
+			owner["version"] = map[string]interface{}{
+				"kind":   "Version",
+				"id":     "openshift-v" + version,
+				"href":   "/api/clusters_mgmt/v1/versions/openshift-v" + version,
+				"raw_id": version,
+			}
+		}
+		s.setPolicyState(path, policy, cmv1.UpgradePolicyStateValueCompleted)
+	case cmv1.UpgradePolicyStateValueCompleted:
+		if stringField(policy, "schedule_type") == string(cmv1.ScheduleTypeAutomatic) {
+			s.setPolicyState(path, policy, cmv1.UpgradePolicyStateValuePending)
+			return
+		}
+		s.remove(path)
+	}
+}
+
+// setClusterState changes the state of the cluster, both in the cluster and in its status.
+func (s *Server) setClusterState(path string, cluster map[string]interface{}, state cmv1.ClusterState) {
+	cluster["state"] = string(state)
+	cluster["status"] = map[string]interface{}{"state": string(state)}
+	_ = s.put(path+"/status", map[string]interface{}{
+		"id":          cluster["id"],
+		"state":       string(state),
+		"description": "",
+		"dns_ready":   state != cmv1.ClusterStatePending,
+	})
+}
+
+// setPolicyState changes the state of the upgrade policy, both in the policy and in its state.
+func (s *Server) setPolicyState(path string, policy map[string]interface{},
+	value cmv1.UpgradePolicyStateValue) {
+	state := map[string]interface{}{
+		"value":       string(value),
+		"description": fmt.Sprintf("Upgrade policy is %s.", value),
+	}
+	policy["state"] = state
+	_ = s.put(path+"/state", deepCopy(state))
+}
+
+func (s *Server) setSubscriptionStatus(cluster map[string]interface{}, status string) {
+	subscription := s.get(subscriptionsPath + "/" + stringField(cluster, "subscription.id"))
+	if subscription != nil {
+		subscription["status"] = status
+	}
+}
+
+// stringField returns the text of the field with the given dot separated path, or an empty string
+// if it doesn't exist.
+func stringField(object map[string]interface{}, path string) string {
+	value, ok := lookup(object, path)
+	if !ok {
+		return ""
+	}
+	return stringValue(value)
+}
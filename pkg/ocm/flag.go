@@ -20,9 +20,6 @@ import (
 	"fmt"
 
 	"github.com/spf13/cobra"
-
-	"github.com/openshift/rosa/pkg/aws"
-	"github.com/openshift/rosa/pkg/logging"
 )
 
 const (
@@ -41,7 +38,7 @@ func AddOptionalClusterFlag(cmd *cobra.Command) {
 		"",
 		clusterFlagDescription,
 	)
-	cmd.RegisterFlagCompletionFunc(clusterFlagName, clusterCompletion)
+	cmd.RegisterFlagCompletionFunc(clusterFlagName, completionFunc(clusterCompleter))
 }
 
 func AddClusterFlag(cmd *cobra.Command) {
@@ -65,32 +62,3 @@ func GetClusterKey() (string, error) {
 	}
 	return clusterKey, nil
 }
-
-func clusterCompletion(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
-	logger := logging.NewLogger()
-
-	ocmClient, err := NewClient().Logger(logger).Build()
-	if err != nil {
-		return []string{}, cobra.ShellCompDirectiveDefault
-	}
-	defer ocmClient.Close()
-
-	awsClient, err := aws.NewClient().Logger(logger).Build()
-	if err != nil {
-		return []string{}, cobra.ShellCompDirectiveDefault
-	}
-	awsCreator, err := awsClient.GetCreator()
-	if err != nil {
-		return []string{}, cobra.ShellCompDirectiveDefault
-	}
-
-	clusters, err := ocmClient.GetClusters(awsCreator, 10)
-	if err != nil {
-		return []string{}, cobra.ShellCompDirectiveDefault
-	}
-	res := []string{}
-	for _, cluster := range clusters {
-		res = append(res, cluster.Name())
-	}
-	return res, cobra.ShellCompDirectiveDefault
-}
@@ -249,6 +249,10 @@ func handleErr(res *ocmerrors.Error, err error) error {
 	}
 	// The error type set will be No Type though
 	errType := errors.ErrorType(res.Status())
+	if res != nil {
+		// Keep the original error so that the operation identifier can be reported:
+		return errType.AddDetails(errors.Errorf("%s", msg), res)
+	}
 	return errType.Set(errors.Errorf("%s", msg))
 }
 
@@ -601,18 +605,24 @@ func (c *Client) GetPolicies(policyType string) (map[string]*cmv1.AWSSTSPolicy,
 
 	m := make(map[string]*cmv1.AWSSTSPolicy)
 
-	stmt := c.ocm.ClustersMgmt().V1().AWSInquiries().STSPolicies().List()
-	if policyType != "" {
-		stmt = stmt.Search(query)
-	}
-	accountRolePoliciesResponse, err := stmt.Send()
+	policies, err := cached(c, "sts_policies/"+policyType, stsPoliciesTTL, stsPolicyListCodec,
+		func() ([]*cmv1.AWSSTSPolicy, error) {
+			stmt := c.ocm.ClustersMgmt().V1().AWSInquiries().STSPolicies().List()
+			if policyType != "" {
+				stmt = stmt.Search(query)
+			}
+			accountRolePoliciesResponse, err := stmt.Send()
+			if err != nil {
+				return nil, handleErr(accountRolePoliciesResponse.Error(), err)
+			}
+			return accountRolePoliciesResponse.Items().Slice(), nil
+		})
 	if err != nil {
-		return m, handleErr(accountRolePoliciesResponse.Error(), err)
+		return m, err
 	}
-	accountRolePoliciesResponse.Items().Each(func(awsPolicy *cmv1.AWSSTSPolicy) bool {
+	for _, awsPolicy := range policies {
 		m[awsPolicy.ID()] = awsPolicy
-		return true
-	})
+	}
 	return m, nil
 }
 
@@ -639,21 +649,28 @@ func (c *Client) GetAllCredRequests() (map[string]*cmv1.STSOperator, error) {
 
 func (c *Client) GetCredRequests(isHypershift bool) (map[string]*cmv1.STSOperator, error) {
 	m := make(map[string]*cmv1.STSOperator)
-	stsCredentialResponse, err := c.ocm.ClustersMgmt().
-		V1().
-		AWSInquiries().
-		STSCredentialRequests().
-		List().
-		Parameter("is_hypershift", isHypershift).
-		Send()
+	name := fmt.Sprintf("sts_credential_requests/%t", isHypershift)
+	credentialRequests, err := cached(c, name, credentialRequestsTTL, credentialRequestListCodec,
+		func() ([]*cmv1.STSCredentialRequest, error) {
+			stsCredentialResponse, err := c.ocm.ClustersMgmt().
+				V1().
+				AWSInquiries().
+				STSCredentialRequests().
+				List().
+				Parameter("is_hypershift", isHypershift).
+				Send()
+			if err != nil {
+				return nil, handleErr(stsCredentialResponse.Error(), err)
+			}
+			return stsCredentialResponse.Items().Slice(), nil
+		})
 	if err != nil {
-		return m, handleErr(stsCredentialResponse.Error(), err)
+		return m, err
 	}
 
-	stsCredentialResponse.Items().Each(func(stsCredentialRequest *cmv1.STSCredentialRequest) bool {
+	for _, stsCredentialRequest := range credentialRequests {
 		m[stsCredentialRequest.Name()] = stsCredentialRequest.Operator()
-		return true
-	})
+	}
 	return m, nil
 }
 
@@ -67,7 +67,7 @@ func (c *Client) GetUninstallLogs(clusterID string, tail int) (logs *cmv1.Log, e
 }
 
 func (c *Client) PollInstallLogs(clusterID string, cb func(*cmv1.LogGetResponse) bool) (logs *cmv1.Log, err error) {
-	ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
+	ctx, cancel := context.WithTimeout(c.context(), time.Hour)
 	defer func() {
 		cancel()
 	}()
@@ -94,7 +94,7 @@ func (c *Client) PollInstallLogs(clusterID string, cb func(*cmv1.LogGetResponse)
 
 func (c *Client) PollUninstallLogs(clusterID string,
 	cb func(*cmv1.LogGetResponse) bool) (logs *cmv1.Log, err error) {
-	ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
+	ctx, cancel := context.WithTimeout(c.context(), time.Hour)
 	defer func() {
 		cancel()
 	}()
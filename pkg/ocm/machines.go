@@ -30,11 +30,30 @@ import (
 const AcceleratedComputing = "accelerated_computing"
 
 func (c *Client) GetMachineTypesInRegion(cloudProviderData *cmv1.CloudProviderData) (MachineTypeList, error) {
+	name := "machine_types/" + bodyHash(cloudProviderData, cmv1.MarshalCloudProviderData)
+	items, err := cached(c, name, machineTypesTTL, machineTypeListCodec,
+		func() ([]*cmv1.MachineType, error) {
+			return c.fetchMachineTypesInRegion(cloudProviderData)
+		})
+	if err != nil {
+		return MachineTypeList{}, err
+	}
+
+	var machineTypes MachineTypeList
+	for _, item := range items {
+		machineTypes.Items = append(machineTypes.Items, &MachineType{
+			MachineType: item,
+		})
+	}
+	return machineTypes, nil
+}
+
+func (c *Client) fetchMachineTypesInRegion(cloudProviderData *cmv1.CloudProviderData) ([]*cmv1.MachineType, error) {
 	collection := c.ocm.ClustersMgmt().V1().AWSInquiries().MachineTypes()
 	page := 1
 	size := 100
 
-	var machineTypes MachineTypeList
+	var machineTypes []*cmv1.MachineType
 	for {
 		response, err := collection.Search().
 			Parameter("order", "category asc").
@@ -43,15 +62,10 @@ func (c *Client) GetMachineTypesInRegion(cloudProviderData *cmv1.CloudProviderDa
 			Size(size).
 			Send()
 		if err != nil {
-			return MachineTypeList{}, err
+			return nil, err
 		}
 
-		response.Items().Each(func(item *cmv1.MachineType) bool {
-			machineTypes.Items = append(machineTypes.Items, &MachineType{
-				MachineType: item,
-			})
-			return true
-		})
+		machineTypes = append(machineTypes, response.Items().Slice()...)
 
 		if response.Size() < size {
 			break
@@ -63,6 +77,20 @@ func (c *Client) GetMachineTypesInRegion(cloudProviderData *cmv1.CloudProviderDa
 }
 
 func (c *Client) GetMachineTypes() (machineTypes MachineTypeList, err error) {
+	items, err := cached(c, "machine_types", machineTypesTTL, machineTypeListCodec, c.fetchMachineTypes)
+	if err != nil {
+		return MachineTypeList{}, err
+	}
+
+	for _, item := range items {
+		machineTypes.Items = append(machineTypes.Items, &MachineType{
+			MachineType: item,
+		})
+	}
+	return
+}
+
+func (c *Client) fetchMachineTypes() (machineTypes []*cmv1.MachineType, err error) {
 	collection := c.ocm.ClustersMgmt().V1().MachineTypes()
 	page := 1
 	size := 100
@@ -79,15 +107,10 @@ func (c *Client) GetMachineTypes() (machineTypes MachineTypeList, err error) {
 			if errMsg == "" {
 				errMsg = err.Error()
 			}
-			return MachineTypeList{}, errors.New(errMsg)
+			return nil, errors.New(errMsg)
 		}
 
-		response.Items().Each(func(item *cmv1.MachineType) bool {
-			machineTypes.Items = append(machineTypes.Items, &MachineType{
-				MachineType: item,
-			})
-			return true
-		})
+		machineTypes = append(machineTypes, response.Items().Slice()...)
 
 		if response.Size() < size {
 			break
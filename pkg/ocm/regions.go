@@ -49,6 +49,14 @@ func (c *Client) GetFilteredRegionsByVersion(roleARN string, version string,
 }
 
 func (c *Client) getFilteredRegions(cloudProviderData *cmv1.CloudProviderData) ([]*cmv1.CloudRegion, error) {
+	name := "regions/" + bodyHash(cloudProviderData, cmv1.MarshalCloudProviderData)
+	return cached(c, name, regionsTTL, cloudRegionListCodec,
+		func() ([]*cmv1.CloudRegion, error) {
+			return c.fetchFilteredRegions(cloudProviderData)
+		})
+}
+
+func (c *Client) fetchFilteredRegions(cloudProviderData *cmv1.CloudProviderData) ([]*cmv1.CloudRegion, error) {
 	collection := c.ocm.ClustersMgmt().V1().AWSInquiries().Regions()
 	page := 1
 	size := 100
@@ -116,6 +124,14 @@ func (c *Client) GetRegions(roleARN string, externalID string) (regions []*cmv1.
 		return nil, fmt.Errorf("Failed to build AWS credentials for user '%s': %v", aws.AdminUserName, err)
 	}
 
+	name := "available_regions/" + bodyHash(awsCredentials, cmv1.MarshalAWS)
+	return cached(c, name, regionsTTL, cloudRegionListCodec,
+		func() ([]*cmv1.CloudRegion, error) {
+			return c.fetchAvailableRegions(awsCredentials)
+		})
+}
+
+func (c *Client) fetchAvailableRegions(awsCredentials *cmv1.AWS) (regions []*cmv1.CloudRegion, err error) {
 	collection := c.ocm.ClustersMgmt().V1().
 		CloudProviders().
 		CloudProvider("aws").
@@ -70,6 +70,34 @@ func (c *Client) GetVersions(channelGroup string, defaultFirst bool) (versions [
 
 func (c *Client) GetVersionsWithProduct(product string, channelGroup string,
 	defaultFirst bool) (versions []*cmv1.Version, err error) {
+	name := fmt.Sprintf("versions/%s/%s", product, channelGroup)
+	versions, err = cached(c, name, versionsTTL, versionListCodec, func() ([]*cmv1.Version, error) {
+		return c.fetchVersions(product, channelGroup)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Sort list in descending order
+	sort.Slice(versions, func(i, j int) bool {
+		if defaultFirst && versions[i].Default() {
+			return true
+		}
+		if defaultFirst && versions[j].Default() {
+			return false
+		}
+		a, erra := ver.NewVersion(versions[i].RawID())
+		b, errb := ver.NewVersion(versions[j].RawID())
+		if erra != nil || errb != nil {
+			return false
+		}
+		return a.GreaterThan(b)
+	})
+
+	return
+}
+
+func (c *Client) fetchVersions(product string, channelGroup string) (versions []*cmv1.Version, err error) {
 	collection := c.ocm.ClustersMgmt().V1().Versions()
 	page := 1
 	size := 100
@@ -98,23 +126,6 @@ func (c *Client) GetVersionsWithProduct(product string, channelGroup string,
 		}
 		page++
 	}
-
-	// Sort list in descending order
-	sort.Slice(versions, func(i, j int) bool {
-		if defaultFirst && versions[i].Default() {
-			return true
-		}
-		if defaultFirst && versions[j].Default() {
-			return false
-		}
-		a, erra := ver.NewVersion(versions[i].RawID())
-		b, errb := ver.NewVersion(versions[j].RawID())
-		if erra != nil || errb != nil {
-			return false
-		}
-		return a.GreaterThan(b)
-	})
-
 	return
 }
 
@@ -20,6 +20,8 @@ package output
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/spf13/cobra"
 )
@@ -27,33 +29,117 @@ import (
 const (
 	JSON           = "json"
 	YAML           = "yaml"
+	JSONPATH       = "jsonpath"
+	GOTEMPLATE     = "go-template"
+	CUSTOMCOLUMNS  = "custom-columns"
+	NAME           = "name"
+	WIDE           = "wide"
+	SPEC           = "spec"
 	FLAG_NAME      = "output"
 	FLAG_SHORTHAND = "o"
 )
 
 var o string
 
-var formats = []string{JSON, YAML}
+var formats = []string{JSON, YAML, JSONPATH + "=...", GOTEMPLATE + "=...", CUSTOMCOLUMNS + "=...", NAME}
+
+// optionalFormats are the formats that only the commands that print them themselves accept.
+var optionalFormats = []string{WIDE, SPEC}
 
 // AddFlag adds the interactive flag to the given set of command line flags.
 func AddFlag(cmd *cobra.Command) {
-	cmd.Flags().StringVarP(
-		&o,
+	addFlag(cmd)
+}
+
+// AddWideFlag adds the output flag to a list command that can print additional columns with the
+// 'wide' format. The rest of the commands reject it.
+func AddWideFlag(cmd *cobra.Command) {
+	addFlag(cmd, WIDE)
+}
+
+// AddSpecFlag adds the output flag to a describe command that can print the object as the spec file
+// that creates it with the 'spec' format. The rest of the commands reject it.
+func AddSpecFlag(cmd *cobra.Command) {
+	addFlag(cmd, SPEC)
+}
+
+func addFlag(cmd *cobra.Command, optional ...string) {
+	allowed := append(formats[:len(formats):len(formats)], optional...)
+	// Like the flags that store a string, adding the flag resets the value to the default:
+	o = ""
+	cmd.Flags().VarP(
+		&value{optional: optional},
 		FLAG_NAME,
 		FLAG_SHORTHAND,
-		"",
-		fmt.Sprintf("Output format. Allowed formats are %s", formats),
+		fmt.Sprintf("Output format. Allowed formats are %s", allowed),
 	)
 
-	cmd.RegisterFlagCompletionFunc(FLAG_NAME, completion)
+	cmd.RegisterFlagCompletionFunc(FLAG_NAME, completion(optional...))
+}
+
+// value is the value of the output flag, stored in the package variable shared by all the commands.
+type value struct {
+	optional []string
+}
+
+func (v *value) String() string {
+	return o
+}
+
+func (v *value) Set(format string) error {
+	if slices.Contains(optionalFormats, format) && !slices.Contains(v.optional, format) {
+		return fmt.Errorf("the '%s' output format isn't supported by this command", format)
+	}
+	o = format
+	return nil
 }
 
-func completion(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
-	return formats, cobra.ShellCompDirectiveDefault
+func (v *value) Type() string {
+	return "string"
 }
 
+func completion(optional ...string) func(*cobra.Command, []string, string) ([]string,
+	cobra.ShellCompDirective) {
+	return func(_ *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		// Formats that take a template are completed up to the '=' so that the user can type it:
+		if strings.Contains(toComplete, "=") {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		result := []string{
+			JSON,
+			YAML,
+			JSONPATH + "=",
+			GOTEMPLATE + "=",
+			CUSTOMCOLUMNS + "=",
+			NAME,
+		}
+		result = append(result, optional...)
+		return result, cobra.ShellCompDirectiveNoSpace
+	}
+}
+
+// HasFlag returns true if a structured output format was requested. The 'wide' and 'spec' formats
+// are printed by the commands themselves, so they don't count as one.
 func HasFlag() bool {
-	return o != ""
+	return o != "" && o != WIDE && o != SPEC
+}
+
+// Spec returns true if the object should be printed as the spec file that creates it. Only the
+// commands that add the flag with AddSpecFlag accept it.
+func Spec() bool {
+	return o == SPEC
+}
+
+// Wide returns true if the tables printed by list commands should include additional columns.
+func Wide() bool {
+	return o == WIDE
+}
+
+// Format returns the name of the requested output format and its argument, if any. For example
+// 'jsonpath={.id}' returns 'jsonpath' and '{.id}'.
+func Format() (string, string) {
+	name, argument, _ := strings.Cut(o, "=")
+	return name, argument
 }
 
 // Enabled retursn a boolean flag that indicates if the interactive mode is enabled.
@@ -28,15 +28,92 @@ var _ = Describe("Output flag", func() {
 		Expect(flag.Name).To(Equal(FLAG_NAME))
 		Expect(flag.Shorthand).To(Equal(FLAG_SHORTHAND))
 		Expect(flag.Value.String()).To(Equal(""))
-		Expect(flag.Usage).To(Equal("Output format. Allowed formats are [json yaml]"))
+		Expect(flag.Usage).To(Equal("Output format. Allowed formats are " +
+			"[json yaml jsonpath=... go-template=... custom-columns=... name]"))
+	})
+
+	It("Rejects the wide format if the command doesn't support it", func() {
+		cmd := &cobra.Command{}
+		AddFlag(cmd)
+
+		err := cmd.Flags().Set(FLAG_NAME, WIDE)
+		Expect(err).To(MatchError(ContainSubstring("the 'wide' output format isn't supported by this command")))
+		Expect(Output()).To(BeEmpty())
+
+		Expect(cmd.Flags().Set(FLAG_NAME, JSON)).To(Succeed())
+		Expect(Output()).To(Equal(JSON))
+	})
+
+	It("Accepts the wide format if the command supports it", func() {
+		cmd := &cobra.Command{}
+		AddWideFlag(cmd)
+
+		flag := cmd.Flag(FLAG_NAME)
+		Expect(flag.Usage).To(Equal("Output format. Allowed formats are " +
+			"[json yaml jsonpath=... go-template=... custom-columns=... name wide]"))
+		Expect(cmd.Flags().Set(FLAG_NAME, WIDE)).To(Succeed())
+		Expect(Wide()).To(BeTrue())
+	})
+
+	It("Rejects the spec format if the command doesn't support it", func() {
+		cmd := &cobra.Command{}
+		AddWideFlag(cmd)
+
+		err := cmd.Flags().Set(FLAG_NAME, SPEC)
+		Expect(err).To(MatchError(ContainSubstring("the 'spec' output format isn't supported by this command")))
+		Expect(Output()).To(BeEmpty())
+	})
+
+	It("Accepts the spec format if the command supports it", func() {
+		cmd := &cobra.Command{}
+		AddSpecFlag(cmd)
+
+		flag := cmd.Flag(FLAG_NAME)
+		Expect(flag.Usage).To(Equal("Output format. Allowed formats are " +
+			"[json yaml jsonpath=... go-template=... custom-columns=... name spec]"))
+		Expect(cmd.Flags().Set(FLAG_NAME, WIDE)).NotTo(Succeed())
+		Expect(cmd.Flags().Set(FLAG_NAME, SPEC)).To(Succeed())
+		Expect(Spec()).To(BeTrue())
 	})
 
 	It("Has a completion function", func() {
-		args, directive := completion(nil, nil, "")
-		Expect(len(args)).To(Equal(2))
-		Expect(args).To(ContainElements(JSON, YAML))
+		args, directive := completion()(nil, nil, "")
+		Expect(len(args)).To(Equal(6))
+		Expect(args).To(ContainElements(JSON, YAML, "jsonpath=", "go-template=", "custom-columns=", NAME))
+		Expect(directive).To(Equal(cobra.ShellCompDirectiveNoSpace))
+
+		args, _ = completion(WIDE)(nil, nil, "")
+		Expect(args).To(ContainElement(WIDE))
+		Expect(args).NotTo(ContainElement(SPEC))
+
+		args, _ = completion(SPEC)(nil, nil, "")
+		Expect(args).To(ContainElement(SPEC))
+		Expect(args).NotTo(ContainElement(WIDE))
+	})
+
+	It("Does not complete templates", func() {
+		args, directive := completion()(nil, nil, "jsonpath=")
+		Expect(args).To(BeEmpty())
+		Expect(directive).To(Equal(cobra.ShellCompDirectiveNoFileComp))
+	})
+
+	It("Does not consider wide a structured format", func() {
+		SetOutput(WIDE)
+		Expect(HasFlag()).To(BeFalse())
+		Expect(Wide()).To(BeTrue())
+	})
+
+	It("Does not consider spec a structured format", func() {
+		SetOutput(SPEC)
+		Expect(HasFlag()).To(BeFalse())
+		Expect(Spec()).To(BeTrue())
+	})
 
-		Expect(directive).To(Equal(cobra.ShellCompDirectiveDefault))
+	It("Splits the format and its argument", func() {
+		SetOutput("jsonpath={.id}={.name}")
+		format, argument := Format()
+		Expect(format).To(Equal(JSONPATH))
+		Expect(argument).To(Equal("{.id}={.name}"))
 	})
 
 	It("Has flag", func() {
@@ -0,0 +1,643 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains a small implementation of the kubectl flavour of JSONPath templates, used by
+// the 'jsonpath' and 'custom-columns' output formats. It supports plain text, quoted literals,
+// '{range}'/'{end}' blocks and paths made of fields, '*' wildcards, indexes, slices, recursive
+// descent ('..') and filters ('[?(@.key==value)]').
+
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type segmentKind int
+
+const (
+	fieldSegment segmentKind = iota
+	wildcardSegment
+	indexSegment
+	sliceSegment
+	recursiveSegment
+	filterSegment
+)
+
+type pathSegment struct {
+	kind   segmentKind
+	name   string
+	index  int
+	start  *int
+	end    *int
+	filter *pathFilter
+}
+
+type pathFilter struct {
+	left     []pathSegment
+	operator string
+	right    interface{}
+	// rightPath is set when the right side of the comparison is also a path
+	rightPath []pathSegment
+}
+
+// pathExpression is a parsed path. Paths starting with '$' are evaluated against the root of the
+// document, the rest against the current element.
+type pathExpression struct {
+	root     bool
+	segments []pathSegment
+}
+
+type templateNodeKind int
+
+const (
+	textNode templateNodeKind = iota
+	pathNode
+	rangeNode
+)
+
+type templateNode struct {
+	kind     templateNodeKind
+	text     string
+	path     pathExpression
+	children []templateNode
+}
+
+// JSONPath is a parsed JSONPath template.
+type JSONPath struct {
+	nodes []templateNode
+}
+
+// ParseJSONPath parses a template like '{range [*]}{.id}{"\t"}{.name}{"\n"}{end}'.
+func ParseJSONPath(template string) (*JSONPath, error) {
+	stack := [][]templateNode{{}}
+	var ranges []pathExpression
+	rest := template
+	for len(rest) > 0 {
+		open := strings.Index(rest, "{")
+		if open < 0 {
+			stack[len(stack)-1] = append(stack[len(stack)-1], templateNode{kind: textNode, text: rest})
+			break
+		}
+		if open > 0 {
+			stack[len(stack)-1] = append(stack[len(stack)-1], templateNode{kind: textNode, text: rest[:open]})
+		}
+		end := closingIndex(rest, open, '{', '}')
+		if end < 0 {
+			return nil, fmt.Errorf("unclosed action in template '%s'", template)
+		}
+		action := strings.TrimSpace(rest[open+1 : end])
+		rest = rest[end+1:]
+
+		switch {
+		case action == "end":
+			if len(ranges) == 0 {
+				return nil, fmt.Errorf("'{end}' without matching '{range}' in template '%s'", template)
+			}
+			children := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			stack[len(stack)-1] = append(stack[len(stack)-1], templateNode{
+				kind:     rangeNode,
+				path:     ranges[len(ranges)-1],
+				children: children,
+			})
+			ranges = ranges[:len(ranges)-1]
+		case strings.HasPrefix(action, "range "):
+			path, err := parsePath(strings.TrimSpace(strings.TrimPrefix(action, "range ")))
+			if err != nil {
+				return nil, err
+			}
+			ranges = append(ranges, path)
+			stack = append(stack, []templateNode{})
+		case isQuoted(action):
+			text, err := unquote(action)
+			if err != nil {
+				return nil, err
+			}
+			stack[len(stack)-1] = append(stack[len(stack)-1], templateNode{kind: textNode, text: text})
+		default:
+			path, err := parsePath(action)
+			if err != nil {
+				return nil, err
+			}
+			stack[len(stack)-1] = append(stack[len(stack)-1], templateNode{kind: pathNode, path: path})
+		}
+	}
+	if len(ranges) > 0 {
+		return nil, fmt.Errorf("'{range}' without matching '{end}' in template '%s'", template)
+	}
+	return &JSONPath{nodes: stack[0]}, nil
+}
+
+// Execute renders the template for the given data, which is expected to be the result of
+// decoding a JSON document.
+func (j *JSONPath) Execute(data interface{}) (string, error) {
+	var b strings.Builder
+	err := executeNodes(&b, j.nodes, data, data)
+	if err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// FindResults evaluates a single path, with or without the surrounding braces, and returns the
+// matching values.
+func FindResults(path string, data interface{}) ([]interface{}, error) {
+	path = strings.TrimSpace(path)
+	if strings.HasPrefix(path, "{") && strings.HasSuffix(path, "}") {
+		path = path[1 : len(path)-1]
+	}
+	expression, err := parsePath(path)
+	if err != nil {
+		return nil, err
+	}
+	return expression.evaluate(data, data), nil
+}
+
+func executeNodes(b *strings.Builder, nodes []templateNode, root interface{}, current interface{}) error {
+	for _, node := range nodes {
+		switch node.kind {
+		case textNode:
+			b.WriteString(node.text)
+		case pathNode:
+			values := node.path.evaluate(root, current)
+			texts := make([]string, 0, len(values))
+			for _, value := range values {
+				text, err := formatValue(value)
+				if err != nil {
+					return err
+				}
+				texts = append(texts, text)
+			}
+			b.WriteString(strings.Join(texts, " "))
+		case rangeNode:
+			for _, value := range node.path.evaluate(root, current) {
+				// Ranging over a single array iterates its elements, like kubectl does:
+				items := []interface{}{value}
+				if array, ok := value.([]interface{}); ok && len(node.path.segments) > 0 &&
+					node.path.segments[len(node.path.segments)-1].kind != wildcardSegment {
+					items = array
+				}
+				for _, item := range items {
+					err := executeNodes(b, node.children, root, item)
+					if err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// formatValue returns the text representation of a value: strings and numbers are printed as is and
+// objects and arrays as JSON.
+func formatValue(value interface{}) (string, error) {
+	switch typed := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return typed, nil
+	case json.Number:
+		return typed.String(), nil
+	case bool:
+		return strconv.FormatBool(typed), nil
+	case float64:
+		return strconv.FormatFloat(typed, 'f', -1, 64), nil
+	default:
+		data, err := json.Marshal(typed)
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	}
+}
+
+func (p pathExpression) evaluate(root interface{}, current interface{}) []interface{} {
+	start := current
+	if p.root {
+		start = root
+	}
+	return evaluateSegments(p.segments, []interface{}{start})
+}
+
+func evaluateSegments(segments []pathSegment, values []interface{}) []interface{} {
+	for _, segment := range segments {
+		var next []interface{}
+		for _, value := range values {
+			next = append(next, segment.apply(value)...)
+		}
+		values = next
+	}
+	return values
+}
+
+func (s pathSegment) apply(value interface{}) []interface{} {
+	switch s.kind {
+	case fieldSegment:
+		if object, ok := value.(map[string]interface{}); ok {
+			if field, ok := object[s.name]; ok {
+				return []interface{}{field}
+			}
+		}
+	case wildcardSegment:
+		return children(value)
+	case indexSegment:
+		if array, ok := value.([]interface{}); ok {
+			index := s.index
+			if index < 0 {
+				index += len(array)
+			}
+			if index >= 0 && index < len(array) {
+				return []interface{}{array[index]}
+			}
+		}
+	case sliceSegment:
+		if array, ok := value.([]interface{}); ok {
+			start, end := 0, len(array)
+			if s.start != nil {
+				start = clampIndex(*s.start, len(array))
+			}
+			if s.end != nil {
+				end = clampIndex(*s.end, len(array))
+			}
+			if start < end {
+				return array[start:end]
+			}
+		}
+	case recursiveSegment:
+		var results []interface{}
+		descend(value, func(node interface{}) {
+			if s.name == "*" {
+				results = append(results, children(node)...)
+				return
+			}
+			if object, ok := node.(map[string]interface{}); ok {
+				if field, ok := object[s.name]; ok {
+					results = append(results, field)
+				}
+			}
+		})
+		return results
+	case filterSegment:
+		var results []interface{}
+		for _, child := range children(value) {
+			if s.filter.matches(child) {
+				results = append(results, child)
+			}
+		}
+		return results
+	}
+	return nil
+}
+
+func clampIndex(index int, length int) int {
+	if index < 0 {
+		index += length
+	}
+	if index < 0 {
+		return 0
+	}
+	if index > length {
+		return length
+	}
+	return index
+}
+
+// children returns the elements of an array or the values of an object sorted by key.
+func children(value interface{}) []interface{} {
+	switch typed := value.(type) {
+	case []interface{}:
+		return typed
+	case map[string]interface{}:
+		keys := make([]string, 0, len(typed))
+		for key := range typed {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		results := make([]interface{}, 0, len(keys))
+		for _, key := range keys {
+			results = append(results, typed[key])
+		}
+		return results
+	}
+	return nil
+}
+
+func descend(value interface{}, visit func(interface{})) {
+	visit(value)
+	for _, child := range children(value) {
+		descend(child, visit)
+	}
+}
+
+func (f *pathFilter) matches(value interface{}) bool {
+	left := evaluateSegments(f.left, []interface{}{value})
+	if f.operator == "" {
+		return len(left) > 0
+	}
+	if len(left) == 0 {
+		return false
+	}
+	right := f.right
+	if f.rightPath != nil {
+		values := evaluateSegments(f.rightPath, []interface{}{value})
+		if len(values) == 0 {
+			return false
+		}
+		right = values[0]
+	}
+	return compare(left[0], f.operator, right)
+}
+
+func compare(left interface{}, operator string, right interface{}) bool {
+	leftNumber, leftIsNumber := toNumber(left)
+	rightNumber, rightIsNumber := toNumber(right)
+	if leftIsNumber && rightIsNumber {
+		switch operator {
+		case "==":
+			return leftNumber == rightNumber
+		case "!=":
+			return leftNumber != rightNumber
+		case "<":
+			return leftNumber < rightNumber
+		case "<=":
+			return leftNumber <= rightNumber
+		case ">":
+			return leftNumber > rightNumber
+		case ">=":
+			return leftNumber >= rightNumber
+		}
+		return false
+	}
+	leftText, _ := formatValue(left)
+	rightText, _ := formatValue(right)
+	switch operator {
+	case "==":
+		return leftText == rightText
+	case "!=":
+		return leftText != rightText
+	case "<":
+		return leftText < rightText
+	case "<=":
+		return leftText <= rightText
+	case ">":
+		return leftText > rightText
+	case ">=":
+		return leftText >= rightText
+	}
+	return false
+}
+
+func toNumber(value interface{}) (float64, bool) {
+	switch typed := value.(type) {
+	case json.Number:
+		number, err := typed.Float64()
+		return number, err == nil
+	case float64:
+		return typed, true
+	}
+	return 0, false
+}
+
+// parsePath parses a path like '.items[*].metadata.name' or '$.id'.
+func parsePath(path string) (pathExpression, error) {
+	expression := pathExpression{}
+	switch {
+	case strings.HasPrefix(path, "$"):
+		expression.root = true
+		path = path[1:]
+	case strings.HasPrefix(path, "@"):
+		path = path[1:]
+	}
+	segments, err := parseSegments(path)
+	if err != nil {
+		return expression, err
+	}
+	expression.segments = segments
+	return expression, nil
+}
+
+func parseSegments(path string) ([]pathSegment, error) {
+	var segments []pathSegment
+	i := 0
+	for i < len(path) {
+		switch {
+		case strings.HasPrefix(path[i:], ".."):
+			name, next := readName(path, i+2)
+			if name == "" {
+				return nil, fmt.Errorf("missing field name after '..' in path '%s'", path)
+			}
+			segments = append(segments, pathSegment{kind: recursiveSegment, name: name})
+			i = next
+		case path[i] == '.':
+			name, next := readName(path, i+1)
+			if name != "" {
+				segments = append(segments, nameSegment(name))
+			}
+			i = next
+		case path[i] == '[':
+			end := closingIndex(path, i, '[', ']')
+			if end < 0 {
+				return nil, fmt.Errorf("unclosed '[' in path '%s'", path)
+			}
+			segment, err := parseBracket(strings.TrimSpace(path[i+1 : end]))
+			if err != nil {
+				return nil, err
+			}
+			segments = append(segments, segment)
+			i = end + 1
+		default:
+			// Allow paths without the leading dot, like 'status.state':
+			name, next := readName(path, i)
+			segments = append(segments, nameSegment(name))
+			i = next
+		}
+	}
+	return segments, nil
+}
+
+func nameSegment(name string) pathSegment {
+	if name == "*" {
+		return pathSegment{kind: wildcardSegment}
+	}
+	return pathSegment{kind: fieldSegment, name: name}
+}
+
+func readName(path string, start int) (string, int) {
+	end := start
+	for end < len(path) && path[end] != '.' && path[end] != '[' {
+		end++
+	}
+	return strings.TrimSpace(path[start:end]), end
+}
+
+func parseBracket(content string) (pathSegment, error) {
+	switch {
+	case content == "*":
+		return pathSegment{kind: wildcardSegment}, nil
+	case strings.HasPrefix(content, "?(") && strings.HasSuffix(content, ")"):
+		filter, err := parseFilter(strings.TrimSpace(content[2 : len(content)-1]))
+		if err != nil {
+			return pathSegment{}, err
+		}
+		return pathSegment{kind: filterSegment, filter: filter}, nil
+	case isQuoted(content):
+		name, err := unquote(content)
+		if err != nil {
+			return pathSegment{}, err
+		}
+		return pathSegment{kind: fieldSegment, name: name}, nil
+	case strings.Contains(content, ":"):
+		parts := strings.SplitN(content, ":", 2)
+		segment := pathSegment{kind: sliceSegment}
+		for i, part := range parts {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				continue
+			}
+			number, err := strconv.Atoi(part)
+			if err != nil {
+				return pathSegment{}, fmt.Errorf("invalid slice '[%s]'", content)
+			}
+			if i == 0 {
+				segment.start = &number
+			} else {
+				segment.end = &number
+			}
+		}
+		return segment, nil
+	}
+	index, err := strconv.Atoi(content)
+	if err != nil {
+		return pathSegment{}, fmt.Errorf("invalid array index '[%s]'", content)
+	}
+	return pathSegment{kind: indexSegment, index: index}, nil
+}
+
+var filterOperators = []string{"==", "!=", "<=", ">=", "<", ">"}
+
+func parseFilter(content string) (*pathFilter, error) {
+	filter := &pathFilter{}
+	left := content
+	right := ""
+	for _, operator := range filterOperators {
+		if index := indexOutsideQuotes(content, operator); index >= 0 {
+			filter.operator = operator
+			left = strings.TrimSpace(content[:index])
+			right = strings.TrimSpace(content[index+len(operator):])
+			break
+		}
+	}
+	if !strings.HasPrefix(left, "@") {
+		return nil, fmt.Errorf("filter '%s' must start with '@'", content)
+	}
+	segments, err := parseSegments(left[1:])
+	if err != nil {
+		return nil, err
+	}
+	filter.left = segments
+	if filter.operator == "" {
+		return filter, nil
+	}
+
+	switch {
+	case strings.HasPrefix(right, "@"):
+		filter.rightPath, err = parseSegments(right[1:])
+		if err != nil {
+			return nil, err
+		}
+	case isQuoted(right):
+		filter.right, err = unquote(right)
+		if err != nil {
+			return nil, err
+		}
+	case right == "true" || right == "false":
+		filter.right = right == "true"
+	default:
+		number, err := strconv.ParseFloat(right, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value '%s' in filter '%s'", right, content)
+		}
+		filter.right = number
+	}
+	return filter, nil
+}
+
+func isQuoted(text string) bool {
+	return len(text) >= 2 &&
+		((text[0] == '"' && text[len(text)-1] == '"') || (text[0] == '\'' && text[len(text)-1] == '\''))
+}
+
+func unquote(text string) (string, error) {
+	if text[0] == '\'' {
+		text = `"` + strings.ReplaceAll(text[1:len(text)-1], `"`, `\"`) + `"`
+	}
+	result, err := strconv.Unquote(text)
+	if err != nil {
+		return "", fmt.Errorf("invalid quoted text %s: %v", text, err)
+	}
+	return result, nil
+}
+
+// closingIndex returns the index of the delimiter that closes the one at the given position,
+// skipping quoted text and nested delimiters.
+func closingIndex(text string, start int, open byte, close byte) int {
+	depth := 0
+	var quote byte
+	for i := start; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == open:
+			depth++
+		case c == close:
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func indexOutsideQuotes(text string, substring string) int {
+	var quote byte
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case strings.HasPrefix(text[i:], substring):
+			return i
+		}
+	}
+	return -1
+}
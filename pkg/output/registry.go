@@ -0,0 +1,209 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the registry of the functions used to print each kind of resource. Every type
+// printed by the OCM SDK has a JSON marshaller, used by all the structured output formats. Types
+// that aren't generated by the SDK don't need one, as they are encoded with the standard JSON
+// encoder.
+//
+// Table printers are registered by the commands that print each kind of resource, next to the code
+// that fetches it, as the human readable output is specific to the command. Commands that need more
+// than the resource to print it, like the state of the scheduled upgrade, register the printer for a
+// local type that wraps the resource.
+
+package output
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+
+	arv1 "github.com/openshift-online/ocm-sdk-go/accesstransparency/v1"
+	amsv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
+	asv1 "github.com/openshift-online/ocm-sdk-go/addonsmgmt/v1"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	msv1 "github.com/openshift-online/ocm-sdk-go/servicemgmt/v1"
+)
+
+// sdkPackagePrefix is the prefix of the packages of the types generated by the OCM SDK. These types
+// only have private fields, so they can't be printed without a marshaller.
+const sdkPackagePrefix = "github.com/openshift-online/ocm-sdk-go"
+
+// Marshaller writes the JSON representation of a resource.
+type Marshaller func(resource interface{}, writer io.Writer) error
+
+// TablePrinter writes the human readable representation of a resource.
+type TablePrinter func(resource interface{}, writer io.Writer) error
+
+type registration struct {
+	marshaller Marshaller
+	table      TablePrinter
+}
+
+var (
+	registryLock sync.RWMutex
+	registry     = map[reflect.Type]*registration{}
+)
+
+func typeOf[T any]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+func register(resourceType reflect.Type) *registration {
+	entry, ok := registry[resourceType]
+	if !ok {
+		entry = &registration{}
+		registry[resourceType] = entry
+	}
+	return entry
+}
+
+// RegisterMarshaller registers the function used to write the JSON representation of the resources
+// of type T, usually one of the Marshal functions of the OCM SDK.
+func RegisterMarshaller[T any](marshal func(T, io.Writer) error) {
+	registryLock.Lock()
+	defer registryLock.Unlock()
+	register(typeOf[T]()).marshaller = func(resource interface{}, writer io.Writer) error {
+		return marshal(resource.(T), writer)
+	}
+}
+
+// RegisterTablePrinter registers the function used to write the human readable representation of
+// the resources of type T.
+func RegisterTablePrinter[T any](print func(T, io.Writer) error) {
+	registryLock.Lock()
+	defer registryLock.Unlock()
+	register(typeOf[T]()).table = func(resource interface{}, writer io.Writer) error {
+		return print(resource.(T), writer)
+	}
+}
+
+func lookup(resource interface{}) *registration {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+	return registry[reflect.TypeOf(resource)]
+}
+
+// marshallerFor returns the marshaller registered for the type of the resource. Types that aren't
+// registered are encoded with the standard JSON encoder, except the ones generated by the OCM SDK,
+// which would silently be printed empty.
+func marshallerFor(resource interface{}) (Marshaller, error) {
+	if entry := lookup(resource); entry != nil && entry.marshaller != nil {
+		return entry.marshaller, nil
+	}
+	resourceType := reflect.TypeOf(resource)
+	if isOpaqueSDKType(resourceType) {
+		return nil, fmt.Errorf("No output marshaller registered for type '%s'", resourceType)
+	}
+	return defaultEncode, nil
+}
+
+// isOpaqueSDKType checks if the type, or the type of the elements of a slice or map, is a struct
+// generated by the OCM SDK without any public field.
+func isOpaqueSDKType(resourceType reflect.Type) bool {
+	for resourceType != nil {
+		switch resourceType.Kind() {
+		case reflect.Pointer, reflect.Slice, reflect.Array, reflect.Map:
+			resourceType = resourceType.Elem()
+			continue
+		case reflect.Struct:
+			if !strings.HasPrefix(resourceType.PkgPath(), sdkPackagePrefix) {
+				return false
+			}
+			for i := 0; i < resourceType.NumField(); i++ {
+				if resourceType.Field(i).IsExported() {
+					return false
+				}
+			}
+			return true
+		}
+		return false
+	}
+	return false
+}
+
+// HasTablePrinter checks if a table printer is registered for the type of the resource.
+func HasTablePrinter(resource interface{}) bool {
+	entry := lookup(resource)
+	return entry != nil && entry.table != nil
+}
+
+// PrintTable prints the human readable representation of the resource to the standard output.
+func PrintTable(resource interface{}) error {
+	return WriteTable(os.Stdout, resource)
+}
+
+// WriteTable writes the human readable representation of the resource to the given writer.
+func WriteTable(writer io.Writer, resource interface{}) error {
+	entry := lookup(resource)
+	if entry == nil || entry.table == nil {
+		return fmt.Errorf("No table printer registered for type '%s'", reflect.TypeOf(resource))
+	}
+	return entry.table(resource, writer)
+}
+
+func init() {
+	RegisterMarshaller(amsv1.MarshalAccount)
+
+	RegisterMarshaller(arv1.MarshalAccessRequest)
+	RegisterMarshaller(arv1.MarshalAccessRequestList)
+
+	RegisterMarshaller(asv1.MarshalAddon)
+	RegisterMarshaller(asv1.MarshalAddonList)
+	RegisterMarshaller(asv1.MarshalAddonInstallation)
+	RegisterMarshaller(asv1.MarshalAddonInstallationList)
+
+	RegisterMarshaller(cmv1.MarshalBreakGlassCredential)
+	RegisterMarshaller(cmv1.MarshalBreakGlassCredentialList)
+	RegisterMarshaller(cmv1.MarshalCloudRegionList)
+	RegisterMarshaller(cmv1.MarshalCluster)
+	RegisterMarshaller(cmv1.MarshalClusterList)
+	RegisterMarshaller(cmv1.MarshalClusterAutoscaler)
+	RegisterMarshaller(cmv1.MarshalControlPlaneUpgradePolicy)
+	RegisterMarshaller(cmv1.MarshalControlPlaneUpgradePolicyList)
+	RegisterMarshaller(cmv1.MarshalDNSDomainList)
+	RegisterMarshaller(cmv1.MarshalExternalAuth)
+	RegisterMarshaller(cmv1.MarshalExternalAuthList)
+	RegisterMarshaller(cmv1.MarshalIdentityProvider)
+	RegisterMarshaller(cmv1.MarshalIdentityProviderList)
+	RegisterMarshaller(cmv1.MarshalIngress)
+	RegisterMarshaller(cmv1.MarshalIngressList)
+	RegisterMarshaller(cmv1.MarshalKubeletConfig)
+	RegisterMarshaller(cmv1.MarshalKubeletConfigList)
+	RegisterMarshaller(cmv1.MarshalMachinePool)
+	RegisterMarshaller(cmv1.MarshalMachinePoolList)
+	RegisterMarshaller(cmv1.MarshalMachineTypeList)
+	RegisterMarshaller(cmv1.MarshalNodePool)
+	RegisterMarshaller(cmv1.MarshalNodePoolList)
+	RegisterMarshaller(cmv1.MarshalNodePoolUpgradePolicy)
+	RegisterMarshaller(cmv1.MarshalNodePoolUpgradePolicyList)
+	RegisterMarshaller(cmv1.MarshalOidcConfig)
+	RegisterMarshaller(cmv1.MarshalOidcConfigList)
+	RegisterMarshaller(cmv1.MarshalSubnetNetworkVerification)
+	RegisterMarshaller(cmv1.MarshalTuningConfig)
+	RegisterMarshaller(cmv1.MarshalTuningConfigList)
+	RegisterMarshaller(cmv1.MarshalUpgradePolicy)
+	RegisterMarshaller(cmv1.MarshalUpgradePolicyList)
+	RegisterMarshaller(cmv1.MarshalUserList)
+	RegisterMarshaller(cmv1.MarshalVersionList)
+	RegisterMarshaller(cmv1.MarshalVersionGateList)
+
+	RegisterMarshaller(msv1.MarshalManagedService)
+	RegisterMarshaller(msv1.MarshalManagedServiceList)
+}
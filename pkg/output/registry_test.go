@@ -0,0 +1,74 @@
+package output
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+type registryTestResource struct {
+	Name string `json:"name"`
+}
+
+type registryTestUnregistered struct {
+	Name string `json:"name"`
+}
+
+var _ = Describe("Registry", func() {
+	BeforeEach(func() {
+		RegisterMarshaller(func(resource *registryTestResource, writer io.Writer) error {
+			_, err := fmt.Fprintf(writer, `{"registered":"%s"}`, resource.Name)
+			return err
+		})
+		RegisterTablePrinter(func(resource *registryTestResource, writer io.Writer) error {
+			_, err := fmt.Fprintf(writer, "NAME\n%s\n", resource.Name)
+			return err
+		})
+	})
+
+	It("Uses the registered marshaller", func() {
+		marshal, err := marshallerFor(&registryTestResource{Name: "foo"})
+		Expect(err).NotTo(HaveOccurred())
+		var out bytes.Buffer
+		Expect(marshal(&registryTestResource{Name: "foo"}, &out)).To(Succeed())
+		Expect(out.String()).To(Equal(`{"registered":"foo"}`))
+	})
+
+	It("Uses the registered SDK marshallers", func() {
+		cluster, err := cmv1.NewCluster().ID("123").Build()
+		Expect(err).NotTo(HaveOccurred())
+		marshal, err := marshallerFor([]*cmv1.Cluster{cluster})
+		Expect(err).NotTo(HaveOccurred())
+		var out bytes.Buffer
+		Expect(marshal([]*cmv1.Cluster{cluster}, &out)).To(Succeed())
+		Expect(out.String()).To(MatchRegexp(`"id":\s*"123"`))
+	})
+
+	It("Encodes unregistered types with public fields", func() {
+		marshal, err := marshallerFor(registryTestUnregistered{Name: "bar"})
+		Expect(err).NotTo(HaveOccurred())
+		var out bytes.Buffer
+		Expect(marshal(registryTestUnregistered{Name: "bar"}, &out)).To(Succeed())
+		Expect(out.String()).To(Equal("{\n  \"name\": \"bar\"\n}\n"))
+	})
+
+	It("Fails for unregistered SDK types", func() {
+		version, err := cmv1.NewVersion().ID("4.14.0").Build()
+		Expect(err).NotTo(HaveOccurred())
+		_, err = marshallerFor(version)
+		Expect(err).To(MatchError("No output marshaller registered for type '*v1.Version'"))
+	})
+
+	It("Writes tables with the registered printer", func() {
+		Expect(HasTablePrinter(&registryTestResource{})).To(BeTrue())
+		Expect(HasTablePrinter(registryTestUnregistered{})).To(BeFalse())
+		var out bytes.Buffer
+		Expect(WriteTable(&out, &registryTestResource{Name: "foo"})).To(Succeed())
+		Expect(out.String()).To(Equal("NAME\nfoo\n"))
+		Expect(WriteTable(&out, registryTestUnregistered{})).NotTo(Succeed())
+	})
+})
@@ -0,0 +1,196 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the functions used to implement the 'jsonpath', 'go-template',
+// 'custom-columns' and 'name' output formats. All of them work on the JSON representation of
+// the resource, so they support every type that can be printed as JSON.
+
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+)
+
+const noneValue = "<none>"
+
+// decode returns the generic representation of a JSON document, keeping numbers as they are.
+func decode(body []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var data interface{}
+	err := decoder.Decode(&data)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to decode resource: %v", err)
+	}
+	return data, nil
+}
+
+func printTemplate(format string, argument string, data interface{}) (string, error) {
+	switch format {
+	case JSONPATH:
+		return printJSONPath(argument, data)
+	case GOTEMPLATE:
+		return printGoTemplate(argument, data)
+	case CUSTOMCOLUMNS:
+		return printCustomColumns(argument, data)
+	case NAME:
+		return printNames(data), nil
+	}
+	return "", fmt.Errorf("Unknown format '%s'. Valid formats are %s", format, formats)
+}
+
+func printJSONPath(argument string, data interface{}) (string, error) {
+	if argument == "" {
+		return "", fmt.Errorf("Missing template for format '%s', for example: -o %s='{.id}'",
+			JSONPATH, JSONPATH)
+	}
+	path, err := ParseJSONPath(argument)
+	if err != nil {
+		return "", fmt.Errorf("Failed to parse jsonpath template: %v", err)
+	}
+	result, err := path.Execute(data)
+	if err != nil {
+		return "", fmt.Errorf("Failed to execute jsonpath template: %v", err)
+	}
+	return ensureNewline(result), nil
+}
+
+func printGoTemplate(argument string, data interface{}) (string, error) {
+	if argument == "" {
+		return "", fmt.Errorf("Missing template for format '%s', for example: -o %s='{{.id}}'",
+			GOTEMPLATE, GOTEMPLATE)
+	}
+	tmpl, err := template.New("output").Option("missingkey=zero").Parse(argument)
+	if err != nil {
+		return "", fmt.Errorf("Failed to parse go-template: %v", err)
+	}
+	var out bytes.Buffer
+	err = tmpl.Execute(&out, data)
+	if err != nil {
+		return "", fmt.Errorf("Failed to execute go-template: %v", err)
+	}
+	return ensureNewline(out.String()), nil
+}
+
+type column struct {
+	header string
+	path   string
+}
+
+// parseColumns parses a specification like 'ID:.id,NAME:.name'.
+func parseColumns(spec string) ([]column, error) {
+	if spec == "" {
+		return nil, fmt.Errorf("Missing columns for format '%s', for example: -o %s=ID:.id,NAME:.name",
+			CUSTOMCOLUMNS, CUSTOMCOLUMNS)
+	}
+	var columns []column
+	for _, part := range strings.Split(spec, ",") {
+		header, path, found := strings.Cut(part, ":")
+		if !found || header == "" || path == "" {
+			return nil, fmt.Errorf("Invalid column '%s', expected format is HEADER:PATH", part)
+		}
+		columns = append(columns, column{header: header, path: path})
+	}
+	return columns, nil
+}
+
+func printCustomColumns(spec string, data interface{}) (string, error) {
+	columns, err := parseColumns(spec)
+	if err != nil {
+		return "", err
+	}
+
+	var out bytes.Buffer
+	writer := tabwriter.NewWriter(&out, 0, 0, 2, ' ', 0)
+	headers := make([]string, len(columns))
+	for i, column := range columns {
+		headers[i] = column.header
+	}
+	fmt.Fprintf(writer, "%s\n", strings.Join(headers, "\t"))
+	for _, item := range items(data) {
+		values := make([]string, len(columns))
+		for i, column := range columns {
+			results, err := FindResults(column.path, item)
+			if err != nil {
+				return "", fmt.Errorf("Failed to parse column '%s': %v", column.header, err)
+			}
+			texts := make([]string, 0, len(results))
+			for _, result := range results {
+				text, err := formatValue(result)
+				if err != nil {
+					return "", err
+				}
+				texts = append(texts, text)
+			}
+			values[i] = strings.Join(texts, ",")
+			if values[i] == "" {
+				values[i] = noneValue
+			}
+		}
+		fmt.Fprintf(writer, "%s\n", strings.Join(values, "\t"))
+	}
+	err = writer.Flush()
+	if err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
+
+// printNames prints one 'kind/id' line per resource, falling back to the name of the resource when
+// it has no identifier.
+func printNames(data interface{}) string {
+	var out strings.Builder
+	for _, item := range items(data) {
+		object, ok := item.(map[string]interface{})
+		if !ok {
+			text, _ := formatValue(item)
+			fmt.Fprintf(&out, "%s\n", text)
+			continue
+		}
+		name := ""
+		for _, key := range []string{"id", "name", "ID", "Name", "arn", "RoleARN", "Arn"} {
+			if value, ok := object[key].(string); ok && value != "" {
+				name = value
+				break
+			}
+		}
+		if kind, ok := object["kind"].(string); ok && kind != "" {
+			name = strings.ToLower(kind) + "/" + name
+		}
+		fmt.Fprintf(&out, "%s\n", name)
+	}
+	return out.String()
+}
+
+// items returns the elements of a list, or the resource itself when it isn't a list.
+func items(data interface{}) []interface{} {
+	if list, ok := data.([]interface{}); ok {
+		return list
+	}
+	return []interface{}{data}
+}
+
+func ensureNewline(text string) string {
+	if text != "" && !strings.HasSuffix(text, "\n") {
+		return text + "\n"
+	}
+	return text
+}
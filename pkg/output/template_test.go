@@ -0,0 +1,106 @@
+package output
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Template formats", func() {
+	var data interface{}
+
+	BeforeEach(func() {
+		var err error
+		data, err = decode([]byte(`[
+			{"kind": "Cluster", "id": "123", "name": "foo", "state": "ready",
+			 "nodes": {"compute": 3}, "aws": {"tags": {"a": "1", "b": "2"}}},
+			{"kind": "Cluster", "id": "456", "name": "bar", "state": "installing",
+			 "nodes": {"compute": 6}}
+		]`))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Context("jsonpath", func() {
+		DescribeTable("Renders templates",
+			func(template string, expected string) {
+				out, err := printTemplate(JSONPATH, template, data)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(out).To(Equal(expected))
+			},
+			Entry("wildcard", "{[*].id}", "123 456\n"),
+			Entry("index", "{[0].name}", "foo\n"),
+			Entry("negative index", "{[-1].name}", "bar\n"),
+			Entry("slice", "{[0:1].name}", "foo\n"),
+			Entry("numbers", "{[1].nodes.compute}", "6\n"),
+			Entry("range", `{range [*]}{.id}{"\t"}{.state}{"\n"}{end}`, "123\tready\n456\tinstalling\n"),
+			Entry("filter", `{[?(@.state=="ready")].id}`, "123\n"),
+			Entry("numeric filter", `{[?(@.nodes.compute>4)].name}`, "bar\n"),
+			Entry("existence filter", `{[?(@.aws)].name}`, "foo\n"),
+			Entry("recursive descent", "{..compute}", "3 6\n"),
+			Entry("object values", "{[0].aws.tags.*}", "1 2\n"),
+			Entry("objects as JSON", "{[0].nodes}", "{\"compute\":3}\n"),
+			Entry("root", "{$[1].id}", "456\n"),
+			Entry("quoted field", "{[0]['name']}", "foo\n"),
+			Entry("missing field", "{[0].missing}", ""),
+		)
+
+		It("Fails on invalid templates", func() {
+			_, err := printTemplate(JSONPATH, "{range [*]}{.id}", data)
+			Expect(err).To(HaveOccurred())
+			_, err = printTemplate(JSONPATH, "{[*].id", data)
+			Expect(err).To(HaveOccurred())
+			_, err = printTemplate(JSONPATH, "", data)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("go-template", func() {
+		It("Renders templates", func() {
+			out, err := printTemplate(GOTEMPLATE, `{{range .}}{{.id}}:{{.nodes.compute}} {{end}}`, data)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out).To(Equal("123:3 456:6 \n"))
+		})
+
+		It("Fails on invalid templates", func() {
+			_, err := printTemplate(GOTEMPLATE, `{{range .}}`, data)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("custom-columns", func() {
+		It("Renders a table", func() {
+			out, err := printTemplate(CUSTOMCOLUMNS, "ID:.id,NAME:{.name},TAGS:.aws.tags.a", data)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out).To(Equal("" +
+				"ID   NAME  TAGS\n" +
+				"123  foo   1\n" +
+				"456  bar   <none>\n"))
+		})
+
+		It("Renders a single resource", func() {
+			single, err := decode([]byte(`{"id": "123", "state": "ready"}`))
+			Expect(err).NotTo(HaveOccurred())
+			out, err := printTemplate(CUSTOMCOLUMNS, "ID:.id,STATE:.state", single)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out).To(Equal("ID   STATE\n123  ready\n"))
+		})
+
+		It("Fails on invalid columns", func() {
+			_, err := printTemplate(CUSTOMCOLUMNS, "ID", data)
+			Expect(err).To(MatchError("Invalid column 'ID', expected format is HEADER:PATH"))
+		})
+	})
+
+	Context("name", func() {
+		It("Prints kind and identifier", func() {
+			out, err := printTemplate(NAME, "", data)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out).To(Equal("cluster/123\ncluster/456\n"))
+		})
+
+		It("Falls back to the name", func() {
+			single, err := decode([]byte(`{"name": "my-role"}`))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(printNames(single)).To(Equal("my-role\n"))
+		})
+	})
+})
@@ -0,0 +1,172 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the discovery of plugins, which are executables named 'rosa-<name>' found in
+// the PATH that are run as 'rosa <name>'. As in kubectl, dashes in the name of a plugin also match
+// separate words, so 'rosa-fleet-report' runs as 'rosa fleet-report' and as 'rosa fleet report'.
+
+package plugin
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// Prefix is the prefix of the names of the plugin executables.
+const Prefix = "rosa-"
+
+// Plugin describes a plugin executable found in the PATH.
+type Plugin struct {
+	Name     string   `json:"name"`
+	Path     string   `json:"path"`
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// Find returns the plugins found in the directories of the PATH, in the order that they are
+// searched. Plugins that can't run, because another plugin with the same name comes first in the
+// PATH or because the name is a built-in command of the given root command, have warnings.
+func Find(root *cobra.Command) []*Plugin {
+	plugins := []*Plugin{}
+	seen := map[string]string{}
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		if dir == "" {
+			continue
+		}
+		files, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, file := range files {
+			name, ok := pluginName(file.Name())
+			if !ok || file.IsDir() {
+				continue
+			}
+			path := filepath.Join(dir, file.Name())
+			plugin := &Plugin{Name: name, Path: path}
+			if !isExecutable(path) {
+				plugin.Warnings = append(plugin.Warnings, "not executable")
+			}
+			if first, ok := seen[name]; ok {
+				plugin.Warnings = append(plugin.Warnings, fmt.Sprintf("shadowed by '%s'", first))
+			} else {
+				seen[name] = path
+			}
+			if Builtin(root, name) {
+				plugin.Warnings = append(plugin.Warnings,
+					fmt.Sprintf("overshadowed by the built-in 'rosa %s' command", name))
+			}
+			plugins = append(plugins, plugin)
+		}
+	}
+	return plugins
+}
+
+// Builtin checks if the given name is a built-in command of the root command, including the ones
+// that cobra adds when the root command is executed.
+func Builtin(root *cobra.Command, name string) bool {
+	switch name {
+	case "help", cobra.ShellCompRequestCmd, cobra.ShellCompNoDescRequestCmd:
+		return true
+	}
+	for _, cmd := range root.Commands() {
+		if cmd.Name() == name || cmd.HasAlias(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// Lookup finds the plugin that runs the given words of the command line, trying the longest
+// combination of words first. It returns the path of the plugin and the remaining words, which are
+// its arguments.
+func Lookup(words []string) (path string, args []string, found bool) {
+	names := []string{}
+	for _, word := range words {
+		if strings.HasPrefix(word, "-") {
+			break
+		}
+		names = append(names, word)
+	}
+	for i := len(names); i > 0; i-- {
+		path, err := exec.LookPath(Prefix + strings.Join(names[:i], "-"))
+		if err == nil {
+			return path, words[i:], true
+		}
+	}
+	return "", nil, false
+}
+
+// SplitFlags splits the command line in the global flags that precede the name of the plugin and
+// the rest. It uses the given flags to know which ones take a value from the next word.
+func SplitFlags(flags *pflag.FlagSet, argv []string) (flagArgs []string, rest []string) {
+	i := 0
+	for i < len(argv) {
+		arg := argv[i]
+		if !strings.HasPrefix(arg, "-") || arg == "-" {
+			break
+		}
+		i++
+		if arg == "--" {
+			break
+		}
+		flagArgs = append(flagArgs, arg)
+		if strings.Contains(arg, "=") {
+			continue
+		}
+		var flag *pflag.Flag
+		if strings.HasPrefix(arg, "--") {
+			flag = flags.Lookup(arg[2:])
+		} else if len(arg) == 2 {
+			flag = flags.ShorthandLookup(arg[1:])
+		}
+		if flag != nil && flag.NoOptDefVal == "" && i < len(argv) {
+			flagArgs = append(flagArgs, argv[i])
+			i++
+		}
+	}
+	return flagArgs, argv[i:]
+}
+
+// pluginName returns the name of the plugin of the given file, if it is a plugin.
+func pluginName(file string) (string, bool) {
+	if !strings.HasPrefix(file, Prefix) {
+		return "", false
+	}
+	name := strings.TrimPrefix(file, Prefix)
+	if runtime.GOOS == "windows" {
+		name = strings.TrimSuffix(name, filepath.Ext(name))
+	}
+	return name, name != ""
+}
+
+func isExecutable(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() {
+		return false
+	}
+	if runtime.GOOS == "windows" {
+		_, err = exec.LookPath(path)
+		return err == nil
+	}
+	return info.Mode()&0111 != 0
+}
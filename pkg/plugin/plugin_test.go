@@ -0,0 +1,103 @@
+package plugin
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+var _ = Describe("Plugin", func() {
+	var first, second string
+	var root *cobra.Command
+
+	create := func(dir string, name string, mode os.FileMode) string {
+		path := filepath.Join(dir, name)
+		Expect(os.WriteFile(path, []byte("#!/bin/sh\n"), mode)).To(Succeed())
+		return path
+	}
+
+	BeforeEach(func() {
+		first = GinkgoT().TempDir()
+		second = GinkgoT().TempDir()
+		GinkgoT().Setenv("PATH", strings.Join([]string{first, second}, string(os.PathListSeparator)))
+		root = &cobra.Command{Use: "rosa"}
+		root.AddCommand(&cobra.Command{Use: "create", Aliases: []string{"add"}})
+	})
+
+	Context("Find", func() {
+		It("Finds the plugins in the order of the PATH with their warnings", func() {
+			report := create(first, "rosa-fleet-report", 0755)
+			create(first, "other-tool", 0755)
+			Expect(os.Mkdir(filepath.Join(first, "rosa-dir"), 0755)).To(Succeed())
+			shadowed := create(second, "rosa-fleet-report", 0755)
+			builtin := create(second, "rosa-create", 0755)
+			broken := create(second, "rosa-broken", 0644)
+
+			plugins := Find(root)
+			Expect(plugins).To(HaveLen(4))
+			Expect(plugins[0]).To(Equal(&Plugin{Name: "fleet-report", Path: report}))
+			Expect(plugins[1].Path).To(Equal(broken))
+			Expect(plugins[1].Warnings).To(Equal([]string{"not executable"}))
+			Expect(plugins[2].Path).To(Equal(builtin))
+			Expect(plugins[2].Warnings).To(Equal([]string{"overshadowed by the built-in 'rosa create' command"}))
+			Expect(plugins[3].Path).To(Equal(shadowed))
+			Expect(plugins[3].Warnings).To(Equal([]string{"shadowed by '" + report + "'"}))
+		})
+	})
+
+	Context("Builtin", func() {
+		It("Recognizes commands, aliases and the commands added by cobra", func() {
+			Expect(Builtin(root, "create")).To(BeTrue())
+			Expect(Builtin(root, "add")).To(BeTrue())
+			Expect(Builtin(root, "help")).To(BeTrue())
+			Expect(Builtin(root, cobra.ShellCompRequestCmd)).To(BeTrue())
+			Expect(Builtin(root, "fleet")).To(BeFalse())
+		})
+	})
+
+	Context("Lookup", func() {
+		It("Prefers the plugin that matches most words", func() {
+			fleet := create(first, "rosa-fleet", 0755)
+			report := create(second, "rosa-fleet-report", 0755)
+
+			path, args, found := Lookup([]string{"fleet", "report", "weekly", "--format", "csv"})
+			Expect(found).To(BeTrue())
+			Expect(path).To(Equal(report))
+			Expect(args).To(Equal([]string{"weekly", "--format", "csv"}))
+
+			path, args, found = Lookup([]string{"fleet", "--report", "weekly"})
+			Expect(found).To(BeTrue())
+			Expect(path).To(Equal(fleet))
+			Expect(args).To(Equal([]string{"--report", "weekly"}))
+
+			_, _, found = Lookup([]string{"costs"})
+			Expect(found).To(BeFalse())
+		})
+	})
+
+	Context("SplitFlags", func() {
+		It("Separates the global flags that precede the name of the plugin", func() {
+			flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+			flags.Bool("debug", false, "")
+			flags.String("region", "", "")
+			flags.StringP("output", "o", "", "")
+
+			flagArgs, rest := SplitFlags(flags, []string{
+				"--debug", "--region", "us-east-1", "-o", "json", "--profile=prod", "fleet", "--debug",
+			})
+			Expect(flagArgs).To(Equal([]string{
+				"--debug", "--region", "us-east-1", "-o", "json", "--profile=prod",
+			}))
+			Expect(rest).To(Equal([]string{"fleet", "--debug"}))
+
+			flagArgs, rest = SplitFlags(flags, []string{"fleet"})
+			Expect(flagArgs).To(BeEmpty())
+			Expect(rest).To(Equal([]string{"fleet"}))
+		})
+	})
+})
@@ -0,0 +1,110 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the logic that runs plugins, passing them the details of the current session
+// in environment variables.
+
+package plugin
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/aws/profile"
+	regionflag "github.com/openshift/rosa/pkg/aws/region"
+	"github.com/openshift/rosa/pkg/debug"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+// Environment variables passed to the plugins:
+const (
+	OCMURLEnv     = "ROSA_OCM_URL"
+	OCMTokenEnv   = "ROSA_OCM_TOKEN"
+	AWSProfileEnv = "ROSA_AWS_PROFILE"
+	AWSRegionEnv  = "ROSA_AWS_REGION"
+	DebugEnv      = "ROSA_DEBUG"
+)
+
+// Minimum time that the access token passed to plugins stays valid:
+const tokenValidity = 15 * time.Minute
+
+// Environment returns the environment variables that describe the current session to a plugin:
+// the URL of the OCM environment with an access token valid for some time, the AWS profile and
+// region, and whether debug mode is enabled. The OCM variables are omitted when not logged in, as
+// not every plugin needs OCM.
+func Environment(r *rosa.Runtime) []string {
+	env := map[string]string{
+		DebugEnv: strconv.FormatBool(debug.Enabled()),
+	}
+	err := r.WithOCME()
+	if err == nil {
+		accessToken, _, err := r.OCMClient.GetConnectionTokens(tokenValidity)
+		if err == nil {
+			env[OCMURLEnv] = r.OCMClient.GetConnectionURL()
+			env[OCMTokenEnv] = accessToken
+		}
+	}
+	if err != nil {
+		r.Reporter.Debugf("Plugin won't get OCM credentials: %v", err)
+	}
+	if value := profile.Profile(); value != "" {
+		env[AWSProfileEnv] = value
+	}
+	region := regionflag.Region()
+	if region == "" {
+		region, _ = aws.GetRegion("")
+	}
+	if region != "" {
+		env[AWSRegionEnv] = region
+	}
+	result := make([]string, 0, len(env))
+	for name, value := range env {
+		result = append(result, name+"="+value)
+	}
+	return result
+}
+
+// Run runs the plugin with the given arguments, adding the given variables to the environment of
+// the current process. Where possible the plugin replaces the current process, so that it receives
+// the signals and its exit code is the exit code of the command, and Run only returns if it can't
+// be started. Elsewhere it returns the exit code of the plugin.
+func Run(path string, args []string, env []string) (int, error) {
+	env = append(os.Environ(), env...)
+	if runtime.GOOS != "windows" {
+		err := syscall.Exec(path, append([]string{path}, args...), env)
+		return 1, err
+	}
+	cmd := exec.Command(path, args...)
+	cmd.Env = env
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode(), nil
+	}
+	if err != nil {
+		return 1, err
+	}
+	return 0, nil
+}
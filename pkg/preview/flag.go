@@ -0,0 +1,46 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains functions used to implement the '--dry-run' command line option of the edit
+// commands.
+
+package preview
+
+import (
+	"github.com/spf13/pflag"
+)
+
+var dryRun bool
+
+// AddFlag adds the dry run flag to the given set of command line flags.
+func AddFlag(flags *pflag.FlagSet) {
+	flags.BoolVar(
+		&dryRun,
+		"dry-run",
+		false,
+		"Show the changes that would be made, field by field, and exit without applying them.",
+	)
+}
+
+// DryRun returns a bool that indicates whether the changes should only be shown.
+func DryRun() bool {
+	return dryRun
+}
+
+// SetDryRun sets the value of the dry run flag.
+func SetDryRun(value bool) {
+	dryRun = value
+}
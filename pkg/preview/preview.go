@@ -0,0 +1,245 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the types and functions used by the edit commands to show the changes that
+// they are about to make, field by field, before sending them.
+
+package preview
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/openshift/rosa/pkg/apply"
+	"github.com/openshift/rosa/pkg/color"
+	"github.com/openshift/rosa/pkg/interactive"
+	"github.com/openshift/rosa/pkg/interactive/confirm"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+// Message prefix and suffix using ANSI escape sequences to highlight disruptive changes:
+const (
+	disruptiveColorPrefix = "\033[0;33m"
+	disruptiveColorSuffix = "\033[m"
+)
+
+// Preview is the difference between the current state of an object and the state requested by an
+// edit command.
+type Preview struct {
+	Object      string
+	Differences []apply.Difference
+
+	// disruptions contains the reason why changing a field is disruptive, indexed by the name of
+	// the field.
+	disruptions map[string]string
+}
+
+// New creates a preview of the changes to the given object, for example "machine pool 'workers'".
+func New(object string) *Preview {
+	return &Preview{
+		Object:      object,
+		disruptions: map[string]string{},
+	}
+}
+
+// Compare creates a preview with the differences between the current object and the patch that
+// will be sent to update it. Only the fields present in the patch are compared.
+func Compare[T any](object string, current, patch T, marshal func(T, io.Writer) error) (*Preview, error) {
+	currentFields, err := fields(current, marshal)
+	if err != nil {
+		return nil, err
+	}
+	patchFields, err := fields(patch, marshal)
+	if err != nil {
+		return nil, err
+	}
+	result := New(object)
+	for _, difference := range apply.Diff(currentFields, patchFields, nil) {
+		// The server omits the fields that have zero values, but patches usually contain them:
+		if difference.Current == nil && isZero(difference.Desired) {
+			continue
+		}
+		// Some numbers are sent as text, and the server may format them differently:
+		if sameNumber(difference.Current, difference.Desired) {
+			continue
+		}
+		result.Differences = append(result.Differences, difference)
+	}
+	return result, nil
+}
+
+func fields[T any](object T, marshal func(T, io.Writer) error) (map[string]interface{}, error) {
+	buffer := &bytes.Buffer{}
+	err := marshal(object, buffer)
+	if err != nil {
+		return nil, err
+	}
+	result := map[string]interface{}{}
+	err = json.Unmarshal(buffer.Bytes(), &result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// isZero returns true if the given value, decoded from JSON, is empty or contains only empty values.
+func isZero(value interface{}) bool {
+	switch typed := value.(type) {
+	case nil:
+		return true
+	case bool:
+		return !typed
+	case float64:
+		return typed == 0
+	case string:
+		return typed == ""
+	case []interface{}:
+		return len(typed) == 0
+	case map[string]interface{}:
+		for _, item := range typed {
+			if !isZero(item) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// sameNumber returns true if both values are text representations of the same number.
+func sameNumber(current, desired interface{}) bool {
+	currentText, ok := current.(string)
+	if !ok {
+		return false
+	}
+	desiredText, ok := desired.(string)
+	if !ok {
+		return false
+	}
+	currentNumber, err := strconv.ParseFloat(currentText, 64)
+	if err != nil {
+		return false
+	}
+	desiredNumber, err := strconv.ParseFloat(desiredText, 64)
+	if err != nil {
+		return false
+	}
+	return currentNumber == desiredNumber
+}
+
+// Add adds the difference of the given field, unless the current and desired values are equal.
+func (p *Preview) Add(field string, current, desired interface{}) *Preview {
+	if !reflect.DeepEqual(current, desired) {
+		p.Differences = append(p.Differences, apply.Difference{
+			Field:   field,
+			Current: current,
+			Desired: desired,
+		})
+	}
+	return p
+}
+
+// Disrupts marks the given field, and the fields nested inside it, as disruptive. The reason is
+// displayed next to the changes of those fields.
+func (p *Preview) Disrupts(field string, reason string) *Preview {
+	p.disruptions[field] = reason
+	return p
+}
+
+// Disruption returns the reason why changing the given field is disruptive, or an empty string if
+// it isn't.
+func (p *Preview) Disruption(field string) string {
+	for {
+		reason, ok := p.disruptions[field]
+		if ok {
+			return reason
+		}
+		index := strings.LastIndex(field, ".")
+		if index < 0 {
+			return ""
+		}
+		field = field[:index]
+	}
+}
+
+// Empty returns true if there are no differences.
+func (p *Preview) Empty() bool {
+	return len(p.Differences) == 0
+}
+
+// Disruptive returns true if any of the differences is disruptive.
+func (p *Preview) Disruptive() bool {
+	for _, difference := range p.Differences {
+		if p.Disruption(difference.Field) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// Print writes the differences, one field per line. Disruptive changes are marked with '!',
+// followed by the reason, and highlighted when color is enabled.
+func (p *Preview) Print(writer io.Writer) {
+	if p.Empty() {
+		fmt.Fprintf(writer, "No changes to %s.\n", p.Object)
+		return
+	}
+	fmt.Fprintf(writer, "~ update %s\n", p.Object)
+	for _, difference := range p.Differences {
+		line := fmt.Sprintf("%s: %s => %s", difference.Field,
+			apply.FormatValue(difference.Current), apply.FormatValue(difference.Desired))
+		reason := p.Disruption(difference.Field)
+		switch {
+		case reason == "":
+			fmt.Fprintf(writer, "    %s\n", line)
+		case color.UseColor():
+			fmt.Fprintf(writer, "  %s! %s (%s)%s\n", disruptiveColorPrefix, line, reason,
+				disruptiveColorSuffix)
+		default:
+			fmt.Fprintf(writer, "  ! %s (%s)\n", line, reason)
+		}
+	}
+}
+
+// Review shows the preview when the changes need to be reviewed before they are sent, and returns
+// true if they should be sent. In dry run mode it always returns false. In interactive mode it asks
+// the user to confirm the changes.
+func (p *Preview) Review(r *rosa.Runtime) bool {
+	if dryRun {
+		p.Print(os.Stdout)
+		r.Reporter.Infof("Dry run, no changes were made to %s", p.Object)
+		return false
+	}
+	if !interactive.Enabled() || p.Empty() {
+		return true
+	}
+	p.Print(os.Stdout)
+	question := "apply these changes to %s"
+	if p.Disruptive() {
+		question = "apply these changes, including the disruptive ones marked with '!', to %s"
+	}
+	if !confirm.Confirm(question, p.Object) {
+		r.Reporter.Infof("No changes were made to %s", p.Object)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,138 @@
+package preview
+
+import (
+	"bytes"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/apply"
+	"github.com/openshift/rosa/pkg/color"
+	"github.com/openshift/rosa/pkg/test"
+)
+
+var _ = Describe("Preview", func() {
+	BeforeEach(func() {
+		color.SetColor("never")
+	})
+
+	AfterEach(func() {
+		color.SetColor("auto")
+		SetDryRun(false)
+	})
+
+	Context("Compare", func() {
+		It("Compares only the fields of the patch", func() {
+			current, err := cmv1.NewNodePool().ID("workers").Replicas(2).AutoRepair(true).
+				Labels(map[string]string{"a": "b"}).Build()
+			Expect(err).ToNot(HaveOccurred())
+			patch, err := cmv1.NewNodePool().ID("workers").Replicas(3).
+				Labels(map[string]string{"a": "c"}).Build()
+			Expect(err).ToNot(HaveOccurred())
+
+			changes, err := Compare("machine pool 'workers'", current, patch, cmv1.MarshalNodePool)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(changes.Differences).To(Equal([]apply.Difference{
+				{Field: "labels.a", Current: "b", Desired: "c"},
+				{Field: "replicas", Current: 2.0, Desired: 3.0},
+			}))
+		})
+
+		It("Ignores zero values missing from the current object", func() {
+			current, err := cmv1.NewClusterAutoscaler().LogVerbosity(2).Build()
+			Expect(err).ToNot(HaveOccurred())
+			patch, err := cmv1.NewClusterAutoscaler().LogVerbosity(1).MaxPodGracePeriod(0).
+				BalanceSimilarNodeGroups(false).ResourceLimits(cmv1.NewAutoscalerResourceLimits().
+				Cores(cmv1.NewResourceRange().Min(0).Max(0))).Build()
+			Expect(err).ToNot(HaveOccurred())
+
+			changes, err := Compare("autoscaler", current, patch, cmv1.MarshalClusterAutoscaler)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(changes.Differences).To(Equal([]apply.Difference{
+				{Field: "log_verbosity", Current: 2.0, Desired: 1.0},
+			}))
+		})
+
+		It("Ignores numbers sent as text that are formatted differently", func() {
+			current, err := cmv1.NewClusterAutoscaler().
+				ScaleDown(cmv1.NewAutoscalerScaleDownConfig().UtilizationThreshold("0.5")).Build()
+			Expect(err).ToNot(HaveOccurred())
+			patch, err := cmv1.NewClusterAutoscaler().
+				ScaleDown(cmv1.NewAutoscalerScaleDownConfig().UtilizationThreshold("0.500000")).Build()
+			Expect(err).ToNot(HaveOccurred())
+
+			changes, err := Compare("autoscaler", current, patch, cmv1.MarshalClusterAutoscaler)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(changes.Empty()).To(BeTrue())
+		})
+	})
+
+	Context("Disruptions", func() {
+		It("Applies to the nested fields", func() {
+			changes := New("cluster 'mycluster'").
+				Add("registry_config.allowed_registries", nil, []string{"quay.io"}).
+				Add("api.listening", "external", "internal").
+				Disrupts("registry_config", "all machinepool nodes will be recreated")
+			Expect(changes.Disruption("registry_config.allowed_registries")).To(
+				Equal("all machinepool nodes will be recreated"))
+			Expect(changes.Disruption("api.listening")).To(BeEmpty())
+			Expect(changes.Disruptive()).To(BeTrue())
+		})
+
+		It("Isn't disruptive when the disruptive fields don't change", func() {
+			changes := New("cluster 'mycluster'").
+				Add("registry_config.allowed_registries", []string{"quay.io"}, []string{"quay.io"}).
+				Add("api.listening", "external", "internal").
+				Disrupts("registry_config", "all machinepool nodes will be recreated")
+			Expect(changes.Differences).To(HaveLen(1))
+			Expect(changes.Disruptive()).To(BeFalse())
+		})
+	})
+
+	Context("Print", func() {
+		It("Marks the disruptive changes", func() {
+			changes := New("machine pool 'workers'").
+				Add("replicas", 2, 3).
+				Add("kubelet_configs", []string{"a"}, []string{"b"}).
+				Disrupts("kubelet_configs", "the nodes of the machine pool will be recreated")
+			buffer := &bytes.Buffer{}
+			changes.Print(buffer)
+			Expect(buffer.String()).To(Equal("~ update machine pool 'workers'\n" +
+				"    replicas: 2 => 3\n" +
+				"  ! kubelet_configs: [\"a\"] => [\"b\"] (the nodes of the machine pool will be recreated)\n"))
+		})
+
+		It("Highlights the disruptive changes when color is enabled", func() {
+			color.SetColor("always")
+			changes := New("machine pool 'workers'").
+				Add("kubelet_configs", []string{"a"}, []string{"b"}).
+				Disrupts("kubelet_configs", "the nodes of the machine pool will be recreated")
+			buffer := &bytes.Buffer{}
+			changes.Print(buffer)
+			Expect(buffer.String()).To(ContainSubstring(
+				"  \033[0;33m! kubelet_configs: [\"a\"] => [\"b\"] (the nodes of the machine pool will be recreated)\033[m\n"))
+		})
+
+		It("Says when there are no changes", func() {
+			buffer := &bytes.Buffer{}
+			New("machine pool 'workers'").Print(buffer)
+			Expect(buffer.String()).To(Equal("No changes to machine pool 'workers'.\n"))
+		})
+	})
+
+	Context("Review", func() {
+		It("Doesn't send the changes in dry run mode", func() {
+			SetDryRun(true)
+			t := test.NewTestRuntime()
+			changes := New("machine pool 'workers'").Add("replicas", 2, 3)
+			Expect(changes.Review(t.RosaRuntime)).To(BeFalse())
+		})
+
+		It("Sends the changes without asking when not interactive", func() {
+			t := test.NewTestRuntime()
+			changes := New("machine pool 'workers'").Add("replicas", 2, 3)
+			Expect(changes.Review(t.RosaRuntime)).To(BeTrue())
+		})
+	})
+})
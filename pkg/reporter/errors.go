@@ -0,0 +1,325 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the classification of the errors reported by the tool into stable error codes
+// and process exit codes, and the JSON envelope used to report them to programs.
+
+package reporter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/aws/smithy-go"
+	ocmerrors "github.com/openshift-online/ocm-sdk-go/errors"
+	"github.com/spf13/pflag"
+	"github.com/zgalor/weberr"
+
+	"github.com/openshift/rosa/pkg/output"
+)
+
+// ErrorCode is the stable identifier of a class of errors. It is included in the JSON error
+// envelope and determines the exit code of the process.
+type ErrorCode string
+
+// The error codes and the exit codes that correspond to them:
+//
+//	1   error                    Any error that doesn't belong to one of the other classes.
+//	2   invalid_usage            The command line is invalid, for example an unknown flag.
+//	3   not_logged_in            There are no valid OCM credentials, or they were rejected.
+//	4   not_found                The cluster or other requested resource doesn't exist.
+//	5   forbidden                The OCM or AWS account isn't allowed to do the operation.
+//	6   aws_credentials_invalid  The AWS credentials are missing, invalid or expired.
+//	7   quota_exceeded           An OCM quota or AWS service limit has been reached.
+//	8   ocm_server_error         The OCM API returned a 5xx response.
+//	9   aws_error                Any other error returned by the AWS API.
+//	10  failed_state             The resource reached a failed state, like a cluster in the 'error' state.
+//	124 timeout                  The duration given with the '--timeout' flag expired.
+//	130 interrupted              The command was interrupted with SIGINT.
+const (
+	ErrorCodeGeneric               ErrorCode = "error"
+	ErrorCodeInvalidUsage          ErrorCode = "invalid_usage"
+	ErrorCodeNotLoggedIn           ErrorCode = "not_logged_in"
+	ErrorCodeNotFound              ErrorCode = "not_found"
+	ErrorCodeForbidden             ErrorCode = "forbidden"
+	ErrorCodeAWSCredentialsInvalid ErrorCode = "aws_credentials_invalid"
+	ErrorCodeQuotaExceeded         ErrorCode = "quota_exceeded"
+	ErrorCodeOCMServerError        ErrorCode = "ocm_server_error"
+	ErrorCodeAWSError              ErrorCode = "aws_error"
+	ErrorCodeFailedState           ErrorCode = "failed_state"
+	ErrorCodeTimeout               ErrorCode = "timeout"
+	ErrorCodeInterrupted           ErrorCode = "interrupted"
+)
+
+var exitCodes = map[ErrorCode]int{
+	ErrorCodeGeneric:               1,
+	ErrorCodeInvalidUsage:          2,
+	ErrorCodeNotLoggedIn:           3,
+	ErrorCodeNotFound:              4,
+	ErrorCodeForbidden:             5,
+	ErrorCodeAWSCredentialsInvalid: 6,
+	ErrorCodeQuotaExceeded:         7,
+	ErrorCodeOCMServerError:        8,
+	ErrorCodeAWSError:              9,
+	ErrorCodeFailedState:           10,
+	ErrorCodeTimeout:               124,
+	ErrorCodeInterrupted:           130,
+}
+
+// ExitCode returns the exit code of the process that corresponds to the error code.
+func (c ErrorCode) ExitCode() int {
+	code, ok := exitCodes[c]
+	if !ok {
+		return 1
+	}
+	return code
+}
+
+var awsCredentialsErrorCodes = map[string]bool{
+	"AuthFailure":                 true,
+	"ExpiredToken":                true,
+	"ExpiredTokenException":       true,
+	"IncompleteSignature":         true,
+	"InvalidClientTokenId":        true,
+	"SignatureDoesNotMatch":       true,
+	"UnrecognizedClientException": true,
+}
+
+var awsForbiddenErrorCodes = map[string]bool{
+	"AccessDenied":          true,
+	"AccessDeniedException": true,
+	"UnauthorizedOperation": true,
+}
+
+// ErrorEnvelope is the document that describes an error to programs. It is written to the standard
+// error stream when errors are reported in JSON format.
+type ErrorEnvelope struct {
+	Kind         string    `json:"kind"`
+	Code         ErrorCode `json:"code"`
+	ExitCode     int       `json:"exit_code"`
+	Message      string    `json:"message"`
+	OperationID  string    `json:"operation_id,omitempty"`
+	HTTPStatus   int       `json:"http_status,omitempty"`
+	OCMCode      string    `json:"ocm_code,omitempty"`
+	AWSErrorCode string    `json:"aws_error_code,omitempty"`
+	AWSRequestID string    `json:"aws_request_id,omitempty"`
+}
+
+// CodedError is implemented by the errors that know their own error code, like the errors of the
+// conditions that can't be met while waiting.
+type CodedError interface {
+	error
+	ErrorCode() ErrorCode
+}
+
+// NewErrorEnvelope creates the envelope for the given message, classifying it with the errors
+// found in the arguments used to format it.
+func NewErrorEnvelope(message string, args ...interface{}) *ErrorEnvelope {
+	envelope := &ErrorEnvelope{
+		Kind:    "Error",
+		Message: message,
+	}
+	status := 0
+	var code ErrorCode
+	deadline, canceled, quota := false, false, false
+	for _, arg := range args {
+		err, ok := arg.(error)
+		if !ok {
+			continue
+		}
+		walkError(err, func(err error) {
+			switch typed := err.(type) {
+			case *ocmerrors.Error:
+				if typed != nil && envelope.HTTPStatus == 0 {
+					envelope.HTTPStatus = typed.Status()
+					envelope.OperationID = typed.OperationID()
+					envelope.OCMCode = typed.Code()
+					quota = strings.Contains(strings.ToLower(typed.Reason()), "quota")
+				}
+			case smithy.APIError:
+				if envelope.AWSErrorCode == "" {
+					envelope.AWSErrorCode = typed.ErrorCode()
+				}
+			}
+			if requestID, ok := err.(interface{ ServiceRequestID() string }); ok &&
+				envelope.AWSRequestID == "" {
+				envelope.AWSRequestID = requestID.ServiceRequestID()
+			}
+			if coded, ok := err.(CodedError); ok && code == "" {
+				code = coded.ErrorCode()
+			}
+			if status == 0 && weberr.GetType(err) != weberr.NoType {
+				status = int(weberr.GetType(err))
+			}
+			deadline = deadline || err == context.DeadlineExceeded
+			canceled = canceled || err == context.Canceled
+		})
+	}
+	if envelope.HTTPStatus != 0 {
+		status = envelope.HTTPStatus
+	}
+	switch {
+	case code != "":
+		envelope.Code = code
+	case deadline:
+		envelope.Code = ErrorCodeTimeout
+	case canceled:
+		envelope.Code = ErrorCodeInterrupted
+	case quota:
+		envelope.Code = ErrorCodeQuotaExceeded
+	default:
+		envelope.Code = classify(envelope.AWSErrorCode, status, envelope.HTTPStatus != 0)
+	}
+	envelope.ExitCode = envelope.Code.ExitCode()
+	return envelope
+}
+
+// classify returns the error code that corresponds to the AWS error code or to the HTTP status of
+// the OCM response. Server errors are only classified as such when they come from an OCM response.
+func classify(awsErrorCode string, status int, fromOCM bool) ErrorCode {
+	switch {
+	case awsCredentialsErrorCodes[awsErrorCode]:
+		return ErrorCodeAWSCredentialsInvalid
+	case awsForbiddenErrorCodes[awsErrorCode]:
+		return ErrorCodeForbidden
+	case strings.HasSuffix(awsErrorCode, "LimitExceeded") ||
+		strings.HasSuffix(awsErrorCode, "QuotaExceededException"):
+		return ErrorCodeQuotaExceeded
+	case awsErrorCode != "":
+		return ErrorCodeAWSError
+	case status == http.StatusUnauthorized:
+		return ErrorCodeNotLoggedIn
+	case status == http.StatusPaymentRequired || status == http.StatusTooManyRequests:
+		return ErrorCodeQuotaExceeded
+	case status == http.StatusForbidden:
+		return ErrorCodeForbidden
+	case status == http.StatusNotFound:
+		return ErrorCodeNotFound
+	case status >= http.StatusInternalServerError && fromOCM:
+		return ErrorCodeOCMServerError
+	}
+	return ErrorCodeGeneric
+}
+
+// walkError calls the given function for the error and for all the errors that it wraps, following
+// the standard unwrapping methods, the 'Cause' method used by the 'weberr' package, and the details
+// that it attaches to errors.
+func walkError(err error, visit func(error)) {
+	for depth := 0; err != nil && depth < 32; depth++ {
+		visit(err)
+		for _, detail := range weberr.GetDetails(err) {
+			if detailErr, ok := detail.(error); ok {
+				walkError(detailErr, visit)
+			}
+		}
+		switch typed := err.(type) {
+		case interface{ Unwrap() []error }:
+			for _, wrapped := range typed.Unwrap() {
+				walkError(wrapped, visit)
+			}
+			return
+		case interface{ Unwrap() error }:
+			err = typed.Unwrap()
+		case interface{ Cause() error }:
+			err = typed.Cause()
+		default:
+			return
+		}
+	}
+}
+
+var (
+	lastErrorLock sync.Mutex
+	lastError     *ErrorEnvelope
+	errorHooks    []func(*ErrorEnvelope)
+)
+
+// ExitCode returns the exit code that corresponds to the last error reported, or 1 if no error has
+// been reported. Commands should use it when they exit after reporting an error.
+func ExitCode() int {
+	lastErrorLock.Lock()
+	defer lastErrorLock.Unlock()
+	if lastError == nil {
+		return 1
+	}
+	return lastError.ExitCode
+}
+
+// OnError adds a function that is called with each error reported, before the command exits.
+func OnError(hook func(*ErrorEnvelope)) {
+	lastErrorLock.Lock()
+	defer lastErrorLock.Unlock()
+	errorHooks = append(errorHooks, hook)
+}
+
+func recordError(envelope *ErrorEnvelope) {
+	lastErrorLock.Lock()
+	lastError = envelope
+	hooks := errorHooks
+	lastErrorLock.Unlock()
+	if envelope == nil {
+		return
+	}
+	for _, hook := range hooks {
+		hook(envelope)
+	}
+}
+
+var errorFormat string
+
+// AddErrorFormatFlag adds the '--error-format' flag to the given set of command line flags.
+func AddErrorFormatFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&errorFormat,
+		"error-format",
+		"",
+		"Format of the error messages, either 'text' or 'json'. "+
+			"Defaults to 'json' when the output format is 'json', and to 'text' otherwise.",
+	)
+}
+
+// SetErrorFormat sets the format of the error messages, either 'text' or 'json'.
+func SetErrorFormat(value string) {
+	errorFormat = value
+}
+
+// IsJSONErrorFormat returns whether errors are reported as JSON envelopes.
+func IsJSONErrorFormat() bool {
+	if errorFormat != "" {
+		return errorFormat == "json"
+	}
+	return output.Output() == "json"
+}
+
+// ValidateErrorFormat checks the value given with the '--error-format' flag.
+func ValidateErrorFormat() error {
+	switch errorFormat {
+	case "", "text", "json":
+		return nil
+	}
+	return fmt.Errorf("Invalid error format '%s', must be 'text' or 'json'", errorFormat)
+}
+
+func writeErrorEnvelope(writer io.Writer, envelope *ErrorEnvelope) {
+	encoder := json.NewEncoder(writer)
+	encoder.SetEscapeHTML(false)
+	_ = encoder.Encode(envelope)
+}
@@ -0,0 +1,162 @@
+package reporter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	"github.com/aws/smithy-go"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	ocmerrors "github.com/openshift-online/ocm-sdk-go/errors"
+	"github.com/zgalor/weberr"
+
+	"github.com/openshift/rosa/pkg/color"
+	"github.com/openshift/rosa/pkg/output"
+)
+
+var _ = Describe("Errors", func() {
+	AfterEach(func() {
+		SetErrorFormat("")
+		output.SetOutput("")
+		recordError(nil)
+	})
+
+	Context("NewErrorEnvelope", func() {
+		It("Classifies errors without known causes as generic", func() {
+			envelope := NewErrorEnvelope("Failed", fmt.Errorf("boom"), "text")
+			Expect(envelope.Code).To(Equal(ErrorCodeGeneric))
+			Expect(envelope.ExitCode).To(Equal(1))
+		})
+
+		It("Extracts the details of OCM errors wrapped by the OCM client", func() {
+			ocmErr, err := ocmerrors.NewError().
+				Status(http.StatusNotFound).
+				Code("CLUSTERS-MGMT-404").
+				OperationID("my-operation").
+				Reason("Cluster not found").
+				Build()
+			Expect(err).NotTo(HaveOccurred())
+			wrapped := fmt.Errorf("Failed to get cluster: %w",
+				weberr.NotFound.AddDetails(weberr.Errorf("%s", ocmErr.Error()), ocmErr))
+
+			envelope := NewErrorEnvelope("Failed", wrapped)
+			Expect(envelope.Code).To(Equal(ErrorCodeNotFound))
+			Expect(envelope.ExitCode).To(Equal(4))
+			Expect(envelope.OperationID).To(Equal("my-operation"))
+			Expect(envelope.HTTPStatus).To(Equal(http.StatusNotFound))
+			Expect(envelope.OCMCode).To(Equal("CLUSTERS-MGMT-404"))
+		})
+
+		It("Classifies OCM server errors", func() {
+			ocmErr, err := ocmerrors.NewError().Status(http.StatusBadGateway).Build()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(NewErrorEnvelope("Failed", ocmErr).Code).To(Equal(ErrorCodeOCMServerError))
+		})
+
+		It("Classifies OCM quota errors", func() {
+			ocmErr, err := ocmerrors.NewError().
+				Status(http.StatusForbidden).
+				Reason("Insufficient quota for the requested cluster").
+				Build()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(NewErrorEnvelope("Failed", ocmErr).Code).To(Equal(ErrorCodeQuotaExceeded))
+		})
+
+		It("Classifies typed errors", func() {
+			err := weberr.Unauthorized.Errorf("Not logged in, run the 'rosa login' command")
+			Expect(NewErrorEnvelope("Failed", err).Code).To(Equal(ErrorCodeNotLoggedIn))
+		})
+
+		It("Extracts the details of AWS errors", func() {
+			err := fmt.Errorf("Failed to create AWS client: %w", &awshttp.ResponseError{
+				ResponseError: &smithyhttp.ResponseError{
+					Response: &smithyhttp.Response{
+						Response: &http.Response{StatusCode: http.StatusForbidden},
+					},
+					Err: &smithy.GenericAPIError{Code: "InvalidClientTokenId"},
+				},
+				RequestID: "my-request",
+			})
+
+			envelope := NewErrorEnvelope("Failed", err)
+			Expect(envelope.Code).To(Equal(ErrorCodeAWSCredentialsInvalid))
+			Expect(envelope.ExitCode).To(Equal(6))
+			Expect(envelope.AWSErrorCode).To(Equal("InvalidClientTokenId"))
+			Expect(envelope.AWSRequestID).To(Equal("my-request"))
+		})
+
+		It("Classifies AWS limits", func() {
+			err := &smithy.GenericAPIError{Code: "VpcLimitExceeded"}
+			Expect(NewErrorEnvelope("Failed", err).Code).To(Equal(ErrorCodeQuotaExceeded))
+		})
+
+		It("Classifies errors that know their code", func() {
+			err := fmt.Errorf("Failed to wait: %w", codedError{})
+			Expect(NewErrorEnvelope("Failed", err).Code).To(Equal(ErrorCodeFailedState))
+		})
+
+		It("Classifies expired deadlines", func() {
+			err := fmt.Errorf("Failed to wait: %w", context.DeadlineExceeded)
+			Expect(NewErrorEnvelope("Failed", err).ExitCode).To(Equal(124))
+		})
+	})
+
+	Context("Errorf", func() {
+		It("Records the exit code of the last error", func() {
+			Expect(ExitCode()).To(Equal(1))
+			var errOut bytes.Buffer
+			r := NewReporter(nil, &errOut)
+			r.Errorf("Failed: %v", weberr.NotFound.Errorf("There is no cluster"))
+			Expect(ExitCode()).To(Equal(4))
+			r.CodedErrorf(ErrorCodeInvalidUsage, "Bad flag")
+			Expect(ExitCode()).To(Equal(2))
+		})
+
+		It("Writes JSON envelopes when the error format is 'json'", func() {
+			color.SetColor("never")
+			defer color.SetColor("auto")
+			SetErrorFormat("json")
+			var errOut bytes.Buffer
+			r := NewReporter(nil, &errOut)
+			err := r.Errorf("Failed: %v", weberr.Forbidden.Errorf("Nope"))
+			Expect(err).To(MatchError("Failed: Nope"))
+
+			var envelope map[string]interface{}
+			Expect(json.Unmarshal(errOut.Bytes(), &envelope)).To(Succeed())
+			Expect(envelope).To(Equal(map[string]interface{}{
+				"kind":      "Error",
+				"code":      "forbidden",
+				"exit_code": float64(5),
+				"message":   "Failed: Nope",
+			}))
+		})
+
+		It("Writes JSON envelopes when the output format is 'json'", func() {
+			output.SetOutput("json")
+			Expect(IsJSONErrorFormat()).To(BeTrue())
+			SetErrorFormat("text")
+			Expect(IsJSONErrorFormat()).To(BeFalse())
+		})
+
+		It("Rejects unknown error formats", func() {
+			SetErrorFormat("xml")
+			Expect(ValidateErrorFormat()).To(MatchError(
+				"Invalid error format 'xml', must be 'text' or 'json'"))
+		})
+	})
+})
+
+type codedError struct{}
+
+func (codedError) Error() string {
+	return "Cluster is in 'error' state"
+}
+
+func (codedError) ErrorCode() ErrorCode {
+	return ErrorCodeFailedState
+}
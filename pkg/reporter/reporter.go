@@ -19,6 +19,7 @@ package reporter
 import (
 	"errors"
 	"fmt"
+	"io"
 	"os"
 
 	"github.com/openshift/rosa/pkg/color"
@@ -26,8 +27,10 @@ import (
 )
 
 // Object is the reported object used by the tool. It prints the messages to the standard output or
-// error streams.
+// error streams, unless other writers are given with NewReporter.
 type Object struct {
+	out    io.Writer
+	errOut io.Writer
 }
 
 // Debugf prints a debug message with the given format and arguments.
@@ -42,9 +45,9 @@ func (r *Object) Debugf(format string, args ...interface{}) {
 func (r *Object) Infof(format string, args ...interface{}) {
 	message := fmt.Sprintf(format, args...)
 	if color.UseColor() {
-		_, _ = fmt.Fprintf(os.Stdout, "%s%s\n", infoColorPrefix, message)
+		_, _ = fmt.Fprintf(r.stdout(), "%s%s\n", infoColorPrefix, message)
 	} else {
-		_, _ = fmt.Fprintf(os.Stdout, "%s%s\n", infoPrefix, message)
+		_, _ = fmt.Fprintf(r.stdout(), "%s%s\n", infoPrefix, message)
 	}
 }
 
@@ -52,21 +55,36 @@ func (r *Object) Infof(format string, args ...interface{}) {
 func (r *Object) Warnf(format string, args ...interface{}) {
 	message := fmt.Sprintf(format, args...)
 	if color.UseColor() {
-		_, _ = fmt.Fprintf(os.Stderr, "%s%s\n", warnColorPrefix, message)
+		_, _ = fmt.Fprintf(r.stderr(), "%s%s\n", warnColorPrefix, message)
 	} else {
-		_, _ = fmt.Fprintf(os.Stderr, "%s%s\n", warnPrefix, message)
+		_, _ = fmt.Fprintf(r.stderr(), "%s%s\n", warnPrefix, message)
 	}
 }
 
 // Errorf prints an error message with the given format and arguments. It also return an error
 // containing the same information, which will be usually discarded, except when the caller needs to
-// report the error and also return it.
+// report the error and also return it. The error is classified using the errors included in the
+// arguments, and the result is what ExitCode returns afterwards.
 func (r *Object) Errorf(format string, args ...interface{}) error {
+	return r.CodedErrorf(ErrorCodeGeneric, format, args...)
+}
+
+// CodedErrorf is like Errorf, but it uses the given error code when the arguments don't contain an
+// error that can be classified.
+func (r *Object) CodedErrorf(code ErrorCode, format string, args ...interface{}) error {
 	message := fmt.Sprintf(format, args...)
-	if color.UseColor() {
-		_, _ = fmt.Fprintf(os.Stderr, "%s%s\n", errorColorPrefix, message)
+	envelope := NewErrorEnvelope(message, args...)
+	if envelope.Code == ErrorCodeGeneric {
+		envelope.Code = code
+		envelope.ExitCode = code.ExitCode()
+	}
+	recordError(envelope)
+	if IsJSONErrorFormat() {
+		writeErrorEnvelope(r.stderr(), envelope)
+	} else if color.UseColor() {
+		_, _ = fmt.Fprintf(r.stderr(), "%s%s\n", errorColorPrefix, message)
 	} else {
-		_, _ = fmt.Fprintf(os.Stderr, "%s%s\n", errorPrefix, message)
+		_, _ = fmt.Fprintf(r.stderr(), "%s%s\n", errorPrefix, message)
 	}
 	return errors.New(message)
 }
@@ -84,14 +102,41 @@ const (
 // Determine whether the reporter output is meant for the terminal
 // or whether it's piped or redirected to a file.
 func (r *Object) IsTerminal() bool {
-	stdout, err := os.Stdout.Stat()
+	file, ok := r.stdout().(*os.File)
+	if !ok {
+		return false
+	}
+	stdout, err := file.Stat()
 	if err != nil {
 		return true
 	}
 	return (stdout.Mode()&os.ModeDevice != 0) && (stdout.Mode()&os.ModeNamedPipe == 0)
 }
 
+func (r *Object) stdout() io.Writer {
+	if r != nil && r.out != nil {
+		return r.out
+	}
+	return os.Stdout
+}
+
+func (r *Object) stderr() io.Writer {
+	if r != nil && r.errOut != nil {
+		return r.errOut
+	}
+	return os.Stderr
+}
+
 // CreateReporter returns a new reporter
 func CreateReporter() *Object {
 	return &Object{}
 }
+
+// NewReporter returns a new reporter that writes the informative messages to out and the warnings
+// and errors to errOut. Nil writers are replaced by the standard output and error streams.
+func NewReporter(out io.Writer, errOut io.Writer) *Object {
+	return &Object{
+		out:    out,
+		errOut: errOut,
+	}
+}
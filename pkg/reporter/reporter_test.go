@@ -1,6 +1,7 @@
 package reporter
 
 import (
+	"bytes"
 	"io"
 	"os"
 	"testing"
@@ -149,6 +150,26 @@ var _ = Describe("Reporter Tests", func() {
 			Expect(stdErr).To(BeEmpty())
 		})
 	})
+
+	Context("Writers", func() {
+		It("Prints to the given writers", func() {
+			color.SetColor("never")
+			var out, errOut bytes.Buffer
+			reporter := NewReporter(&out, &errOut)
+
+			stdOut, stdErr := captureStdOutAndStdError(func() {
+				reporter.Infof("Hello")
+				reporter.Warnf("Careful")
+				reporter.Errorf("Failed")
+			})
+
+			Expect(stdOut).To(BeEmpty())
+			Expect(stdErr).To(BeEmpty())
+			Expect(out.String()).To(Equal(infoPrefix + "Hello\n"))
+			Expect(errOut.String()).To(Equal(warnPrefix + "Careful\n" + errorPrefix + "Failed\n"))
+			Expect(reporter.IsTerminal()).To(BeFalse())
+		})
+	})
 })
 
 func captureStdOutAndStdError(function func()) (string, string) {
@@ -0,0 +1,72 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the '--retry-*' flags.
+
+package retry
+
+import (
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+const (
+	attemptsFlag   = "retry-attempts"
+	backoffFlag    = "retry-backoff"
+	maxBackoffFlag = "retry-max-backoff"
+	jitterFlag     = "retry-jitter"
+)
+
+var (
+	flags      *pflag.FlagSet
+	attempts   int
+	backoff    time.Duration
+	maxBackoff time.Duration
+	jitter     float64
+)
+
+// AddFlags adds the flags that change the retry policy to the given set of command line flags. The
+// flags that aren't used keep the values of the configuration, or the defaults of each API.
+func AddFlags(fs *pflag.FlagSet) {
+	flags = fs
+	fs.IntVar(
+		&attempts,
+		attemptsFlag,
+		0,
+		"Maximum attempts of the OCM and AWS API calls that are throttled or fail transiently. "+
+			"Use 1 to disable retries. Defaults to 3 for OCM and 12 for AWS.",
+	)
+	fs.DurationVar(
+		&backoff,
+		backoffFlag,
+		0,
+		"Time to wait before the first retry. It is doubled for each retry, unless the server "+
+			"asks to wait a different time with the 'Retry-After' header. Defaults to 1s.",
+	)
+	fs.DurationVar(
+		&maxBackoff,
+		maxBackoffFlag,
+		0,
+		"Maximum time to wait between retries. Defaults to 30s for OCM and 5s for AWS.",
+	)
+	fs.Float64Var(
+		&jitter,
+		jitterFlag,
+		0,
+		"Fraction of the wait between retries that is randomly added or subtracted. Defaults to 0.2.",
+	)
+}
@@ -0,0 +1,187 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the policy that decides how many times the OCM and AWS API calls that are
+// throttled or fail transiently are retried, and how long to wait between the attempts.
+
+package retry
+
+import (
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/openshift/rosa/pkg/config"
+)
+
+// Policy describes how API calls are retried.
+type Policy struct {
+	// MaxAttempts is the maximum number of attempts of each call, including the first one.
+	MaxAttempts int
+
+	// Backoff is the time to wait before the first retry. It is doubled for each retry, up to
+	// MaxBackoff.
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+
+	// Jitter is the fraction of the wait that is randomly added or subtracted, so that clients
+	// that were throttled at the same time don't retry at the same time.
+	Jitter float64
+}
+
+// DefaultPolicy is the policy used for the OCM API when the configuration and the flags don't
+// change it. It retries like the OCM SDK does by default.
+var DefaultPolicy = Policy{
+	MaxAttempts: 3,
+	Backoff:     1 * time.Second,
+	MaxBackoff:  30 * time.Second,
+	Jitter:      0.2,
+}
+
+// Current returns the policy that results from replacing the given defaults with the settings of
+// the configuration, and then with the '--retry-*' flags.
+func Current(cfg *config.Config, defaults Policy) (Policy, error) {
+	policy := defaults
+	if cfg != nil {
+		if cfg.RetryAttempts != 0 {
+			policy.MaxAttempts = cfg.RetryAttempts
+		}
+		if cfg.RetryBackoff != "" {
+			backoff, err := ParseBackoff("retry_backoff", cfg.RetryBackoff)
+			if err != nil {
+				return Policy{}, err
+			}
+			policy.Backoff = backoff
+		}
+		if cfg.RetryMaxBackoff != "" {
+			backoff, err := ParseBackoff("retry_max_backoff", cfg.RetryMaxBackoff)
+			if err != nil {
+				return Policy{}, err
+			}
+			policy.MaxBackoff = backoff
+		}
+		if cfg.RetryJitter != nil {
+			policy.Jitter = *cfg.RetryJitter
+		}
+	}
+	if flags != nil {
+		if flags.Changed(attemptsFlag) {
+			policy.MaxAttempts = attempts
+		}
+		if flags.Changed(backoffFlag) {
+			policy.Backoff = backoff
+		}
+		if flags.Changed(maxBackoffFlag) {
+			policy.MaxBackoff = maxBackoff
+		}
+		if flags.Changed(jitterFlag) {
+			policy.Jitter = jitter
+		}
+	}
+	err := policy.Validate()
+	if err != nil {
+		return Policy{}, err
+	}
+	// A longer first wait than the maximum given by the defaults means that the user wants to
+	// wait longer:
+	if policy.MaxBackoff < policy.Backoff {
+		policy.MaxBackoff = policy.Backoff
+	}
+	return policy, nil
+}
+
+// Validate checks that the values of the policy are in range.
+func (p Policy) Validate() error {
+	err := ValidateAttempts(p.MaxAttempts)
+	if err != nil {
+		return err
+	}
+	if p.Backoff <= 0 || p.MaxBackoff <= 0 {
+		return fmt.Errorf("Retry backoff must be a positive duration")
+	}
+	return ValidateJitter(p.Jitter)
+}
+
+// ValidateAttempts checks that the maximum number of attempts of a call is at least one.
+func ValidateAttempts(value int) error {
+	if value < 1 {
+		return fmt.Errorf("Retry attempts must be at least 1, but got %d", value)
+	}
+	return nil
+}
+
+// ValidateJitter checks that the jitter is a fraction between zero and one.
+func ValidateJitter(value float64) error {
+	if value < 0 || value > 1 {
+		return fmt.Errorf("Retry jitter must be between 0 and 1, but got %v", value)
+	}
+	return nil
+}
+
+// ParseBackoff parses the duration of a backoff setting, like '500ms' or '2s'.
+func ParseBackoff(name string, value string) (time.Duration, error) {
+	result, err := time.ParseDuration(value)
+	if err != nil || result <= 0 {
+		return 0, fmt.Errorf("Invalid value '%s' for '%s', it must be a positive duration like '2s'",
+			value, name)
+	}
+	return result, nil
+}
+
+// Delay returns the time to wait before the given retry, starting with one for the first retry.
+// When the server said how long to wait, with the 'Retry-After' header, that is honoured instead,
+// but never beyond the maximum backoff, so that a server can't keep the command waiting for hours.
+func (p Policy) Delay(retry int, retryAfter time.Duration) time.Duration {
+	if retryAfter > 0 {
+		if retryAfter > p.MaxBackoff {
+			return p.MaxBackoff
+		}
+		return retryAfter
+	}
+	delay := p.Backoff
+	for i := 1; i < retry && delay < p.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > p.MaxBackoff {
+		delay = p.MaxBackoff
+	}
+	// #nosec G404 -- the jitter doesn't need a secure random number
+	factor := p.Jitter * (1 - 2*rand.Float64())
+	return delay + time.Duration(float64(delay)*factor)
+}
+
+// RetryAfter returns the time to wait requested by the 'Retry-After' header of the response, which
+// can contain a number of seconds or a date. It returns zero if there is no valid header.
+func RetryAfter(header http.Header, now time.Time) time.Duration {
+	value := header.Get("Retry-After")
+	if value == "" {
+		return 0
+	}
+	seconds, err := strconv.Atoi(value)
+	if err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	date, err := http.ParseTime(value)
+	if err != nil || !date.After(now) {
+		return 0
+	}
+	return date.Sub(now)
+}
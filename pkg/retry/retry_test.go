@@ -0,0 +1,219 @@
+package retry
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/pflag"
+
+	"github.com/openshift/rosa/pkg/config"
+)
+
+var _ = Describe("Policy", func() {
+	AfterEach(func() {
+		flags = nil
+	})
+
+	It("Replaces the defaults with the configuration and then with the flags", func() {
+		jitter := 0.5
+		cfg := &config.Config{
+			RetryAttempts: 5,
+			RetryBackoff:  "2s",
+			RetryJitter:   &jitter,
+		}
+		policy, err := Current(cfg, DefaultPolicy)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(policy).To(Equal(Policy{
+			MaxAttempts: 5,
+			Backoff:     2 * time.Second,
+			MaxBackoff:  DefaultPolicy.MaxBackoff,
+			Jitter:      0.5,
+		}))
+
+		fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+		AddFlags(fs)
+		Expect(fs.Parse([]string{"--retry-attempts=7", "--retry-max-backoff=1m"})).To(Succeed())
+		policy, err = Current(cfg, DefaultPolicy)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(policy).To(Equal(Policy{
+			MaxAttempts: 7,
+			Backoff:     2 * time.Second,
+			MaxBackoff:  time.Minute,
+			Jitter:      0.5,
+		}))
+	})
+
+	It("Rejects invalid settings", func() {
+		_, err := Current(&config.Config{RetryBackoff: "soon"}, DefaultPolicy)
+		Expect(err).To(MatchError(ContainSubstring("Invalid value 'soon' for 'retry_backoff'")))
+
+		fs := pflag.NewFlagSet("test", pflag.ContinueOnError)
+		AddFlags(fs)
+		Expect(fs.Parse([]string{"--retry-jitter=2"})).To(Succeed())
+		_, err = Current(nil, DefaultPolicy)
+		Expect(err).To(MatchError("Retry jitter must be between 0 and 1, but got 2"))
+	})
+
+	It("Doubles the wait for each retry up to the maximum", func() {
+		policy := Policy{MaxAttempts: 10, Backoff: time.Second, MaxBackoff: 5 * time.Second}
+		Expect(policy.Delay(1, 0)).To(Equal(time.Second))
+		Expect(policy.Delay(2, 0)).To(Equal(2 * time.Second))
+		Expect(policy.Delay(3, 0)).To(Equal(4 * time.Second))
+		Expect(policy.Delay(4, 0)).To(Equal(5 * time.Second))
+		Expect(policy.Delay(9, 0)).To(Equal(5 * time.Second))
+		Expect(policy.Delay(2, 3*time.Second)).To(Equal(3 * time.Second))
+		Expect(policy.Delay(2, time.Hour)).To(Equal(5 * time.Second))
+
+		policy.Jitter = 0.2
+		for i := 0; i < 100; i++ {
+			Expect(policy.Delay(1, 0)).To(BeNumerically("~", time.Second, 200*time.Millisecond))
+		}
+	})
+
+	It("Parses the 'Retry-After' header", func() {
+		now := time.Now()
+		header := http.Header{}
+		Expect(RetryAfter(header, now)).To(BeZero())
+		header.Set("Retry-After", "3")
+		Expect(RetryAfter(header, now)).To(Equal(3 * time.Second))
+		header.Set("Retry-After", now.Add(10*time.Second).UTC().Format(http.TimeFormat))
+		Expect(RetryAfter(header, now)).To(BeNumerically("~", 10*time.Second, time.Second))
+		header.Set("Retry-After", "later")
+		Expect(RetryAfter(header, now)).To(BeZero())
+	})
+})
+
+var _ = Describe("Transport", func() {
+	var (
+		server   *httptest.Server
+		requests atomic.Int32
+		handler  func(w http.ResponseWriter, r *http.Request, attempt int)
+		client   *http.Client
+	)
+
+	policy := Policy{MaxAttempts: 3, Backoff: time.Millisecond, MaxBackoff: time.Millisecond}
+
+	BeforeEach(func() {
+		requests.Store(0)
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			handler(w, r, int(requests.Add(1)))
+		}))
+		logger := logrus.New()
+		logger.SetOutput(GinkgoWriter)
+		client = &http.Client{
+			Transport: NewTransportWrapper(policy, logger)(http.DefaultTransport),
+		}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("Retries throttled requests sending the body again", func() {
+		handler = func(w http.ResponseWriter, r *http.Request, attempt int) {
+			body, _ := io.ReadAll(r.Body)
+			Expect(string(body)).To(Equal(`{"name":"mycluster"}`))
+			if attempt < 3 {
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+		}
+		response, err := client.Post(server.URL, "application/json", strings.NewReader(`{"name":"mycluster"}`))
+		Expect(err).NotTo(HaveOccurred())
+		response.Body.Close()
+		Expect(response.StatusCode).To(Equal(http.StatusCreated))
+		Expect(requests.Load()).To(BeEquivalentTo(3))
+	})
+
+	It("Doesn't modify the request of the caller", func() {
+		handler = func(w http.ResponseWriter, r *http.Request, attempt int) {
+			if attempt < 2 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}
+		var sent []*http.Request
+		wrapper := NewTransportWrapper(policy, logrus.New())
+		transport := wrapper(roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			sent = append(sent, r)
+			return http.DefaultTransport.RoundTrip(r)
+		}))
+		request, err := http.NewRequest(http.MethodPut, server.URL, strings.NewReader("{}"))
+		Expect(err).NotTo(HaveOccurred())
+		body := request.Body
+		response, err := transport.RoundTrip(request)
+		Expect(err).NotTo(HaveOccurred())
+		response.Body.Close()
+		Expect(response.StatusCode).To(Equal(http.StatusOK))
+		Expect(sent).To(HaveLen(2))
+		Expect(sent[0]).NotTo(BeIdenticalTo(request))
+		Expect(sent[1]).NotTo(BeIdenticalTo(sent[0]))
+		Expect(Attempt(sent[0])).To(Equal(1))
+		Expect(Attempt(sent[1])).To(Equal(2))
+		Expect(Attempt(request)).To(Equal(1))
+		Expect(request.Body).To(BeIdenticalTo(body))
+	})
+
+	It("Returns the last response when the attempts are exhausted", func() {
+		handler = func(w http.ResponseWriter, r *http.Request, attempt int) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		response, err := client.Get(server.URL)
+		Expect(err).NotTo(HaveOccurred())
+		response.Body.Close()
+		Expect(response.StatusCode).To(Equal(http.StatusServiceUnavailable))
+		Expect(requests.Load()).To(BeEquivalentTo(3))
+	})
+
+	It("Retries server errors only for requests without side effects", func() {
+		handler = func(w http.ResponseWriter, r *http.Request, attempt int) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		response, err := client.Post(server.URL, "application/json", strings.NewReader("{}"))
+		Expect(err).NotTo(HaveOccurred())
+		response.Body.Close()
+		Expect(requests.Load()).To(BeEquivalentTo(1))
+
+		response, err = client.Get(server.URL)
+		Expect(err).NotTo(HaveOccurred())
+		response.Body.Close()
+		Expect(requests.Load()).To(BeEquivalentTo(4))
+	})
+
+	It("Stops waiting when the request is cancelled", func() {
+		handler = func(w http.ResponseWriter, r *http.Request, attempt int) {
+			w.Header().Set("Retry-After", "60")
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+		client.Transport = NewTransportWrapper(
+			Policy{MaxAttempts: 3, Backoff: time.Millisecond, MaxBackoff: time.Minute},
+			logrus.New(),
+		)(http.DefaultTransport)
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		request, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+		Expect(err).NotTo(HaveOccurred())
+		start := time.Now()
+		_, err = client.Do(request)
+		Expect(err).To(MatchError(context.DeadlineExceeded))
+		Expect(time.Since(start)).To(BeNumerically("<", 10*time.Second))
+		Expect(requests.Load()).To(BeEquivalentTo(1))
+	})
+})
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(request *http.Request) (*http.Response, error) {
+	return f(request)
+}
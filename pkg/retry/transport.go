@@ -0,0 +1,154 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the transport that retries the requests sent to OCM.
+
+package retry
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Fragments of the messages of the errors that mean that the connection failed before the server
+// processed the request, so it can be sent again regardless of the method:
+var transientErrors = []string{
+	"EOF",
+	"connection reset by peer",
+	"PROTOCOL_ERROR",
+	"REFUSED_STREAM",
+}
+
+// attemptKey is the key of the context value that contains the number of the attempt.
+type attemptKey struct{}
+
+// Attempt returns the number of the attempt of a request sent by the transport that retries
+// requests, starting with 1.
+func Attempt(request *http.Request) int {
+	attempt, ok := request.Context().Value(attemptKey{}).(int)
+	if !ok {
+		return 1
+	}
+	return attempt
+}
+
+type transport struct {
+	wrapped http.RoundTripper
+	policy  Policy
+	logger  *logrus.Logger
+}
+
+// NewTransportWrapper returns a function that wraps transports so that the requests that are
+// throttled or fail transiently are retried following the given policy. Each attempt is sent with a
+// copy of the request, and the retries are logged in debug mode.
+func NewTransportWrapper(policy Policy, logger *logrus.Logger) func(http.RoundTripper) http.RoundTripper {
+	return func(wrapped http.RoundTripper) http.RoundTripper {
+		return &transport{
+			wrapped: wrapped,
+			policy:  policy,
+			logger:  logger,
+		}
+	}
+}
+
+func (t *transport) RoundTrip(request *http.Request) (response *http.Response, err error) {
+	// Keep a copy of the body, so that it can be sent again. The request of the caller isn't
+	// modified, each attempt is sent with a clone that has its own reader of the body:
+	var body []byte
+	if request.Body != nil && request.Body != http.NoBody {
+		body, err = io.ReadAll(request.Body)
+		request.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	for attempt := 1; ; attempt++ {
+		response, err = t.wrapped.RoundTrip(t.clone(request, body, attempt))
+		if attempt >= t.policy.MaxAttempts {
+			return response, err
+		}
+		reason, retryAfter := t.retryable(request, response, err)
+		if reason == "" {
+			return response, err
+		}
+		if response != nil {
+			response.Body.Close()
+		}
+		delay := t.policy.Delay(attempt, retryAfter)
+		t.logger.Debugf("Request '%s %s' %s, retrying in %s (attempt %d of %d)",
+			request.Method, request.URL, reason, delay.Round(time.Millisecond), attempt+1,
+			t.policy.MaxAttempts)
+		timer := time.NewTimer(delay)
+		select {
+		case <-request.Context().Done():
+			timer.Stop()
+			return nil, request.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// clone returns the copy of the request sent in the given attempt, with a fresh reader of the body
+// and the number of the attempt in the context.
+func (t *transport) clone(request *http.Request, body []byte, attempt int) *http.Request {
+	result := request.Clone(context.WithValue(request.Context(), attemptKey{}, attempt))
+	if body != nil {
+		result.Body = io.NopCloser(bytes.NewReader(body))
+		result.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(body)), nil
+		}
+	}
+	return result
+}
+
+// retryable returns the reason to retry the request, or an empty string if it shouldn't be retried,
+// and the time that the server asked to wait.
+func (t *transport) retryable(request *http.Request, response *http.Response, err error) (string,
+	time.Duration) {
+	if err != nil {
+		if request.Context().Err() != nil {
+			return "", 0
+		}
+		for _, fragment := range transientErrors {
+			if strings.Contains(err.Error(), fragment) {
+				return fmt.Sprintf("failed with '%v'", err), 0
+			}
+		}
+		return "", 0
+	}
+	code := response.StatusCode
+	switch {
+	case code == http.StatusTooManyRequests || code == http.StatusServiceUnavailable:
+		// The server didn't process the request, so it is safe to retry regardless of the method:
+		return fmt.Sprintf("failed with status %d", code), RetryAfter(response.Header, time.Now())
+	case code >= http.StatusInternalServerError && idempotent(request.Method):
+		// The server may have processed the request, so only the requests without side effects are
+		// retried:
+		return fmt.Sprintf("failed with status %d", code), RetryAfter(response.Header, time.Now())
+	}
+	return "", 0
+}
+
+func idempotent(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead
+}
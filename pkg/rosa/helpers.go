@@ -4,6 +4,8 @@ import (
 	"os"
 
 	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/reporter"
 )
 
 const hostedCpFlagName = "hosted-cp"
@@ -14,7 +16,7 @@ func HostedClusterOnlyFlag(r *Runtime, cmd *cobra.Command, flagName string) {
 		if isFlagSet {
 			r.Reporter.Errorf("Setting the `%s` flag is only supported for Hosted Control Plane clusters",
 				flagName)
-			os.Exit(1)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 }
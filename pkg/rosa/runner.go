@@ -5,6 +5,8 @@ import (
 	"os"
 
 	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/reporter"
 )
 
 // RuntimeVisitor are functions that configure the Runtime for a command.
@@ -18,8 +20,8 @@ type CommandRunner func(ctx context.Context, runtime *Runtime, command *cobra.Co
 // of instantiating several key resources on behalf of a command
 func DefaultRunner(visitor RuntimeVisitor, runner CommandRunner) func(command *cobra.Command, args []string) {
 	return func(command *cobra.Command, args []string) {
-		ctx := context.Background()
 		r := NewRuntime()
+		ctx := r.Context
 		defer r.Cleanup()
 
 		if visitor != nil {
@@ -28,8 +30,8 @@ func DefaultRunner(visitor RuntimeVisitor, runner CommandRunner) func(command *c
 
 		err := runner(ctx, r, command, args)
 		if err != nil {
-			r.Reporter.Errorf(err.Error())
-			os.Exit(1)
+			r.Reporter.Errorf("%v", err)
+			os.Exit(reporter.ExitCode())
 		}
 	}
 }
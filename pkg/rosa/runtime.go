@@ -1,6 +1,9 @@
 package rosa
 
 import (
+	"context"
+	"fmt"
+	"io"
 	"os"
 	"time"
 
@@ -9,12 +12,17 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/config"
+	"github.com/openshift/rosa/pkg/interrupt"
 	"github.com/openshift/rosa/pkg/logging"
 	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/reporter"
+	"github.com/openshift/rosa/pkg/trace"
 )
 
 type Runtime struct {
+	// Context is cancelled when the user interrupts the command or the '--timeout' expires.
+	Context    context.Context
 	Reporter   *reporter.Object
 	Logger     *logrus.Logger
 	OCMClient  *ocm.Client
@@ -23,44 +31,150 @@ type Runtime struct {
 	ClusterKey string
 	Cluster    *cmv1.Cluster
 	Spinner    *spinner.Spinner
+
+	// config is the OCM configuration given to NewRuntimeE. When it is nil the configuration file
+	// is used.
+	config *config.Config
+}
+
+// RuntimeOptions contains the dependencies that can be injected in a runtime created with
+// NewRuntimeE. Fields that aren't set get the same values used by the command line tool, except
+// the context, which defaults to a background context so that signals aren't intercepted.
+type RuntimeOptions struct {
+	// Context cancels the requests and polling loops of the runtime.
+	Context context.Context
+
+	// Config is the OCM configuration used instead of the configuration file. Tokens refreshed
+	// by the connection are updated in this object and never saved.
+	Config *config.Config
+
+	// Logger is the logger used by the OCM and AWS clients.
+	Logger *logrus.Logger
+
+	// Out and ErrOut are the writers where the reporter prints informative messages and
+	// warnings or errors.
+	Out    io.Writer
+	ErrOut io.Writer
 }
 
 func NewRuntime() *Runtime {
 	reporter := reporter.CreateReporter()
 	logger := logging.NewLogger()
 	spinner := spinner.New(spinner.CharSets[9], 100*time.Millisecond)
-	return &Runtime{Reporter: reporter, Logger: logger, Spinner: spinner}
+	return &Runtime{
+		Context:  interrupt.RootContext(),
+		Reporter: reporter,
+		Logger:   logger,
+		Spinner:  spinner,
+	}
+}
+
+// NewRuntimeE creates a runtime for programs that use the tool as a library. Unlike NewRuntime, and
+// as long as only the methods ending with E are used, the runtime never exits the process.
+func NewRuntimeE(options RuntimeOptions) (*Runtime, error) {
+	if options.Config != nil && config.IsNotValid(options.Config) {
+		return nil, fmt.Errorf("The OCM configuration doesn't contain valid credentials")
+	}
+	ctx := options.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	logger := options.Logger
+	if logger == nil {
+		logger = logging.NewLogger()
+	}
+	out := options.Out
+	if out == nil {
+		out = os.Stdout
+	}
+	spinner := spinner.New(spinner.CharSets[9], 100*time.Millisecond, spinner.WithWriter(out))
+	return &Runtime{
+		Context:  ctx,
+		Reporter: reporter.NewReporter(options.Out, options.ErrOut),
+		Logger:   logger,
+		Spinner:  spinner,
+		config:   options.Config,
+	}, nil
 }
 
 // Adds an OCM client to the runtime. Requires a deferred call to `.Cleanup()` to close connections.
 func (r *Runtime) WithOCM() *Runtime {
-	if r.OCMClient == nil {
-		r.OCMClient = ocm.CreateNewClientOrExit(r.Logger, r.Reporter)
+	err := r.WithOCME()
+	if err != nil {
+		r.Reporter.Errorf("%v", err)
+		os.Exit(reporter.ExitCode())
 	}
 	return r
 }
 
+// WithOCME adds an OCM client to the runtime, returning an error if it can't be created. Requires a
+// deferred call to `.Cleanup()` to close connections.
+func (r *Runtime) WithOCME() error {
+	if r.OCMClient != nil {
+		return nil
+	}
+	span := trace.Begin("Connect to OCM")
+	client, err := ocm.NewClient().
+		Logger(r.Logger).
+		Config(r.config).
+		InMemory(r.config != nil).
+		Context(r.Context).
+		Build()
+	if err != nil {
+		err = fmt.Errorf("Failed to create OCM connection: %w", err)
+		span.End(err)
+		return err
+	}
+	span.End(nil)
+	r.OCMClient = client
+	return nil
+}
+
 // Adds an AWS client to the runtime
 func (r *Runtime) WithAWS() *Runtime {
-	// dependency to ocm client to validate the region
-	r.WithOCM()
-	err := r.OCMClient.ValidateAwsClientRegion()
+	err := r.WithAWSE()
 	if err != nil {
 		r.Reporter.Errorf("%v", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
+	}
+	return r
+}
+
+// WithAWSE adds an AWS client to the runtime, returning an error if it can't be created. It also
+// adds the OCM client, which is needed to validate the region.
+func (r *Runtime) WithAWSE() (err error) {
+	// dependency to ocm client to validate the region
+	err = r.WithOCME()
+	if err != nil {
+		return err
+	}
+	span := trace.Begin("Connect to AWS")
+	defer func() {
+		span.End(err)
+	}()
+	err = r.OCMClient.ValidateAwsClientRegion()
+	if err != nil {
+		return err
 	}
 	if r.AWSClient == nil {
-		r.AWSClient = aws.CreateNewClientOrExit(r.Logger, r.Reporter)
+		builder := aws.NewClient().
+			Logger(r.Logger).
+			Context(r.Context)
+		if r.config != nil {
+			builder.Config(r.config)
+		}
+		r.AWSClient, err = builder.Build()
+		if err != nil {
+			return fmt.Errorf("Failed to create AWS client: %w", err)
+		}
 	}
 	if r.Creator == nil {
-		var err error
 		r.Creator, err = r.AWSClient.GetCreator()
 		if err != nil {
-			r.Reporter.Errorf("Failed to get AWS creator: %v", err)
-			os.Exit(1)
+			return fmt.Errorf("Failed to get AWS creator: %w", err)
 		}
 	}
-	return r
+	return nil
 }
 
 func (r *Runtime) Cleanup() {
@@ -73,38 +187,69 @@ func (r *Runtime) Cleanup() {
 
 // Load the cluster key provided by the user into the runtime and return it
 func (r *Runtime) GetClusterKey() string {
-	clusterKey, err := ocm.GetClusterKey()
+	clusterKey, err := r.GetClusterKeyE()
 	if err != nil {
 		r.Reporter.Errorf("%s", err)
-		os.Exit(1)
+		os.Exit(reporter.ExitCode())
 	}
-	r.ClusterKey = clusterKey
 	return clusterKey
 }
 
+// GetClusterKeyE loads the cluster key provided with the '--cluster' flag into the runtime and
+// returns it. Programs that don't parse the command line can set the ClusterKey field instead.
+func (r *Runtime) GetClusterKeyE() (string, error) {
+	clusterKey, err := ocm.GetClusterKey()
+	if err != nil {
+		return "", err
+	}
+	r.ClusterKey = clusterKey
+	return clusterKey, nil
+}
+
 func (r *Runtime) FetchCluster() *cmv1.Cluster {
+	cluster, err := r.FetchClusterE()
+	if err != nil {
+		r.Reporter.Errorf("%v", err)
+		os.Exit(reporter.ExitCode())
+	}
+	return cluster
+}
+
+// FetchClusterE loads the cluster identified by the cluster key of the runtime, returning an error
+// if it doesn't exist or can't be retrieved.
+func (r *Runtime) FetchClusterE() (*cmv1.Cluster, error) {
 	if r.Cluster != nil {
-		return r.Cluster
+		return r.Cluster, nil
 	}
 
 	// We don't want to lazy init the OCM client since it requires cleanup
 	if r.OCMClient == nil {
-		r.Reporter.Errorf("Tried to fetch a cluster without initializing the OCM client, exiting.")
-		os.Exit(1)
+		return nil, fmt.Errorf("Tried to fetch a cluster without initializing the OCM client")
 	}
 	if r.ClusterKey == "" {
-		r.GetClusterKey()
+		_, err := r.GetClusterKeyE()
+		if err != nil {
+			return nil, err
+		}
 	}
 	if r.Creator == nil {
-		r.WithAWS()
+		err := r.WithAWSE()
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	r.Reporter.Debugf("Loading cluster '%s'", r.ClusterKey)
+	span := trace.Begin("Fetch cluster")
+	span.Set("rosa.cluster", r.ClusterKey)
 	cluster, err := r.OCMClient.GetCluster(r.ClusterKey, r.Creator)
 	if err != nil {
-		r.Reporter.Errorf("Failed to get cluster '%s': %v", r.ClusterKey, err)
-		os.Exit(1)
+		err = fmt.Errorf("Failed to get cluster '%s': %w", r.ClusterKey, err)
+		span.End(err)
+		return nil, err
 	}
+	span.Set("rosa.cluster.id", cluster.ID())
+	span.End(nil)
 	r.Cluster = cluster
-	return cluster
+	return cluster, nil
 }
@@ -0,0 +1,87 @@
+package rosa
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	. "github.com/openshift-online/ocm-sdk-go/testing"
+
+	"github.com/openshift/rosa/pkg/color"
+	"github.com/openshift/rosa/pkg/config"
+)
+
+var _ = Describe("Runtime", func() {
+	Context("NewRuntimeE", func() {
+		It("Uses the injected writers", func() {
+			color.SetColor("never")
+			defer color.SetColor("auto")
+			var out, errOut bytes.Buffer
+			r, err := NewRuntimeE(RuntimeOptions{Out: &out, ErrOut: &errOut})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(r.Context).To(Equal(context.Background()))
+			r.Reporter.Infof("Hello")
+			r.Reporter.Warnf("Careful")
+			Expect(out.String()).To(Equal("INFO: Hello\n"))
+			Expect(errOut.String()).To(Equal("WARN: Careful\n"))
+		})
+
+		It("Fails with a configuration without credentials", func() {
+			_, err := NewRuntimeE(RuntimeOptions{Config: &config.Config{}})
+			Expect(err).To(MatchError("The OCM configuration doesn't contain valid credentials"))
+		})
+
+		It("Fails to fetch a cluster without the OCM client", func() {
+			r, err := NewRuntimeE(RuntimeOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			r.ClusterKey = "my-cluster"
+			_, err = r.FetchClusterE()
+			Expect(err).To(MatchError("Tried to fetch a cluster without initializing the OCM client"))
+		})
+	})
+
+	Context("WithOCME", func() {
+		var server *ghttp.Server
+		var configFile string
+
+		BeforeEach(func() {
+			server = MakeTCPServer()
+			configFile = filepath.Join(GinkgoT().TempDir(), "ocm.json")
+			os.Setenv("OCM_CONFIG", configFile)
+		})
+
+		AfterEach(func() {
+			server.Close()
+			os.Unsetenv("OCM_CONFIG")
+		})
+
+		It("Uses the injected configuration without saving it", func() {
+			cfg := &config.Config{
+				URL:         server.URL(),
+				TokenURL:    server.URL(),
+				ClientID:    "cloud-services",
+				AccessToken: MakeTokenString("Bearer", 10*time.Minute),
+			}
+			r, err := NewRuntimeE(RuntimeOptions{Config: cfg})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(r.WithOCME()).To(Succeed())
+			defer r.Cleanup()
+			Expect(r.OCMClient).NotTo(BeNil())
+			Expect(r.OCMClient.GetConnectionURL()).To(Equal(server.URL()))
+			Expect(configFile).NotTo(BeAnExistingFile())
+		})
+
+		It("Returns an error when the configuration can't be loaded", func() {
+			r, err := NewRuntimeE(RuntimeOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			err = r.WithOCME()
+			Expect(err).To(MatchError(ContainSubstring("Failed to create OCM connection")))
+			Expect(r.OCMClient).To(BeNil())
+		})
+	})
+})
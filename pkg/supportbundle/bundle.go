@@ -0,0 +1,113 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the support bundle generated by the 'rosa collect support-bundle' command,
+// and the code that writes it as a compressed tarball.
+
+package supportbundle
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/openshift/rosa/pkg/logging"
+)
+
+// File is a file of a support bundle.
+type File struct {
+	Name string
+	Data []byte
+}
+
+// Bundle contains the files collected for a cluster, in the order they were collected, and the
+// errors of the artifacts that couldn't be collected.
+type Bundle struct {
+	Files  []*File
+	Errors []string
+	time   time.Time
+}
+
+// addJSON adds a file with the given JSON document, after redacting the fields that contain
+// secrets, tokens or passwords.
+func (b *Bundle) addJSON(name string, data []byte) error {
+	redacted, err := logging.RedactJSON(data, logging.SensitiveFields)
+	if err != nil {
+		return err
+	}
+	b.add(name, redacted)
+	return nil
+}
+
+// add adds a file with the given data, after redacting the credentials of the URLs that it contains,
+// like the ones of proxies that appear in the logs.
+func (b *Bundle) add(name string, data []byte) {
+	data = []byte(logging.RedactURLs(string(data)))
+	if len(data) > 0 && data[len(data)-1] != '\n' {
+		data = append(data, '\n')
+	}
+	b.Files = append(b.Files, &File{Name: name, Data: data})
+}
+
+// File returns the file with the given name, or nil if the bundle doesn't contain it.
+func (b *Bundle) File(name string) *File {
+	for _, file := range b.Files {
+		if file.Name == name {
+			return file
+		}
+	}
+	return nil
+}
+
+// Write writes the bundle to the given writer as a gzip compressed tarball, with all the files
+// inside the given directory. When some artifacts couldn't be collected their errors are written
+// to the 'errors.txt' file.
+func (b *Bundle) Write(writer io.Writer, directory string) error {
+	files := append([]*File{}, b.Files...)
+	if len(b.Errors) > 0 {
+		files = append(files, &File{
+			Name: "errors.txt",
+			Data: []byte(strings.Join(b.Errors, "\n") + "\n"),
+		})
+	}
+	compressor := gzip.NewWriter(writer)
+	archive := tar.NewWriter(compressor)
+	for _, file := range files {
+		err := archive.WriteHeader(&tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     path.Join(directory, file.Name),
+			Mode:     0600,
+			Size:     int64(len(file.Data)),
+			ModTime:  b.time,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to write file '%s' of the support bundle: %v", file.Name, err)
+		}
+		_, err = archive.Write(file.Data)
+		if err != nil {
+			return fmt.Errorf("failed to write file '%s' of the support bundle: %v", file.Name, err)
+		}
+	}
+	err := archive.Close()
+	if err != nil {
+		return err
+	}
+	return compressor.Close()
+}
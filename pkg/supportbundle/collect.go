@@ -0,0 +1,427 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the functions that collect the artifacts of a support bundle from the OCM
+// and AWS APIs.
+
+package supportbundle
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"runtime"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	errors "github.com/zgalor/weberr"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/info"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+// collection contains the information needed by the collectors.
+type collection struct {
+	r       *rosa.Runtime
+	cluster *cmv1.Cluster
+	tail    int
+	bundle  *Bundle
+}
+
+type collector struct {
+	artifact string
+	collect  func(c *collection) error
+}
+
+var collectors = []collector{
+	{artifact: "the version and environment", collect: collectEnvironment},
+	{artifact: "the cluster", collect: collectCluster},
+	{artifact: "the install logs", collect: collectInstallLogs},
+	{artifact: "the uninstall logs", collect: collectUninstallLogs},
+	{artifact: "the limited support reasons", collect: collectLimitedSupportReasons},
+	{artifact: "the inflight checks", collect: collectInflightChecks},
+	{artifact: "the machine pools", collect: collectMachinePools},
+	{artifact: "the identity providers", collect: collectIdentityProviders},
+	{artifact: "the ingresses", collect: collectIngresses},
+	{artifact: "the account and operator roles", collect: collectRoles},
+	{artifact: "the OIDC configuration and provider", collect: collectOIDC},
+	{artifact: "the network verification", collect: collectNetworkVerification},
+}
+
+// Collect collects the artifacts of the given cluster, getting the given number of lines of the
+// install and uninstall logs. Artifacts that can't be collected don't stop the collection of the
+// others, their errors are added to the bundle.
+func Collect(r *rosa.Runtime, cluster *cmv1.Cluster, tail int) *Bundle {
+	c := &collection{
+		r:       r,
+		cluster: cluster,
+		tail:    tail,
+		bundle:  &Bundle{time: time.Now()},
+	}
+	for _, collector := range collectors {
+		r.Reporter.Debugf("Collecting %s of cluster '%s'", collector.artifact, cluster.ID())
+		err := collector.collect(c)
+		if err != nil {
+			c.bundle.Errors = append(c.bundle.Errors, fmt.Sprintf("Failed to collect %s: %v",
+				collector.artifact, err))
+		}
+	}
+	return c.bundle
+}
+
+// addMarshalled adds a JSON file with the document written by the given marshal function of the
+// OCM SDK.
+func (c *collection) addMarshalled(name string, marshal func(writer io.Writer) error) error {
+	buffer := &bytes.Buffer{}
+	err := marshal(buffer)
+	if err != nil {
+		return err
+	}
+	return c.bundle.addJSON(name, buffer.Bytes())
+}
+
+func (c *collection) addObject(name string, object interface{}) error {
+	data, err := json.Marshal(object)
+	if err != nil {
+		return err
+	}
+	return c.bundle.addJSON(name, data)
+}
+
+// checkAccount checks that the AWS credentials belong to the account of the cluster, as the roles
+// and the OIDC provider of the cluster can't be inspected from another account.
+func (c *collection) checkAccount() error {
+	parsed, err := arn.Parse(c.cluster.AWS().STS().RoleARN())
+	if err != nil {
+		return err
+	}
+	if parsed.AccountID != c.r.Creator.AccountID {
+		return fmt.Errorf("the cluster belongs to AWS account '%s' and the AWS credentials to account '%s'",
+			parsed.AccountID, c.r.Creator.AccountID)
+	}
+	return nil
+}
+
+type environment struct {
+	RosaVersion  string    `json:"rosa_version"`
+	Build        string    `json:"build"`
+	GoVersion    string    `json:"go_version"`
+	OS           string    `json:"os"`
+	Arch         string    `json:"arch"`
+	OCMURL       string    `json:"ocm_url"`
+	AWSRegion    string    `json:"aws_region"`
+	AWSAccountID string    `json:"aws_account_id"`
+	AWSCallerARN string    `json:"aws_caller_arn"`
+	CollectedAt  time.Time `json:"collected_at"`
+}
+
+func collectEnvironment(c *collection) error {
+	return c.addObject("version.json", &environment{
+		RosaVersion:  info.DefaultVersion,
+		Build:        info.Build,
+		GoVersion:    runtime.Version(),
+		OS:           runtime.GOOS,
+		Arch:         runtime.GOARCH,
+		OCMURL:       c.r.OCMClient.GetConnectionURL(),
+		AWSRegion:    c.r.AWSClient.GetRegion(),
+		AWSAccountID: c.r.Creator.AccountID,
+		AWSCallerARN: c.r.Creator.ARN,
+		CollectedAt:  c.bundle.time.UTC(),
+	})
+}
+
+func collectCluster(c *collection) error {
+	return c.addMarshalled("cluster.json", func(writer io.Writer) error {
+		return cmv1.MarshalCluster(c.cluster, writer)
+	})
+}
+
+func collectInstallLogs(c *collection) error {
+	logs, err := c.r.OCMClient.GetInstallLogs(c.cluster.ID(), c.tail)
+	if errors.GetType(err) == errors.NotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	c.bundle.add("logs/install.log", []byte(logs.Content()))
+	return nil
+}
+
+func collectUninstallLogs(c *collection) error {
+	logs, err := c.r.OCMClient.GetUninstallLogs(c.cluster.ID(), c.tail)
+	if errors.GetType(err) == errors.NotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	c.bundle.add("logs/uninstall.log", []byte(logs.Content()))
+	return nil
+}
+
+func collectLimitedSupportReasons(c *collection) error {
+	reasons, err := c.r.OCMClient.GetLimitedSupportReasons(c.cluster.ID())
+	if err != nil {
+		return err
+	}
+	return c.addMarshalled("limited-support-reasons.json", func(writer io.Writer) error {
+		return cmv1.MarshalLimitedSupportReasonList(reasons, writer)
+	})
+}
+
+func collectInflightChecks(c *collection) error {
+	inflightChecks, err := c.r.OCMClient.GetInflightChecks(c.cluster.ID())
+	if err != nil {
+		return err
+	}
+	return c.addMarshalled("inflight-checks.json", func(writer io.Writer) error {
+		return cmv1.MarshalInflightCheckList(inflightChecks, writer)
+	})
+}
+
+func collectMachinePools(c *collection) error {
+	if ocm.IsHyperShiftCluster(c.cluster) {
+		nodePools, err := c.r.OCMClient.GetNodePools(c.cluster.ID())
+		if err != nil {
+			return err
+		}
+		return c.addMarshalled("node-pools.json", func(writer io.Writer) error {
+			return cmv1.MarshalNodePoolList(nodePools, writer)
+		})
+	}
+	machinePools, err := c.r.OCMClient.GetMachinePools(c.cluster.ID())
+	if err != nil {
+		return err
+	}
+	return c.addMarshalled("machine-pools.json", func(writer io.Writer) error {
+		return cmv1.MarshalMachinePoolList(machinePools, writer)
+	})
+}
+
+func collectIdentityProviders(c *collection) error {
+	identityProviders, err := c.r.OCMClient.GetIdentityProviders(c.cluster.ID())
+	if err != nil {
+		return err
+	}
+	return c.addMarshalled("identity-providers.json", func(writer io.Writer) error {
+		return cmv1.MarshalIdentityProviderList(identityProviders, writer)
+	})
+}
+
+func collectIngresses(c *collection) error {
+	ingresses, err := c.r.OCMClient.GetIngresses(c.cluster.ID())
+	if err != nil {
+		return err
+	}
+	return c.addMarshalled("ingresses.json", func(writer io.Writer) error {
+		return cmv1.MarshalIngressList(ingresses, writer)
+	})
+}
+
+// role contains the details of an account or operator role, with its trust policy and the
+// documents of its policies. Roles that can't be read contain the error instead.
+type role struct {
+	Type                     string          `json:"type,omitempty"`
+	Namespace                string          `json:"namespace,omitempty"`
+	Name                     string          `json:"name,omitempty"`
+	ARN                      string          `json:"arn"`
+	AssumeRolePolicyDocument json.RawMessage `json:"assume_role_policy_document,omitempty"`
+	Policies                 []*policy       `json:"policies,omitempty"`
+	Error                    string          `json:"error,omitempty"`
+}
+
+type policy struct {
+	Name     string          `json:"name"`
+	ARN      string          `json:"arn,omitempty"`
+	Type     string          `json:"type"`
+	Document json.RawMessage `json:"document,omitempty"`
+}
+
+// document returns the given policy document, decoding it first if it is URL encoded, as the IAM
+// API returns it.
+func document(text string) (json.RawMessage, error) {
+	decoded, err := url.QueryUnescape(text)
+	if err != nil {
+		return nil, err
+	}
+	if json.Valid([]byte(decoded)) {
+		return json.RawMessage(decoded), nil
+	}
+	return json.Marshal(decoded)
+}
+
+func (c *collection) describeRole(description *role) {
+	err := c.readRole(description)
+	if err != nil {
+		description.Error = err.Error()
+	}
+}
+
+func (c *collection) readRole(description *role) error {
+	iamRole, err := c.r.AWSClient.GetRoleByARN(description.ARN)
+	if err != nil {
+		return err
+	}
+	if iamRole.AssumeRolePolicyDocument != nil {
+		description.AssumeRolePolicyDocument, err = document(*iamRole.AssumeRolePolicyDocument)
+		if err != nil {
+			return err
+		}
+	}
+	policies, err := c.r.AWSClient.GetAttachedPolicy(iamRole.RoleName)
+	if err != nil {
+		return err
+	}
+	for _, policyDetail := range policies {
+		text := ""
+		if policyDetail.PolicyType == aws.Inline {
+			output, err := c.r.AWSClient.IsRolePolicyExists(*iamRole.RoleName, policyDetail.PolicyName)
+			if err != nil {
+				return err
+			}
+			if output.PolicyDocument != nil {
+				text = *output.PolicyDocument
+			}
+		} else {
+			text, err = c.r.AWSClient.GetDefaultPolicyDocument(policyDetail.PolicyArn)
+			if err != nil {
+				return err
+			}
+		}
+		policyDocument, err := document(text)
+		if err != nil {
+			return err
+		}
+		description.Policies = append(description.Policies, &policy{
+			Name:     policyDetail.PolicyName,
+			ARN:      policyDetail.PolicyArn,
+			Type:     policyDetail.PolicyType,
+			Document: policyDocument,
+		})
+	}
+	return nil
+}
+
+func collectRoles(c *collection) error {
+	if !ocm.IsSts(c.cluster) {
+		return nil
+	}
+	err := c.checkAccount()
+	if err != nil {
+		return err
+	}
+	sts := c.cluster.AWS().STS()
+	accountRoles := []*role{}
+	for _, accountRole := range []*role{
+		{Type: aws.InstallerAccountRoleType, ARN: sts.RoleARN()},
+		{Type: aws.SupportAccountRoleType, ARN: sts.SupportRoleARN()},
+		{Type: aws.ControlPlaneAccountRoleType, ARN: sts.InstanceIAMRoles().MasterRoleARN()},
+		{Type: aws.WorkerAccountRoleType, ARN: sts.InstanceIAMRoles().WorkerRoleARN()},
+	} {
+		if accountRole.ARN == "" {
+			continue
+		}
+		c.describeRole(accountRole)
+		accountRoles = append(accountRoles, accountRole)
+	}
+	err = c.addObject("roles/account-roles.json", accountRoles)
+	if err != nil {
+		return err
+	}
+	operatorRoles := []*role{}
+	for _, operatorIAMRole := range sts.OperatorIAMRoles() {
+		operatorRole := &role{
+			Namespace: operatorIAMRole.Namespace(),
+			Name:      operatorIAMRole.Name(),
+			ARN:       operatorIAMRole.RoleARN(),
+		}
+		c.describeRole(operatorRole)
+		operatorRoles = append(operatorRoles, operatorRole)
+	}
+	return c.addObject("roles/operator-roles.json", operatorRoles)
+}
+
+type oidcDetails struct {
+	IssuerURL   string          `json:"issuer_url"`
+	Config      json.RawMessage `json:"config,omitempty"`
+	ProviderARN string          `json:"provider_arn,omitempty"`
+}
+
+// collectOIDC collects the OIDC configuration from OCM and the ARN of the OIDC provider from AWS.
+// The configuration is written even when the provider can't be found.
+func collectOIDC(c *collection) error {
+	if !ocm.IsSts(c.cluster) {
+		return nil
+	}
+	sts := c.cluster.AWS().STS()
+	details := &oidcDetails{
+		IssuerURL: sts.OIDCEndpointURL(),
+	}
+	if id := sts.OidcConfig().ID(); id != "" {
+		oidcConfig, err := c.r.OCMClient.GetOidcConfig(id)
+		if err != nil {
+			return err
+		}
+		buffer := &bytes.Buffer{}
+		err = cmv1.MarshalOidcConfig(oidcConfig, buffer)
+		if err != nil {
+			return err
+		}
+		details.Config = buffer.Bytes()
+	}
+	providerErr := c.checkAccount()
+	if providerErr == nil {
+		details.ProviderARN, providerErr = c.r.AWSClient.GetOpenIDConnectProviderByOidcEndpointUrl(
+			details.IssuerURL)
+	}
+	if providerErr == nil && details.ProviderARN == "" {
+		providerErr = fmt.Errorf("there is no OIDC provider for '%s'", details.IssuerURL)
+	}
+	err := c.addObject("oidc.json", details)
+	if err != nil {
+		return err
+	}
+	return providerErr
+}
+
+// collectNetworkVerification collects the last network verification of the subnets of clusters
+// installed in an existing VPC. Subnets that were never verified are omitted.
+func collectNetworkVerification(c *collection) error {
+	subnets := c.cluster.AWS().SubnetIDs()
+	if len(subnets) == 0 {
+		return nil
+	}
+	verifications := []*cmv1.SubnetNetworkVerification{}
+	for _, subnet := range subnets {
+		verification, err := c.r.OCMClient.GetVerifyNetworkSubnet(subnet)
+		if errors.GetType(err) == errors.NotFound {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		verifications = append(verifications, verification)
+	}
+	return c.addMarshalled("network-verification.json", func(writer io.Writer) error {
+		return cmv1.MarshalSubnetNetworkVerificationList(verifications, writer)
+	})
+}
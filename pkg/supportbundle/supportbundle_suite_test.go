@@ -0,0 +1,13 @@
+package supportbundle
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestSupportBundle(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Support Bundle Suite")
+}
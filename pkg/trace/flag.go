@@ -0,0 +1,47 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the '--trace-file' flag.
+
+package trace
+
+import (
+	"github.com/spf13/pflag"
+)
+
+var file string
+
+// AddFlag adds the '--trace-file' flag to the given set of command line flags.
+func AddFlag(flags *pflag.FlagSet) {
+	flags.StringVar(
+		&file,
+		"trace-file",
+		"",
+		"File where the spans of the command, of its phases and of each OCM and AWS API call are "+
+			"written in the OTLP JSON lines format, for performance analysis.",
+	)
+}
+
+// SetFile sets the file where the spans are written. An empty name disables tracing.
+func SetFile(value string) {
+	file = value
+}
+
+// File returns the name of the file where the spans are written, or an empty string if tracing is
+// disabled.
+func File() string {
+	return file
+}
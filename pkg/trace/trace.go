@@ -0,0 +1,404 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the spans that describe where the time of a command goes, and the logic that
+// writes them to the trace file in the JSON encoding of the OpenTelemetry protocol (OTLP), so that
+// they can be loaded into tracing tools offline.
+//
+// The file contains one OTLP document per line, each with one span, like the files written by the
+// file exporter of the OpenTelemetry collector. Commands often exit the process directly, so each
+// span is appended to the file when it ends. The span of the command is always the last line, and it
+// is replaced each time, extending to the end of the last span written.
+
+package trace
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/openshift/rosa/pkg/info"
+	"github.com/openshift/rosa/pkg/reporter"
+)
+
+// Kinds of spans, with the values of the OTLP 'SpanKind' enumeration:
+const (
+	KindInternal = 1
+	KindClient   = 3
+)
+
+// Codes of the status of the spans, with the values of the OTLP 'StatusCode' enumeration:
+const (
+	statusUnset = 0
+	statusError = 2
+)
+
+// Name of the instrumentation scope of the spans:
+const scopeName = "github.com/openshift/rosa"
+
+// Span measures a command, a phase of a command or an API call. All its methods do nothing when
+// the span is nil, which is what Begin and Call return when tracing is disabled.
+type Span struct {
+	name       string
+	kind       int
+	id         string
+	parent     string
+	start      time.Time
+	end        time.Time
+	attributes map[string]interface{}
+	failure    string
+}
+
+func init() {
+	reporter.OnError(failed)
+}
+
+var (
+	lock    sync.Mutex
+	traceID string
+	root    *Span
+	phases  []*Span
+	lastEnd time.Time
+
+	// Size of the trace file without the line of the span of the command:
+	size int64
+)
+
+// Start starts tracing the command with the given name, if a trace file was given. It creates the
+// file, so that the errors writing to it are reported before the command runs.
+func Start(name string, attributes map[string]interface{}) error {
+	if file == "" {
+		return nil
+	}
+	lock.Lock()
+	defer lock.Unlock()
+	traceID = newID(16)
+	root = newSpan(name, KindInternal, "")
+	root.attributes = attributes
+	phases = nil
+	lastEnd = root.start
+	size = 0
+	err := os.MkdirAll(filepath.Dir(file), 0700)
+	if err != nil {
+		return err
+	}
+	err = os.WriteFile(file, nil, 0600)
+	if err != nil {
+		return err
+	}
+	return write(nil)
+}
+
+// Enabled checks if a command is being traced.
+func Enabled() bool {
+	lock.Lock()
+	defer lock.Unlock()
+	return root != nil
+}
+
+// Stop ends the span of the command, recording the given error, and writes the trace file for the
+// last time.
+func Stop(err error) error {
+	lock.Lock()
+	defer lock.Unlock()
+	if root == nil {
+		return nil
+	}
+	root.end = time.Now()
+	if err != nil && root.failure == "" {
+		root.failure = err.Error()
+	}
+	err = write(nil)
+	root = nil
+	phases = nil
+	return err
+}
+
+// failed marks the span of the command as failed with the reported error, and writes the trace
+// file, as the command usually exits right after reporting it.
+func failed(envelope *reporter.ErrorEnvelope) {
+	lock.Lock()
+	defer lock.Unlock()
+	if root == nil {
+		return
+	}
+	root.failure = envelope.Message
+	_ = write(nil)
+}
+
+// Begin starts a phase of the command. The spans that begin before the phase ends are its children.
+func Begin(name string) *Span {
+	lock.Lock()
+	defer lock.Unlock()
+	if root == nil {
+		return nil
+	}
+	span := newSpan(name, KindInternal, current().id)
+	phases = append(phases, span)
+	return span
+}
+
+// Call starts the span of a call to a remote API, as a child of the current phase.
+func Call(name string) *Span {
+	lock.Lock()
+	defer lock.Unlock()
+	if root == nil {
+		return nil
+	}
+	return newSpan(name, KindClient, current().id)
+}
+
+// Set sets an attribute of the span. Values can be strings, integers, booleans or slices of
+// strings.
+func (s *Span) Set(key string, value interface{}) {
+	if s == nil {
+		return
+	}
+	lock.Lock()
+	defer lock.Unlock()
+	s.attributes[key] = value
+}
+
+// End ends the span, recording the error if it isn't nil, and writes it to the trace file.
+func (s *Span) End(err error) {
+	if s == nil {
+		return
+	}
+	lock.Lock()
+	defer lock.Unlock()
+	if root == nil || !s.end.IsZero() {
+		return
+	}
+	s.end = time.Now()
+	if err != nil {
+		s.failure = err.Error()
+	}
+	for i, phase := range phases {
+		if phase == s {
+			phases = append(phases[:i], phases[i+1:]...)
+			break
+		}
+	}
+	if s.end.After(lastEnd) {
+		lastEnd = s.end
+	}
+	// The file was already checked when tracing started, and failing to write it shouldn't affect
+	// the command:
+	_ = write(s)
+}
+
+// current returns the innermost phase that hasn't ended, or the span of the command.
+func current() *Span {
+	if len(phases) > 0 {
+		return phases[len(phases)-1]
+	}
+	return root
+}
+
+func newSpan(name string, kind int, parent string) *Span {
+	return &Span{
+		name:       name,
+		kind:       kind,
+		id:         newID(8),
+		parent:     parent,
+		start:      time.Now(),
+		attributes: map[string]interface{}{},
+	}
+}
+
+func newID(size int) string {
+	data := make([]byte, size)
+	_, _ = rand.Read(data)
+	return hex.EncodeToString(data)
+}
+
+// write appends the given span, if any, to the trace file, followed by the span of the command,
+// which replaces the previous one. Until the command is stopped its span ends with the last span.
+func write(span *Span) error {
+	command := *root
+	if command.end.IsZero() {
+		command.end = lastEnd
+	}
+	writer, err := os.OpenFile(file, os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer writer.Close()
+	if span != nil {
+		line, err := encodeLine(span)
+		if err != nil {
+			return err
+		}
+		_, err = writer.WriteAt(line, size)
+		if err != nil {
+			return err
+		}
+		size += int64(len(line))
+	}
+	line, err := encodeLine(&command)
+	if err != nil {
+		return err
+	}
+	_, err = writer.WriteAt(line, size)
+	if err != nil {
+		return err
+	}
+	return writer.Truncate(size + int64(len(line)))
+}
+
+// encodeLine returns the line of the trace file that contains the given span.
+func encodeLine(span *Span) ([]byte, error) {
+	document := &otlpDocument{
+		ResourceSpans: []*otlpResourceSpans{{
+			Resource: otlpResource{
+				Attributes: encodeAttributes(map[string]interface{}{
+					"service.name":    "rosa",
+					"service.version": info.DefaultVersion,
+				}),
+			},
+			ScopeSpans: []*otlpScopeSpans{{
+				Scope: otlpScope{Name: scopeName, Version: info.DefaultVersion},
+				Spans: []*otlpSpan{encodeSpan(span)},
+			}},
+		}},
+	}
+	data, err := json.Marshal(document)
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// Types of the OTLP JSON encoding. Trace and span identifiers are hexadecimal strings, and 64 bit
+// integers are decimal strings.
+type otlpDocument struct {
+	ResourceSpans []*otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource      `json:"resource"`
+	ScopeSpans []*otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []*otlpKeyValue `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope   `json:"scope"`
+	Spans []*otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+type otlpSpan struct {
+	TraceID           string          `json:"traceId"`
+	SpanID            string          `json:"spanId"`
+	ParentSpanID      string          `json:"parentSpanId,omitempty"`
+	Name              string          `json:"name"`
+	Kind              int             `json:"kind"`
+	StartTimeUnixNano string          `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string          `json:"endTimeUnixNano"`
+	Attributes        []*otlpKeyValue `json:"attributes,omitempty"`
+	Status            otlpStatus      `json:"status"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+type otlpKeyValue struct {
+	Key   string        `json:"key"`
+	Value *otlpAnyValue `json:"value"`
+}
+
+type otlpAnyValue struct {
+	StringValue *string         `json:"stringValue,omitempty"`
+	IntValue    *string         `json:"intValue,omitempty"`
+	BoolValue   *bool           `json:"boolValue,omitempty"`
+	ArrayValue  *otlpArrayValue `json:"arrayValue,omitempty"`
+}
+
+type otlpArrayValue struct {
+	Values []*otlpAnyValue `json:"values"`
+}
+
+func encodeSpan(span *Span) *otlpSpan {
+	status := otlpStatus{Code: statusUnset}
+	if span.failure != "" {
+		status = otlpStatus{Code: statusError, Message: span.failure}
+	}
+	return &otlpSpan{
+		TraceID:           traceID,
+		SpanID:            span.id,
+		ParentSpanID:      span.parent,
+		Name:              span.name,
+		Kind:              span.kind,
+		StartTimeUnixNano: strconv.FormatInt(span.start.UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(span.end.UnixNano(), 10),
+		Attributes:        encodeAttributes(span.attributes),
+		Status:            status,
+	}
+}
+
+// encodeAttributes encodes the attributes sorted by key, skipping the values of unsupported types.
+func encodeAttributes(attributes map[string]interface{}) []*otlpKeyValue {
+	keys := make([]string, 0, len(attributes))
+	for key := range attributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	result := []*otlpKeyValue{}
+	for _, key := range keys {
+		value := encodeValue(attributes[key])
+		if value != nil {
+			result = append(result, &otlpKeyValue{Key: key, Value: value})
+		}
+	}
+	return result
+}
+
+func encodeValue(value interface{}) *otlpAnyValue {
+	switch typed := value.(type) {
+	case string:
+		return &otlpAnyValue{StringValue: &typed}
+	case int:
+		text := strconv.Itoa(typed)
+		return &otlpAnyValue{IntValue: &text}
+	case int64:
+		text := strconv.FormatInt(typed, 10)
+		return &otlpAnyValue{IntValue: &text}
+	case bool:
+		return &otlpAnyValue{BoolValue: &typed}
+	case []string:
+		values := make([]*otlpAnyValue, len(typed))
+		for i := range typed {
+			values[i] = &otlpAnyValue{StringValue: &typed[i]}
+		}
+		return &otlpAnyValue{ArrayValue: &otlpArrayValue{Values: values}}
+	}
+	return nil
+}
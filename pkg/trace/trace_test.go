@@ -0,0 +1,149 @@
+package trace
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/rosa/pkg/reporter"
+	"github.com/openshift/rosa/pkg/retry"
+)
+
+var _ = Describe("Trace", func() {
+	var name string
+
+	// lines returns the spans of the trace file in the order of its lines.
+	lines := func() []*otlpSpan {
+		data, err := os.ReadFile(name)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(data).To(HaveSuffix("\n"))
+		spans := []*otlpSpan{}
+		for _, line := range strings.Split(strings.TrimSuffix(string(data), "\n"), "\n") {
+			document := &otlpDocument{}
+			Expect(json.Unmarshal([]byte(line), document)).To(Succeed())
+			Expect(document.ResourceSpans).To(HaveLen(1))
+			Expect(document.ResourceSpans[0].ScopeSpans).To(HaveLen(1))
+			Expect(document.ResourceSpans[0].ScopeSpans[0].Spans).To(HaveLen(1))
+			span := document.ResourceSpans[0].ScopeSpans[0].Spans[0]
+			Expect(span.TraceID).To(HaveLen(32))
+			Expect(span.SpanID).To(HaveLen(16))
+			spans = append(spans, span)
+		}
+		return spans
+	}
+
+	// read returns the spans of the trace file indexed by name.
+	read := func() map[string]*otlpSpan {
+		spans := map[string]*otlpSpan{}
+		for _, span := range lines() {
+			spans[span.Name] = span
+		}
+		return spans
+	}
+
+	attribute := func(span *otlpSpan, key string) *otlpAnyValue {
+		for _, attribute := range span.Attributes {
+			if attribute.Key == key {
+				return attribute.Value
+			}
+		}
+		return nil
+	}
+
+	BeforeEach(func() {
+		name = filepath.Join(GinkgoT().TempDir(), "traces", "trace.json")
+		SetFile(name)
+	})
+
+	AfterEach(func() {
+		Expect(Stop(nil)).To(Succeed())
+		SetFile("")
+	})
+
+	It("Does nothing when there is no trace file", func() {
+		SetFile("")
+		Expect(Start("rosa whoami", nil)).To(Succeed())
+		Expect(Enabled()).To(BeFalse())
+		span := Begin("Connect to OCM")
+		Expect(span).To(BeNil())
+		span.Set("key", "value")
+		span.End(nil)
+	})
+
+	It("Writes the phases and calls as children of the command", func() {
+		Expect(Start("rosa describe cluster", map[string]interface{}{"rosa.flags": []string{"cluster"}})).
+			To(Succeed())
+		Expect(read()).To(HaveKey("rosa describe cluster"))
+
+		phase := Begin("Fetch cluster")
+		call := Call("GET /api/clusters_mgmt/v1/clusters")
+		call.Set("http.response.status_code", 200)
+		call.End(nil)
+		phase.End(errors.New("boom"))
+		other := Call("IAM/GetRole")
+		other.End(nil)
+
+		Expect(lines()).To(HaveLen(4))
+		Expect(lines()[3].Name).To(Equal("rosa describe cluster"))
+		spans := read()
+		command := spans["rosa describe cluster"]
+		Expect(command.ParentSpanID).To(BeEmpty())
+		Expect(command.Kind).To(Equal(KindInternal))
+		Expect(command.EndTimeUnixNano).To(Equal(spans["IAM/GetRole"].EndTimeUnixNano))
+		Expect(*attribute(command, "rosa.flags").ArrayValue.Values[0].StringValue).To(Equal("cluster"))
+		Expect(spans["Fetch cluster"].ParentSpanID).To(Equal(command.SpanID))
+		Expect(spans["Fetch cluster"].Status).To(Equal(otlpStatus{Code: statusError, Message: "boom"}))
+		Expect(spans["GET /api/clusters_mgmt/v1/clusters"].ParentSpanID).To(Equal(spans["Fetch cluster"].SpanID))
+		Expect(spans["GET /api/clusters_mgmt/v1/clusters"].Kind).To(Equal(KindClient))
+		Expect(*attribute(spans["GET /api/clusters_mgmt/v1/clusters"], "http.response.status_code").IntValue).
+			To(Equal("200"))
+		Expect(spans["IAM/GetRole"].ParentSpanID).To(Equal(command.SpanID))
+	})
+
+	It("Marks the command as failed when an error is reported", func() {
+		Expect(Start("rosa whoami", nil)).To(Succeed())
+		reporter.NewReporter(GinkgoWriter, GinkgoWriter).Errorf("Not logged in")
+		Expect(read()["rosa whoami"].Status).To(Equal(otlpStatus{Code: statusError, Message: "Not logged in"}))
+	})
+
+	It("Traces each attempt of the OCM requests", func() {
+		attempts := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			attempts++
+			w.Header().Set("X-Operation-Id", "my-operation")
+			if attempts == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}
+		}))
+		defer server.Close()
+		Expect(Start("rosa list clusters", nil)).To(Succeed())
+
+		policy := retry.Policy{MaxAttempts: 2, Backoff: time.Millisecond, MaxBackoff: time.Millisecond}
+		transport := retry.NewTransportWrapper(policy, logrus.New())(TransportWrapper(http.DefaultTransport))
+		request, err := http.NewRequest(http.MethodGet, server.URL+"/api/clusters_mgmt/v1/clusters", nil)
+		Expect(err).NotTo(HaveOccurred())
+		response, err := transport.RoundTrip(request)
+		Expect(err).NotTo(HaveOccurred())
+		response.Body.Close()
+
+		spans := lines()
+		Expect(spans).To(HaveLen(3))
+		for i, span := range spans[:2] {
+			Expect(span.Name).To(Equal("GET /api/clusters_mgmt/v1/clusters"))
+			Expect(*attribute(span, "rosa.ocm.resource").StringValue).To(Equal("clusters_mgmt/v1/clusters"))
+			Expect(*attribute(span, "rosa.ocm.operation_id").StringValue).To(Equal("my-operation"))
+			Expect(*attribute(span, "http.request.resend_count").IntValue).To(Equal([]string{"0", "1"}[i]))
+		}
+		Expect(spans[0].Status.Code).To(Equal(statusError))
+		Expect(spans[1].Status.Code).To(Equal(statusUnset))
+	})
+})
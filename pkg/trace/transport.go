@@ -0,0 +1,71 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the transport that traces the requests sent to OCM.
+
+package trace
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/openshift/rosa/pkg/retry"
+)
+
+// Prefix of the paths of the OCM API:
+const apiPrefix = "/api/"
+
+type transport struct {
+	wrapped http.RoundTripper
+}
+
+// TransportWrapper traces each attempt of the OCM requests, while a command is being traced. The
+// number of the attempt is the one set by the transport that retries the requests.
+func TransportWrapper(wrapped http.RoundTripper) http.RoundTripper {
+	return &transport{
+		wrapped: wrapped,
+	}
+}
+
+func (t *transport) RoundTrip(request *http.Request) (*http.Response, error) {
+	span := Call(fmt.Sprintf("%s %s", request.Method, request.URL.Path))
+	if span == nil {
+		return t.wrapped.RoundTrip(request)
+	}
+	span.Set("http.request.method", request.Method)
+	span.Set("server.address", request.URL.Host)
+	span.Set("url.path", request.URL.Path)
+	span.Set("http.request.resend_count", retry.Attempt(request)-1)
+	if strings.HasPrefix(request.URL.Path, apiPrefix) {
+		span.Set("rosa.ocm.resource", strings.Trim(strings.TrimPrefix(request.URL.Path, apiPrefix), "/"))
+	}
+	response, err := t.wrapped.RoundTrip(request)
+	if err != nil {
+		span.End(err)
+		return response, err
+	}
+	span.Set("http.response.status_code", response.StatusCode)
+	if operationID := response.Header.Get("X-Operation-Id"); operationID != "" {
+		span.Set("rosa.ocm.operation_id", operationID)
+	}
+	if response.StatusCode >= http.StatusBadRequest {
+		span.End(fmt.Errorf("%s", response.Status))
+	} else {
+		span.End(nil)
+	}
+	return response, nil
+}
@@ -0,0 +1,126 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the calculation of the sequence of versions that takes a cluster from its
+// current version to the target version, using only upgrades that OCM makes available.
+
+package upgradeplan
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	ver "github.com/hashicorp/go-version"
+)
+
+// FindPath returns the versions that a cluster at the given version has to be upgraded to, in
+// order, to reach the target. The upgrades map contains the versions that each version can be
+// upgraded to directly. The target is either a version, like '4.16.3', or a minor version, like
+// '4.16' or '4.16.x', meaning the latest version of that minor that can be reached.
+//
+// The path has the least number of hops. When there are several, the one with the latest
+// intermediate versions is chosen, as they contain the most fixes.
+func FindPath(from string, target string, upgrades map[string][]string) ([]string, error) {
+	current, err := ver.NewVersion(from)
+	if err != nil {
+		return nil, fmt.Errorf("invalid current version '%s': %v", from, err)
+	}
+	minor := strings.TrimSuffix(target, ".x")
+	targetVersion, err := ver.NewVersion(minor)
+	if err != nil {
+		return nil, fmt.Errorf("invalid target version '%s': %v", target, err)
+	}
+	isMinor := len(strings.Split(minor, ".")) == 2
+	if isMinor {
+		if minorOf(current) > minorOf(targetVersion) || current.Segments()[0] != targetVersion.Segments()[0] {
+			return nil, fmt.Errorf("version '%s' is older than the current version '%s'", target, from)
+		}
+	} else if !targetVersion.GreaterThan(current) {
+		return nil, fmt.Errorf("version '%s' isn't newer than the current version '%s'", target, from)
+	}
+
+	// Breadth first search, visiting the newest versions first, so that the first path found to
+	// each version is the shortest one with the latest intermediate versions:
+	parents := map[string]string{from: ""}
+	queue := []string{from}
+	for len(queue) > 0 {
+		version := queue[0]
+		queue = queue[1:]
+		for _, next := range sortDescending(upgrades[version]) {
+			if _, visited := parents[next]; visited {
+				continue
+			}
+			parents[next] = version
+			queue = append(queue, next)
+		}
+	}
+
+	goal := ""
+	if isMinor {
+		var latest *ver.Version
+		for version := range parents {
+			parsed, err := ver.NewVersion(version)
+			if err != nil || version == from {
+				continue
+			}
+			if sameMinor(parsed, targetVersion) && (latest == nil || parsed.GreaterThan(latest)) {
+				latest = parsed
+				goal = version
+			}
+		}
+	} else if _, ok := parents[target]; ok {
+		goal = target
+	}
+	if goal == "" {
+		return nil, fmt.Errorf("version '%s' can't be reached from version '%s' with the available upgrades",
+			target, from)
+	}
+
+	path := []string{}
+	for version := goal; version != from; version = parents[version] {
+		path = append([]string{version}, path...)
+	}
+	return path, nil
+}
+
+// sortDescending returns a copy of the given versions sorted from the newest to the oldest. Invalid
+// versions are ignored.
+func sortDescending(versions []string) []string {
+	parsed := []*ver.Version{}
+	for _, version := range versions {
+		value, err := ver.NewVersion(version)
+		if err == nil {
+			parsed = append(parsed, value)
+		}
+	}
+	sort.SliceStable(parsed, func(i, j int) bool {
+		return parsed[i].GreaterThan(parsed[j])
+	})
+	result := make([]string, len(parsed))
+	for i, value := range parsed {
+		result[i] = value.Original()
+	}
+	return result
+}
+
+func minorOf(version *ver.Version) int {
+	return version.Segments()[1]
+}
+
+func sameMinor(a, b *ver.Version) bool {
+	return a.Segments()[0] == b.Segments()[0] && minorOf(a) == minorOf(b)
+}
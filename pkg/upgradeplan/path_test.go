@@ -0,0 +1,52 @@
+package upgradeplan
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FindPath", func() {
+	upgrades := map[string][]string{
+		"4.12.40": {"4.12.45", "4.13.10"},
+		"4.12.45": {"4.13.10", "4.13.20"},
+		"4.13.10": {"4.14.5"},
+		"4.13.20": {"4.14.5", "4.14.8"},
+		"4.14.5":  {},
+		"4.14.8":  {},
+	}
+
+	It("Finds the shortest path to a version", func() {
+		path, err := FindPath("4.12.40", "4.14.5", upgrades)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(path).To(Equal([]string{"4.13.10", "4.14.5"}))
+	})
+
+	It("Prefers the latest intermediate versions", func() {
+		path, err := FindPath("4.12.45", "4.14.5", upgrades)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(path).To(Equal([]string{"4.13.20", "4.14.5"}))
+	})
+
+	It("Finds the latest version of a minor version", func() {
+		path, err := FindPath("4.12.40", "4.14", upgrades)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(path).To(Equal([]string{"4.12.45", "4.13.20", "4.14.8"}))
+	})
+
+	It("Accepts a minor version ending in '.x'", func() {
+		path, err := FindPath("4.12.40", "4.14.x", upgrades)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(path).To(Equal([]string{"4.12.45", "4.13.20", "4.14.8"}))
+	})
+
+	It("Fails if the version can't be reached", func() {
+		_, err := FindPath("4.12.40", "4.15.2", upgrades)
+		Expect(err).To(MatchError("version '4.15.2' can't be reached from version '4.12.40' with the " +
+			"available upgrades"))
+	})
+
+	It("Fails if the version isn't newer than the current one", func() {
+		_, err := FindPath("4.13.10", "4.12.45", upgrades)
+		Expect(err).To(MatchError("version '4.12.45' isn't newer than the current version '4.13.10'"))
+	})
+})
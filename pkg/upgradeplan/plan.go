@@ -0,0 +1,318 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the upgrade plan calculated by the 'rosa upgrade cluster --plan' command,
+// which lists the hops from the current version to the target one and what has to be done before
+// each of them.
+
+package upgradeplan
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	ver "github.com/hashicorp/go-version"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+// maxNodePoolMinorSkew is the number of minor versions that the machine pools of a hosted cluster
+// can be behind its control plane.
+const maxNodePoolMinorSkew = 2
+
+// Hop is one of the upgrades of the plan, with the actions that it requires.
+type Hop struct {
+	Version string
+
+	// Version gates that have to be acknowledged. For the first hop they are the gates that the
+	// cluster is missing, for the rest all the gates of the minor version that the hop enters:
+	Gates []*cmv1.VersionGate
+
+	// Whether the policies of the account and operator roles have to be upgraded:
+	AccountRolePolicies  bool
+	OperatorRolePolicies bool
+
+	// Operator roles that have to be created, as 'namespace/name' of the operator:
+	MissingOperatorRoles []string
+
+	// Machine pools of hosted clusters that have to be upgraded before the hop:
+	NodePoolConstraints []string
+}
+
+// Plan contains the hops that take a cluster from its current version to the target version.
+type Plan struct {
+	ClusterID   string
+	ClusterName string
+	From        string
+	To          string
+	Hops        []*Hop
+}
+
+// Build calculates the plan to upgrade the given cluster to the target version, which can also be
+// a minor version like '4.16' or '4.16.x'.
+func Build(r *rosa.Runtime, cluster *cmv1.Cluster, target string) (*Plan, error) {
+	from := ClusterVersion(cluster)
+	upgrades, err := availableUpgrades(r, cluster)
+	if err != nil {
+		return nil, err
+	}
+	path, err := FindPath(from, target, upgrades)
+	if err != nil {
+		return nil, err
+	}
+	plan := &Plan{
+		ClusterID:   cluster.ID(),
+		ClusterName: cluster.Name(),
+		From:        from,
+		To:          path[len(path)-1],
+	}
+
+	isSTS := cluster.AWS().STS().RoleARN() != ""
+	isHypershift := cluster.Hypershift().Enabled()
+	var credRequests map[string]*cmv1.STSOperator
+	operatorRolePolicyPrefix := ""
+	if isSTS {
+		credRequests, err = r.OCMClient.GetCredRequests(isHypershift)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get operator credential requests: %v", err)
+		}
+		if !hasManagedPolicies(cluster) {
+			operatorRolePolicyPrefix, err = aws.GetOperatorRolePolicyPrefixFromCluster(cluster, r.AWSClient)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get operator role policy prefix: %v", err)
+			}
+		}
+	}
+	var nodePools []*cmv1.NodePool
+	if isHypershift {
+		nodePools, err = r.OCMClient.GetNodePools(cluster.ID())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get machine pools: %v", err)
+		}
+	}
+
+	missingRoles := map[string]bool{}
+	previous := from
+	for i, version := range path {
+		hop := &Hop{Version: version}
+		if i == 0 {
+			hop.Gates, err = missingGates(r, cluster, version)
+		} else {
+			hop.Gates, err = minorGates(r, isSTS, previous, version)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get version gates of version '%s': %v", version, err)
+		}
+		if isSTS {
+			err = checkRoles(r, cluster, hop, credRequests, operatorRolePolicyPrefix, missingRoles)
+			if err != nil {
+				return nil, fmt.Errorf("failed to check roles for version '%s': %v", version, err)
+			}
+		}
+		hop.NodePoolConstraints = NodePoolConstraints(nodePools, version)
+		plan.Hops = append(plan.Hops, hop)
+		previous = version
+	}
+	return plan, nil
+}
+
+// ClusterVersion returns the raw version of the cluster, like '4.14.10'.
+func ClusterVersion(cluster *cmv1.Cluster) string {
+	if cluster.OpenshiftVersion() != "" {
+		return cluster.OpenshiftVersion()
+	}
+	return cluster.Version().RawID()
+}
+
+// hasManagedPolicies returns true if the roles of the cluster use AWS managed policies, which are
+// upgraded by AWS.
+func hasManagedPolicies(cluster *cmv1.Cluster) bool {
+	return cluster.Hypershift().Enabled() || cluster.AWS().STS().ManagedPolicies()
+}
+
+// availableUpgrades returns the versions that each version of the channel group of the cluster can
+// be upgraded to, only including the versions that can be used by the cluster.
+func availableUpgrades(r *rosa.Runtime, cluster *cmv1.Cluster) (map[string][]string, error) {
+	product := ""
+	if cluster.Hypershift().Enabled() {
+		product = ocm.HcpProduct
+	}
+	versions, err := r.OCMClient.GetVersionsWithProduct(product, cluster.Version().ChannelGroup(), false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get versions: %v", err)
+	}
+	usable := map[string]bool{}
+	for _, version := range versions {
+		if !cluster.Hypershift().Enabled() || version.HostedControlPlaneEnabled() {
+			usable[version.RawID()] = true
+		}
+	}
+	filter := func(candidates []string) []string {
+		result := []string{}
+		for _, candidate := range candidates {
+			if usable[candidate] {
+				result = append(result, candidate)
+			}
+		}
+		return result
+	}
+	upgrades := map[string][]string{}
+	for _, version := range versions {
+		upgrades[version.RawID()] = filter(version.AvailableUpgrades())
+	}
+	// The upgrades of the cluster itself take precedence, as its version may no longer be listed:
+	if clusterUpgrades := cluster.Version().AvailableUpgrades(); len(clusterUpgrades) > 0 {
+		upgrades[ClusterVersion(cluster)] = filter(clusterUpgrades)
+	}
+	return upgrades, nil
+}
+
+// missingGates returns the version gates that the cluster is missing to upgrade to the given
+// version, using a dry run of the upgrade policy.
+func missingGates(r *rosa.Runtime, cluster *cmv1.Cluster, version string) ([]*cmv1.VersionGate, error) {
+	if cluster.Hypershift().Enabled() {
+		policy, err := cmv1.NewControlPlaneUpgradePolicy().
+			UpgradeType(cmv1.UpgradeTypeControlPlane).
+			ScheduleType(cmv1.ScheduleTypeManual).
+			Version(version).
+			Build()
+		if err != nil {
+			return nil, err
+		}
+		return r.OCMClient.GetMissingGateAgreementsHypershift(cluster.ID(), policy)
+	}
+	policy, err := cmv1.NewUpgradePolicy().
+		ScheduleType(cmv1.ScheduleTypeManual).
+		Version(version).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+	return r.OCMClient.GetMissingGateAgreementsClassic(cluster.ID(), policy)
+}
+
+// minorGates returns the version gates of the minor version of the hop, if it is a different minor
+// version than the previous one. The STS only gates are excluded for clusters that don't use STS.
+func minorGates(r *rosa.Runtime, isSTS bool, previous string, version string) ([]*cmv1.VersionGate, error) {
+	minor := ocm.GetVersionMinor(version)
+	if minor == ocm.GetVersionMinor(previous) {
+		return nil, nil
+	}
+	gates, err := r.OCMClient.ListAllOcpGates(minor)
+	if err != nil {
+		return nil, err
+	}
+	result := []*cmv1.VersionGate{}
+	for _, gate := range gates {
+		if isSTS || !gate.STSOnly() {
+			result = append(result, gate)
+		}
+	}
+	return result, nil
+}
+
+// checkRoles fills the role actions of the hop. Operator roles already reported as missing by a
+// previous hop aren't reported again.
+func checkRoles(r *rosa.Runtime, cluster *cmv1.Cluster, hop *Hop, credRequests map[string]*cmv1.STSOperator,
+	operatorRolePolicyPrefix string, reported map[string]bool) error {
+	minor := ocm.GetVersionMinor(hop.Version)
+	if !hasManagedPolicies(cluster) {
+		var err error
+		hop.AccountRolePolicies, err = r.AWSClient.IsUpgradedNeededForAccountRolePoliciesUsingCluster(cluster,
+			minor)
+		if err != nil {
+			return err
+		}
+		hop.OperatorRolePolicies, err = r.AWSClient.IsUpgradedNeededForOperatorRolePoliciesUsingCluster(cluster,
+			r.Creator.Partition, r.Creator.AccountID, minor, credRequests, operatorRolePolicyPrefix)
+		if err != nil {
+			return err
+		}
+	}
+	missing, err := r.OCMClient.FindMissingOperatorRolesForUpgrade(cluster, hop.Version, credRequests)
+	if err != nil {
+		return err
+	}
+	for _, operator := range missing {
+		name := fmt.Sprintf("%s/%s", operator.Namespace(), operator.Name())
+		if !reported[name] {
+			reported[name] = true
+			hop.MissingOperatorRoles = append(hop.MissingOperatorRoles, name)
+		}
+	}
+	sort.Strings(hop.MissingOperatorRoles)
+	return nil
+}
+
+// NodePoolConstraints returns the machine pools of a hosted cluster that are too far behind for its
+// control plane to be upgraded to the given version, explaining what they have to be upgraded to.
+func NodePoolConstraints(nodePools []*cmv1.NodePool, version string) []string {
+	target, err := ver.NewVersion(version)
+	if err != nil {
+		return nil
+	}
+	constraints := []string{}
+	for _, nodePool := range nodePools {
+		poolVersion := ocm.GetRawVersionId(nodePool.Version().ID())
+		current, err := ver.NewVersion(poolVersion)
+		if err != nil {
+			continue
+		}
+		if minorOf(target)-minorOf(current) > maxNodePoolMinorSkew {
+			constraints = append(constraints, fmt.Sprintf("Upgrade machine pool '%s' from version '%s' to "+
+				"version %d.%d or later", nodePool.ID(), poolVersion, target.Segments()[0],
+				minorOf(target)-maxNodePoolMinorSkew))
+		}
+	}
+	return constraints
+}
+
+// Print writes the plan, with the actions required before each hop.
+func (p *Plan) Print(writer io.Writer) {
+	fmt.Fprintf(writer, "Upgrade plan for cluster '%s' from version '%s' to '%s':\n", p.ClusterName, p.From,
+		p.To)
+	for i, hop := range p.Hops {
+		fmt.Fprintf(writer, "%d. Upgrade to version '%s'\n", i+1, hop.Version)
+		for _, constraint := range hop.NodePoolConstraints {
+			fmt.Fprintf(writer, "   - %s\n", constraint)
+		}
+		minor := ocm.GetVersionMinor(hop.Version)
+		if hop.AccountRolePolicies {
+			fmt.Fprintf(writer, "   - Upgrade the account role policies to version %s\n", minor)
+		}
+		if hop.OperatorRolePolicies {
+			fmt.Fprintf(writer, "   - Upgrade the operator role policies to version %s\n", minor)
+		}
+		if len(hop.MissingOperatorRoles) > 0 {
+			fmt.Fprintf(writer, "   - Create the operator roles for %s\n",
+				strings.Join(hop.MissingOperatorRoles, ", "))
+		}
+		for _, gate := range hop.Gates {
+			fmt.Fprintf(writer, "   - Acknowledge version gate: %s\n", gate.Description())
+			if gate.WarningMessage() != "" {
+				fmt.Fprintf(writer, "     Warning: %s\n", gate.WarningMessage())
+			}
+			if gate.DocumentationURL() != "" {
+				fmt.Fprintf(writer, "     URL:     %s\n", gate.DocumentationURL())
+			}
+		}
+	}
+}
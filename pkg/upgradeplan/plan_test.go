@@ -0,0 +1,98 @@
+package upgradeplan
+
+import (
+	"bytes"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	. "github.com/openshift-online/ocm-sdk-go/testing"
+
+	"github.com/openshift/rosa/pkg/test"
+)
+
+var _ = Describe("Plan", func() {
+	var t *test.TestingRuntime
+
+	BeforeEach(func() {
+		t = test.NewTestRuntime()
+	})
+
+	Describe("Build", func() {
+		It("Lists the hops with the version gates of each of them", func() {
+			cluster := test.MockCluster(func(c *cmv1.ClusterBuilder) {
+				c.Version(cmv1.NewVersion().RawID("4.13.10").ChannelGroup("stable"))
+			})
+			t.ApiServer.AppendHandlers(
+				RespondWithJSON(http.StatusOK, `{"kind": "VersionList", "page": 1, "size": 3, "total": 3,
+					"items": [{"kind": "Version", "id": "openshift-v4.13.10", "raw_id": "4.13.10",
+					"available_upgrades": ["4.14.5"]}, {"kind": "Version", "id": "openshift-v4.14.5",
+					"raw_id": "4.14.5", "available_upgrades": ["4.15.2"]}, {"kind": "Version",
+					"id": "openshift-v4.15.2", "raw_id": "4.15.2"}]}`),
+				RespondWithJSON(http.StatusBadRequest, `{"kind": "Error", "reason": "Missing agreements",
+					"details": [{"kind": "VersionGate", "id": "gate-414", "description": "API removals in 4.14",
+					"documentation_url": "https://example.com/4.14"}]}`),
+				RespondWithJSON(http.StatusOK, `{"kind": "VersionGateList", "page": 1, "size": 2, "total": 2,
+					"items": [{"kind": "VersionGate", "id": "gate-415", "description": "API removals in 4.15"},
+					{"kind": "VersionGate", "id": "sts-415", "description": "New permissions",
+					"sts_only": true}]}`),
+			)
+			plan, err := Build(t.RosaRuntime, cluster, "4.15.2")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(plan.From).To(Equal("4.13.10"))
+			Expect(plan.To).To(Equal("4.15.2"))
+			Expect(plan.Hops).To(HaveLen(2))
+			Expect(plan.Hops[0].Version).To(Equal("4.14.5"))
+			Expect(plan.Hops[0].Gates).To(HaveLen(1))
+			Expect(plan.Hops[0].Gates[0].ID()).To(Equal("gate-414"))
+			Expect(plan.Hops[1].Version).To(Equal("4.15.2"))
+			Expect(plan.Hops[1].Gates).To(HaveLen(1))
+			Expect(plan.Hops[1].Gates[0].ID()).To(Equal("gate-415"))
+		})
+	})
+
+	Describe("Node pool constraints", func() {
+		It("Reports the machine pools more than two minor versions behind", func() {
+			workers, err := cmv1.NewNodePool().ID("workers").
+				Version(cmv1.NewVersion().ID("openshift-v4.13.10")).Build()
+			Expect(err).NotTo(HaveOccurred())
+			gpu, err := cmv1.NewNodePool().ID("gpu").
+				Version(cmv1.NewVersion().ID("openshift-v4.14.5")).Build()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(NodePoolConstraints([]*cmv1.NodePool{workers, gpu}, "4.16.2")).To(Equal([]string{
+				"Upgrade machine pool 'workers' from version '4.13.10' to version 4.14 or later",
+			}))
+		})
+	})
+
+	Describe("Print", func() {
+		It("Prints the actions required before each hop", func() {
+			gate, err := cmv1.NewVersionGate().ID("gate-414").Description("API removals in 4.14").
+				DocumentationURL("https://example.com/4.14").Build()
+			Expect(err).NotTo(HaveOccurred())
+			plan := &Plan{
+				ClusterName: "mycluster",
+				From:        "4.13.10",
+				To:          "4.15.2",
+				Hops: []*Hop{
+					{Version: "4.14.5", Gates: []*cmv1.VersionGate{gate}, AccountRolePolicies: true,
+						MissingOperatorRoles: []string{"openshift-cluster-csi-drivers/ebs-cloud-credentials"}},
+					{Version: "4.15.2", NodePoolConstraints: []string{"Upgrade machine pool 'workers' from " +
+						"version '4.12.10' to version 4.13 or later"}},
+				},
+			}
+			out := &bytes.Buffer{}
+			plan.Print(out)
+			Expect(out.String()).To(Equal("Upgrade plan for cluster 'mycluster' from version '4.13.10' to " +
+				"'4.15.2':\n" +
+				"1. Upgrade to version '4.14.5'\n" +
+				"   - Upgrade the account role policies to version 4.14\n" +
+				"   - Create the operator roles for openshift-cluster-csi-drivers/ebs-cloud-credentials\n" +
+				"   - Acknowledge version gate: API removals in 4.14\n" +
+				"     URL:     https://example.com/4.14\n" +
+				"2. Upgrade to version '4.15.2'\n" +
+				"   - Upgrade machine pool 'workers' from version '4.12.10' to version 4.13 or later\n"))
+		})
+	})
+})
@@ -11,6 +11,7 @@ import (
 
 	"github.com/openshift/rosa/pkg/cache"
 	"github.com/openshift/rosa/pkg/clients"
+	"github.com/openshift/rosa/pkg/config"
 	"github.com/openshift/rosa/pkg/logging"
 	"github.com/openshift/rosa/pkg/output"
 )
@@ -30,7 +31,14 @@ var _ RosaVersion = &rosaVersion{}
 
 func NewRosaVersion() (RosaVersion, error) {
 	logger := logging.NewLogger()
-	transport := http.DefaultTransport
+	cfg, err := config.Load()
+	if err != nil {
+		return &rosaVersion{}, fmt.Errorf("failed to load config file: %v", err)
+	}
+	transport, err := cfg.NetworkTransport()
+	if err != nil {
+		return &rosaVersion{}, fmt.Errorf("failed to create transport: %v", err)
+	}
 	if logger.IsLevelEnabled(logrus.DebugLevel) {
 		dumper, err := logging.NewRoundTripper().Logger(logger).Next(transport).Build()
 		if err != nil {
@@ -0,0 +1,181 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the conditions that the wait commands can wait for. Each condition reports
+// the state of the resource when it changes, and fails when the resource reaches a state from which
+// the condition can't be met.
+
+package wait
+
+import (
+	"fmt"
+
+	asv1 "github.com/openshift-online/ocm-sdk-go/addonsmgmt/v1"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	errors "github.com/zgalor/weberr"
+
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+// reportChanges returns a function that reports the given state when it is different from the
+// last one reported.
+func reportChanges(r *rosa.Runtime, format string, args ...interface{}) func(state string) {
+	last := ""
+	return func(state string) {
+		if state == last {
+			return
+		}
+		last = state
+		r.Reporter.Infof("%s", fmt.Sprintf(format, append(args, state)...))
+	}
+}
+
+// ClusterState is met when the cluster is in the given state. It fails when the cluster is in the
+// 'error' state, is being uninstalled or no longer exists, unless that is the desired state.
+func ClusterState(r *rosa.Runtime, cluster *cmv1.Cluster, desired cmv1.ClusterState) Condition {
+	report := reportChanges(r, "Cluster '%s' is in '%s' state", r.ClusterKey)
+	return func() (bool, error) {
+		state, err := r.OCMClient.GetClusterState(cluster.ID())
+		if errors.GetType(err) == errors.NotFound {
+			return false, Failed("Cluster '%s' no longer exists", r.ClusterKey)
+		}
+		if err != nil {
+			return false, err
+		}
+		report(string(state))
+		switch {
+		case state == desired:
+			return true, nil
+		case state == cmv1.ClusterStateError:
+			return false, Failed("Cluster '%s' is in 'error' state", r.ClusterKey)
+		case state == cmv1.ClusterStateUninstalling:
+			return false, Failed("Cluster '%s' is being uninstalled", r.ClusterKey)
+		}
+		return false, nil
+	}
+}
+
+// ClusterDeleted is met when the cluster no longer exists. It fails when the cluster is in the
+// 'error' state.
+func ClusterDeleted(r *rosa.Runtime, cluster *cmv1.Cluster) Condition {
+	report := reportChanges(r, "Cluster '%s' is in '%s' state", r.ClusterKey)
+	return func() (bool, error) {
+		state, err := r.OCMClient.GetClusterState(cluster.ID())
+		if errors.GetType(err) == errors.NotFound {
+			return true, nil
+		}
+		if err != nil {
+			return false, err
+		}
+		report(string(state))
+		if state == cmv1.ClusterStateError {
+			return false, Failed("Cluster '%s' is in 'error' state", r.ClusterKey)
+		}
+		return false, nil
+	}
+}
+
+// NodePoolReplicasReady is met when the number of replicas of the node pool that are ready is the
+// desired one, or is within the limits when autoscaling is enabled. It fails when the node pool no
+// longer exists.
+func NodePoolReplicasReady(r *rosa.Runtime, cluster *cmv1.Cluster, nodePoolID string) Condition {
+	report := reportChanges(r, "Machine pool '%s' has %s", nodePoolID)
+	return func() (bool, error) {
+		nodePool, exists, err := r.OCMClient.GetNodePool(cluster.ID(), nodePoolID)
+		if err != nil {
+			return false, err
+		}
+		if !exists {
+			return false, Failed("Machine pool '%s' no longer exists on cluster '%s'", nodePoolID, r.ClusterKey)
+		}
+		current := nodePool.Status().CurrentReplicas()
+		if autoscaling, ok := nodePool.GetAutoscaling(); ok {
+			report(fmt.Sprintf("%d replicas ready, between %d and %d desired", current,
+				autoscaling.MinReplica(), autoscaling.MaxReplica()))
+			return current >= autoscaling.MinReplica() && current <= autoscaling.MaxReplica(), nil
+		}
+		report(fmt.Sprintf("%d of %d replicas ready", current, nodePool.Replicas()))
+		return current == nodePool.Replicas(), nil
+	}
+}
+
+// UpgradeCompleted is met when the cluster has no pending upgrade, or when its upgrade has completed.
+// Recurring upgrades are considered completed while there is no new version to upgrade to. It fails
+// when the upgrade fails or is cancelled.
+func UpgradeCompleted(r *rosa.Runtime, cluster *cmv1.Cluster) Condition {
+	report := reportChanges(r, "Upgrade of cluster '%s' is in '%s' state", r.ClusterKey)
+	return func() (bool, error) {
+		var scheduleType cmv1.ScheduleType
+		var version string
+		var state *cmv1.UpgradePolicyState
+		if cluster.Hypershift().Enabled() {
+			policy, err := r.OCMClient.GetControlPlaneScheduledUpgrade(cluster.ID())
+			if err != nil {
+				return false, err
+			}
+			if policy == nil {
+				return true, nil
+			}
+			scheduleType, version, state = policy.ScheduleType(), policy.Version(), policy.State()
+		} else {
+			policy, policyState, err := r.OCMClient.GetScheduledUpgrade(cluster.ID())
+			if err != nil {
+				return false, err
+			}
+			if policy == nil {
+				return true, nil
+			}
+			scheduleType, version, state = policy.ScheduleType(), policy.Version(), policyState
+		}
+		value := state.Value()
+		report(string(value))
+		switch value {
+		case cmv1.UpgradePolicyStateValueCompleted:
+			return true, nil
+		case cmv1.UpgradePolicyStateValuePending:
+			return scheduleType == cmv1.ScheduleTypeAutomatic, nil
+		case cmv1.UpgradePolicyStateValueFailed, cmv1.UpgradePolicyStateValueCancelled:
+			return false, Failed("Upgrade of cluster '%s' to version '%s' is in '%s' state: %s",
+				r.ClusterKey, version, value, state.Description())
+		}
+		return false, nil
+	}
+}
+
+// AddOnInstalled is met when the add-on is ready. It fails when the installation fails or when the
+// add-on is being uninstalled.
+func AddOnInstalled(r *rosa.Runtime, cluster *cmv1.Cluster, addOnID string) Condition {
+	report := reportChanges(r, "Add-on '%s' is in '%s' state", addOnID)
+	return func() (bool, error) {
+		installation, err := r.OCMClient.GetAddOnInstallation(cluster.ID(), addOnID)
+		if err != nil {
+			return false, err
+		}
+		state := installation.State()
+		report(string(state))
+		switch state {
+		case asv1.AddonInstallationStateReady:
+			return true, nil
+		case asv1.AddonInstallationStateFailed:
+			return false, Failed("Installation of add-on '%s' on cluster '%s' failed: %s", addOnID,
+				r.ClusterKey, installation.StateDescription())
+		case asv1.AddonInstallationStateDeleting, asv1.AddonInstallationStateDeletePending,
+			asv1.AddonInstallationStateDeleteFailed, asv1.AddonInstallationStateDeleted:
+			return false, Failed("Add-on '%s' is being uninstalled from cluster '%s'", addOnID, r.ClusterKey)
+		}
+		return false, nil
+	}
+}
@@ -0,0 +1,166 @@
+package wait
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	. "github.com/openshift-online/ocm-sdk-go/testing"
+
+	"github.com/openshift/rosa/pkg/reporter"
+	"github.com/openshift/rosa/pkg/test"
+)
+
+var _ = Describe("Conditions", func() {
+	var t *test.TestingRuntime
+	var stdout *bytes.Buffer
+	var cluster *cmv1.Cluster
+
+	// poll checks the condition until it is met or fails, without waiting between checks.
+	poll := func(condition Condition) error {
+		return Poll(context.Background(), time.Millisecond, condition)
+	}
+
+	BeforeEach(func() {
+		t = test.NewTestRuntime()
+		stdout = &bytes.Buffer{}
+		t.RosaRuntime.Reporter = reporter.NewReporter(stdout, &bytes.Buffer{})
+		cluster = test.MockCluster(nil)
+		t.SetCluster("mycluster", cluster)
+	})
+
+	Describe("ClusterState", func() {
+		It("Is met when the cluster reaches the state, reporting the changes", func() {
+			t.ApiServer.AppendHandlers(
+				RespondWithJSON(http.StatusOK, `{"kind": "ClusterStatus", "state": "installing"}`),
+				RespondWithJSON(http.StatusOK, `{"kind": "ClusterStatus", "state": "installing"}`),
+				RespondWithJSON(http.StatusOK, `{"kind": "ClusterStatus", "state": "ready"}`),
+			)
+			Expect(poll(ClusterState(t.RosaRuntime, cluster, cmv1.ClusterStateReady))).To(Succeed())
+			Expect(stdout.String()).To(Equal("INFO: Cluster 'mycluster' is in 'installing' state\n" +
+				"INFO: Cluster 'mycluster' is in 'ready' state\n"))
+		})
+
+		It("Fails when the cluster is in error state", func() {
+			t.ApiServer.AppendHandlers(
+				RespondWithJSON(http.StatusOK, `{"kind": "ClusterStatus", "state": "error"}`),
+			)
+			err := poll(ClusterState(t.RosaRuntime, cluster, cmv1.ClusterStateReady))
+			Expect(err).To(MatchError("Cluster 'mycluster' is in 'error' state"))
+			Expect(IsFailed(err)).To(BeTrue())
+		})
+
+		It("Fails when the cluster no longer exists", func() {
+			t.ApiServer.AppendHandlers(
+				RespondWithJSON(http.StatusNotFound, `{"kind": "Error", "reason": "Cluster not found"}`),
+			)
+			err := poll(ClusterState(t.RosaRuntime, cluster, cmv1.ClusterStateHibernating))
+			Expect(err).To(MatchError("Cluster 'mycluster' no longer exists"))
+			Expect(IsFailed(err)).To(BeTrue())
+		})
+	})
+
+	Describe("ClusterDeleted", func() {
+		It("Is met when the cluster no longer exists", func() {
+			t.ApiServer.AppendHandlers(
+				RespondWithJSON(http.StatusOK, `{"kind": "ClusterStatus", "state": "uninstalling"}`),
+				RespondWithJSON(http.StatusNotFound, `{"kind": "Error", "reason": "Cluster not found"}`),
+			)
+			Expect(poll(ClusterDeleted(t.RosaRuntime, cluster))).To(Succeed())
+		})
+	})
+
+	Describe("NodePoolReplicasReady", func() {
+		It("Is met when the replicas are ready", func() {
+			t.ApiServer.AppendHandlers(
+				RespondWithJSON(http.StatusOK, `{"kind": "NodePool", "id": "workers", "replicas": 3,
+					"status": {"current_replicas": 1}}`),
+				RespondWithJSON(http.StatusOK, `{"kind": "NodePool", "id": "workers", "replicas": 3,
+					"status": {"current_replicas": 3}}`),
+			)
+			Expect(poll(NodePoolReplicasReady(t.RosaRuntime, cluster, "workers"))).To(Succeed())
+			Expect(stdout.String()).To(Equal("INFO: Machine pool 'workers' has 1 of 3 replicas ready\n" +
+				"INFO: Machine pool 'workers' has 3 of 3 replicas ready\n"))
+		})
+
+		It("Is met when the replicas are within the autoscaling limits", func() {
+			t.ApiServer.AppendHandlers(
+				RespondWithJSON(http.StatusOK, `{"kind": "NodePool", "id": "workers",
+					"autoscaling": {"min_replica": 2, "max_replica": 5}, "status": {"current_replicas": 2}}`),
+			)
+			Expect(poll(NodePoolReplicasReady(t.RosaRuntime, cluster, "workers"))).To(Succeed())
+		})
+
+		It("Fails when the machine pool no longer exists", func() {
+			t.ApiServer.AppendHandlers(
+				RespondWithJSON(http.StatusNotFound, `{"kind": "Error", "reason": "Node pool not found"}`),
+			)
+			err := poll(NodePoolReplicasReady(t.RosaRuntime, cluster, "workers"))
+			Expect(IsFailed(err)).To(BeTrue())
+		})
+	})
+
+	Describe("UpgradeCompleted", func() {
+		policies := `{"kind": "UpgradePolicyList", "page": 1, "size": 1, "total": 1, "items": [
+			{"kind": "UpgradePolicy", "id": "policy", "upgrade_type": "OSD", "schedule_type": "manual",
+			"version": "4.14.10"}
+		]}`
+
+		It("Is met when there is no scheduled upgrade", func() {
+			t.ApiServer.AppendHandlers(
+				RespondWithJSON(http.StatusOK, `{"kind": "UpgradePolicyList", "page": 1, "size": 0,
+					"total": 0, "items": []}`),
+			)
+			Expect(poll(UpgradeCompleted(t.RosaRuntime, cluster))).To(Succeed())
+		})
+
+		It("Is met when the upgrade completes", func() {
+			t.ApiServer.AppendHandlers(
+				RespondWithJSON(http.StatusOK, policies),
+				RespondWithJSON(http.StatusOK, `{"kind": "UpgradePolicyState", "value": "started"}`),
+				RespondWithJSON(http.StatusOK, policies),
+				RespondWithJSON(http.StatusOK, `{"kind": "UpgradePolicyState", "value": "completed"}`),
+			)
+			Expect(poll(UpgradeCompleted(t.RosaRuntime, cluster))).To(Succeed())
+		})
+
+		It("Fails when the upgrade fails", func() {
+			t.ApiServer.AppendHandlers(
+				RespondWithJSON(http.StatusOK, policies),
+				RespondWithJSON(http.StatusOK, `{"kind": "UpgradePolicyState", "value": "failed",
+					"description": "Upgrade failed"}`),
+			)
+			err := poll(UpgradeCompleted(t.RosaRuntime, cluster))
+			Expect(err).To(MatchError("Upgrade of cluster 'mycluster' to version '4.14.10' is in 'failed' " +
+				"state: Upgrade failed"))
+			Expect(IsFailed(err)).To(BeTrue())
+		})
+	})
+
+	Describe("AddOnInstalled", func() {
+		It("Is met when the add-on is ready", func() {
+			t.ApiServer.AppendHandlers(
+				RespondWithJSON(http.StatusOK, `{"kind": "AddOnInstallation", "id": "my-addon",
+					"state": "installing"}`),
+				RespondWithJSON(http.StatusOK, `{"kind": "AddOnInstallation", "id": "my-addon",
+					"state": "ready"}`),
+			)
+			Expect(poll(AddOnInstalled(t.RosaRuntime, cluster, "my-addon"))).To(Succeed())
+		})
+
+		It("Fails when the installation fails", func() {
+			t.ApiServer.AppendHandlers(
+				RespondWithJSON(http.StatusOK, `{"kind": "AddOnInstallation", "id": "my-addon",
+					"state": "failed", "state_description": "Missing parameter"}`),
+			)
+			err := poll(AddOnInstalled(t.RosaRuntime, cluster, "my-addon"))
+			Expect(err).To(MatchError("Installation of add-on 'my-addon' on cluster 'mycluster' failed: " +
+				"Missing parameter"))
+			Expect(IsFailed(err)).To(BeTrue())
+		})
+	})
+})
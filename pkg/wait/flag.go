@@ -0,0 +1,60 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains functions used to implement the '--interval' command line option of the wait
+// commands.
+
+package wait
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+// DefaultInterval is the time between checks when the '--interval' flag isn't used.
+const DefaultInterval = 10 * time.Second
+
+var interval = DefaultInterval
+
+// AddIntervalFlag adds the '--interval' flag to the given set of command line flags.
+func AddIntervalFlag(flags *pflag.FlagSet) {
+	flags.DurationVar(
+		&interval,
+		"interval",
+		DefaultInterval,
+		"Time between checks of the condition, for example '30s'.",
+	)
+}
+
+// Interval returns the duration given with the '--interval' flag.
+func Interval() time.Duration {
+	return interval
+}
+
+// SetInterval sets the time between checks of the condition.
+func SetInterval(value time.Duration) {
+	interval = value
+}
+
+// ValidateInterval returns an error if the duration given with the '--interval' flag isn't positive.
+func ValidateInterval() error {
+	if interval <= 0 {
+		return fmt.Errorf("Invalid interval '%s', it must be greater than zero", interval)
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// This file contains the loop used by the 'rosa wait' commands to check a condition periodically
+// until it is met, it can no longer be met, or the command is stopped.
+
+package wait
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/openshift/rosa/pkg/reporter"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+// Condition checks the state of a resource. It returns true when the awaited condition is met, and
+// an error created with Failed when the resource reached a state from which it can't be met.
+type Condition func() (bool, error)
+
+// FailedError indicates that the resource reached a state from which the condition can't be met, for
+// example a cluster in the 'error' state.
+type FailedError struct {
+	message string
+}
+
+func (e *FailedError) Error() string {
+	return e.message
+}
+
+// ErrorCode returns the code used to report the error, so that it is classified as a failed state
+// also when it is reported by the caller.
+func (e *FailedError) ErrorCode() reporter.ErrorCode {
+	return reporter.ErrorCodeFailedState
+}
+
+// Failed creates an error indicating that the condition can't be met.
+func Failed(format string, args ...interface{}) error {
+	return &FailedError{
+		message: fmt.Sprintf(format, args...),
+	}
+}
+
+// IsFailed returns true if the error indicates that the condition can't be met.
+func IsFailed(err error) bool {
+	var failed *FailedError
+	return errors.As(err, &failed)
+}
+
+// stoppedError is returned when the context is cancelled before the condition is met. The message
+// is the cause of the cancellation, and it wraps the error of the context so that it is classified
+// as a timeout or an interruption when it is reported.
+type stoppedError struct {
+	cause error
+	err   error
+}
+
+func (e *stoppedError) Error() string {
+	return e.cause.Error()
+}
+
+func (e *stoppedError) Unwrap() error {
+	return e.err
+}
+
+// Poll checks the condition immediately and then once per interval until it is met, it returns an
+// error, or the context is cancelled.
+func Poll(ctx context.Context, interval time.Duration, condition Condition) error {
+	for {
+		done, err := condition()
+		if err != nil || done {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return &stoppedError{
+				cause: context.Cause(ctx),
+				err:   ctx.Err(),
+			}
+		case <-time.After(interval):
+		}
+	}
+}
+
+// For waits until the condition is met, checking it with the interval given with the '--interval'
+// flag, and stopping when the context of the runtime is cancelled. Errors are reported and returned.
+// A condition that can't be met is reported with the 'failed_state' code, and the expiration of the
+// '--timeout' flag with the 'timeout' code.
+func For(r *rosa.Runtime, description string, condition Condition) error {
+	err := Until(r, description, condition)
+	if err != nil {
+		return r.Reporter.Errorf("%v", err)
+	}
+	return nil
+}
+
+// Until is like For, but it doesn't report the error, so that the caller can return it. The error
+// keeps the code that For would report.
+func Until(r *rosa.Runtime, description string, condition Condition) error {
+	r.Reporter.Debugf("Waiting for %s, checking every %s", description, interval)
+	err := Poll(r.Context, interval, condition)
+	if err != nil {
+		return fmt.Errorf("Failed to wait for %s: %w", description, err)
+	}
+	return nil
+}
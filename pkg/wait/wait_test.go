@@ -0,0 +1,86 @@
+package wait
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift/rosa/pkg/reporter"
+	"github.com/openshift/rosa/pkg/test"
+)
+
+var _ = Describe("Poll", func() {
+	It("Checks the condition until it is met", func() {
+		checks := 0
+		err := Poll(context.Background(), time.Millisecond, func() (bool, error) {
+			checks++
+			return checks == 3, nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(checks).To(Equal(3))
+	})
+
+	It("Stops at the first error", func() {
+		checks := 0
+		err := Poll(context.Background(), time.Millisecond, func() (bool, error) {
+			checks++
+			return false, Failed("Cluster '%s' is in 'error' state", "mycluster")
+		})
+		Expect(err).To(MatchError("Cluster 'mycluster' is in 'error' state"))
+		Expect(IsFailed(err)).To(BeTrue())
+		Expect(checks).To(Equal(1))
+	})
+
+	It("Stops when the context expires", func() {
+		ctx, cancel := context.WithTimeoutCause(context.Background(), 10*time.Millisecond,
+			errors.New("timed out after 10ms"))
+		defer cancel()
+		err := Poll(ctx, time.Millisecond, func() (bool, error) {
+			return false, nil
+		})
+		Expect(err).To(MatchError("timed out after 10ms"))
+		Expect(IsFailed(err)).To(BeFalse())
+		Expect(reporter.NewErrorEnvelope("Failed", err).Code).To(Equal(reporter.ErrorCodeTimeout))
+	})
+})
+
+var _ = Describe("For", func() {
+	var t *test.TestingRuntime
+
+	BeforeEach(func() {
+		t = test.NewTestRuntime()
+		t.RosaRuntime.Context = context.Background()
+		SetInterval(time.Millisecond)
+	})
+
+	AfterEach(func() {
+		SetInterval(DefaultInterval)
+	})
+
+	It("Reports conditions that can't be met with the failed state code", func() {
+		stderr := &bytes.Buffer{}
+		t.RosaRuntime.Reporter = reporter.NewReporter(&bytes.Buffer{}, stderr)
+		err := For(t.RosaRuntime, "cluster 'mycluster' to be ready", func() (bool, error) {
+			return false, Failed("Cluster 'mycluster' is in 'error' state")
+		})
+		Expect(err).To(HaveOccurred())
+		Expect(stderr.String()).To(Equal("ERR: Failed to wait for cluster 'mycluster' to be ready: " +
+			"Cluster 'mycluster' is in 'error' state\n"))
+		Expect(reporter.ExitCode()).To(Equal(10))
+	})
+	It("Returns the error without reporting it when waiting until the condition is met", func() {
+		stderr := &bytes.Buffer{}
+		t.RosaRuntime.Reporter = reporter.NewReporter(&bytes.Buffer{}, stderr)
+		err := Until(t.RosaRuntime, "cluster 'mycluster' to be ready", func() (bool, error) {
+			return false, Failed("Cluster 'mycluster' is in 'error' state")
+		})
+		Expect(err).To(MatchError("Failed to wait for cluster 'mycluster' to be ready: " +
+			"Cluster 'mycluster' is in 'error' state"))
+		Expect(stderr.String()).To(BeEmpty())
+		Expect(reporter.NewErrorEnvelope("Failed", err).Code).To(Equal(reporter.ErrorCodeFailedState))
+	})
+})